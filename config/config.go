@@ -4,9 +4,12 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"soloterm/domain/character"
+	"soloterm/domain/session"
 	"soloterm/domain/tag"
 	"soloterm/shared/validation"
 	"strings"
+	"time"
 
 	"gopkg.in/yaml.v3"
 )
@@ -17,11 +20,29 @@ const (
 
 // Config represents the application configuration
 type Config struct {
-	FullFilePath    string        `yaml:"-"`
-	DatabaseDir     string        `yaml:"database_dir,omitempty"`
-	CoreTags        tag.CoreTags  `yaml:"core_tags"`
-	TagTypes        []tag.TagType `yaml:"tag_types"`
-	TagExcludeWords []string      `yaml:"tag_exclude_words"`
+	FullFilePath       string                    `yaml:"-"`
+	DatabaseDir        string                    `yaml:"database_dir,omitempty"`
+	CoreTags           tag.CoreTags              `yaml:"core_tags"`
+	TagTypes           []tag.TagType             `yaml:"tag_types"`
+	TagExcludeWords    []string                  `yaml:"tag_exclude_words"`
+	KeyBindings        []KeyBinding              `yaml:"key_bindings,omitempty"`
+	SessionTemplates   []session.Template        `yaml:"session_templates,omitempty"`
+	SheetTemplates     []character.SheetTemplate `yaml:"sheet_templates,omitempty"`
+	TrashRetentionDays int                       `yaml:"trash_retention_days,omitempty"`
+	SyncIntervalSecs   int                       `yaml:"sync_interval_seconds,omitempty"`
+	SheetChangeTags    bool                      `yaml:"sheet_change_tags,omitempty"`
+}
+
+// DefaultSyncInterval is how often games with a sync folder are synced when
+// sync_interval_seconds is unset
+const DefaultSyncInterval = 30 * time.Second
+
+// SyncInterval returns how often games with a sync folder are synced
+func (c *Config) SyncInterval() time.Duration {
+	if c.SyncIntervalSecs <= 0 {
+		return DefaultSyncInterval
+	}
+	return time.Duration(c.SyncIntervalSecs) * time.Second
 }
 
 // Load loads the configuration file from the directory passed in
@@ -83,6 +104,35 @@ func (c *Config) Validate() error {
 		}
 	}
 
+	for i, b := range c.KeyBindings {
+		if err := b.validate(); err != nil {
+			return fmt.Errorf("key_bindings[%d]: %w", i, err)
+		}
+	}
+
+	for i, t := range c.SessionTemplates {
+		if strings.TrimSpace(t.Name) == "" {
+			return fmt.Errorf("session_templates[%d]: name is required", i)
+		}
+		if strings.TrimSpace(t.Content) == "" {
+			return fmt.Errorf("session_templates[%d]: content is required", i)
+		}
+	}
+
+	for i := range c.SheetTemplates {
+		if err := c.SheetTemplates[i].Validate(); err != nil {
+			return fmt.Errorf("sheet_templates[%d]: %w", i, err)
+		}
+	}
+
+	if c.TrashRetentionDays < 0 {
+		return fmt.Errorf("trash_retention_days cannot be negative")
+	}
+
+	if c.SyncIntervalSecs < 0 {
+		return fmt.Errorf("sync_interval_seconds cannot be negative")
+	}
+
 	return nil
 }
 
@@ -126,6 +176,72 @@ func (c *Config) save() error {
 # will exclude that tag from appearing in the recent tags list.
 # This is useful for filtering out completed or archived tags.
 # Words are matched case-insensitively.
+#
+# key_bindings add extra keys to the session editor. Each binding has a key
+# such as F9, Alt+1 or Ctrl+Alt+N, an optional label for the help screen, and
+# exactly one of:
+#   template:   text to insert at the cursor
+#   snippet_id: the ID of a dice snippet to roll, inserting the result
+#   roll:       a dice expression to roll, inserting the result
+# Bindings take precedence over the editor's own keys. Application-wide keys
+# such as F1, Tab, Ctrl+Q and Ctrl+R cannot be rebound, nor can editing keys
+# such as Enter, Backspace, Esc and the arrows.
+# Example:
+#   key_bindings:
+#     - key: Alt+1
+#       label: Insert a scene header
+#       template: "S1 *"
+#     - key: Ctrl+Alt+N
+#       roll: "Damage: 2d6"
+#
+# session_templates are offered in the New Session form as the starting
+# content of the session. Each game can also have its own session template,
+# set in the game form. Templates can use the same placeholders as tag
+# templates, plus {{active_tags}} for the latest active tags, {{previously}}
+# for the previous session's summary (or its consequences, =>, if it has no
+# summary) and {{recap}} for every session summary in the game.
+# Example:
+#   session_templates:
+#     - name: Standard
+#       content: |
+#         S1 *{{cursor}}*
+#         {{previously}}
+#
+#         {{active_tags}}
+#         Chaos: {{prompt:Chaos Factor}}
+#
+# sheet_templates are offered in the New Character form to start the
+# character's sheet. Each section holds attributes, or stands alone with a
+# value. system is filled in for the character and defaults to the name.
+# Built-in templates for Cairn, Ironsworn and Mothership are always offered
+# unless one here has the same name.
+# Example:
+#   sheet_templates:
+#     - name: Knave
+#       sections:
+#         - name: Abilities
+#           attributes:
+#             - name: STR
+#               value: "+0"
+#             - name: DEX
+#               value: "+0"
+#         - name: HP
+#           value: "1"
+#
+# trash_retention_days sets how long deleted games, sessions and characters
+# stay in the trash before they are removed for good when the app starts.
+# Leave unset to keep them until you purge them from the trash.
+# Example: trash_retention_days: 30
+#
+# sync_interval_seconds sets how often games with a sync folder are checked
+# for changes to their Markdown files. They are also checked whenever the
+# terminal regains focus. Leave unset to check every 30 seconds.
+# Example: sync_interval_seconds: 10
+#
+# sheet_change_tags inserts a tag line into the open session whenever a value
+# on a character's sheet changes, such as [PC:Kira | HP 5→3]. Changes are
+# always kept in the entry's history either way.
+# Example: sheet_change_tags: true
 
 ` + string(data)
 
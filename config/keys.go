@@ -0,0 +1,182 @@
+package config
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// KeyBinding maps a key in the session editor to an action. Exactly one of
+// Template, SnippetID or Roll must be set.
+type KeyBinding struct {
+	Key       string `yaml:"key"`
+	Label     string `yaml:"label,omitempty"`
+	Template  string `yaml:"template,omitempty"`
+	SnippetID int64  `yaml:"snippet_id,omitempty"`
+	Roll      string `yaml:"roll,omitempty"`
+}
+
+// Description returns the label shown in help, falling back to what the binding does
+func (b KeyBinding) Description() string {
+	switch {
+	case b.Label != "":
+		return b.Label
+	case b.Template != "":
+		return "Insert " + strings.TrimSpace(b.Template)
+	case b.SnippetID != 0:
+		return fmt.Sprintf("Roll snippet %d", b.SnippetID)
+	default:
+		return "Roll " + b.Roll
+	}
+}
+
+// appKeys are handled application-wide before the session editor sees them
+var appKeys = []tcell.Key{
+	tcell.KeyF1, tcell.KeyTab, tcell.KeyBacktab,
+	tcell.KeyCtrlP, tcell.KeyCtrlR, tcell.KeyCtrlQ, tcell.KeyCtrlG,
+	tcell.KeyCtrlC, tcell.KeyCtrlS, tcell.KeyCtrlL,
+}
+
+// editingKeys are needed to write in the session editor
+var editingKeys = []tcell.Key{
+	tcell.KeyEnter, tcell.KeyBackspace, tcell.KeyBackspace2, tcell.KeyEsc,
+	tcell.KeyUp, tcell.KeyDown, tcell.KeyLeft, tcell.KeyRight,
+}
+
+// validate checks the key parses, is free to bind and exactly one action is set
+func (b KeyBinding) validate() error {
+	key, err := ParseKey(b.Key)
+	if err != nil {
+		return err
+	}
+	if key.Key == tcell.KeyRune && key.Mod&(tcell.ModCtrl|tcell.ModAlt) == 0 {
+		return fmt.Errorf("key %q needs a Ctrl or Alt modifier", b.Key)
+	}
+	if slices.Contains(appKeys, key.Key) {
+		return fmt.Errorf("key %q is used application-wide and can't be rebound", b.Key)
+	}
+	if slices.Contains(editingKeys, key.Key) {
+		return fmt.Errorf("key %q is needed for editing and can't be rebound", b.Key)
+	}
+
+	actions := 0
+	if b.Template != "" {
+		actions++
+	}
+	if b.SnippetID != 0 {
+		actions++
+	}
+	if strings.TrimSpace(b.Roll) != "" {
+		actions++
+	}
+	if actions != 1 {
+		return fmt.Errorf("exactly one of template, snippet_id or roll is required")
+	}
+	return nil
+}
+
+// Key is a parsed key such as "F5", "Alt+1" or "Ctrl+Alt+N"
+type Key struct {
+	Key  tcell.Key
+	Rune rune // set when Key is tcell.KeyRune, always lower case
+	Mod  tcell.ModMask
+}
+
+// namedKeys maps lower-cased key names to tcell keys, built from tcell's own names
+var namedKeys = func() map[string]tcell.Key {
+	keys := make(map[string]tcell.Key)
+	for k, name := range tcell.KeyNames {
+		if !strings.HasPrefix(name, "Ctrl-") {
+			keys[strings.ToLower(name)] = k
+		}
+	}
+	keys["escape"] = tcell.KeyEsc
+	keys["pageup"] = tcell.KeyPgUp
+	keys["pagedown"] = tcell.KeyPgDn
+	keys["del"] = tcell.KeyDelete
+	keys["ins"] = tcell.KeyInsert
+	return keys
+}()
+
+// ParseKey parses a key description made of optional Ctrl, Alt and Shift
+// modifiers followed by a key name or a single character, joined with "+".
+// Matching is case-insensitive.
+func ParseKey(s string) (Key, error) {
+	parts := strings.Split(strings.TrimSpace(s), "+")
+	// A trailing "+" means the key itself is "+", e.g. "Alt++"
+	if len(parts) > 1 && parts[len(parts)-1] == "" && parts[len(parts)-2] == "" {
+		parts = append(parts[:len(parts)-2], "+")
+	}
+
+	var key Key
+	for _, mod := range parts[:len(parts)-1] {
+		switch strings.ToLower(strings.TrimSpace(mod)) {
+		case "ctrl":
+			key.Mod |= tcell.ModCtrl
+		case "alt":
+			key.Mod |= tcell.ModAlt
+		case "shift":
+			key.Mod |= tcell.ModShift
+		default:
+			return Key{}, fmt.Errorf("unknown modifier %q in key %q", mod, s)
+		}
+	}
+
+	name := strings.TrimSpace(parts[len(parts)-1])
+	if name == "" {
+		return Key{}, fmt.Errorf("key %q is missing a key name", s)
+	}
+
+	if k, ok := namedKeys[strings.ToLower(name)]; ok {
+		key.Key = k
+		return key, nil
+	}
+
+	r, size := utf8.DecodeRuneInString(name)
+	if size != len(name) {
+		return Key{}, fmt.Errorf("unknown key %q", s)
+	}
+	r = unicode.ToLower(r)
+
+	// Terminals report Ctrl+letter as a control key rather than a rune
+	if key.Mod&tcell.ModCtrl != 0 && r >= 'a' && r <= 'z' {
+		key.Key = tcell.KeyCtrlA + tcell.Key(r-'a')
+		// These arrive as Backspace, Tab and Enter, so they can't be told apart
+		switch key.Key {
+		case tcell.KeyCtrlH, tcell.KeyCtrlI, tcell.KeyCtrlM:
+			return Key{}, fmt.Errorf("key %q is the same key as %s in a terminal", s, tcell.KeyNames[key.Key])
+		}
+		return key, nil
+	}
+
+	// Ctrl+[ arrives as Esc
+	if key.Mod&tcell.ModCtrl != 0 && r == '[' {
+		return Key{}, fmt.Errorf("key %q is the same key as %s in a terminal", s, tcell.KeyNames[tcell.KeyEsc])
+	}
+
+	key.Key = tcell.KeyRune
+	key.Rune = r
+	return key, nil
+}
+
+// Matches reports whether event is this key
+func (k Key) Matches(event *tcell.EventKey) bool {
+	mods := event.Modifiers() & (tcell.ModCtrl | tcell.ModAlt | tcell.ModShift)
+
+	switch {
+	case k.Key >= tcell.KeyCtrlA && k.Key <= tcell.KeyCtrlZ:
+		// Ctrl is implied by the key itself and not always reported
+		return event.Key() == k.Key && mods&tcell.ModAlt == k.Mod&tcell.ModAlt
+	case k.Key == tcell.KeyRune:
+		// Shift is implied by the character typed
+		return event.Key() == tcell.KeyRune &&
+			unicode.ToLower(event.Rune()) == k.Rune &&
+			mods&^tcell.ModShift == k.Mod&^tcell.ModShift
+	default:
+		return event.Key() == k.Key && mods == k.Mod
+	}
+}
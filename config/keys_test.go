@@ -0,0 +1,130 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseKey(t *testing.T) {
+	tests := []struct {
+		name     string
+		key      string
+		expected Key
+	}{
+		{"function key", "F5", Key{Key: tcell.KeyF5}},
+		{"named key ignores case and spaces", " pagedown ", Key{Key: tcell.KeyPgDn}},
+		{"alt with a character", "Alt+1", Key{Key: tcell.KeyRune, Rune: '1', Mod: tcell.ModAlt}},
+		{"characters are lower cased", "alt+shift+X", Key{Key: tcell.KeyRune, Rune: 'x', Mod: tcell.ModAlt | tcell.ModShift}},
+		{"plus as the key", "Alt++", Key{Key: tcell.KeyRune, Rune: '+', Mod: tcell.ModAlt}},
+		{"ctrl with a letter is a control key", "Ctrl+J", Key{Key: tcell.KeyCtrlJ, Mod: tcell.ModCtrl}},
+		{"ctrl and alt with a letter", "Ctrl+Alt+N", Key{Key: tcell.KeyCtrlN, Mod: tcell.ModCtrl | tcell.ModAlt}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			key, err := ParseKey(tc.key)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, key)
+		})
+	}
+}
+
+func TestParseKey_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		key      string
+		expected string
+	}{
+		{"unknown modifier", "Hyper+A", `unknown modifier "Hyper"`},
+		{"missing key name", "Alt+", "is missing a key name"},
+		{"unknown key", "Alt+Foo", `unknown key "Alt+Foo"`},
+		{"ctrl+i is tab", "Ctrl+I", "is the same key as Tab"},
+		{"ctrl+m is enter", "Ctrl+M", "is the same key as Enter"},
+		{"ctrl+h is backspace", "Ctrl+H", "is the same key as Backspace"},
+		{"ctrl+[ is esc", "Ctrl+[", "is the same key as Esc"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := ParseKey(tc.key)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.expected)
+		})
+	}
+}
+
+func TestKey_Matches(t *testing.T) {
+	tests := []struct {
+		name     string
+		key      string
+		event    *tcell.EventKey
+		expected bool
+	}{
+		{"ctrl key with ctrl reported", "Ctrl+N", tcell.NewEventKey(tcell.KeyCtrlN, 0, tcell.ModCtrl), true},
+		{"ctrl key without ctrl reported", "Ctrl+N", tcell.NewEventKey(tcell.KeyCtrlN, 0, tcell.ModNone), true},
+		{"ctrl key with an extra alt", "Ctrl+N", tcell.NewEventKey(tcell.KeyCtrlN, 0, tcell.ModAlt), false},
+		{"ctrl+alt key without ctrl reported", "Ctrl+Alt+N", tcell.NewEventKey(tcell.KeyCtrlN, 0, tcell.ModAlt), true},
+		{"ctrl+alt key without alt", "Ctrl+Alt+N", tcell.NewEventKey(tcell.KeyCtrlN, 0, tcell.ModCtrl), false},
+		{"alt character", "Alt+x", tcell.NewEventKey(tcell.KeyRune, 'x', tcell.ModAlt), true},
+		{"alt character typed with shift", "Alt+x", tcell.NewEventKey(tcell.KeyRune, 'X', tcell.ModAlt|tcell.ModShift), true},
+		{"character without alt", "Alt+x", tcell.NewEventKey(tcell.KeyRune, 'x', tcell.ModNone), false},
+		{"character with an extra ctrl", "Alt+x", tcell.NewEventKey(tcell.KeyRune, 'x', tcell.ModCtrl|tcell.ModAlt), false},
+		{"alt plus", "Alt++", tcell.NewEventKey(tcell.KeyRune, '+', tcell.ModAlt), true},
+		{"alt plus typed with shift", "Alt++", tcell.NewEventKey(tcell.KeyRune, '+', tcell.ModAlt|tcell.ModShift), true},
+		{"function key", "F5", tcell.NewEventKey(tcell.KeyF5, 0, tcell.ModNone), true},
+		{"function key with shift", "F5", tcell.NewEventKey(tcell.KeyF5, 0, tcell.ModShift), false},
+		{"shifted function key", "Shift+F5", tcell.NewEventKey(tcell.KeyF5, 0, tcell.ModShift), true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			key, err := ParseKey(tc.key)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, key.Matches(tc.event))
+		})
+	}
+}
+
+func TestKeyBinding_Validate(t *testing.T) {
+	tests := []struct {
+		name     string
+		binding  KeyBinding
+		expected string // empty when valid
+	}{
+		{"template", KeyBinding{Key: "Alt+1", Template: "S1 *"}, ""},
+		{"snippet", KeyBinding{Key: "F5", SnippetID: 3}, ""},
+		{"roll", KeyBinding{Key: "Ctrl+J", Roll: "1d6"}, ""},
+		{"character without a modifier", KeyBinding{Key: "a", Template: "x"}, "needs a Ctrl or Alt modifier"},
+		{"unparseable key", KeyBinding{Key: "Ctrl+[", Template: "x"}, "is the same key as Esc"},
+		{"no action", KeyBinding{Key: "Alt+1"}, "exactly one of"},
+		{"two actions", KeyBinding{Key: "Alt+1", Template: "x", Roll: "1d6"}, "exactly one of"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.binding.validate()
+			if tc.expected == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.expected)
+		})
+	}
+}
+
+func TestKeyBinding_ValidateRejectsReservedKeys(t *testing.T) {
+	for _, key := range []string{"F1", "Tab", "Backtab", "Ctrl+P", "Ctrl+R", "Ctrl+Q", "Ctrl+G", "Ctrl+C", "Ctrl+S", "Ctrl+L", "Ctrl+Alt+Q"} {
+		err := KeyBinding{Key: key, Template: "x"}.validate()
+		require.Error(t, err, key)
+		assert.Contains(t, err.Error(), "is used application-wide", key)
+	}
+	for _, key := range []string{"Enter", "Backspace", "Backspace2", "Esc", "Up", "Down", "Left", "Shift+Right"} {
+		err := KeyBinding{Key: key, Template: "x"}.validate()
+		require.Error(t, err, key)
+		assert.Contains(t, err.Error(), "is needed for editing", key)
+	}
+}
@@ -8,7 +8,7 @@ import (
 )
 
 func AddColumn(db *sqlx.DB, tableName string, column string, columnType string, notnull bool, defaultValue *string) error {
-	exists, err := columnExists(db, tableName, column)
+	exists, err := ColumnExists(db, tableName, column)
 	if err != nil {
 		return err
 	}
@@ -34,7 +34,7 @@ func AddColumn(db *sqlx.DB, tableName string, column string, columnType string,
 }
 
 func RemoveColumn(db *sqlx.DB, tableName string, column string) error {
-	exists, err := columnExists(db, tableName, column)
+	exists, err := ColumnExists(db, tableName, column)
 	if err != nil {
 		return err
 	}
@@ -50,7 +50,7 @@ func RemoveColumn(db *sqlx.DB, tableName string, column string) error {
 }
 
 func RenameColumn(db *sqlx.DB, tableName string, columnName string, newColunnName string) error {
-	exists, err := columnExists(db, tableName, columnName)
+	exists, err := ColumnExists(db, tableName, columnName)
 	if err != nil {
 		return err
 	}
@@ -65,8 +65,19 @@ func RenameColumn(db *sqlx.DB, tableName string, columnName string, newColunnNam
 	return err
 }
 
-// columnExists checks if a column exists in a table
-func columnExists(db *sqlx.DB, table string, column string) (bool, error) {
+// TableExists checks if a table exists
+func TableExists(db *sqlx.DB, table string) (bool, error) {
+	var exists bool
+	err := db.Get(&exists, "SELECT COUNT(*) > 0 FROM sqlite_master WHERE type = 'table' AND name = ?", table)
+	if err != nil && err != sql.ErrNoRows {
+		return false, err
+	}
+
+	return exists, nil
+}
+
+// ColumnExists checks if a column exists in a table
+func ColumnExists(db *sqlx.DB, table string, column string) (bool, error) {
 	var exists bool
 
 	// Note: pragma_table_info requires string interpolation, not ? placeholders
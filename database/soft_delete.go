@@ -0,0 +1,67 @@
+package database
+
+import (
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// Soft deleted rows have deleted_at set. They are hidden from the app until
+// they are restored, or removed for good when they are purged.
+
+// AddDeletedAtColumn adds the nullable deleted_at column used for soft deletes
+func AddDeletedAtColumn(db *sqlx.DB, tableName string) error {
+	return AddColumn(db, tableName, "deleted_at", "DATETIME", false, nil)
+}
+
+// SoftDelete marks a row as deleted
+// Returns the number of rows deleted and an error if the id doesn't exist or is already deleted
+func SoftDelete(db *sqlx.DB, tableName string, id int64) (int64, error) {
+	query := fmt.Sprintf(`UPDATE %s SET deleted_at = datetime('now','subsec') WHERE id = ? AND deleted_at IS NULL`, tableName)
+	return execOne(db, query, id)
+}
+
+// Restore clears deleted_at on a soft deleted row
+func Restore(db *sqlx.DB, tableName string, id int64) error {
+	query := fmt.Sprintf(`UPDATE %s SET deleted_at = NULL WHERE id = ? AND deleted_at IS NOT NULL`, tableName)
+	_, err := execOne(db, query, id)
+	return err
+}
+
+// Purge permanently removes a soft deleted row. Rows that haven't been soft
+// deleted are left alone.
+func Purge(db *sqlx.DB, tableName string, id int64) error {
+	query := fmt.Sprintf(`DELETE FROM %s WHERE id = ? AND deleted_at IS NOT NULL`, tableName)
+	_, err := execOne(db, query, id)
+	return err
+}
+
+// PurgeExpired permanently removes rows that were soft deleted more than days ago
+// Returns the number of rows removed
+func PurgeExpired(db *sqlx.DB, tableName string, days int) (int64, error) {
+	query := fmt.Sprintf(`DELETE FROM %s WHERE deleted_at IS NOT NULL AND deleted_at < datetime('now', ?)`, tableName)
+	result, err := db.Exec(query, fmt.Sprintf("-%d days", days))
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+// execOne runs a statement against a single row by id, failing when no row matched
+func execOne(db *sqlx.DB, query string, id int64) (int64, error) {
+	result, err := db.Exec(query, id)
+	if err != nil {
+		return 0, err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	if rows == 0 {
+		return 0, fmt.Errorf("id '%d' not found", id)
+	}
+
+	return rows, nil
+}
@@ -1,7 +1,11 @@
 package character
 
 import (
+	"errors"
+	"fmt"
 	"soloterm/shared/validation"
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -12,6 +16,9 @@ const (
 	MaxAttributeValueLength = 50
 )
 
+// ErrNotNumeric is returned when stepping an attribute that isn't numeric
+var ErrNotNumeric = errors.New("entry is not numeric")
+
 type Attribute struct {
 	ID                  int64     `db:"id"`
 	CharacterID         int64     `db:"character_id"`
@@ -19,6 +26,9 @@ type Attribute struct {
 	PositionInGroup     int       `db:"position_in_group"`
 	Name                string    `db:"name"`
 	Value               string    `db:"value"`
+	Numeric             bool      `db:"numeric"` // Value is a number, or current/max such as 7/12
+	Floor               *int      `db:"floor"`   // Lowest value of a numeric entry, nil for none
+	Ceiling             *int      `db:"ceiling"` // Highest value of a numeric entry, nil for none
 	CreatedAt           time.Time `db:"created_at"`
 	UpdatedAt           time.Time `db:"updated_at"`
 	GroupCount          int       `db:"group_count"`
@@ -43,5 +53,106 @@ func (a *Attribute) Validate() *validation.Validator {
 	v.Check("name", len(a.Name) >= MinNameLength && len(a.Name) <= MaxNameLength, "must be between %d and %d characters", MinNameLength, MaxNameLength)
 	v.Check("value", len(a.Value) >= MinAttributeValueLength && len(a.Value) <= MaxAttributeValueLength, "must be between %d and %d characters", MinAttributeValueLength, MaxAttributeValueLength)
 	v.Check("character_id", a.CharacterID != 0, "is required")
+	v.Check("floor", a.Numeric || a.Floor == nil, "requires a numeric entry")
+	v.Check("ceiling", a.Numeric || a.Ceiling == nil, "requires a numeric entry")
+	if !a.Numeric {
+		return v
+	}
+
+	if a.Floor != nil && a.Ceiling != nil {
+		v.Check("ceiling", *a.Ceiling >= *a.Floor, "must not be below the floor")
+	}
+	n, err := ParseNumericValue(a.Value)
+	if err != nil {
+		v.Check("value", false, "must be a number, or current/max such as 7/12")
+		return v
+	}
+	if n.HasMax {
+		v.Check("value", n.Current <= n.Max, "must not be more than its max of %d", n.Max)
+	}
+	if a.Floor != nil {
+		v.Check("value", n.Current >= *a.Floor, "must not be below the floor of %d", *a.Floor)
+	}
+	if a.Ceiling != nil {
+		v.Check("value", n.Current <= *a.Ceiling && (!n.HasMax || n.Max <= *a.Ceiling), "must not be above the ceiling of %d", *a.Ceiling)
+	}
 	return v
 }
+
+// Step adds delta to the current value of a numeric attribute, keeping it
+// within the floor, the ceiling and its max. Returns ErrNotNumeric when the
+// attribute isn't numeric.
+func (a *Attribute) Step(delta int) error {
+	if !a.Numeric {
+		return ErrNotNumeric
+	}
+	n, err := ParseNumericValue(a.Value)
+	if err != nil {
+		return err
+	}
+
+	n.Current += delta
+	if n.HasMax {
+		n.Current = min(n.Current, n.Max)
+	}
+	if a.Ceiling != nil {
+		n.Current = min(n.Current, *a.Ceiling)
+	}
+	if a.Floor != nil {
+		n.Current = max(n.Current, *a.Floor)
+	}
+	a.Value = n.String()
+	return nil
+}
+
+// NumericValue is the value of a numeric attribute, such as 7 or 7/12
+type NumericValue struct {
+	Current int
+	Max     int
+	HasMax  bool
+}
+
+// ParseNumericValue parses a number, or current/max such as 7/12
+func ParseNumericValue(value string) (NumericValue, error) {
+	var n NumericValue
+	current, limit, hasMax := strings.Cut(value, "/")
+
+	var err error
+	if n.Current, err = strconv.Atoi(strings.TrimSpace(current)); err != nil {
+		return n, fmt.Errorf("%q is not a number", value)
+	}
+	if hasMax {
+		if n.Max, err = strconv.Atoi(strings.TrimSpace(limit)); err != nil {
+			return n, fmt.Errorf("%q does not have a numeric max", value)
+		}
+		n.HasMax = true
+	}
+	return n, nil
+}
+
+// String formats the value as it is stored, such as 7/12
+func (n NumericValue) String() string {
+	if n.HasMax {
+		return fmt.Sprintf("%d/%d", n.Current, n.Max)
+	}
+	return strconv.Itoa(n.Current)
+}
+
+// AttributeChange is a recorded change to an attribute's value, such as HP
+// going from 5 to 3 during a session
+type AttributeChange struct {
+	ID            int64     `db:"id"`
+	AttributeID   int64     `db:"attribute_id"`
+	SessionID     *int64    `db:"session_id"` // The session being played, nil for none
+	OldValue      string    `db:"old_value"`
+	NewValue      string    `db:"new_value"`
+	CreatedAt     time.Time `db:"created_at"`
+	AttributeName string    `db:"attribute_name"`
+	SessionName   string    `db:"session_name"` // Blank when no session was being played
+}
+
+// Tag returns the change as a Lonelog tag for the character, such as
+// [PC:Kira | HP 5→3]
+func (c *AttributeChange) Tag(characterName string) string {
+	return fmt.Sprintf("[PC:%s | %s %s→%s]", characterName, c.AttributeName, c.OldValue, c.NewValue)
+}
@@ -5,6 +5,8 @@ import (
 	"errors"
 	"fmt"
 	"soloterm/database"
+
+	"github.com/jmoiron/sqlx"
 )
 
 // AttributeRepository handles database operations for attributes
@@ -20,9 +22,9 @@ func NewAttributeRepository(db *database.DBStore) *AttributeRepository {
 // Save creates or updates an attribute
 func (r *AttributeRepository) Save(attribute *Attribute) error {
 	if attribute.ID == 0 {
-		return r.insert(attribute)
+		return insertAttribute(r.db.Connection, attribute)
 	} else {
-		return r.update(attribute)
+		return updateAttribute(r.db.Connection, attribute)
 	}
 }
 
@@ -117,38 +119,145 @@ func (r *AttributeRepository) SwapPositions(idA int64, posA int, idB int64, posB
 	return err
 }
 
-// insert inserts a new attribute record
-func (r *AttributeRepository) insert(attribute *Attribute) error {
+// InsertAll inserts new attributes in a single transaction, so either all of
+// them are saved or none are
+func (r *AttributeRepository) InsertAll(attributes []*Attribute) error {
+	tx, err := r.db.Connection.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, attribute := range attributes {
+		if err := insertAttribute(tx, attribute); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// SaveWithChange creates or updates an attribute and, when an existing
+// attribute's value changes, records the change in the session, all in a
+// single transaction. Returns the change, or nil when the value didn't change.
+func (r *AttributeRepository) SaveWithChange(attribute *Attribute, sessionID *int64) (*AttributeChange, error) {
+	tx, err := r.db.Connection.Beginx()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	change, err := saveAttributeWithChange(tx, attribute, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	return change, tx.Commit()
+}
+
+// GetChanges retrieves the changes to an attribute's value, newest first,
+// with the name of the session each was made in
+func (r *AttributeRepository) GetChanges(attributeID int64) ([]*AttributeChange, error) {
+	var changes []*AttributeChange
+	query := `
+		SELECT c.*, a.name AS attribute_name, COALESCE(s.name, '') AS session_name
+		FROM attribute_changes c
+		JOIN attributes a ON a.id = c.attribute_id
+		LEFT JOIN sessions s ON s.id = c.session_id
+		WHERE c.attribute_id = ?
+		ORDER BY c.created_at DESC, c.id DESC
+	`
+	err := r.db.Connection.Select(&changes, query, attributeID)
+	if err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
+
+// insertAttribute inserts a new attribute record. It takes a Queryer so the
+// character repository can insert a sheet in the same transaction.
+func insertAttribute(q sqlx.Queryer, attribute *Attribute) error {
 	query := `
-		INSERT INTO attributes (character_id, attribute_group, position_in_group, name, value, created_at, updated_at)
-		VALUES (?, ?, ?, ?, ?, datetime('now', 'subsec'), datetime('now', 'subsec'))
+		INSERT INTO attributes (character_id, attribute_group, position_in_group, name, value, numeric, floor, ceiling, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, datetime('now', 'subsec'), datetime('now', 'subsec'))
 		RETURNING id, created_at, updated_at
 	`
 
-	err := r.db.Connection.QueryRowx(query,
+	err := q.QueryRowx(query,
 		attribute.CharacterID,
 		attribute.Group,
 		attribute.PositionInGroup,
 		attribute.Name,
 		attribute.Value,
+		attribute.Numeric,
+		attribute.Floor,
+		attribute.Ceiling,
 	).StructScan(attribute)
 
 	return err
 }
 
-// update updates an existing attribute record
-func (r *AttributeRepository) update(attribute *Attribute) error {
+// saveAttributeWithChange saves the attribute and records a change to an
+// existing attribute's value against sessionID, which may be nil
+func saveAttributeWithChange(q sqlx.Queryer, attribute *Attribute, sessionID *int64) (*AttributeChange, error) {
+	if attribute.ID == 0 {
+		return nil, insertAttribute(q, attribute)
+	}
+
+	var oldValue string
+	if err := sqlx.Get(q, &oldValue, "SELECT value FROM attributes WHERE id = ?", attribute.ID); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.New("attribute not found")
+		}
+		return nil, err
+	}
+	if err := updateAttribute(q, attribute); err != nil {
+		return nil, err
+	}
+	if oldValue == attribute.Value {
+		return nil, nil
+	}
+
+	change := &AttributeChange{
+		AttributeID:   attribute.ID,
+		SessionID:     sessionID,
+		OldValue:      oldValue,
+		NewValue:      attribute.Value,
+		AttributeName: attribute.Name,
+	}
+	query := `
+		INSERT INTO attribute_changes (attribute_id, session_id, old_value, new_value, created_at)
+		VALUES (?, ?, ?, ?, datetime('now', 'subsec'))
+		RETURNING id, created_at
+	`
+	err := q.QueryRowx(query,
+		change.AttributeID,
+		change.SessionID,
+		change.OldValue,
+		change.NewValue,
+	).StructScan(change)
+	if err != nil {
+		return nil, err
+	}
+	return change, nil
+}
+
+// updateAttribute updates an existing attribute record
+func updateAttribute(q sqlx.Queryer, attribute *Attribute) error {
 	query := `
-		UPDATE attributes SET attribute_group = ?, position_in_group = ?, name = ?, value = ?, updated_at = datetime('now','subsec')
+		UPDATE attributes SET attribute_group = ?, position_in_group = ?, name = ?, value = ?, numeric = ?, floor = ?, ceiling = ?, updated_at = datetime('now','subsec')
 		WHERE id = ?
 		RETURNING created_at, updated_at
 	`
 
-	err := r.db.Connection.QueryRowx(query,
+	err := q.QueryRowx(query,
 		attribute.Group,
 		attribute.PositionInGroup,
 		attribute.Name,
 		attribute.Value,
+		attribute.Numeric,
+		attribute.Floor,
+		attribute.Ceiling,
 		attribute.ID,
 	).StructScan(attribute)
 
@@ -1,10 +1,14 @@
 package character
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+)
 
 // AttributeService handles attribute business logic
 type AttributeService struct {
-	repo *AttributeRepository
+	repo          *AttributeRepository
+	activeSession func() *int64
 }
 
 // NewAttributeService creates a new attribute service
@@ -12,21 +16,133 @@ func NewAttributeService(repo *AttributeRepository) *AttributeService {
 	return &AttributeService{repo: repo}
 }
 
-// Save validates and saves an attribute (create or update)
+// SetActiveSession sets how the service finds the session being played, which
+// changes to attribute values are recorded against
+func (s *AttributeService) SetActiveSession(activeSession func() *int64) {
+	s.activeSession = activeSession
+}
+
+// Save validates and saves an attribute (create or update). A change to an
+// existing attribute's value is recorded in its history.
 func (s *AttributeService) Save(a *Attribute) (*Attribute, error) {
+	a, _, err := s.SaveWithChange(a)
+	return a, err
+}
+
+// SaveWithChange saves an attribute like Save and returns the change recorded
+// in its history, or nil when the value didn't change
+func (s *AttributeService) SaveWithChange(a *Attribute) (*Attribute, *AttributeChange, error) {
 	// Validate
 	validator := a.Validate()
 	if validator.HasErrors() {
-		return nil, validator
+		return nil, nil, validator
+	}
+
+	var sessionID *int64
+	if s.activeSession != nil {
+		sessionID = s.activeSession()
 	}
 
-	// Save to database
-	err := s.repo.Save(a)
+	// Save to database along with the change
+	change, err := s.repo.SaveWithChange(a, sessionID)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	return a, nil
+	return a, change, nil
+}
+
+// History retrieves the changes to an attribute's value, newest first
+func (s *AttributeService) History(attributeID int64) ([]*AttributeChange, error) {
+	return s.repo.GetChanges(attributeID)
+}
+
+// CreateAll validates and inserts new attributes together. Nothing is saved
+// when any of them is invalid.
+func (s *AttributeService) CreateAll(attrs []*Attribute) error {
+	for _, a := range attrs {
+		if validator := a.Validate(); validator.HasErrors() {
+			return validator
+		}
+	}
+	return s.repo.InsertAll(attrs)
+}
+
+// Adjust steps a numeric attribute's current value by delta, such as -1 for a
+// point of damage, and saves it. Returns ErrNotNumeric when the attribute
+// isn't numeric. The change is recorded like any other save, and is nil when
+// the value was already at its limit.
+func (s *AttributeService) Adjust(id int64, delta int) (*Attribute, *AttributeChange, error) {
+	a, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := a.Step(delta); err != nil {
+		return nil, nil, err
+	}
+	return s.SaveWithChange(a)
+}
+
+// matchSheet gives each of attrs, the new sheet in display order, the ID of
+// the entry already on the sheet with the same section and name, so saving it
+// updates that entry and its history carries on. An entry moved to another
+// section is matched by its name alone. Returns the IDs of the existing
+// entries that are no longer on the sheet.
+func matchSheet(existing []*Attribute, attrs []*Attribute) []int64 {
+	byKey := make(map[string]*Attribute)
+	for _, key := range sheetKeys(existing) {
+		byKey[key.key] = key.attr
+	}
+
+	matched := make(map[int64]bool)
+	for _, key := range sheetKeys(attrs) {
+		if match, ok := byKey[key.key]; ok {
+			key.attr.ID = match.ID
+			matched[match.ID] = true
+		}
+	}
+	for _, a := range attrs {
+		if a.ID != 0 {
+			continue
+		}
+		for _, e := range existing {
+			if !matched[e.ID] && strings.EqualFold(e.Name, a.Name) {
+				a.ID = e.ID
+				matched[e.ID] = true
+				break
+			}
+		}
+	}
+
+	var removed []int64
+	for _, e := range existing {
+		if !matched[e.ID] {
+			removed = append(removed, e.ID)
+		}
+	}
+	return removed
+}
+
+type sheetKey struct {
+	key  string
+	attr *Attribute
+}
+
+// sheetKeys identifies each attribute by its section's name and its own,
+// ignoring case. A repeated name gets a count so each one is kept.
+func sheetKeys(attrs []*Attribute) []sheetKey {
+	var keys []sheetKey
+	seen := make(map[string]int)
+	section := ""
+	for _, a := range attrs {
+		if a.PositionInGroup == 0 {
+			section = strings.ToLower(a.Name)
+		}
+		key := section + "\x00" + strings.ToLower(a.Name)
+		seen[key]++
+		keys = append(keys, sheetKey{key: fmt.Sprintf("%s\x00%d", key, seen[key]), attr: a})
+	}
+	return keys
 }
 
 // Delete removes an attribute by ID
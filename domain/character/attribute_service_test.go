@@ -1,10 +1,14 @@
 package character
 
 import (
+	"errors"
 	"testing"
 	"time"
 
 	testhelper "soloterm/shared/testing"
+
+	// Blank import so the sessions table exists for attribute changes
+	_ "soloterm/domain/session"
 )
 
 func TestAttributeService_Save(t *testing.T) {
@@ -379,3 +383,167 @@ func TestAttributeService_Delete(t *testing.T) {
 	})
 
 }
+
+func TestAttributeService_Adjust(t *testing.T) {
+	// Setup
+	db := testhelper.SetupTestDB(t)
+	defer testhelper.TeardownTestDB(t, db)
+
+	repo := NewRepository(db)
+	attrRepo := NewAttributeRepository(db)
+	attrService := NewAttributeService(attrRepo)
+
+	character, _ := NewCharacter("Test Character", "FlexD6", "Fighter", "Human")
+	repo.Save(character)
+
+	floor := 0
+	hp, _ := NewAttribute(character.ID, 0, 0, "HP", "1/12")
+	hp.Numeric = true
+	hp.Floor = &floor
+	hp, err := attrService.Save(hp)
+	if err != nil {
+		t.Fatalf("Save() failed: %v", err)
+	}
+
+	t.Run("saves the new value", func(t *testing.T) {
+		adjusted, change, err := attrService.Adjust(hp.ID, -1)
+		if err != nil {
+			t.Fatalf("Adjust() failed: %v", err)
+		}
+		if adjusted.Value != "0/12" {
+			t.Errorf("Expected 0/12, got %q", adjusted.Value)
+		}
+		if change == nil || change.OldValue != "1/12" || change.NewValue != "0/12" {
+			t.Errorf("Expected a change from 1/12 to 0/12, got %+v", change)
+		}
+
+		stored, _ := attrService.GetByID(hp.ID)
+		if stored.Value != "0/12" || !stored.Numeric || stored.Floor == nil || *stored.Floor != 0 || stored.Ceiling != nil {
+			t.Errorf("Expected the stored entry to be numeric at 0/12 with a floor of 0, got %+v", stored)
+		}
+	})
+
+	t.Run("stays at the floor", func(t *testing.T) {
+		adjusted, change, err := attrService.Adjust(hp.ID, -1)
+		if err != nil {
+			t.Fatalf("Adjust() failed: %v", err)
+		}
+		if adjusted.Value != "0/12" {
+			t.Errorf("Expected 0/12, got %q", adjusted.Value)
+		}
+		if change != nil {
+			t.Errorf("Expected no change at the floor, got %+v", change)
+		}
+	})
+
+	t.Run("not numeric", func(t *testing.T) {
+		gear, _ := NewAttribute(character.ID, 1, 0, "Gear", "Rope")
+		gear, _ = attrService.Save(gear)
+		if _, _, err := attrService.Adjust(gear.ID, 1); !errors.Is(err, ErrNotNumeric) {
+			t.Errorf("Expected ErrNotNumeric, got %v", err)
+		}
+	})
+}
+
+func TestAttributeService_History(t *testing.T) {
+	// Setup
+	db := testhelper.SetupTestDB(t)
+	defer testhelper.TeardownTestDB(t, db)
+
+	repo := NewRepository(db)
+	attrRepo := NewAttributeRepository(db)
+	attrService := NewAttributeService(attrRepo)
+
+	character, _ := NewCharacter("Kira", "FlexD6", "Fighter", "Human")
+	repo.Save(character)
+
+	gameID := testhelper.CreateTestGame(t, db, "Game")
+	sessionID := testhelper.CreateTestSession(t, db, gameID, "Session 1", "")
+	var activeSession *int64
+	attrService.SetActiveSession(func() *int64 { return activeSession })
+
+	hp, _ := NewAttribute(character.ID, 0, 0, "HP", "5")
+	hp, _ = attrService.Save(hp)
+
+	t.Run("creating is not a change", func(t *testing.T) {
+		changes, err := attrService.History(hp.ID)
+		if err != nil {
+			t.Fatalf("History() failed: %v", err)
+		}
+		if len(changes) != 0 {
+			t.Errorf("Expected no changes, got %d", len(changes))
+		}
+	})
+
+	t.Run("records value changes newest first", func(t *testing.T) {
+		hp.Value = "4"
+		if _, err := attrService.Save(hp); err != nil {
+			t.Fatalf("Save() failed: %v", err)
+		}
+
+		activeSession = &sessionID
+		hp.Value = "3"
+		_, change, err := attrService.SaveWithChange(hp)
+		if err != nil {
+			t.Fatalf("SaveWithChange() failed: %v", err)
+		}
+		if got := change.Tag(character.Name); got != "[PC:Kira | HP 4→3]" {
+			t.Errorf("Expected the change as a tag, got %q", got)
+		}
+
+		// Renaming doesn't change the value
+		hp.Name = "Health"
+		if _, err := attrService.Save(hp); err != nil {
+			t.Fatalf("Save() failed: %v", err)
+		}
+
+		changes, err := attrService.History(hp.ID)
+		if err != nil {
+			t.Fatalf("History() failed: %v", err)
+		}
+		if len(changes) != 2 {
+			t.Fatalf("Expected 2 changes, got %d", len(changes))
+		}
+		if changes[0].OldValue != "4" || changes[0].NewValue != "3" || changes[0].SessionName != "Session 1" {
+			t.Errorf("Expected 4 to 3 in Session 1 first, got %+v", changes[0])
+		}
+		if changes[0].AttributeName != "Health" {
+			t.Errorf("Expected the current attribute name, got %q", changes[0].AttributeName)
+		}
+		if changes[1].OldValue != "5" || changes[1].NewValue != "4" || changes[1].SessionID != nil {
+			t.Errorf("Expected 5 to 4 outside a session second, got %+v", changes[1])
+		}
+	})
+
+	t.Run("a change that can't be recorded isn't saved", func(t *testing.T) {
+		if _, err := db.Connection.Exec("ALTER TABLE attribute_changes RENAME TO attribute_changes_gone"); err != nil {
+			t.Fatalf("Failed to break the attribute_changes table: %v", err)
+		}
+		defer db.Connection.Exec("ALTER TABLE attribute_changes_gone RENAME TO attribute_changes")
+
+		hp.Value = "2"
+		if _, _, err := attrService.SaveWithChange(hp); err == nil {
+			t.Fatal("Expected an error recording the change")
+		}
+		saved, err := attrService.GetByID(hp.ID)
+		if err != nil {
+			t.Fatalf("GetByID() failed: %v", err)
+		}
+		if saved.Value != "3" {
+			t.Errorf("Expected the value to stay 3, got %q", saved.Value)
+		}
+	})
+
+	t.Run("deleting the attribute removes its history", func(t *testing.T) {
+		if err := attrService.Delete(hp.ID); err != nil {
+			t.Fatalf("Delete() failed: %v", err)
+		}
+		changes, err := attrRepo.GetChanges(hp.ID)
+		if err != nil {
+			t.Fatalf("GetChanges() failed: %v", err)
+		}
+		if len(changes) != 0 {
+			t.Errorf("Expected the history to be deleted, got %d changes", len(changes))
+		}
+	})
+}
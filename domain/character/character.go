@@ -19,13 +19,14 @@ const (
 
 // Character represents a character in the system
 type Character struct {
-	ID        int64     `db:"id"`
-	Name      string    `db:"name"`
-	System    string    `db:"system"`
-	Role      string    `db:"role"`
-	Species   string    `db:"species"`
-	CreatedAt time.Time `db:"created_at"`
-	UpdatedAt time.Time `db:"updated_at"`
+	ID        int64      `db:"id"`
+	Name      string     `db:"name"`
+	System    string     `db:"system"`
+	Role      string     `db:"role"`
+	Species   string     `db:"species"`
+	CreatedAt time.Time  `db:"created_at"`
+	UpdatedAt time.Time  `db:"updated_at"`
+	DeletedAt *time.Time `db:"deleted_at"` // Set while the character is in the trash
 }
 
 func NewCharacter(name string, system string, role string, species string) (*Character, error) {
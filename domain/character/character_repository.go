@@ -3,8 +3,9 @@ package character
 import (
 	"database/sql"
 	"errors"
-	"fmt"
 	"soloterm/database"
+
+	"github.com/jmoiron/sqlx"
 )
 
 // Repository handles database operations for characters
@@ -22,38 +23,42 @@ func NewRepository(db *database.DBStore) *Repository {
 // The character pointer is updated with the current values after save
 func (r *Repository) Save(character *Character) error {
 	if character.ID == 0 {
-		return r.insert(character)
+		return r.insert(r.db.Connection, character)
 	} else {
-		return r.update(character)
+		return r.update(r.db.Connection, character)
 	}
 }
 
-// Delete removes a character by id
+// Delete moves a character to the trash. Their sheet stays with them.
 // Returns the number of rows deleted and an error if the id doesn't exist
 func (r *Repository) Delete(id int64) (int64, error) {
 	if id == 0 {
 		return 0, errors.New("id cannot be empty")
 	}
 
-	query := `DELETE FROM characters WHERE id = ?`
-
-	result, err := r.db.Connection.Exec(query, id)
+	return database.SoftDelete(r.db.Connection, "characters", id)
+}
 
-	if err != nil {
-		return 0, err
-	}
+// Restore brings a character back from the trash
+func (r *Repository) Restore(id int64) error {
+	return database.Restore(r.db.Connection, "characters", id)
+}
 
-	// Check if a row was actually deleted
-	rows, err := result.RowsAffected()
-	if err != nil {
-		return 0, err
-	}
+// Purge permanently removes a character in the trash, along with their sheet
+func (r *Repository) Purge(id int64) error {
+	return database.Purge(r.db.Connection, "characters", id)
+}
 
-	if rows == 0 {
-		return 0, fmt.Errorf("id '%d' not found", id)
-	}
+// PurgeExpired permanently removes characters that have been in the trash for more than days
+func (r *Repository) PurgeExpired(days int) (int64, error) {
+	return database.PurgeExpired(r.db.Connection, "characters", days)
+}
 
-	return rows, nil
+// GetDeleted retrieves the characters in the trash, most recently deleted first
+func (r *Repository) GetDeleted() ([]*Character, error) {
+	var characters []*Character
+	err := r.db.Connection.Select(&characters, "SELECT * FROM characters WHERE deleted_at IS NOT NULL ORDER BY deleted_at DESC")
+	return characters, err
 }
 
 // GetByID retrieves a character by ID
@@ -63,7 +68,7 @@ func (r *Repository) GetByID(id int64) (*Character, error) {
 	}
 
 	var character Character
-	err := r.db.Connection.Get(&character, "SELECT * FROM characters WHERE id = ?", id)
+	err := r.db.Connection.Get(&character, "SELECT * FROM characters WHERE id = ? AND deleted_at IS NULL", id)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, errors.New("character not found")
@@ -77,22 +82,135 @@ func (r *Repository) GetByID(id int64) (*Character, error) {
 // GetAll retrieves all characters ordered by name
 func (r *Repository) GetAll() ([]*Character, error) {
 	var characters []*Character
-	err := r.db.Connection.Select(&characters, "SELECT * FROM characters ORDER BY lower(system), lower(name) ASC")
+	err := r.db.Connection.Select(&characters, "SELECT * FROM characters WHERE deleted_at IS NULL ORDER BY lower(system), lower(name) ASC")
 	if err != nil {
 		return nil, err
 	}
 	return characters, nil
 }
 
+// FindByName retrieves the character with the name in the system, ignoring
+// case and the trash. Returns nil when there is none.
+func (r *Repository) FindByName(name string, system string) (*Character, error) {
+	var character Character
+	query := `SELECT * FROM characters
+		WHERE name = ? COLLATE NOCASE AND system = ? COLLATE NOCASE AND deleted_at IS NULL
+		ORDER BY id LIMIT 1`
+	err := r.db.Connection.Get(&character, query, name, system)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &character, nil
+}
+
+// GetAllForGame retrieves the characters in the game, ordered like GetAll
+func (r *Repository) GetAllForGame(gameID int64) ([]*Character, error) {
+	var characters []*Character
+	query := `SELECT c.* FROM characters c
+		JOIN character_games cg ON cg.character_id = c.id
+		WHERE cg.game_id = ? AND c.deleted_at IS NULL
+		ORDER BY lower(c.system), lower(c.name) ASC`
+	err := r.db.Connection.Select(&characters, query, gameID)
+	if err != nil {
+		return nil, err
+	}
+	return characters, nil
+}
+
+// AddToGame puts a character in a game. Adding them again does nothing.
+func (r *Repository) AddToGame(characterID int64, gameID int64) error {
+	_, err := r.db.Connection.Exec("INSERT OR IGNORE INTO character_games (character_id, game_id) VALUES (?, ?)", characterID, gameID)
+	return err
+}
+
+// RemoveFromGame takes a character out of a game
+func (r *Repository) RemoveFromGame(characterID int64, gameID int64) error {
+	_, err := r.db.Connection.Exec("DELETE FROM character_games WHERE character_id = ? AND game_id = ?", characterID, gameID)
+	return err
+}
+
+// GetGameIDs retrieves the IDs of the games a character is in
+func (r *Repository) GetGameIDs(characterID int64) ([]int64, error) {
+	var ids []int64
+	err := r.db.Connection.Select(&ids, "SELECT game_id FROM character_games WHERE character_id = ? ORDER BY game_id", characterID)
+	return ids, err
+}
+
+// GetGameNames retrieves the names of the games a character is in, leaving
+// out games in the trash, ordered by name
+func (r *Repository) GetGameNames(characterID int64) ([]string, error) {
+	var names []string
+	query := `SELECT g.name FROM games g
+		JOIN character_games cg ON cg.game_id = g.id
+		WHERE cg.character_id = ? AND g.deleted_at IS NULL
+		ORDER BY lower(g.name)`
+	err := r.db.Connection.Select(&names, query, characterID)
+	return names, err
+}
+
+// InsertWithSheet inserts a new character and their attributes in a single
+// transaction, so a character is never left without their sheet
+func (r *Repository) InsertWithSheet(character *Character, attributes []*Attribute) error {
+	tx, err := r.db.Connection.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := r.insert(tx, character); err != nil {
+		return err
+	}
+	for _, attribute := range attributes {
+		attribute.CharacterID = character.ID
+		if err := insertAttribute(tx, attribute); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// UpdateWithSheet updates a character and replaces their sheet in a single
+// transaction. Attributes with an ID are updated, recording changes to their
+// values outside of any session, the others are inserted, and the attributes
+// in removedIDs are deleted.
+func (r *Repository) UpdateWithSheet(character *Character, attributes []*Attribute, removedIDs []int64) error {
+	tx, err := r.db.Connection.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := r.update(tx, character); err != nil {
+		return err
+	}
+	for _, attribute := range attributes {
+		attribute.CharacterID = character.ID
+		if _, err := saveAttributeWithChange(tx, attribute, nil); err != nil {
+			return err
+		}
+	}
+	for _, id := range removedIDs {
+		if _, err := tx.Exec("DELETE FROM attributes WHERE id = ? AND character_id = ?", id, character.ID); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
 // insert inserts a new character record
-func (r *Repository) insert(character *Character) error {
+func (r *Repository) insert(q sqlx.Queryer, character *Character) error {
 	query := `
 		INSERT INTO characters (name, system, role, species, created_at, updated_at)
 		VALUES (?, ?, ?, ?, datetime('now', 'subsec'), datetime('now', 'subsec'))
 		RETURNING id, created_at, updated_at
 	`
 
-	err := r.db.Connection.QueryRowx(query,
+	err := q.QueryRowx(query,
 		character.Name,
 		character.System,
 		character.Role,
@@ -103,14 +221,14 @@ func (r *Repository) insert(character *Character) error {
 }
 
 // update updates an existing character record
-func (r *Repository) update(character *Character) error {
+func (r *Repository) update(q sqlx.Queryer, character *Character) error {
 	query := `
 		UPDATE characters SET name = ?, system = ?, role = ?, species = ?, updated_at = datetime('now','subsec')
 		WHERE id = ?
 		RETURNING created_at, updated_at
 	`
 
-	err := r.db.Connection.QueryRowx(query,
+	err := q.QueryRowx(query,
 		character.Name,
 		character.System,
 		character.Role,
@@ -4,6 +4,7 @@ import (
 	"testing"
 	"time"
 
+	_ "soloterm/domain/game"
 	testhelper "soloterm/shared/testing"
 )
 
@@ -209,3 +210,162 @@ func TestCharacterRepository_Delete(t *testing.T) {
 	})
 
 }
+
+func TestCharacterRepository_Games(t *testing.T) {
+	// Setup
+	db := testhelper.SetupTestDB(t)
+	defer testhelper.TeardownTestDB(t, db)
+
+	repo := NewRepository(db)
+	firstGameID := testhelper.CreateTestGame(t, db, "Ironsworn Campaign")
+	secondGameID := testhelper.CreateTestGame(t, db, "Another Campaign")
+
+	kara, _ := NewCharacter("Kara", "Ironsworn", "Scout", "Human")
+	bren, _ := NewCharacter("Bren", "Ironsworn", "Warden", "Human")
+	for _, c := range []*Character{kara, bren} {
+		if err := repo.Save(c); err != nil {
+			t.Fatalf("Save() failed: %v", err)
+		}
+	}
+
+	t.Run("add to games", func(t *testing.T) {
+		for _, err := range []error{
+			repo.AddToGame(kara.ID, firstGameID),
+			repo.AddToGame(kara.ID, secondGameID),
+			repo.AddToGame(kara.ID, firstGameID), // adding twice does nothing
+			repo.AddToGame(bren.ID, secondGameID),
+		} {
+			if err != nil {
+				t.Fatalf("AddToGame() failed: %v", err)
+			}
+		}
+
+		party, err := repo.GetAllForGame(firstGameID)
+		if err != nil {
+			t.Fatalf("GetAllForGame() failed: %v", err)
+		}
+		if len(party) != 1 || party[0].ID != kara.ID {
+			t.Errorf("Expected only Kara in the first game, got %v", party)
+		}
+
+		party, err = repo.GetAllForGame(secondGameID)
+		if err != nil {
+			t.Fatalf("GetAllForGame() failed: %v", err)
+		}
+		if len(party) != 2 || party[0].Name != "Bren" {
+			t.Errorf("Expected Bren then Kara in the second game, got %v", party)
+		}
+
+		names, err := repo.GetGameNames(kara.ID)
+		if err != nil {
+			t.Fatalf("GetGameNames() failed: %v", err)
+		}
+		if len(names) != 2 || names[0] != "Another Campaign" {
+			t.Errorf("Expected both games ordered by name, got %v", names)
+		}
+	})
+
+	t.Run("characters in the trash are left out", func(t *testing.T) {
+		if _, err := repo.Delete(bren.ID); err != nil {
+			t.Fatalf("Delete() failed: %v", err)
+		}
+		party, err := repo.GetAllForGame(secondGameID)
+		if err != nil {
+			t.Fatalf("GetAllForGame() failed: %v", err)
+		}
+		if len(party) != 1 || party[0].ID != kara.ID {
+			t.Errorf("Expected only Kara in the second game, got %v", party)
+		}
+	})
+
+	t.Run("remove from game", func(t *testing.T) {
+		if err := repo.RemoveFromGame(kara.ID, firstGameID); err != nil {
+			t.Fatalf("RemoveFromGame() failed: %v", err)
+		}
+		ids, err := repo.GetGameIDs(kara.ID)
+		if err != nil {
+			t.Fatalf("GetGameIDs() failed: %v", err)
+		}
+		if len(ids) != 1 || ids[0] != secondGameID {
+			t.Errorf("Expected Kara in the second game only, got %v", ids)
+		}
+	})
+}
+
+func TestCharacterRepository_InsertWithSheet(t *testing.T) {
+	// Setup
+	db := testhelper.SetupTestDB(t)
+	defer testhelper.TeardownTestDB(t, db)
+
+	repo := NewRepository(db)
+	attrRepo := NewAttributeRepository(db)
+
+	character, _ := NewCharacter("Kara", "Ironsworn", "Scout", "Human")
+	edge, _ := NewAttribute(0, 0, 0, "Edge", "2")
+	if err := repo.InsertWithSheet(character, []*Attribute{edge}); err != nil {
+		t.Fatalf("InsertWithSheet() failed: %v", err)
+	}
+	attrs, _ := attrRepo.GetForCharacter(character.ID)
+	if len(attrs) != 1 || attrs[0].CharacterID != character.ID {
+		t.Fatalf("Expected Edge on the new character's sheet, got %d attributes", len(attrs))
+	}
+
+	t.Run("failed sheet saves no character", func(t *testing.T) {
+		if _, err := db.Connection.Exec("ALTER TABLE attributes RENAME TO attributes_gone"); err != nil {
+			t.Fatalf("Failed to break the attributes table: %v", err)
+		}
+
+		character, _ := NewCharacter("Bren", "Ironsworn", "Warden", "Human")
+		heart, _ := NewAttribute(0, 0, 0, "Heart", "3")
+		if err := repo.InsertWithSheet(character, []*Attribute{heart}); err == nil {
+			t.Fatal("Expected an error inserting the sheet")
+		}
+		chars, _ := repo.GetAll()
+		if len(chars) != 1 {
+			t.Errorf("Expected only the first character, got %d", len(chars))
+		}
+	})
+}
+
+func TestMigrate_PutsExistingCharactersInEveryGame(t *testing.T) {
+	db := testhelper.SetupTestDB(t)
+	defer testhelper.TeardownTestDB(t, db)
+	repo := NewRepository(db)
+
+	first := testhelper.CreateTestGame(t, db, "First")
+	second := testhelper.CreateTestGame(t, db, "Second")
+	kira, _ := NewCharacter("Kira", "FlexD6", "Scout", "Human")
+	bren, _ := NewCharacter("Bren", "FlexD6", "Warden", "Human")
+	repo.Save(kira)
+	repo.Save(bren)
+
+	// Simulate upgrading from a database from before characters joined games
+	if _, err := db.Connection.Exec("DROP TABLE character_games"); err != nil {
+		t.Fatalf("Failed to drop character_games: %v", err)
+	}
+	if err := Migrate(db); err != nil {
+		t.Fatalf("Migrate() failed: %v", err)
+	}
+
+	for _, gameID := range []int64{first, second} {
+		chars, err := repo.GetAllForGame(gameID)
+		if err != nil {
+			t.Fatalf("GetAllForGame() failed: %v", err)
+		}
+		if len(chars) != 2 {
+			t.Errorf("Expected both characters in game %d, got %d", gameID, len(chars))
+		}
+	}
+
+	// Later migrations leave the parties alone
+	if err := repo.RemoveFromGame(kira.ID, second); err != nil {
+		t.Fatalf("RemoveFromGame() failed: %v", err)
+	}
+	if err := Migrate(db); err != nil {
+		t.Fatalf("Migrate() failed: %v", err)
+	}
+	chars, _ := repo.GetAllForGame(second)
+	if len(chars) != 1 || chars[0].ID != bren.ID {
+		t.Errorf("Expected only Bren in the second game, got %d characters", len(chars))
+	}
+}
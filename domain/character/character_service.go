@@ -1,5 +1,11 @@
 package character
 
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
 // Service handles character business logic
 type Service struct {
 	repo        *Repository
@@ -28,6 +34,26 @@ func (s *Service) Save(c *Character) (*Character, error) {
 	return c, nil
 }
 
+// CreateFromTemplate saves a new character and fills their sheet from the
+// template, together. The character's system defaults to the template's.
+func (s *Service) CreateFromTemplate(c *Character, t *SheetTemplate) (*Character, error) {
+	if err := t.Validate(); err != nil {
+		return nil, fmt.Errorf("sheet template %q: %w", t.Name, err)
+	}
+	if strings.TrimSpace(c.System) == "" {
+		c.System = t.SystemName()
+	}
+	if validator := c.Validate(); validator.HasErrors() {
+		return nil, validator
+	}
+
+	// The template is valid, so its attributes are too once they have the character
+	if err := s.repo.InsertWithSheet(c, t.Sections.Attributes(0)); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
 // Duplicate makes a copy of the character including all attributes and returns the character
 func (s *Service) Duplicate(id int64) (*Character, error) {
 
@@ -59,10 +85,74 @@ func (s *Service) Duplicate(id int64) (*Character, error) {
 		}
 	}
 
+	// Keep the copy in the same games
+	gameIDs, err := s.repo.GetGameIDs(id)
+	if err != nil {
+		return nil, err
+	}
+	for _, gameID := range gameIDs {
+		if err := s.repo.AddToGame(char.ID, gameID); err != nil {
+			return nil, err
+		}
+	}
+
 	return char, nil
 
 }
 
+// Export returns the character and their sheet as a sheet file
+func (s *Service) Export(id int64) (*SheetFile, error) {
+	c, err := s.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	attrs, err := s.attrService.GetForCharacter(id)
+	if err != nil {
+		return nil, err
+	}
+	return NewSheetFile(c, attrs), nil
+}
+
+// Import saves the character in a sheet file. A character with the same name
+// and system is updated to match the file, keeping the history of entries
+// still on the sheet; otherwise a new character is created. Either way the
+// character and their sheet are saved together. Changes to values are
+// recorded outside of any session, as they weren't made in play. Reports
+// whether an existing character was updated.
+func (s *Service) Import(f *SheetFile) (*Character, bool, error) {
+	if err := f.Validate(); err != nil {
+		return nil, false, err
+	}
+
+	c, err := s.repo.FindByName(f.Name, f.System)
+	if err != nil {
+		return nil, false, err
+	}
+	if c == nil {
+		c = f.Character()
+		if err := s.repo.InsertWithSheet(c, f.Sections.Attributes(0)); err != nil {
+			return nil, false, err
+		}
+		return c, false, nil
+	}
+
+	c.Role = f.Role
+	c.Species = f.Species
+	if validator := c.Validate(); validator.HasErrors() {
+		return nil, false, validator
+	}
+	existing, err := s.attrService.GetForCharacter(c.ID)
+	if err != nil {
+		return nil, false, err
+	}
+	attrs := f.Sections.Attributes(c.ID)
+	removed := matchSheet(existing, attrs)
+	if err := s.repo.UpdateWithSheet(c, attrs, removed); err != nil {
+		return nil, false, err
+	}
+	return c, true, nil
+}
+
 // Delete removes a character by ID
 func (s *Service) Delete(id int64) error {
 	_, err := s.repo.Delete(id)
@@ -78,3 +168,32 @@ func (s *Service) GetByID(id int64) (*Character, error) {
 func (s *Service) GetAll() ([]*Character, error) {
 	return s.repo.GetAll()
 }
+
+// GetAllForGame retrieves the game's party: the characters in the game
+func (s *Service) GetAllForGame(gameID int64) ([]*Character, error) {
+	return s.repo.GetAllForGame(gameID)
+}
+
+// AddToGame puts a character in a game's party
+func (s *Service) AddToGame(characterID int64, gameID int64) error {
+	return s.repo.AddToGame(characterID, gameID)
+}
+
+// RemoveFromGame takes a character out of a game's party
+func (s *Service) RemoveFromGame(characterID int64, gameID int64) error {
+	return s.repo.RemoveFromGame(characterID, gameID)
+}
+
+// IsInGame reports whether a character is in a game's party
+func (s *Service) IsInGame(characterID int64, gameID int64) (bool, error) {
+	ids, err := s.repo.GetGameIDs(characterID)
+	if err != nil {
+		return false, err
+	}
+	return slices.Contains(ids, gameID), nil
+}
+
+// GameNames retrieves the names of the games a character is in
+func (s *Service) GameNames(characterID int64) ([]string, error) {
+	return s.repo.GetGameNames(characterID)
+}
@@ -1,9 +1,11 @@
 package character
 
 import (
+	"strings"
 	"testing"
 	"time"
 
+	_ "soloterm/domain/game"
 	testhelper "soloterm/shared/testing"
 )
 
@@ -179,6 +181,10 @@ func TestCharacterService_Duplicate(t *testing.T) {
 	if err != nil {
 		t.Fatalf("Save() failed: %v", err)
 	}
+	gameID := testhelper.CreateTestGame(t, db, "Test Game")
+	if err := service.AddToGame(character.ID, gameID); err != nil {
+		t.Fatalf("AddToGame() failed: %v", err)
+	}
 
 	attr, _ := NewAttribute(character.ID, 0, 1, "Health: Max", "10")
 	attr, _ = attrService.Save(attr)
@@ -214,6 +220,15 @@ func TestCharacterService_Duplicate(t *testing.T) {
 		t.Errorf("Expected 2 attributes, got %d", len(attrs))
 	}
 
+	// The copy is in the same games
+	inGame, err := service.IsInGame(char.ID, gameID)
+	if err != nil {
+		t.Fatalf("IsInGame() failed: %v", err)
+	}
+	if !inGame {
+		t.Error("Expected the copy to be in the original's game")
+	}
+
 	// Original is still in tact
 	attrs, err = attrService.GetForCharacter(character.ID)
 	if err != nil {
@@ -224,3 +239,174 @@ func TestCharacterService_Duplicate(t *testing.T) {
 	}
 
 }
+
+func TestCharacterService_CreateFromTemplate(t *testing.T) {
+	// Setup
+	db := testhelper.SetupTestDB(t)
+	defer testhelper.TeardownTestDB(t, db)
+
+	attrService := NewAttributeService(NewAttributeRepository(db))
+	service := NewService(NewRepository(db), attrService)
+
+	tmpl := DefaultSheetTemplates()[1] // Ironsworn
+	character, _ := NewCharacter("Kara", "", "Scout", "Human")
+	character, err := service.CreateFromTemplate(character, &tmpl)
+	if err != nil {
+		t.Fatalf("CreateFromTemplate() failed: %v", err)
+	}
+
+	if character.System != "Ironsworn" {
+		t.Errorf("Expected the system to come from the template, got %q", character.System)
+	}
+
+	attrs, err := attrService.GetForCharacter(character.ID)
+	if err != nil {
+		t.Fatalf("GetForCharacter() failed: %v", err)
+	}
+	if len(attrs) != len(tmpl.Sections.Attributes(character.ID)) {
+		t.Fatalf("Expected %d attributes, got %d", len(tmpl.Sections.Attributes(character.ID)), len(attrs))
+	}
+	if attrs[0].Name != "Stats" || attrs[1].Name != "Edge" || attrs[1].Group != 0 || attrs[1].PositionInGroup != 1 {
+		t.Errorf("Expected the Stats section first with Edge in it, got %q and %q", attrs[0].Name, attrs[1].Name)
+	}
+	last := attrs[len(attrs)-1]
+	if last.Name != "Experience" || last.Group != 3 || last.PositionInGroup != 0 {
+		t.Errorf("Expected Experience last as its own section, got %+v", *last)
+	}
+
+	t.Run("invalid template saves nothing", func(t *testing.T) {
+		character, _ := NewCharacter("Bren", "Ironsworn", "Warden", "Human")
+		_, err := service.CreateFromTemplate(character, &SheetTemplate{Name: "Broken"})
+		if err == nil {
+			t.Fatal("Expected an error for a template without sections")
+		}
+		chars, _ := service.GetAll()
+		if len(chars) != 1 {
+			t.Errorf("Expected only the first character, got %d", len(chars))
+		}
+	})
+}
+
+func TestCharacterService_Import(t *testing.T) {
+	// Setup
+	db := testhelper.SetupTestDB(t)
+	defer testhelper.TeardownTestDB(t, db)
+
+	attrService := NewAttributeService(NewAttributeRepository(db))
+	service := NewService(NewRepository(db), attrService)
+
+	f := testSheetFile()
+	character, updated, err := service.Import(f)
+	if err != nil {
+		t.Fatalf("Import() failed: %v", err)
+	}
+	if updated {
+		t.Error("Expected a new character")
+	}
+
+	exported, err := service.Export(character.ID)
+	if err != nil {
+		t.Fatalf("Export() failed: %v", err)
+	}
+	first, _ := f.Render(SheetFormatYAML)
+	second, _ := exported.Render(SheetFormatYAML)
+	if first != second {
+		t.Errorf("Expected the export to match the imported file\nimported:\n%s\nexported:\n%s", first, second)
+	}
+
+	// Imports are not play, so they aren't recorded in the open session
+	gameID := testhelper.CreateTestGame(t, db, "Game")
+	sessionID := testhelper.CreateTestSession(t, db, gameID, "Session 1", "")
+	attrService.SetActiveSession(func() *int64 { return &sessionID })
+
+	t.Run("re-import updates the character", func(t *testing.T) {
+		before, _ := attrService.GetForCharacter(character.ID)
+		hpID := before[len(before)-1].ID
+
+		f.Name = "KIRA"
+		f.Role = "Warden"
+		f.Sections[0].Attributes = f.Sections[0].Attributes[1:] // STR is dropped
+		f.Sections[1].Value = "1/4"
+		f.Sections = append(f.Sections, SheetSection{Name: "Gold", Value: "12"})
+
+		again, updated, err := service.Import(f)
+		if err != nil {
+			t.Fatalf("Import() failed: %v", err)
+		}
+		if !updated || again.ID != character.ID {
+			t.Fatalf("Expected character %d to be updated, got %d (updated %v)", character.ID, again.ID, updated)
+		}
+		if again.Role != "Warden" {
+			t.Errorf("Expected the role to be updated, got %q", again.Role)
+		}
+
+		attrs, _ := attrService.GetForCharacter(character.ID)
+		var names []string
+		for _, a := range attrs {
+			names = append(names, a.Name)
+		}
+		if strings.Join(names, ",") != "Abilities,DEX,HP,Gold" {
+			t.Errorf("Expected Abilities,DEX,HP,Gold, got %v", names)
+		}
+
+		history, _ := attrService.History(hpID)
+		if len(history) != 1 || history[0].OldValue != "3/4" || history[0].NewValue != "1/4" {
+			t.Errorf("Expected HP to keep its entry and record 3/4 to 1/4, got %+v", history)
+		}
+		if len(history) == 1 && history[0].SessionID != nil {
+			t.Errorf("Expected the change to be recorded outside the session, got session %d", *history[0].SessionID)
+		}
+	})
+
+	t.Run("an entry moved to another section keeps its history", func(t *testing.T) {
+		before, _ := attrService.GetForCharacter(character.ID)
+		goldID := before[len(before)-1].ID
+
+		f.Sections[0].Attributes = append(f.Sections[0].Attributes, SheetEntry{Name: "Gold", Value: "10"})
+		f.Sections = f.Sections[:len(f.Sections)-1]
+		if _, _, err := service.Import(f); err != nil {
+			t.Fatalf("Import() failed: %v", err)
+		}
+
+		history, _ := attrService.History(goldID)
+		if len(history) != 1 || history[0].OldValue != "12" || history[0].NewValue != "10" {
+			t.Errorf("Expected Gold to keep its entry and record 12 to 10, got %+v", history)
+		}
+	})
+
+	t.Run("a failed re-import changes nothing", func(t *testing.T) {
+		if _, err := db.Connection.Exec("ALTER TABLE attribute_changes RENAME TO attribute_changes_gone"); err != nil {
+			t.Fatalf("Failed to break the attribute_changes table: %v", err)
+		}
+		defer db.Connection.Exec("ALTER TABLE attribute_changes_gone RENAME TO attribute_changes")
+
+		f.Role = "Scout"
+		f.Sections[0].Attributes = nil
+		f.Sections[1].Value = "4/4"
+		if _, _, err := service.Import(f); err == nil {
+			t.Fatal("Expected an error recording the HP change")
+		}
+
+		c, _ := service.GetByID(character.ID)
+		if c.Role != "Warden" {
+			t.Errorf("Expected the role to stay Warden, got %q", c.Role)
+		}
+		attrs, _ := attrService.GetForCharacter(character.ID)
+		if len(attrs) != 4 {
+			t.Errorf("Expected the sheet to keep its 4 entries, got %d", len(attrs))
+		}
+	})
+
+	t.Run("invalid file saves nothing", func(t *testing.T) {
+		bad := testSheetFile()
+		bad.Name = "Other"
+		bad.Sections[1].Value = "lots"
+		if _, _, err := service.Import(bad); err == nil {
+			t.Fatal("Expected a validation error")
+		}
+		all, _ := service.GetAll()
+		if len(all) != 1 {
+			t.Errorf("Expected only Kira, got %d characters", len(all))
+		}
+	})
+}
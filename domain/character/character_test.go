@@ -1,6 +1,7 @@
 package character
 
 import (
+	"errors"
 	"strings"
 	"testing"
 )
@@ -278,3 +279,89 @@ func TestCharacter_AttributeValidation(t *testing.T) {
 		})
 	}
 }
+
+func TestCharacter_NumericAttributeValidation(t *testing.T) {
+	floor, ceiling := 0, 12
+	below := -1
+
+	testCases := []struct {
+		testName   string
+		value      string
+		floor      *int
+		ceiling    *int
+		shouldPass bool
+	}{
+		{testName: "number", value: "3", shouldPass: true},
+		{testName: "negative number", value: "-2", shouldPass: true},
+		{testName: "current and max", value: "7/12", shouldPass: true},
+		{testName: "spaces around the max", value: "7 / 12", shouldPass: true},
+		{testName: "not a number", value: "three", shouldPass: false},
+		{testName: "blank", value: "", shouldPass: false},
+		{testName: "max not a number", value: "7/lots", shouldPass: false},
+		{testName: "current above max", value: "13/12", shouldPass: false},
+		{testName: "within floor and ceiling", value: "7/12", floor: &floor, ceiling: &ceiling, shouldPass: true},
+		{testName: "below floor", value: "-1", floor: &floor, shouldPass: false},
+		{testName: "above ceiling", value: "13", ceiling: &ceiling, shouldPass: false},
+		{testName: "max above ceiling", value: "7/14", ceiling: &ceiling, shouldPass: false},
+		{testName: "ceiling below floor", value: "0", floor: &floor, ceiling: &below, shouldPass: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			attr := &Attribute{CharacterID: 1, Name: "HP", Value: tc.value, Numeric: true, Floor: tc.floor, Ceiling: tc.ceiling}
+			v := attr.Validate()
+			if tc.shouldPass && v.HasErrors() {
+				t.Errorf("Expected %q to pass, got %v", tc.value, v.Errors)
+			}
+			if !tc.shouldPass && !v.HasErrors() {
+				t.Errorf("Expected %q to fail", tc.value)
+			}
+		})
+	}
+
+	t.Run("floor requires a numeric entry", func(t *testing.T) {
+		attr := &Attribute{CharacterID: 1, Name: "Gear", Value: "Rope", Floor: &floor}
+		if !attr.Validate().HasError("floor") {
+			t.Error("Expected a floor error on an entry that isn't numeric")
+		}
+	})
+}
+
+func TestAttribute_Step(t *testing.T) {
+	floor, ceiling := 0, 5
+
+	testCases := []struct {
+		testName string
+		value    string
+		floor    *int
+		ceiling  *int
+		delta    int
+		expected string
+	}{
+		{testName: "increment", value: "3", delta: 1, expected: "4"},
+		{testName: "decrement keeps the max", value: "7/12", delta: -1, expected: "6/12"},
+		{testName: "stops at the max", value: "12/12", delta: 1, expected: "12/12"},
+		{testName: "stops at the floor", value: "0/12", floor: &floor, delta: -1, expected: "0/12"},
+		{testName: "stops at the ceiling", value: "5", ceiling: &ceiling, delta: 1, expected: "5"},
+		{testName: "no floor goes negative", value: "0", delta: -1, expected: "-1"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			attr := &Attribute{Value: tc.value, Numeric: true, Floor: tc.floor, Ceiling: tc.ceiling}
+			if err := attr.Step(tc.delta); err != nil {
+				t.Fatalf("Step() failed: %v", err)
+			}
+			if attr.Value != tc.expected {
+				t.Errorf("Expected %q, got %q", tc.expected, attr.Value)
+			}
+		})
+	}
+
+	t.Run("not numeric", func(t *testing.T) {
+		attr := &Attribute{Value: "3"}
+		if err := attr.Step(1); !errors.Is(err, ErrNotNumeric) {
+			t.Errorf("Expected ErrNotNumeric, got %v", err)
+		}
+	})
+}
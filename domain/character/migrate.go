@@ -21,6 +21,16 @@ func Migrate(dbStore *database.DBStore) error {
 		return err
 	}
 
+	// Migration: Create the table of games each character plays in
+	if err := createCharacterGamesTable(dbStore); err != nil {
+		return err
+	}
+
+	// Migration: Create the log of attribute value changes
+	if err := createAttributeChangesTable(dbStore); err != nil {
+		return err
+	}
+
 	if err := addMissingColumns(dbStore); err != nil {
 		return err
 	}
@@ -71,10 +81,87 @@ func createAttributesTable(dbStore *database.DBStore) error {
 	return err
 }
 
+// createCharacterGamesTable creates the table linking characters to the games
+// they play in. A character can be in several games. Characters made before
+// there were parties join every game, so no party is empty after upgrading.
+func createCharacterGamesTable(dbStore *database.DBStore) error {
+	existed, err := database.TableExists(dbStore.Connection, "character_games")
+	if err != nil {
+		return err
+	}
+
+	schema := `
+		CREATE TABLE IF NOT EXISTS character_games (
+			character_id INTEGER NOT NULL,
+			game_id INTEGER NOT NULL,
+			PRIMARY KEY (character_id, game_id),
+			FOREIGN KEY (character_id) REFERENCES characters(id) ON DELETE CASCADE,
+			FOREIGN KEY (game_id) REFERENCES games(id) ON DELETE CASCADE
+		);
+
+		CREATE INDEX IF NOT EXISTS idx_character_games_by_game_id ON character_games (game_id);
+	`
+	if _, err := dbStore.Connection.Exec(schema); err != nil {
+		return err
+	}
+
+	// A new database has no games table until the game migrations run
+	hasGames, err := database.TableExists(dbStore.Connection, "games")
+	if err != nil {
+		return err
+	}
+	if existed || !hasGames {
+		return nil
+	}
+	_, err = dbStore.Connection.Exec(`
+		INSERT INTO character_games (character_id, game_id)
+		SELECT c.id, g.id FROM characters c CROSS JOIN games g
+	`)
+	return err
+}
+
+// createAttributeChangesTable creates the log of changes to attribute values.
+// A change keeps its session as long as the session exists.
+func createAttributeChangesTable(dbStore *database.DBStore) error {
+	schema := `
+		CREATE TABLE IF NOT EXISTS attribute_changes (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			attribute_id INTEGER NOT NULL,
+			session_id INTEGER,
+			old_value STRING NOT NULL,
+			new_value STRING NOT NULL,
+			created_at DATETIME NOT NULL,
+			FOREIGN KEY (attribute_id) REFERENCES attributes(id) ON DELETE CASCADE,
+			FOREIGN KEY (session_id) REFERENCES sessions(id) ON DELETE SET NULL
+		);
+
+		CREATE INDEX IF NOT EXISTS idx_attribute_changes_by_attribute_id ON attribute_changes (attribute_id);
+	`
+	_, err := dbStore.Connection.Exec(schema)
+	return err
+}
+
 func removeExistingColumns(dbStore *database.DBStore) error {
 	return nil
 }
 
 func addMissingColumns(dbStore *database.DBStore) error {
+	// Soft delete characters into the trash
+	if err := database.AddDeletedAtColumn(dbStore.Connection, "characters"); err != nil {
+		return err
+	}
+
+	// Numeric entries with an optional floor and ceiling
+	numericDefault := "0"
+	if err := database.AddColumn(dbStore.Connection, "attributes", "numeric", "INTEGER", true, &numericDefault); err != nil {
+		return err
+	}
+	if err := database.AddColumn(dbStore.Connection, "attributes", "floor", "INTEGER", false, nil); err != nil {
+		return err
+	}
+	if err := database.AddColumn(dbStore.Connection, "attributes", "ceiling", "INTEGER", false, nil); err != nil {
+		return err
+	}
+
 	return nil
 }
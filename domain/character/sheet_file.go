@@ -0,0 +1,103 @@
+package character
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// SheetFormat is a file format for character sheets
+type SheetFormat string
+
+const (
+	SheetFormatYAML SheetFormat = "yaml"
+	SheetFormatJSON SheetFormat = "json"
+)
+
+// SheetFormatForPath picks the format by the file's extension: .json for JSON
+// and anything else, such as .yaml, for YAML
+func SheetFormatForPath(path string) SheetFormat {
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		return SheetFormatJSON
+	}
+	return SheetFormatYAML
+}
+
+// SheetFile is a character and their sheet as written to a file, for sharing
+// pre-generated characters or moving them between databases
+type SheetFile struct {
+	Name     string        `json:"name" yaml:"name"`
+	System   string        `json:"system" yaml:"system"`
+	Role     string        `json:"role" yaml:"role"`
+	Species  string        `json:"species" yaml:"species"`
+	Sections SheetSections `json:"sections" yaml:"sections"`
+}
+
+// NewSheetFile builds the file of a character and their attributes, which are
+// in display order as returned by GetForCharacter
+func NewSheetFile(c *Character, attrs []*Attribute) *SheetFile {
+	f := &SheetFile{Name: c.Name, System: c.System, Role: c.Role, Species: c.Species}
+	for i, a := range attrs {
+		if i > 0 && a.Group == attrs[i-1].Group && a.PositionInGroup > 0 {
+			section := &f.Sections[len(f.Sections)-1]
+			section.Attributes = append(section.Attributes, SheetEntry{
+				Name: a.Name, Value: a.Value, Numeric: a.Numeric, Floor: a.Floor, Ceiling: a.Ceiling,
+			})
+			continue
+		}
+		f.Sections = append(f.Sections, SheetSection{
+			Name: a.Name, Value: a.Value, Numeric: a.Numeric, Floor: a.Floor, Ceiling: a.Ceiling,
+		})
+	}
+	return f
+}
+
+// ParseSheetFile reads a sheet file written as JSON or YAML
+func ParseSheetFile(data string) (*SheetFile, error) {
+	var f SheetFile
+	var err error
+	if strings.HasPrefix(strings.TrimSpace(data), "{") {
+		err = json.Unmarshal([]byte(data), &f)
+	} else {
+		err = yaml.Unmarshal([]byte(data), &f)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("not a character sheet: %w", err)
+	}
+	if err := f.Validate(); err != nil {
+		return nil, err
+	}
+	return &f, nil
+}
+
+// Render writes the file in the given format
+func (f *SheetFile) Render(format SheetFormat) (string, error) {
+	var data []byte
+	var err error
+	if format == SheetFormatJSON {
+		data, err = json.MarshalIndent(f, "", "  ")
+		data = append(data, '\n')
+	} else {
+		data, err = yaml.Marshal(f)
+	}
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// Validate checks the file holds a character and a valid sheet
+func (f *SheetFile) Validate() error {
+	if v := f.Character().Validate(); v.HasErrors() {
+		return v
+	}
+	return f.Sections.validate()
+}
+
+// Character returns the character in the file, not yet saved
+func (f *SheetFile) Character() *Character {
+	return &Character{Name: f.Name, System: f.System, Role: f.Role, Species: f.Species}
+}
@@ -0,0 +1,93 @@
+package character
+
+import (
+	"strings"
+	"testing"
+)
+
+func testSheetFile() *SheetFile {
+	floor := 0
+	return &SheetFile{
+		Name: "Kira", System: "Cairn", Role: "Scout", Species: "Human",
+		Sections: []SheetSection{
+			{Name: "Abilities", Attributes: []SheetEntry{{Name: "STR", Value: "10"}, {Name: "DEX", Value: "14"}}},
+			{Name: "HP", Value: "3/4", Numeric: true, Floor: &floor},
+		},
+	}
+}
+
+func TestSheetFile_RoundTrip(t *testing.T) {
+	for _, path := range []string{"kira.yaml", "kira.json"} {
+		t.Run(path, func(t *testing.T) {
+			data, err := testSheetFile().Render(SheetFormatForPath(path))
+			if err != nil {
+				t.Fatalf("Render() failed: %v", err)
+			}
+
+			f, err := ParseSheetFile(data)
+			if err != nil {
+				t.Fatalf("ParseSheetFile() failed: %v\n%s", err, data)
+			}
+			if f.Name != "Kira" || f.System != "Cairn" || f.Role != "Scout" || f.Species != "Human" {
+				t.Errorf("Expected the character fields back, got %+v", f)
+			}
+			if len(f.Sections) != 2 || len(f.Sections[0].Attributes) != 2 || f.Sections[0].Attributes[1].Value != "14" {
+				t.Fatalf("Expected the sections back, got %+v", f.Sections)
+			}
+			hp := f.Sections[1]
+			if !hp.Numeric || hp.Floor == nil || *hp.Floor != 0 || hp.Ceiling != nil {
+				t.Errorf("Expected HP to stay numeric with a floor of 0, got %+v", hp)
+			}
+		})
+	}
+
+	t.Run("format by extension", func(t *testing.T) {
+		json, _ := testSheetFile().Render(SheetFormatForPath("Kira.JSON"))
+		if !strings.HasPrefix(json, "{") {
+			t.Errorf("Expected JSON for .JSON, got %q", json)
+		}
+		yaml, _ := testSheetFile().Render(SheetFormatForPath("kira.yml"))
+		if !strings.HasPrefix(yaml, "name: Kira") {
+			t.Errorf("Expected YAML for .yml, got %q", yaml)
+		}
+	})
+}
+
+func TestNewSheetFile(t *testing.T) {
+	c := &Character{Name: "Kira", System: "Cairn", Role: "Scout", Species: "Human"}
+	attrs := testSheetFile().Sections.Attributes(1)
+
+	f := NewSheetFile(c, attrs)
+	if len(f.Sections) != 2 {
+		t.Fatalf("Expected 2 sections, got %d", len(f.Sections))
+	}
+	if f.Sections[0].Name != "Abilities" || len(f.Sections[0].Attributes) != 2 || f.Sections[0].Attributes[0].Name != "STR" {
+		t.Errorf("Expected Abilities with STR and DEX, got %+v", f.Sections[0])
+	}
+	if f.Sections[1].Name != "HP" || len(f.Sections[1].Attributes) != 0 {
+		t.Errorf("Expected a standalone HP entry, got %+v", f.Sections[1])
+	}
+}
+
+func TestParseSheetFile_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		err  string
+	}{
+		{"not a sheet", "- just\n- a list\n", "not a character sheet"},
+		{"bad json", "{\"name\": ", "not a character sheet"},
+		{"missing name", "system: Cairn\nrole: Scout\nspecies: Human\n", "name: is required"},
+		{"unnamed entry", "name: Kira\nsystem: Cairn\nrole: Scout\nspecies: Human\nsections:\n  - value: \"1\"\n", "sections[0]: name is required"},
+		{"bad number", "name: Kira\nsystem: Cairn\nrole: Scout\nspecies: Human\nsections:\n  - name: Gear\n    attributes:\n      - name: Supply\n        value: lots\n        numeric: true\n", "sections[0].attributes[0]: value: must be a number"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseSheetFile(tt.data)
+			if err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Errorf("Expected an error containing %q, got %v", tt.err, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,211 @@
+package character
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SheetTemplate is a named starting sheet for a game system, such as the stats
+// and tracks of an Ironsworn character
+type SheetTemplate struct {
+	Name string `yaml:"name"`
+	// System is filled in for characters made from the template. It defaults to the name.
+	System   string        `yaml:"system,omitempty"`
+	Sections SheetSections `yaml:"sections"`
+}
+
+// SheetSections are the sections of a sheet template or sheet file, in order
+type SheetSections []SheetSection
+
+// SheetSection is a section of a sheet. The section's own entry comes first,
+// followed by its attributes in order. A section without attributes is a
+// standalone entry, such as Momentum: 2.
+type SheetSection struct {
+	Name       string       `json:"name" yaml:"name"`
+	Value      string       `json:"value,omitempty" yaml:"value,omitempty"`
+	Numeric    bool         `json:"numeric,omitempty" yaml:"numeric,omitempty"`
+	Floor      *int         `json:"floor,omitempty" yaml:"floor,omitempty"`
+	Ceiling    *int         `json:"ceiling,omitempty" yaml:"ceiling,omitempty"`
+	Attributes []SheetEntry `json:"attributes,omitempty" yaml:"attributes,omitempty"`
+}
+
+// SheetEntry is an attribute within a sheet section. A numeric entry is
+// adjusted with + and - on the sheet, within its floor and ceiling.
+type SheetEntry struct {
+	Name    string `json:"name" yaml:"name"`
+	Value   string `json:"value,omitempty" yaml:"value,omitempty"`
+	Numeric bool   `json:"numeric,omitempty" yaml:"numeric,omitempty"`
+	Floor   *int   `json:"floor,omitempty" yaml:"floor,omitempty"`
+	Ceiling *int   `json:"ceiling,omitempty" yaml:"ceiling,omitempty"`
+}
+
+// entry returns the section's own entry
+func (s SheetSection) entry() SheetEntry {
+	return SheetEntry{Name: s.Name, Value: s.Value, Numeric: s.Numeric, Floor: s.Floor, Ceiling: s.Ceiling}
+}
+
+// SystemName returns the system of characters made from the template
+func (t *SheetTemplate) SystemName() string {
+	if strings.TrimSpace(t.System) != "" {
+		return t.System
+	}
+	return t.Name
+}
+
+// Validate checks the template can be turned into a sheet
+func (t *SheetTemplate) Validate() error {
+	if strings.TrimSpace(t.Name) == "" {
+		return fmt.Errorf("name is required")
+	}
+	if len(t.Sections) == 0 {
+		return fmt.Errorf("sections cannot be empty")
+	}
+	return t.Sections.validate()
+}
+
+// validate checks every section and attribute can be saved
+func (ss SheetSections) validate() error {
+	for i, s := range ss {
+		if err := s.entry().validate(); err != nil {
+			return fmt.Errorf("sections[%d]: %w", i, err)
+		}
+		for j, a := range s.Attributes {
+			if err := a.validate(); err != nil {
+				return fmt.Errorf("sections[%d].attributes[%d]: %w", i, j, err)
+			}
+		}
+	}
+	return nil
+}
+
+// Attributes builds a character's sheet from the sections. Each section is a
+// group with the section itself first and its attributes after it, in order.
+func (ss SheetSections) Attributes(characterID int64) []*Attribute {
+	var attrs []*Attribute
+	for group, s := range ss {
+		attrs = append(attrs, s.entry().attribute(characterID, group, 0))
+		for i, a := range s.Attributes {
+			attrs = append(attrs, a.attribute(characterID, group, i+1))
+		}
+	}
+	return attrs
+}
+
+func (e SheetEntry) attribute(characterID int64, group int, position int) *Attribute {
+	attr, _ := NewAttribute(characterID, group, position, e.Name, e.Value)
+	attr.Numeric = e.Numeric
+	attr.Floor = e.Floor
+	attr.Ceiling = e.Ceiling
+	return attr
+}
+
+func (e SheetEntry) validate() error {
+	if strings.TrimSpace(e.Name) == "" {
+		return fmt.Errorf("name is required")
+	}
+	if len(e.Name) > MaxAttributeNameLength {
+		return fmt.Errorf("name must be at most %d characters", MaxAttributeNameLength)
+	}
+	if len(e.Value) > MaxAttributeValueLength {
+		return fmt.Errorf("value must be at most %d characters", MaxAttributeValueLength)
+	}
+	// Numbers, floors and ceilings follow the rules of a saved attribute
+	if v := e.attribute(1, 0, 0).Validate(); v.HasErrors() {
+		return v
+	}
+	return nil
+}
+
+// bound returns a floor or ceiling for a built-in template
+func bound(n int) *int {
+	return &n
+}
+
+// SheetTemplates returns the templates offered for a new character: the custom
+// templates first, then the built-in ones they don't replace by name.
+func SheetTemplates(custom []SheetTemplate) []SheetTemplate {
+	templates := append([]SheetTemplate{}, custom...)
+	for _, builtIn := range DefaultSheetTemplates() {
+		replaced := false
+		for _, t := range custom {
+			if strings.EqualFold(strings.TrimSpace(t.Name), builtIn.Name) {
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			templates = append(templates, builtIn)
+		}
+	}
+	return templates
+}
+
+// DefaultSheetTemplates returns the built-in sheet templates
+func DefaultSheetTemplates() []SheetTemplate {
+	return []SheetTemplate{
+		{
+			Name: "Cairn",
+			Sections: []SheetSection{
+				{Name: "Attributes", Attributes: []SheetEntry{
+					{Name: "STR"},
+					{Name: "DEX"},
+					{Name: "WIL"},
+				}},
+				{Name: "Protection", Attributes: []SheetEntry{
+					{Name: "HP"},
+					{Name: "Armor", Value: "0", Numeric: true, Floor: bound(0), Ceiling: bound(3)},
+				}},
+				{Name: "Gold", Value: "0", Numeric: true, Floor: bound(0)},
+				{Name: "Inventory", Value: "0/10", Numeric: true, Floor: bound(0)},
+			},
+		},
+		{
+			Name: "Ironsworn",
+			Sections: []SheetSection{
+				{Name: "Stats", Attributes: []SheetEntry{
+					{Name: "Edge"},
+					{Name: "Heart"},
+					{Name: "Iron"},
+					{Name: "Shadow"},
+					{Name: "Wits"},
+				}},
+				{Name: "Momentum", Value: "2", Numeric: true, Floor: bound(-6), Ceiling: bound(10), Attributes: []SheetEntry{
+					{Name: "Max", Value: "10"},
+					{Name: "Reset", Value: "2"},
+				}},
+				{Name: "Tracks", Attributes: []SheetEntry{
+					{Name: "Health", Value: "5", Numeric: true, Floor: bound(0), Ceiling: bound(5)},
+					{Name: "Spirit", Value: "5", Numeric: true, Floor: bound(0), Ceiling: bound(5)},
+					{Name: "Supply", Value: "5", Numeric: true, Floor: bound(0), Ceiling: bound(5)},
+				}},
+				{Name: "Experience", Value: "0", Numeric: true, Floor: bound(0)},
+			},
+		},
+		{
+			Name: "Mothership",
+			Sections: []SheetSection{
+				{Name: "Stats", Attributes: []SheetEntry{
+					{Name: "Strength"},
+					{Name: "Speed"},
+					{Name: "Intellect"},
+					{Name: "Combat"},
+				}},
+				{Name: "Saves", Attributes: []SheetEntry{
+					{Name: "Sanity"},
+					{Name: "Fear"},
+					{Name: "Body"},
+				}},
+				{Name: "Health", Attributes: []SheetEntry{
+					{Name: "Health"},
+					{Name: "Max Health"},
+					{Name: "Wounds", Value: "0", Numeric: true, Floor: bound(0)},
+					{Name: "Max Wounds", Value: "2"},
+				}},
+				{Name: "Stress", Value: "2", Numeric: true, Floor: bound(0), Attributes: []SheetEntry{
+					{Name: "Minimum", Value: "2"},
+				}},
+				{Name: "Credits", Value: "0", Numeric: true, Floor: bound(0)},
+			},
+		},
+	}
+}
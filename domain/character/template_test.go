@@ -0,0 +1,114 @@
+package character
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSheetTemplate_Attributes(t *testing.T) {
+	tmpl := SheetTemplate{
+		Name: "Test",
+		Sections: []SheetSection{
+			{Name: "Stats", Attributes: []SheetEntry{{Name: "Edge", Value: "2"}, {Name: "Iron"}}},
+			{Name: "Momentum", Value: "2", Numeric: true, Floor: bound(-6), Ceiling: bound(10)},
+		},
+	}
+
+	attrs := tmpl.Sections.Attributes(7)
+
+	expected := []struct {
+		name     string
+		value    string
+		group    int
+		position int
+	}{
+		{"Stats", "", 0, 0},
+		{"Edge", "2", 0, 1},
+		{"Iron", "", 0, 2},
+		{"Momentum", "2", 1, 0},
+	}
+	if len(attrs) != len(expected) {
+		t.Fatalf("Expected %d attributes, got %d", len(expected), len(attrs))
+	}
+	for i, e := range expected {
+		a := attrs[i]
+		if a.CharacterID != 7 || a.Name != e.name || a.Value != e.value || a.Group != e.group || a.PositionInGroup != e.position {
+			t.Errorf("attrs[%d] = %+v, expected %+v", i, *a, e)
+		}
+	}
+	momentum := attrs[3]
+	if !momentum.Numeric || momentum.Floor == nil || *momentum.Floor != -6 || momentum.Ceiling == nil || *momentum.Ceiling != 10 {
+		t.Errorf("Expected Momentum to be numeric between -6 and 10, got %+v", *momentum)
+	}
+	if attrs[1].Numeric {
+		t.Errorf("Expected Edge to be a text entry")
+	}
+}
+
+func TestSheetTemplate_Validate(t *testing.T) {
+	tests := []struct {
+		name     string
+		template SheetTemplate
+		err      string
+	}{
+		{"valid", SheetTemplate{Name: "Test", Sections: []SheetSection{{Name: "HP"}}}, ""},
+		{"missing name", SheetTemplate{Sections: []SheetSection{{Name: "HP"}}}, "name is required"},
+		{"no sections", SheetTemplate{Name: "Test"}, "sections cannot be empty"},
+		{"unnamed section", SheetTemplate{Name: "Test", Sections: []SheetSection{{Value: "1"}}}, "sections[0]: name is required"},
+		{
+			"long value",
+			SheetTemplate{Name: "Test", Sections: []SheetSection{{Name: "Stats", Attributes: []SheetEntry{{Name: "Edge", Value: strings.Repeat("x", MaxAttributeValueLength+1)}}}}},
+			"sections[0].attributes[0]: value must be at most",
+		},
+		{
+			"numeric text",
+			SheetTemplate{Name: "Test", Sections: []SheetSection{{Name: "Tracks", Attributes: []SheetEntry{{Name: "Health", Value: "lots", Numeric: true}}}}},
+			"sections[0].attributes[0]: value: must be a number",
+		},
+		{
+			"floor on a text entry",
+			SheetTemplate{Name: "Test", Sections: []SheetSection{{Name: "Momentum", Value: "2", Floor: bound(-6)}}},
+			"sections[0]: floor: requires a numeric entry",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.template.Validate()
+			if tt.err == "" {
+				if err != nil {
+					t.Errorf("Expected no error, got %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Errorf("Expected error containing %q, got %v", tt.err, err)
+			}
+		})
+	}
+
+	for _, tmpl := range DefaultSheetTemplates() {
+		if err := tmpl.Validate(); err != nil {
+			t.Errorf("Built-in template %s is invalid: %v", tmpl.Name, err)
+		}
+	}
+}
+
+func TestSheetTemplates(t *testing.T) {
+	custom := []SheetTemplate{
+		{Name: "ironsworn", System: "Ironsworn", Sections: []SheetSection{{Name: "Edge"}}},
+		{Name: "Knave", Sections: []SheetSection{{Name: "HP"}}},
+	}
+
+	var names []string
+	for _, tmpl := range SheetTemplates(custom) {
+		names = append(names, tmpl.Name)
+	}
+
+	expected := []string{"ironsworn", "Knave", "Cairn", "Mothership"}
+	if strings.Join(names, ",") != strings.Join(expected, ",") {
+		t.Errorf("Expected %v, got %v", expected, names)
+	}
+	if custom[1].SystemName() != "Knave" {
+		t.Errorf("Expected the system to default to the name, got %q", custom[1].SystemName())
+	}
+}
@@ -0,0 +1,152 @@
+// Package codex provides the entities of a game's world, such as NPCs,
+// locations, factions and items, with their custom fields, the relations
+// between them and the sessions they appear in.
+package codex
+
+import (
+	"soloterm/shared/validation"
+	"strings"
+	"time"
+)
+
+const (
+	MinNameLength       = 1
+	MaxNameLength       = 50
+	MaxSummaryLength    = 500
+	MinFieldNameLength  = 1
+	MaxFieldNameLength  = 50
+	MaxFieldValueLength = 200
+)
+
+// Kind is the type of a codex entry
+type Kind string
+
+const (
+	KindNPC      Kind = "npc"
+	KindLocation Kind = "location"
+	KindFaction  Kind = "faction"
+	KindItem     Kind = "item"
+)
+
+// Kinds lists every kind in the order they are shown
+var Kinds = []Kind{KindNPC, KindLocation, KindFaction, KindItem}
+
+// kindDetails holds the label of each kind and the Lonelog tag type, such as
+// the N in [N:Name], that resolves to it
+var kindDetails = map[Kind]struct{ label, tagType string }{
+	KindNPC:      {"NPC", "N"},
+	KindLocation: {"Location", "L"},
+	KindFaction:  {"Faction", "F"},
+	KindItem:     {"Item", "I"},
+}
+
+// Label returns the name of the kind for display
+func (k Kind) Label() string {
+	return kindDetails[k].label
+}
+
+// TagType returns the Lonelog tag type of the kind
+func (k Kind) TagType() string {
+	return kindDetails[k].tagType
+}
+
+// IsValid reports whether k is one of the known kinds
+func (k Kind) IsValid() bool {
+	_, ok := kindDetails[k]
+	return ok
+}
+
+// KindForTagType returns the kind a Lonelog tag type resolves to. Tag types
+// ignore case.
+func KindForTagType(tagType string) (Kind, bool) {
+	for _, k := range Kinds {
+		if strings.EqualFold(k.TagType(), tagType) {
+			return k, true
+		}
+	}
+	return "", false
+}
+
+// Entry is a single entity of a game's world
+type Entry struct {
+	ID        int64     `db:"id"`
+	GameID    int64     `db:"game_id"`
+	Kind      Kind      `db:"kind"`
+	Name      string    `db:"name"`
+	Summary   string    `db:"summary"`
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+	Fields    []*Field  `db:"-"` // saved and loaded with the entry
+}
+
+// Field is a custom name and value kept on an entry, such as "Age: 42"
+type Field struct {
+	ID       int64  `db:"id"`
+	EntryID  int64  `db:"entry_id"`
+	Name     string `db:"name"`
+	Value    string `db:"value"`
+	Position int    `db:"position"`
+}
+
+func NewEntry(gameID int64, kind Kind, name string) (*Entry, error) {
+	entry := &Entry{
+		ID:     0,
+		GameID: gameID,
+		Kind:   kind,
+		Name:   name,
+	}
+
+	return entry, nil
+}
+
+func (e *Entry) Validate() *validation.Validator {
+	v := validation.NewValidator()
+	v.Check("game_id", e.GameID != 0, "is required")
+	v.Check("kind", e.Kind.IsValid(), "must be one of NPC, Location, Faction or Item")
+	v.Check("name", e.Name != "", "is required")
+	v.Check("name", len(e.Name) >= MinNameLength && len(e.Name) <= MaxNameLength, "must be between %d and %d characters", MinNameLength, MaxNameLength)
+	v.Check("summary", len(e.Summary) <= MaxSummaryLength, "must be at most %d characters", MaxSummaryLength)
+	for i, f := range e.Fields {
+		v.Check("fields", len(f.Name) >= MinFieldNameLength && len(f.Name) <= MaxFieldNameLength, "line %d: the name must be between %d and %d characters", i+1, MinFieldNameLength, MaxFieldNameLength)
+		v.Check("fields", len(f.Value) <= MaxFieldValueLength, "line %d: the value must be at most %d characters", i+1, MaxFieldValueLength)
+	}
+	return v
+}
+
+func (e *Entry) IsNew() bool {
+	return e.ID == 0
+}
+
+// Identifier returns the Lonelog tag identifier naming the entry, such as
+// "N:Captain Vex"
+func (e *Entry) Identifier() string {
+	return e.Kind.TagType() + ":" + e.Name
+}
+
+// ParseFields reads one field per line written as "Name: Value". A line
+// without a colon is a field with no value, and blank lines are skipped.
+func ParseFields(text string) []*Field {
+	var fields []*Field
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		name, value, _ := strings.Cut(line, ":")
+		fields = append(fields, &Field{
+			Name:     strings.TrimSpace(name),
+			Value:    strings.TrimSpace(value),
+			Position: len(fields) + 1,
+		})
+	}
+	return fields
+}
+
+// FormatFields writes fields in the form read by ParseFields
+func FormatFields(fields []*Field) string {
+	lines := make([]string, len(fields))
+	for i, f := range fields {
+		lines[i] = f.Name + ": " + f.Value
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,97 @@
+package codex
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEntry_Validate(t *testing.T) {
+	valid := func() *Entry {
+		return &Entry{GameID: 1, Kind: KindNPC, Name: "Captain Vex"}
+	}
+
+	t.Run("valid entry", func(t *testing.T) {
+		assert.False(t, valid().Validate().HasErrors())
+	})
+
+	t.Run("requires a name", func(t *testing.T) {
+		e := valid()
+		e.Name = ""
+		assert.True(t, e.Validate().HasError("name"))
+	})
+
+	t.Run("name too long", func(t *testing.T) {
+		e := valid()
+		e.Name = strings.Repeat("a", MaxNameLength+1)
+		assert.True(t, e.Validate().HasError("name"))
+	})
+
+	t.Run("unknown kind", func(t *testing.T) {
+		e := valid()
+		e.Kind = "dragon"
+		assert.True(t, e.Validate().HasError("kind"))
+	})
+
+	t.Run("requires a game", func(t *testing.T) {
+		e := valid()
+		e.GameID = 0
+		assert.True(t, e.Validate().HasError("game_id"))
+	})
+
+	t.Run("field without a name", func(t *testing.T) {
+		e := valid()
+		e.Fields = []*Field{{Name: "", Value: "42"}}
+		assert.True(t, e.Validate().HasError("fields"))
+	})
+}
+
+func TestEntry_Identifier(t *testing.T) {
+	assert.Equal(t, "N:Captain Vex", (&Entry{Kind: KindNPC, Name: "Captain Vex"}).Identifier())
+	assert.Equal(t, "L:The Keep", (&Entry{Kind: KindLocation, Name: "The Keep"}).Identifier())
+	assert.Equal(t, "F:Iron Guild", (&Entry{Kind: KindFaction, Name: "Iron Guild"}).Identifier())
+	assert.Equal(t, "I:Sunblade", (&Entry{Kind: KindItem, Name: "Sunblade"}).Identifier())
+}
+
+func TestKindForTagType(t *testing.T) {
+	kind, ok := KindForTagType("N")
+	require.True(t, ok)
+	assert.Equal(t, KindNPC, kind)
+
+	kind, ok = KindForTagType("l")
+	require.True(t, ok, "Expected tag types to ignore case")
+	assert.Equal(t, KindLocation, kind)
+
+	_, ok = KindForTagType("Thread")
+	assert.False(t, ok)
+}
+
+func TestParseFields(t *testing.T) {
+	fields := ParseFields("Age: 42\n\n  Motive : Revenge: at any cost \nScarred\n")
+
+	require.Len(t, fields, 3)
+	assert.Equal(t, "Age", fields[0].Name)
+	assert.Equal(t, "42", fields[0].Value)
+	assert.Equal(t, "Motive", fields[1].Name)
+	assert.Equal(t, "Revenge: at any cost", fields[1].Value, "Expected only the first colon to split")
+	assert.Equal(t, "Scarred", fields[2].Name)
+	assert.Empty(t, fields[2].Value)
+	assert.Equal(t, 3, fields[2].Position)
+
+	assert.Equal(t, "Age: 42\nMotive: Revenge: at any cost\nScarred: ", FormatFields(fields))
+	assert.Empty(t, ParseFields("  \n"))
+}
+
+func TestConnection_Label(t *testing.T) {
+	c := &Connection{Relation: Relation{FromID: 1, ToID: 2, Type: MemberOf}}
+	assert.Equal(t, "member of", c.Label(1))
+	assert.Equal(t, "has member", c.Label(2))
+}
+
+func TestRelation_Validate(t *testing.T) {
+	assert.False(t, (&Relation{FromID: 1, ToID: 2, Type: LocatedAt}).Validate().HasErrors())
+	assert.True(t, (&Relation{FromID: 1, ToID: 1, Type: LocatedAt}).Validate().HasError("to_id"))
+	assert.True(t, (&Relation{FromID: 1, ToID: 2, Type: "likes"}).Validate().HasError("type"))
+}
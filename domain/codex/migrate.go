@@ -0,0 +1,92 @@
+package codex
+
+import (
+	"soloterm/database"
+
+	// Entries belong to games, so the games table must be created first
+	_ "soloterm/domain/game"
+)
+
+func init() {
+	// Register this package's migrations with the database package
+	database.RegisterMigration(Migrate)
+}
+
+// Migrate runs all migrations for the codex domain
+func Migrate(db *database.DBStore) error {
+	// Migration: Create codex entries table
+	if err := createEntriesTable(db); err != nil {
+		return err
+	}
+
+	// Migration: Create codex fields table
+	if err := createFieldsTable(db); err != nil {
+		return err
+	}
+
+	// Migration: Create codex relations table
+	if err := createRelationsTable(db); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// createEntriesTable creates the codex entries table and index. Names are
+// unique within a kind in a game, ignoring case, so tags resolve to one entry.
+func createEntriesTable(db *database.DBStore) error {
+	schema := `
+		CREATE TABLE IF NOT EXISTS codex_entries (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			game_id INTEGER NOT NULL,
+			kind STRING NOT NULL,
+			name STRING NOT NULL,
+			summary TEXT NOT NULL DEFAULT '',
+			created_at DATETIME NOT NULL,
+			updated_at DATETIME NOT NULL,
+			FOREIGN KEY (game_id) REFERENCES games(id) ON DELETE CASCADE
+		);
+
+		CREATE UNIQUE INDEX IF NOT EXISTS idx_codex_entries_by_name ON codex_entries (game_id, kind, name COLLATE NOCASE);
+	`
+	_, err := db.Connection.Exec(schema)
+	return err
+}
+
+// createFieldsTable creates the table of custom fields kept on entries
+func createFieldsTable(db *database.DBStore) error {
+	schema := `
+		CREATE TABLE IF NOT EXISTS codex_fields (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			entry_id INTEGER NOT NULL,
+			name STRING NOT NULL,
+			value STRING NOT NULL DEFAULT '',
+			position INTEGER NOT NULL DEFAULT 0,
+			FOREIGN KEY (entry_id) REFERENCES codex_entries(id) ON DELETE CASCADE
+		);
+
+		CREATE INDEX IF NOT EXISTS idx_codex_fields_by_entry_id ON codex_fields (entry_id, position);
+	`
+	_, err := db.Connection.Exec(schema)
+	return err
+}
+
+// createRelationsTable creates the table of relations between entries
+func createRelationsTable(db *database.DBStore) error {
+	schema := `
+		CREATE TABLE IF NOT EXISTS codex_relations (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			from_id INTEGER NOT NULL,
+			to_id INTEGER NOT NULL,
+			type STRING NOT NULL,
+			created_at DATETIME NOT NULL,
+			UNIQUE (from_id, to_id, type),
+			FOREIGN KEY (from_id) REFERENCES codex_entries(id) ON DELETE CASCADE,
+			FOREIGN KEY (to_id) REFERENCES codex_entries(id) ON DELETE CASCADE
+		);
+
+		CREATE INDEX IF NOT EXISTS idx_codex_relations_by_to_id ON codex_relations (to_id);
+	`
+	_, err := db.Connection.Exec(schema)
+	return err
+}
@@ -0,0 +1,86 @@
+package codex
+
+import (
+	"soloterm/shared/validation"
+	"time"
+)
+
+// RelationType is how one entry relates to another
+type RelationType string
+
+const (
+	MemberOf   RelationType = "member_of"
+	LocatedAt  RelationType = "located_at"
+	AlliedWith RelationType = "allied_with"
+	EnemyOf    RelationType = "enemy_of"
+	Owns       RelationType = "owns"
+	RelatedTo  RelationType = "related_to"
+)
+
+// RelationTypes lists every relation type in the order they are shown
+var RelationTypes = []RelationType{MemberOf, LocatedAt, AlliedWith, EnemyOf, Owns, RelatedTo}
+
+// relationLabels holds how each relation reads from the entry it starts at
+// and from the entry it points to
+var relationLabels = map[RelationType]struct{ label, inverse string }{
+	MemberOf:   {"member of", "has member"},
+	LocatedAt:  {"located at", "location of"},
+	AlliedWith: {"allied with", "allied with"},
+	EnemyOf:    {"enemy of", "enemy of"},
+	Owns:       {"owns", "owned by"},
+	RelatedTo:  {"related to", "related to"},
+}
+
+// Label returns how the relation reads from the entry it starts at, such as
+// "member of"
+func (t RelationType) Label() string {
+	return relationLabels[t].label
+}
+
+// InverseLabel returns how the relation reads from the entry it points to,
+// such as "has member"
+func (t RelationType) InverseLabel() string {
+	return relationLabels[t].inverse
+}
+
+// IsValid reports whether t is one of the known relation types
+func (t RelationType) IsValid() bool {
+	_, ok := relationLabels[t]
+	return ok
+}
+
+// Relation links one entry to another, such as an NPC who is a member of a
+// faction
+type Relation struct {
+	ID        int64        `db:"id"`
+	FromID    int64        `db:"from_id"`
+	ToID      int64        `db:"to_id"`
+	Type      RelationType `db:"type"`
+	CreatedAt time.Time    `db:"created_at"`
+}
+
+func (r *Relation) Validate() *validation.Validator {
+	v := validation.NewValidator()
+	v.Check("from_id", r.FromID != 0, "is required")
+	v.Check("to_id", r.ToID != 0, "is required")
+	v.Check("to_id", r.FromID != r.ToID, "cannot be the same entry")
+	v.Check("type", r.Type.IsValid(), "is not a known relation")
+	return v
+}
+
+// Connection is a relation seen from one of its two entries, with the entry
+// at the other end
+type Connection struct {
+	Relation
+	OtherID   int64  `db:"other_id"`
+	OtherName string `db:"other_name"`
+	OtherKind Kind   `db:"other_kind"`
+}
+
+// Label returns how the connection reads from the entry it was loaded for
+func (c *Connection) Label(entryID int64) string {
+	if c.FromID == entryID {
+		return c.Type.Label()
+	}
+	return c.Type.InverseLabel()
+}
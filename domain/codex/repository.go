@@ -0,0 +1,240 @@
+package codex
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"soloterm/database"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// Repository handles database operations for codex entries and their
+// fields and relations
+type Repository struct {
+	db *database.DBStore
+}
+
+// NewRepository creates a new Repository
+func NewRepository(db *database.DBStore) *Repository {
+	return &Repository{db: db}
+}
+
+// Save creates or updates an entry and replaces its fields in a single
+// transaction
+// Automatically manages created_at, and updated_at
+// The entry pointer is updated with the current values after save
+func (r *Repository) Save(entry *Entry) error {
+	tx, err := r.db.Connection.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if entry.ID == 0 {
+		// INSERT - new entry
+		err = r.insert(tx, entry)
+	} else {
+		// UPDATE - existing entry
+		err = r.update(tx, entry)
+	}
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec("DELETE FROM codex_fields WHERE entry_id = ?", entry.ID); err != nil {
+		return err
+	}
+	for i, f := range entry.Fields {
+		f.EntryID = entry.ID
+		f.Position = i + 1
+		err := tx.QueryRowx(`
+			INSERT INTO codex_fields (entry_id, name, value, position) VALUES (?, ?, ?, ?)
+			RETURNING id`, f.EntryID, f.Name, f.Value, f.Position).Scan(&f.ID)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// Delete removes an entry by id, along with its fields and relations
+// Returns the number of rows deleted and an error if the id doesn't exist
+func (r *Repository) Delete(id int64) (int64, error) {
+	if id == 0 {
+		return 0, errors.New("id cannot be empty")
+	}
+
+	result, err := r.db.Connection.Exec(`DELETE FROM codex_entries WHERE id = ?`, id)
+	if err != nil {
+		return 0, err
+	}
+
+	// Check if a row was actually deleted
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	if rows == 0 {
+		return 0, fmt.Errorf("id '%d' not found", id)
+	}
+
+	return rows, nil
+}
+
+// GetByID retrieves an entry by ID, with its fields
+func (r *Repository) GetByID(id int64) (*Entry, error) {
+	if id == 0 {
+		return nil, errors.New("id cannot be zero")
+	}
+
+	var entry Entry
+	err := r.db.Connection.Get(&entry, "SELECT * FROM codex_entries WHERE id = ?", id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.New("codex entry not found")
+		}
+		return nil, err
+	}
+
+	if err := r.loadFields(&entry); err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+// GetAllForGame retrieves the game's entries, without their fields, by kind
+// and then name
+func (r *Repository) GetAllForGame(gameID int64) ([]*Entry, error) {
+	var entries []*Entry
+	query := `SELECT * FROM codex_entries WHERE game_id = ?
+		ORDER BY CASE kind WHEN ? THEN 1 WHEN ? THEN 2 WHEN ? THEN 3 WHEN ? THEN 4 ELSE 5 END, lower(name)`
+	err := r.db.Connection.Select(&entries, query, gameID, KindNPC, KindLocation, KindFaction, KindItem)
+	if err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// FindByName retrieves the game's entry of the kind with the name, ignoring
+// case. Returns nil when there is none.
+func (r *Repository) FindByName(gameID int64, kind Kind, name string) (*Entry, error) {
+	var entry Entry
+	query := "SELECT * FROM codex_entries WHERE game_id = ? AND kind = ? AND name = ? COLLATE NOCASE"
+	err := r.db.Connection.Get(&entry, query, gameID, kind, name)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &entry, nil
+}
+
+// SaveRelation inserts a relation between two entries
+func (r *Repository) SaveRelation(relation *Relation) error {
+	query := `
+		INSERT INTO codex_relations (from_id, to_id, type, created_at)
+		VALUES (?, ?, ?, datetime('now', 'subsec'))
+		RETURNING id, created_at
+	`
+	return r.db.Connection.QueryRowx(query, relation.FromID, relation.ToID, relation.Type).StructScan(relation)
+}
+
+// DeleteRelation removes a relation by id
+func (r *Repository) DeleteRelation(id int64) error {
+	result, err := r.db.Connection.Exec("DELETE FROM codex_relations WHERE id = ?", id)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return fmt.Errorf("id '%d' not found", id)
+	}
+	return nil
+}
+
+// RelationExists reports whether the relation is already recorded
+func (r *Repository) RelationExists(relation *Relation) (bool, error) {
+	var count int
+	query := "SELECT COUNT(*) FROM codex_relations WHERE from_id = ? AND to_id = ? AND type = ?"
+	err := r.db.Connection.Get(&count, query, relation.FromID, relation.ToID, relation.Type)
+	return count > 0, err
+}
+
+// GetConnections retrieves the relations starting or ending at the entry,
+// each with the entry at the other end, ordered by that entry's name
+func (r *Repository) GetConnections(entryID int64) ([]*Connection, error) {
+	var connections []*Connection
+	query := `SELECT r.id, r.from_id, r.to_id, r.type, r.created_at,
+			e.id AS other_id, e.name AS other_name, e.kind AS other_kind
+		FROM codex_relations r
+		JOIN codex_entries e ON e.id = CASE WHEN r.from_id = ? THEN r.to_id ELSE r.from_id END
+		WHERE r.from_id = ? OR r.to_id = ?
+		ORDER BY lower(e.name), r.id`
+	err := r.db.Connection.Select(&connections, query, entryID, entryID, entryID)
+	if err != nil {
+		return nil, err
+	}
+	return connections, nil
+}
+
+// GetRelationsForGame retrieves every relation between the game's entries,
+// oldest first
+func (r *Repository) GetRelationsForGame(gameID int64) ([]*Relation, error) {
+	var relations []*Relation
+	query := `SELECT r.* FROM codex_relations r
+		JOIN codex_entries e ON e.id = r.from_id
+		WHERE e.game_id = ?
+		ORDER BY r.id`
+	err := r.db.Connection.Select(&relations, query, gameID)
+	if err != nil {
+		return nil, err
+	}
+	return relations, nil
+}
+
+// loadFields reads the entry's fields in order
+func (r *Repository) loadFields(entry *Entry) error {
+	entry.Fields = nil
+	return r.db.Connection.Select(&entry.Fields, "SELECT * FROM codex_fields WHERE entry_id = ? ORDER BY position", entry.ID)
+}
+
+// Inserts a new record
+func (r *Repository) insert(tx *sqlx.Tx, entry *Entry) error {
+	query := `
+		INSERT INTO codex_entries (game_id, kind, name, summary, created_at, updated_at)
+		VALUES (?, ?, ?, ?, datetime('now', 'subsec'), datetime('now', 'subsec'))
+		RETURNING id, created_at, updated_at
+	`
+
+	// Execute and scan the returned values back into entry
+	return tx.QueryRowx(query,
+		entry.GameID,
+		entry.Kind,
+		entry.Name,
+		entry.Summary,
+	).StructScan(entry)
+}
+
+// Updates an existing record
+func (r *Repository) update(tx *sqlx.Tx, entry *Entry) error {
+	query := `
+		UPDATE codex_entries SET kind = ?, name = ?, summary = ?, updated_at = datetime('now','subsec')
+		WHERE id = ?
+		RETURNING game_id, created_at, updated_at
+	`
+
+	// Execute and scan the returned values back into entry
+	return tx.QueryRowx(query,
+		entry.Kind,
+		entry.Name,
+		entry.Summary,
+		entry.ID,
+	).StructScan(entry)
+}
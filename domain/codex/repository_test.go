@@ -0,0 +1,167 @@
+package codex
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	testhelper "soloterm/shared/testing"
+)
+
+func TestRepository_Save(t *testing.T) {
+	db := testhelper.SetupTestDB(t)
+	defer testhelper.TeardownTestDB(t, db)
+	repo := NewRepository(db)
+
+	gameID := testhelper.CreateTestGame(t, db, "Game")
+
+	t.Run("insert with fields", func(t *testing.T) {
+		entry := &Entry{GameID: gameID, Kind: KindNPC, Name: "Captain Vex", Summary: "Harbour master",
+			Fields: ParseFields("Age: 42\nMotive: Revenge")}
+		require.NoError(t, repo.Save(entry))
+
+		assert.NotZero(t, entry.ID)
+		assert.False(t, entry.CreatedAt.IsZero())
+
+		stored, err := repo.GetByID(entry.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "Harbour master", stored.Summary)
+		require.Len(t, stored.Fields, 2)
+		assert.Equal(t, "Age", stored.Fields[0].Name)
+		assert.Equal(t, "Revenge", stored.Fields[1].Value)
+	})
+
+	t.Run("update replaces the fields", func(t *testing.T) {
+		entry := &Entry{GameID: gameID, Kind: KindLocation, Name: "The Keep", Fields: ParseFields("Ruler: Duke")}
+		require.NoError(t, repo.Save(entry))
+		firstUpdatedAt := entry.UpdatedAt
+		time.Sleep(10 * time.Millisecond)
+
+		entry.Name = "The Old Keep"
+		entry.Fields = ParseFields("Walls: Crumbling")
+		require.NoError(t, repo.Save(entry))
+
+		stored, err := repo.GetByID(entry.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "The Old Keep", stored.Name)
+		require.Len(t, stored.Fields, 1)
+		assert.Equal(t, "Walls", stored.Fields[0].Name)
+		assert.NotEqual(t, firstUpdatedAt, entry.UpdatedAt)
+	})
+
+	t.Run("names are unique within a kind", func(t *testing.T) {
+		require.NoError(t, repo.Save(&Entry{GameID: gameID, Kind: KindItem, Name: "Sunblade"}))
+		assert.Error(t, repo.Save(&Entry{GameID: gameID, Kind: KindItem, Name: "SUNBLADE"}))
+		assert.NoError(t, repo.Save(&Entry{GameID: gameID, Kind: KindNPC, Name: "Sunblade"}), "Expected other kinds to allow the name")
+	})
+}
+
+func TestRepository_GetAllForGame(t *testing.T) {
+	db := testhelper.SetupTestDB(t)
+	defer testhelper.TeardownTestDB(t, db)
+	repo := NewRepository(db)
+
+	gameID := testhelper.CreateTestGame(t, db, "Game")
+	otherID := testhelper.CreateTestGame(t, db, "Other")
+	testhelper.CreateTestCodexEntry(t, db, gameID, string(KindItem), "Sunblade")
+	testhelper.CreateTestCodexEntry(t, db, gameID, string(KindNPC), "vex")
+	testhelper.CreateTestCodexEntry(t, db, gameID, string(KindNPC), "Aldric")
+	testhelper.CreateTestCodexEntry(t, db, gameID, string(KindLocation), "The Keep")
+	testhelper.CreateTestCodexEntry(t, db, otherID, string(KindNPC), "Elsewhere")
+
+	entries, err := repo.GetAllForGame(gameID)
+	require.NoError(t, err)
+
+	var names []string
+	for _, e := range entries {
+		names = append(names, e.Name)
+	}
+	assert.Equal(t, []string{"Aldric", "vex", "The Keep", "Sunblade"}, names)
+}
+
+func TestRepository_FindByName(t *testing.T) {
+	db := testhelper.SetupTestDB(t)
+	defer testhelper.TeardownTestDB(t, db)
+	repo := NewRepository(db)
+
+	gameID := testhelper.CreateTestGame(t, db, "Game")
+	id := testhelper.CreateTestCodexEntry(t, db, gameID, string(KindNPC), "Captain Vex")
+
+	entry, err := repo.FindByName(gameID, KindNPC, "captain vex")
+	require.NoError(t, err)
+	require.NotNil(t, entry)
+	assert.Equal(t, id, entry.ID)
+
+	entry, err = repo.FindByName(gameID, KindLocation, "Captain Vex")
+	require.NoError(t, err)
+	assert.Nil(t, entry)
+}
+
+func TestRepository_Connections(t *testing.T) {
+	db := testhelper.SetupTestDB(t)
+	defer testhelper.TeardownTestDB(t, db)
+	repo := NewRepository(db)
+
+	gameID := testhelper.CreateTestGame(t, db, "Game")
+	vex := testhelper.CreateTestCodexEntry(t, db, gameID, string(KindNPC), "Vex")
+	guild := testhelper.CreateTestCodexEntry(t, db, gameID, string(KindFaction), "Iron Guild")
+	keep := testhelper.CreateTestCodexEntry(t, db, gameID, string(KindLocation), "Keep")
+
+	require.NoError(t, repo.SaveRelation(&Relation{FromID: vex, ToID: guild, Type: MemberOf}))
+	require.NoError(t, repo.SaveRelation(&Relation{FromID: guild, ToID: keep, Type: LocatedAt}))
+
+	connections, err := repo.GetConnections(guild)
+	require.NoError(t, err)
+	require.Len(t, connections, 2)
+	assert.Equal(t, "Keep", connections[0].OtherName)
+	assert.Equal(t, KindLocation, connections[0].OtherKind)
+	assert.Equal(t, "located at", connections[0].Label(guild))
+	assert.Equal(t, "Vex", connections[1].OtherName)
+	assert.Equal(t, "has member", connections[1].Label(guild))
+
+	t.Run("deleting an entry removes its relations", func(t *testing.T) {
+		_, err := repo.Delete(keep)
+		require.NoError(t, err)
+
+		connections, err := repo.GetConnections(guild)
+		require.NoError(t, err)
+		require.Len(t, connections, 1)
+		assert.Equal(t, "Vex", connections[0].OtherName)
+	})
+
+	t.Run("delete relation", func(t *testing.T) {
+		id := connections[1].ID
+		require.NoError(t, repo.DeleteRelation(id))
+
+		remaining, err := repo.GetConnections(vex)
+		require.NoError(t, err)
+		assert.Empty(t, remaining)
+
+		assert.Error(t, repo.DeleteRelation(id), "Expected an error deleting a missing relation")
+	})
+}
+
+func TestRepository_GetRelationsForGame(t *testing.T) {
+	db := testhelper.SetupTestDB(t)
+	defer testhelper.TeardownTestDB(t, db)
+	repo := NewRepository(db)
+
+	gameID := testhelper.CreateTestGame(t, db, "Game")
+	vex := testhelper.CreateTestCodexEntry(t, db, gameID, string(KindNPC), "Vex")
+	guild := testhelper.CreateTestCodexEntry(t, db, gameID, string(KindFaction), "Iron Guild")
+	otherID := testhelper.CreateTestGame(t, db, "Other")
+	ada := testhelper.CreateTestCodexEntry(t, db, otherID, string(KindNPC), "Ada")
+	docks := testhelper.CreateTestCodexEntry(t, db, otherID, string(KindLocation), "Docks")
+
+	require.NoError(t, repo.SaveRelation(&Relation{FromID: vex, ToID: guild, Type: MemberOf}))
+	require.NoError(t, repo.SaveRelation(&Relation{FromID: ada, ToID: docks, Type: LocatedAt}))
+
+	relations, err := repo.GetRelationsForGame(gameID)
+	require.NoError(t, err)
+	require.Len(t, relations, 1, "Expected only the game's own relations")
+	assert.Equal(t, vex, relations[0].FromID)
+	assert.Equal(t, guild, relations[0].ToID)
+	assert.Equal(t, MemberOf, relations[0].Type)
+}
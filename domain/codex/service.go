@@ -0,0 +1,160 @@
+package codex
+
+import (
+	"errors"
+	"fmt"
+	"soloterm/domain/lonelog"
+	"soloterm/domain/tag"
+	"strings"
+	"time"
+)
+
+// ErrNotFound is returned when a tag doesn't resolve to a codex entry
+var ErrNotFound = errors.New("codex entry not found")
+
+// Service handles codex business logic
+type Service struct {
+	repo    *Repository
+	tagRepo *tag.Repository
+}
+
+// NewService creates a new codex service. The tag index is used to find the
+// sessions each entry appears in.
+func NewService(repo *Repository, tagRepo *tag.Repository) *Service {
+	return &Service{repo: repo, tagRepo: tagRepo}
+}
+
+// Save validates and saves an entry and its fields (create or update)
+func (s *Service) Save(entry *Entry) (*Entry, error) {
+	entry.Name = strings.TrimSpace(entry.Name)
+	entry.Summary = strings.TrimSpace(entry.Summary)
+
+	// Validate
+	validator := entry.Validate()
+	if !validator.HasError("name") && entry.Kind.IsValid() {
+		existing, err := s.repo.FindByName(entry.GameID, entry.Kind, entry.Name)
+		if err != nil {
+			return nil, err
+		}
+		validator.Check("name", existing == nil || existing.ID == entry.ID, "is already used by another %s", entry.Kind.Label())
+	}
+	if validator.HasErrors() {
+		return nil, validator
+	}
+
+	// Save to database
+	err := s.repo.Save(entry)
+	if err != nil {
+		return nil, err
+	}
+
+	return entry, nil
+}
+
+// Delete removes an entry by ID, along with its relations
+func (s *Service) Delete(id int64) error {
+	_, err := s.repo.Delete(id)
+	return err
+}
+
+// GetByID retrieves an entry by ID, with its fields
+func (s *Service) GetByID(id int64) (*Entry, error) {
+	return s.repo.GetByID(id)
+}
+
+// GetAllForGame retrieves the game's entries by kind and then name
+func (s *Service) GetAllForGame(gameID int64) ([]*Entry, error) {
+	return s.repo.GetAllForGame(gameID)
+}
+
+// Relate records that from relates to to, such as an NPC who is a member of
+// a faction
+func (s *Service) Relate(relation *Relation) (*Relation, error) {
+	validator := relation.Validate()
+	if !validator.HasErrors() {
+		exists, err := s.repo.RelationExists(relation)
+		if err != nil {
+			return nil, err
+		}
+		validator.Check("to_id", !exists, "is already %s this entry", relation.Type.Label())
+	}
+	if validator.HasErrors() {
+		return nil, validator
+	}
+
+	if err := s.repo.SaveRelation(relation); err != nil {
+		return nil, err
+	}
+	return relation, nil
+}
+
+// Unrelate removes a relation by ID
+func (s *Service) Unrelate(relationID int64) error {
+	return s.repo.DeleteRelation(relationID)
+}
+
+// Connections retrieves the relations of the entry in both directions
+func (s *Service) Connections(entryID int64) ([]*Connection, error) {
+	return s.repo.GetConnections(entryID)
+}
+
+// RelationsForGame retrieves every relation between the game's entries
+func (s *Service) RelationsForGame(gameID int64) ([]*Relation, error) {
+	return s.repo.GetRelationsForGame(gameID)
+}
+
+// Appearance is a session whose tags mention an entry
+type Appearance struct {
+	SessionID   int64
+	SessionName string
+	PlayedAt    time.Time
+	Offset      int    // byte offset of the first mention in the session
+	Raw         string // the first mention, such as "[N:Captain Vex | wary]"
+	Mentions    int
+}
+
+// Appearances returns the sessions that tag the entry, such as with
+// [N:Captain Vex] in any case, oldest first
+func (s *Service) Appearances(entry *Entry) ([]Appearance, error) {
+	occurrences, err := s.tagRepo.GetOccurrencesIgnoringCase(entry.GameID, entry.Identifier())
+	if err != nil {
+		return nil, err
+	}
+
+	// Occurrences are grouped by session, so one pass collects them
+	var appearances []Appearance
+	for _, o := range occurrences {
+		if n := len(appearances); n > 0 && appearances[n-1].SessionID == o.SessionID {
+			appearances[n-1].Mentions++
+			continue
+		}
+		appearances = append(appearances, Appearance{
+			SessionID:   o.SessionID,
+			SessionName: o.SessionName,
+			PlayedAt:    o.PlayedAt,
+			Offset:      o.Offset,
+			Raw:         o.Raw,
+			Mentions:    1,
+		})
+	}
+	return appearances, nil
+}
+
+// ResolveTag returns the game's entry named by a Lonelog tag, such as the
+// NPC Captain Vex for [N:Captain Vex]. Names ignore case. Returns ErrNotFound
+// when the tag type has no kind or no entry has the name.
+func (s *Service) ResolveTag(gameID int64, t *lonelog.Tag) (*Entry, error) {
+	kind, ok := KindForTagType(t.Type)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s tags are not kept in the codex", ErrNotFound, t.Type)
+	}
+
+	entry, err := s.repo.FindByName(gameID, kind, t.Name)
+	if err != nil {
+		return nil, err
+	}
+	if entry == nil {
+		return nil, fmt.Errorf("%w: no %s named %q", ErrNotFound, kind.Label(), t.Name)
+	}
+	return s.repo.GetByID(entry.ID)
+}
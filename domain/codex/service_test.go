@@ -0,0 +1,116 @@
+package codex
+
+import (
+	"soloterm/database"
+	"soloterm/domain/lonelog"
+	"soloterm/domain/tag"
+	testhelper "soloterm/shared/testing"
+	"soloterm/shared/validation"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	// Appearances come from sessions indexed by the tag repository
+	_ "soloterm/domain/session"
+)
+
+func setupService(t *testing.T) (*database.DBStore, *Service) {
+	t.Helper()
+	db := testhelper.SetupTestDB(t)
+	t.Cleanup(func() { testhelper.TeardownTestDB(t, db) })
+	return db, NewService(NewRepository(db), tag.NewRepository(db))
+}
+
+func TestService_Save(t *testing.T) {
+	db, svc := setupService(t)
+	gameID := testhelper.CreateTestGame(t, db, "Game")
+
+	entry, err := svc.Save(&Entry{GameID: gameID, Kind: KindNPC, Name: "  Vex  ", Summary: " Smuggler "})
+	require.NoError(t, err)
+	assert.Equal(t, "Vex", entry.Name)
+	assert.Equal(t, "Smuggler", entry.Summary)
+
+	t.Run("duplicate name", func(t *testing.T) {
+		_, err := svc.Save(&Entry{GameID: gameID, Kind: KindNPC, Name: "VEX"})
+		v, ok := err.(*validation.Validator)
+		require.True(t, ok, "Expected a validation error, got %v", err)
+		assert.True(t, v.HasError("name"))
+	})
+
+	t.Run("resaving keeps its own name", func(t *testing.T) {
+		entry.Summary = "Retired smuggler"
+		_, err := svc.Save(entry)
+		assert.NoError(t, err)
+	})
+}
+
+func TestService_Relate(t *testing.T) {
+	db, svc := setupService(t)
+	gameID := testhelper.CreateTestGame(t, db, "Game")
+	vex := testhelper.CreateTestCodexEntry(t, db, gameID, string(KindNPC), "Vex")
+	guild := testhelper.CreateTestCodexEntry(t, db, gameID, string(KindFaction), "Iron Guild")
+
+	_, err := svc.Relate(&Relation{FromID: vex, ToID: guild, Type: MemberOf})
+	require.NoError(t, err)
+
+	_, err = svc.Relate(&Relation{FromID: vex, ToID: guild, Type: MemberOf})
+	assert.Error(t, err, "Expected an error relating the same entries twice")
+
+	_, err = svc.Relate(&Relation{FromID: vex, ToID: vex, Type: RelatedTo})
+	assert.Error(t, err, "Expected an error relating an entry to itself")
+
+	connections, err := svc.Connections(guild)
+	require.NoError(t, err)
+	require.Len(t, connections, 1)
+	assert.Equal(t, "Vex", connections[0].OtherName)
+}
+
+func TestService_Appearances(t *testing.T) {
+	db, svc := setupService(t)
+	tagRepo := tag.NewRepository(db)
+	gameID := testhelper.CreateTestGame(t, db, "Game")
+	vex := &Entry{ID: testhelper.CreateTestCodexEntry(t, db, gameID, string(KindNPC), "Vex"), GameID: gameID, Kind: KindNPC, Name: "Vex"}
+
+	first := "Met [N:Vex] at the docks.\nLater [N:Vex | angry] again."
+	firstID := testhelper.CreateTestSession(t, db, gameID, "Session 1", first)
+	require.NoError(t, tagRepo.ReplaceForSession(gameID, firstID, first))
+
+	second := "No one of note."
+	secondID := testhelper.CreateTestSession(t, db, gameID, "Session 2", second)
+	require.NoError(t, tagRepo.ReplaceForSession(gameID, secondID, second))
+
+	third := "[N:VEX] returns."
+	thirdID := testhelper.CreateTestSession(t, db, gameID, "Session 3", third)
+	require.NoError(t, tagRepo.ReplaceForSession(gameID, thirdID, third))
+
+	appearances, err := svc.Appearances(vex)
+	require.NoError(t, err)
+	require.Len(t, appearances, 2)
+	assert.Equal(t, firstID, appearances[0].SessionID)
+	assert.Equal(t, "Session 1", appearances[0].SessionName)
+	assert.Equal(t, 2, appearances[0].Mentions)
+	assert.Equal(t, 4, appearances[0].Offset)
+	assert.Equal(t, "[N:Vex]", appearances[0].Raw)
+	assert.Equal(t, thirdID, appearances[1].SessionID)
+	assert.Equal(t, 1, appearances[1].Mentions)
+}
+
+func TestService_ResolveTag(t *testing.T) {
+	db, svc := setupService(t)
+	gameID := testhelper.CreateTestGame(t, db, "Game")
+	id := testhelper.CreateTestCodexEntry(t, db, gameID, string(KindLocation), "The Keep")
+
+	node := lonelog.TagAt("[L:the keep | ruined]", 1)
+	require.NotNil(t, node)
+
+	entry, err := svc.ResolveTag(gameID, node.Tag)
+	require.NoError(t, err)
+	assert.Equal(t, id, entry.ID)
+
+	_, err = svc.ResolveTag(gameID, &lonelog.Tag{Type: "N", Name: "The Keep"})
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	_, err = svc.ResolveTag(gameID, &lonelog.Tag{Type: "Thread", Name: "The Keep"})
+	assert.ErrorIs(t, err, ErrNotFound)
+}
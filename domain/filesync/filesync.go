@@ -0,0 +1,157 @@
+// Package filesync mirrors a game's sessions to Markdown files in a linked
+// folder, and brings edits made to those files back into the app.
+//
+// Each session's file is recorded with a hash of the content as it was when
+// the two were last in step. On a sync, whichever side no longer matches the
+// hash has changed; when both have, the session is in conflict and is left
+// alone until the user picks a side.
+package filesync
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Extension is the extension of the mirrored files
+const Extension = ".md"
+
+// File records the file a session is mirrored to
+type File struct {
+	SessionID int64     `db:"session_id"`
+	GameID    int64     `db:"game_id"`
+	Name      string    `db:"name"` // File name within the game's sync folder
+	Hash      string    `db:"hash"` // Hash of the content when the session and file were last in step
+	SyncedAt  time.Time `db:"synced_at"`
+
+	// Set when loading a game's files
+	Orphaned bool `db:"orphaned"` // The session has been purged
+	Trashed  bool `db:"trashed"`  // The session is in the trash
+}
+
+// Conflict is a session whose content and file both changed since they were
+// last in step
+type Conflict struct {
+	SessionID   int64
+	SessionName string
+	Path        string
+	AppContent  string
+	FileContent string
+}
+
+// Import is a file changed outside the app while its session wasn't, waiting
+// to be brought into the session
+type Import struct {
+	SessionID   int64
+	SessionName string
+	Path        string
+	Hash        string // Hash recorded for the file when the sync read it
+	Content     string
+}
+
+// conflict returns the import as a conflict with the session's content
+func (i *Import) conflict(appContent string) *Conflict {
+	return &Conflict{
+		SessionID:   i.SessionID,
+		SessionName: i.SessionName,
+		Path:        i.Path,
+		AppContent:  appContent,
+		FileContent: i.Content,
+	}
+}
+
+// Result summarises a sync
+type Result struct {
+	Written   int // Files written from their sessions
+	Imported  int // Sessions updated from their files
+	Created   int // Sessions created from new files
+	Conflicts []*Conflict
+	Imports   []*Import // Files changed outside the app, until Service.Import brings them in
+}
+
+// Changed reports whether the sync updated any sessions
+func (r *Result) Changed() bool {
+	return r.Imported > 0 || r.Created > 0
+}
+
+// add accumulates another game's result
+func (r *Result) add(other *Result) {
+	r.Written += other.Written
+	r.Imported += other.Imported
+	r.Created += other.Created
+	r.Conflicts = append(r.Conflicts, other.Conflicts...)
+	r.Imports = append(r.Imports, other.Imports...)
+}
+
+// Hold turns the pending import for a session into a conflict with
+// appContent, for a session with edits that haven't been saved yet
+func (r *Result) Hold(sessionID int64, appContent string) {
+	r.Imports = slices.DeleteFunc(r.Imports, func(i *Import) bool {
+		if i.SessionID != sessionID {
+			return false
+		}
+		r.Conflicts = append(r.Conflicts, i.conflict(appContent))
+		return true
+	})
+}
+
+// String describes what the sync did, e.g. "2 files written, 1 session updated"
+func (r *Result) String() string {
+	var parts []string
+	count := func(n int, one, many string) {
+		if n == 1 {
+			parts = append(parts, "1 "+one)
+		} else if n > 1 {
+			parts = append(parts, fmt.Sprintf("%d %s", n, many))
+		}
+	}
+	count(r.Written, "file written", "files written")
+	count(r.Imported, "session updated", "sessions updated")
+	count(r.Created, "session added", "sessions added")
+	count(len(r.Conflicts), "conflict", "conflicts")
+	return strings.Join(parts, ", ")
+}
+
+// hash returns the hash recorded for content
+func hash(content string) string {
+	sum := sha256.Sum256([]byte(content))
+	return hex.EncodeToString(sum[:])
+}
+
+// ExpandDir resolves a leading "~" in a sync folder to the home directory
+func ExpandDir(dir string) string {
+	dir = strings.TrimSpace(dir)
+	if dir == "~" || strings.HasPrefix(dir, "~/") || strings.HasPrefix(dir, `~\`) {
+		if home, err := os.UserHomeDir(); err == nil {
+			return filepath.Join(home, dir[1:])
+		}
+	}
+	return dir
+}
+
+// fileName returns a file name for a session, unique among taken. Names are
+// compared case-insensitively as some file systems are.
+func fileName(sessionName string, taken map[string]bool) string {
+	base := strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`/\:*?"<>|`, r) || r < ' ' {
+			return '-'
+		}
+		return r
+	}, sessionName)
+	base = strings.Trim(base, " .")
+	if base == "" {
+		base = "Session"
+	}
+
+	name := base + Extension
+	for n := 2; taken[strings.ToLower(name)]; n++ {
+		name = fmt.Sprintf("%s (%d)%s", base, n, Extension)
+	}
+	taken[strings.ToLower(name)] = true
+	return name
+}
@@ -0,0 +1,38 @@
+package filesync
+
+import (
+	"soloterm/database"
+
+	// The files reference games, so their table must be created first
+	_ "soloterm/domain/game"
+)
+
+func init() {
+	// Register this package's migrations with the database package
+	database.RegisterMigration(Migrate)
+}
+
+// Migrate runs all migrations for the file sync domain
+func Migrate(db *database.DBStore) error {
+	// Migration: Create session files table
+	return createSessionFilesTable(db)
+}
+
+// createSessionFilesTable creates the table recording each session's file.
+// Rows outlive their sessions so a purged session's file can be cleaned up.
+func createSessionFilesTable(db *database.DBStore) error {
+	schema := `
+		CREATE TABLE IF NOT EXISTS session_files (
+			session_id INTEGER PRIMARY KEY,
+			game_id INTEGER NOT NULL,
+			name TEXT NOT NULL,
+			hash TEXT NOT NULL,
+			synced_at DATETIME NOT NULL,
+			FOREIGN KEY (game_id) REFERENCES games(id) ON DELETE CASCADE
+		);
+
+		CREATE INDEX IF NOT EXISTS idx_session_files_by_game_id ON session_files (game_id);
+	`
+	_, err := db.Connection.Exec(schema)
+	return err
+}
@@ -0,0 +1,65 @@
+package filesync
+
+import (
+	"database/sql"
+	"errors"
+	"soloterm/database"
+)
+
+// Repository handles database operations for session files
+type Repository struct {
+	db *database.DBStore
+}
+
+// NewRepository creates a new Repository
+func NewRepository(db *database.DBStore) *Repository {
+	return &Repository{db: db}
+}
+
+// Save records the file a session is mirrored to, replacing any earlier record
+func (r *Repository) Save(file *File) error {
+	query := `
+		INSERT INTO session_files (session_id, game_id, name, hash, synced_at)
+		VALUES (?, ?, ?, ?, datetime('now', 'subsec'))
+		ON CONFLICT (session_id) DO UPDATE SET
+			game_id = excluded.game_id, name = excluded.name, hash = excluded.hash, synced_at = excluded.synced_at
+		RETURNING synced_at
+	`
+	return r.db.Connection.QueryRowx(query, file.SessionID, file.GameID, file.Name, file.Hash).StructScan(file)
+}
+
+// Delete removes the record of a session's file
+func (r *Repository) Delete(sessionID int64) error {
+	_, err := r.db.Connection.Exec("DELETE FROM session_files WHERE session_id = ?", sessionID)
+	return err
+}
+
+// GetForGame retrieves the files recorded for a game, noting which sessions
+// are in the trash or have been purged
+func (r *Repository) GetForGame(gameID int64) ([]*File, error) {
+	var files []*File
+	query := `SELECT f.*, s.id IS NULL AS orphaned, s.deleted_at IS NOT NULL AS trashed
+		FROM session_files f
+		LEFT JOIN sessions s ON s.id = f.session_id
+		WHERE f.game_id = ?
+		ORDER BY f.name`
+	err := r.db.Connection.Select(&files, query, gameID)
+	return files, err
+}
+
+// GetBySessionID retrieves the file recorded for a session, or nil if there is none
+func (r *Repository) GetBySessionID(sessionID int64) (*File, error) {
+	var file File
+	query := `SELECT f.*, s.id IS NULL AS orphaned, s.deleted_at IS NOT NULL AS trashed
+		FROM session_files f
+		LEFT JOIN sessions s ON s.id = f.session_id
+		WHERE f.session_id = ?`
+	err := r.db.Connection.Get(&file, query, sessionID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &file, nil
+}
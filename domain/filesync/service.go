@@ -0,0 +1,343 @@
+package filesync
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"soloterm/domain/game"
+	"soloterm/domain/session"
+	"strings"
+)
+
+// Service keeps sessions and their files in step
+type Service struct {
+	repo           *Repository
+	gameService    *game.Service
+	sessionService *session.Service
+}
+
+// NewService creates a new file sync service
+func NewService(repo *Repository, gameService *game.Service, sessionService *session.Service) *Service {
+	return &Service{repo: repo, gameService: gameService, sessionService: sessionService}
+}
+
+// SyncAll syncs every game with a sync folder. A game that fails to sync
+// doesn't stop the others; their errors are returned together.
+func (s *Service) SyncAll() (*Result, error) {
+	games, err := s.gameService.GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	total := &Result{}
+	var errs []error
+	for _, g := range games {
+		if strings.TrimSpace(g.SyncDir) == "" {
+			continue
+		}
+		result, err := s.Sync(g)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", g.Name, err))
+			continue
+		}
+		total.add(result)
+	}
+	return total, errors.Join(errs...)
+}
+
+// HasSyncDirs reports whether any game has a sync folder
+func (s *Service) HasSyncDirs() (bool, error) {
+	games, err := s.gameService.GetAll()
+	if err != nil {
+		return false, err
+	}
+	return slices.ContainsFunc(games, func(g *game.Game) bool {
+		return strings.TrimSpace(g.SyncDir) != ""
+	}), nil
+}
+
+// Sync brings a game's sessions and the files in its sync folder into step.
+// Sessions without a file get one and new files become new sessions. Files
+// changed outside the app are returned as imports, for Import to bring into
+// their session, and sessions changed on both sides are returned as conflicts.
+func (s *Service) Sync(g *game.Game) (*Result, error) {
+	dir := ExpandDir(g.SyncDir)
+	if dir == "" {
+		return nil, fmt.Errorf("the game has no sync folder")
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+
+	sessions, err := s.sessionService.GetAllWithContentForGame(g.ID)
+	if err != nil {
+		return nil, err
+	}
+	files, err := s.repo.GetForGame(g.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	taken := make(map[string]bool)
+	bySession := make(map[int64]*File)
+	for _, f := range files {
+		taken[strings.ToLower(f.Name)] = true
+		bySession[f.SessionID] = f
+	}
+
+	result := &Result{}
+	for _, sess := range sessions {
+		file := bySession[sess.ID]
+		delete(bySession, sess.ID)
+
+		if file == nil {
+			// New to this game's folder, perhaps moved from another game
+			if err := s.removeMoved(sess.ID); err != nil {
+				return nil, err
+			}
+			file = &File{SessionID: sess.ID, GameID: g.ID, Name: fileName(sess.Name, taken)}
+			if err := s.write(dir, file, sess.Content); err != nil {
+				return nil, err
+			}
+			result.Written++
+			continue
+		}
+
+		if err := s.syncSession(dir, file, sess, result); err != nil {
+			return nil, err
+		}
+	}
+
+	// What's left belongs to sessions in the trash or purged from it
+	for _, file := range bySession {
+		if !file.Orphaned {
+			continue
+		}
+		if err := s.removeIfUnchanged(filepath.Join(dir, file.Name), file.Hash); err != nil {
+			return nil, err
+		}
+		if err := s.repo.Delete(file.SessionID); err != nil {
+			return nil, err
+		}
+	}
+
+	created, err := s.importNewFiles(g, dir, taken)
+	if err != nil {
+		return nil, err
+	}
+	result.Created = created
+
+	return result, nil
+}
+
+// syncSession brings a session and its recorded file into step
+func (s *Service) syncSession(dir string, file *File, sess *session.Session, result *Result) error {
+	path := filepath.Join(dir, file.Name)
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		// Deleted outside the app, so write it again
+		if err := s.write(dir, file, sess.Content); err != nil {
+			return err
+		}
+		result.Written++
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	fileContent := string(data)
+	appHash := hash(sess.Content)
+	fileHash := hash(fileContent)
+
+	switch {
+	case appHash == fileHash:
+		if file.Hash != appHash {
+			file.Hash = appHash
+			return s.repo.Save(file)
+		}
+	case fileHash == file.Hash:
+		// Only the session changed
+		if err := s.write(dir, file, sess.Content); err != nil {
+			return err
+		}
+		result.Written++
+	case appHash == file.Hash:
+		// Only the file changed
+		result.Imports = append(result.Imports, &Import{
+			SessionID:   sess.ID,
+			SessionName: sess.Name,
+			Path:        path,
+			Hash:        file.Hash,
+			Content:     fileContent,
+		})
+	default:
+		result.Conflicts = append(result.Conflicts, &Conflict{
+			SessionID:   sess.ID,
+			SessionName: sess.Name,
+			Path:        path,
+			AppContent:  sess.Content,
+			FileContent: fileContent,
+		})
+	}
+	return nil
+}
+
+// Import brings the files a sync found changed outside the app into their
+// sessions. It's kept apart from the sync so the app can import alongside
+// reloading the session it has open. A session saved since the sync read its
+// file becomes a conflict instead.
+func (s *Service) Import(result *Result) error {
+	imports := result.Imports
+	result.Imports = nil
+	for _, i := range imports {
+		file, err := s.repo.GetBySessionID(i.SessionID)
+		if err != nil {
+			return err
+		}
+		if file == nil || file.Orphaned || file.Trashed {
+			// Deleted since the sync
+			continue
+		}
+		sess, err := s.sessionService.GetByID(i.SessionID)
+		if err != nil {
+			return err
+		}
+
+		switch hash(sess.Content) {
+		case hash(i.Content):
+			// Already imported by a later sync
+			continue
+		case i.Hash:
+			sess.Content = i.Content
+			if _, err := s.sessionService.Save(sess); err != nil {
+				return err
+			}
+			file.Hash = hash(i.Content)
+			if err := s.repo.Save(file); err != nil {
+				return err
+			}
+			result.Imported++
+		default:
+			result.Conflicts = append(result.Conflicts, i.conflict(sess.Content))
+		}
+	}
+	return nil
+}
+
+// importNewFiles creates a session for each Markdown file in the folder that
+// isn't mirroring one already. Returns the number created.
+func (s *Service) importNewFiles(g *game.Game, dir string, taken map[string]bool) (int, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return 0, err
+	}
+
+	created := 0
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.EqualFold(filepath.Ext(name), Extension) || taken[strings.ToLower(name)] {
+			continue
+		}
+
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			return created, err
+		}
+		content := string(data)
+		sess := &session.Session{
+			GameID:  g.ID,
+			Name:    strings.TrimSuffix(name, filepath.Ext(name)),
+			Content: content,
+		}
+		if _, err := s.sessionService.Save(sess); err != nil {
+			return created, fmt.Errorf("%s: %w", name, err)
+		}
+		if err := s.repo.Save(&File{SessionID: sess.ID, GameID: g.ID, Name: name, Hash: hash(content)}); err != nil {
+			return created, err
+		}
+		taken[strings.ToLower(name)] = true
+		created++
+	}
+	return created, nil
+}
+
+// removeMoved removes the file a session left behind in another game's
+// folder, when it wasn't changed there
+func (s *Service) removeMoved(sessionID int64) error {
+	old, err := s.repo.GetBySessionID(sessionID)
+	if err != nil || old == nil {
+		return err
+	}
+	if g, err := s.gameService.GetByID(old.GameID); err == nil && strings.TrimSpace(g.SyncDir) != "" {
+		if err := s.removeIfUnchanged(filepath.Join(ExpandDir(g.SyncDir), old.Name), old.Hash); err != nil {
+			return err
+		}
+	}
+	return s.repo.Delete(sessionID)
+}
+
+// removeIfUnchanged removes the file at path if its content still has the
+// recorded hash. Changed files are left for the user.
+func (s *Service) removeIfUnchanged(path string, recorded string) error {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if hash(string(data)) != recorded {
+		return nil
+	}
+	return os.Remove(path)
+}
+
+// write writes content to the file and records it as in step
+func (s *Service) write(dir string, file *File, content string) error {
+	if err := os.WriteFile(filepath.Join(dir, file.Name), []byte(content), 0644); err != nil {
+		return err
+	}
+	file.Hash = hash(content)
+	return s.repo.Save(file)
+}
+
+// KeepApp resolves a conflict by writing the session's content over the file
+func (s *Service) KeepApp(c *Conflict) error {
+	file, err := s.conflictFile(c)
+	if err != nil {
+		return err
+	}
+	return s.write(filepath.Dir(c.Path), file, c.AppContent)
+}
+
+// KeepFile resolves a conflict by saving the file's content to the session
+func (s *Service) KeepFile(c *Conflict) error {
+	file, err := s.conflictFile(c)
+	if err != nil {
+		return err
+	}
+	sess, err := s.sessionService.GetByID(c.SessionID)
+	if err != nil {
+		return err
+	}
+	sess.Content = c.FileContent
+	if _, err := s.sessionService.Save(sess); err != nil {
+		return err
+	}
+	file.Hash = hash(c.FileContent)
+	return s.repo.Save(file)
+}
+
+func (s *Service) conflictFile(c *Conflict) (*File, error) {
+	file, err := s.repo.GetBySessionID(c.SessionID)
+	if err != nil {
+		return nil, err
+	}
+	if file == nil {
+		return nil, fmt.Errorf("session %d has no file", c.SessionID)
+	}
+	return file, nil
+}
@@ -0,0 +1,292 @@
+package filesync
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"soloterm/database"
+	"soloterm/domain/game"
+	"soloterm/domain/session"
+	testhelper "soloterm/shared/testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fixture struct {
+	db             *database.DBStore
+	svc            *Service
+	gameService    *game.Service
+	sessionService *session.Service
+	game           *game.Game
+	dir            string
+}
+
+func setup(t *testing.T) *fixture {
+	t.Helper()
+	db := testhelper.SetupTestDB(t)
+	t.Cleanup(func() { testhelper.TeardownTestDB(t, db) })
+
+	gameService := game.NewService(game.NewRepository(db))
+	sessionService := session.NewService(session.NewRepository(db))
+
+	dir := t.TempDir()
+	g, err := gameService.Save(&game.Game{Name: "Test Game", SyncDir: dir})
+	require.NoError(t, err)
+
+	return &fixture{
+		db:             db,
+		svc:            NewService(NewRepository(db), gameService, sessionService),
+		gameService:    gameService,
+		sessionService: sessionService,
+		game:           g,
+		dir:            dir,
+	}
+}
+
+func (f *fixture) addSession(t *testing.T, name, content string) *session.Session {
+	t.Helper()
+	s, err := f.sessionService.Save(&session.Session{GameID: f.game.ID, Name: name, Content: content})
+	require.NoError(t, err)
+	return s
+}
+
+func (f *fixture) sync(t *testing.T) *Result {
+	t.Helper()
+	result, err := f.svc.Sync(f.game)
+	require.NoError(t, err)
+	require.NoError(t, f.svc.Import(result))
+	return result
+}
+
+func (f *fixture) readFile(t *testing.T, name string) string {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join(f.dir, name))
+	require.NoError(t, err)
+	return string(data)
+}
+
+func (f *fixture) writeFile(t *testing.T, name, content string) {
+	t.Helper()
+	require.NoError(t, os.WriteFile(filepath.Join(f.dir, name), []byte(content), 0644))
+}
+
+func (f *fixture) content(t *testing.T, id int64) string {
+	t.Helper()
+	s, err := f.sessionService.GetByID(id)
+	require.NoError(t, err)
+	return s.Content
+}
+
+func TestSync_WritesSessions(t *testing.T) {
+	f := setup(t)
+	f.addSession(t, "Session One", "@ Open the gate")
+	f.addSession(t, "Session One", "Same name")
+	f.addSession(t, "What/Now?", "Odd name")
+
+	result := f.sync(t)
+	assert.Equal(t, 3, result.Written)
+	assert.Equal(t, "@ Open the gate", f.readFile(t, "Session One.md"))
+	assert.Equal(t, "Same name", f.readFile(t, "Session One (2).md"))
+	assert.Equal(t, "Odd name", f.readFile(t, "What-Now-.md"))
+
+	result = f.sync(t)
+	assert.Equal(t, &Result{}, result, "Expected nothing to do once in step")
+}
+
+func TestSync_BothDirections(t *testing.T) {
+	f := setup(t)
+	s := f.addSession(t, "Session One", "first")
+	f.sync(t)
+
+	t.Run("app edits are written", func(t *testing.T) {
+		s.Content = "edited in the app"
+		_, err := f.sessionService.Save(s)
+		require.NoError(t, err)
+
+		result := f.sync(t)
+		assert.Equal(t, 1, result.Written)
+		assert.Equal(t, "edited in the app", f.readFile(t, "Session One.md"))
+	})
+
+	t.Run("file edits are imported", func(t *testing.T) {
+		f.writeFile(t, "Session One.md", "edited in the vault")
+
+		result := f.sync(t)
+		assert.Equal(t, 1, result.Imported)
+		assert.True(t, result.Changed())
+		assert.Equal(t, "edited in the vault", f.content(t, s.ID))
+	})
+
+	t.Run("deleted files are written again", func(t *testing.T) {
+		require.NoError(t, os.Remove(filepath.Join(f.dir, "Session One.md")))
+
+		result := f.sync(t)
+		assert.Equal(t, 1, result.Written)
+		assert.Equal(t, "edited in the vault", f.readFile(t, "Session One.md"))
+	})
+}
+
+func TestImport(t *testing.T) {
+	setupImport := func(t *testing.T) (*fixture, *session.Session, *Result) {
+		f := setup(t)
+		s := f.addSession(t, "Session One", "base")
+		f.sync(t)
+		f.writeFile(t, "Session One.md", "file version")
+
+		result, err := f.svc.Sync(f.game)
+		require.NoError(t, err)
+		require.Len(t, result.Imports, 1)
+		assert.Equal(t, "base", f.content(t, s.ID), "Expected the sync to leave importing to Import")
+		return f, s, result
+	}
+
+	t.Run("a session saved since the sync is a conflict", func(t *testing.T) {
+		f, s, result := setupImport(t)
+		s.Content = "app version"
+		_, err := f.sessionService.Save(s)
+		require.NoError(t, err)
+
+		require.NoError(t, f.svc.Import(result))
+		assert.Equal(t, 0, result.Imported)
+		require.Len(t, result.Conflicts, 1)
+		assert.Equal(t, "app version", result.Conflicts[0].AppContent)
+		assert.Equal(t, "file version", result.Conflicts[0].FileContent)
+		assert.Equal(t, "app version", f.content(t, s.ID))
+	})
+
+	t.Run("a file already imported by a later sync is skipped", func(t *testing.T) {
+		f, s, result := setupImport(t)
+		assert.Equal(t, 1, f.sync(t).Imported)
+
+		require.NoError(t, f.svc.Import(result))
+		assert.Equal(t, 0, result.Imported)
+		assert.Empty(t, result.Conflicts)
+		assert.Equal(t, "file version", f.content(t, s.ID))
+	})
+
+	t.Run("a held import is a conflict", func(t *testing.T) {
+		f, s, result := setupImport(t)
+		result.Hold(s.ID, "unsaved edits")
+
+		require.NoError(t, f.svc.Import(result))
+		assert.Equal(t, 0, result.Imported)
+		require.Len(t, result.Conflicts, 1)
+		assert.Equal(t, "unsaved edits", result.Conflicts[0].AppContent)
+		assert.Equal(t, "base", f.content(t, s.ID), "Expected the session to be left alone")
+	})
+}
+
+func TestSync_NewFilesBecomeSessions(t *testing.T) {
+	f := setup(t)
+	f.writeFile(t, "Prologue.md", "S1 *Arrival*")
+	f.writeFile(t, "notes.txt", "not markdown")
+
+	result := f.sync(t)
+	assert.Equal(t, 1, result.Created)
+
+	sessions, err := f.sessionService.GetAllWithContentForGame(f.game.ID)
+	require.NoError(t, err)
+	require.Len(t, sessions, 1)
+	assert.Equal(t, "Prologue", sessions[0].Name)
+	assert.Equal(t, "S1 *Arrival*", sessions[0].Content)
+
+	assert.Equal(t, &Result{}, f.sync(t), "Expected the new session to be linked to its file")
+}
+
+func TestSync_Conflicts(t *testing.T) {
+	setupConflict := func(t *testing.T) (*fixture, *Conflict) {
+		f := setup(t)
+		s := f.addSession(t, "Session One", "base")
+		f.sync(t)
+
+		s.Content = "app version"
+		_, err := f.sessionService.Save(s)
+		require.NoError(t, err)
+		f.writeFile(t, "Session One.md", "file version")
+
+		result := f.sync(t)
+		require.Len(t, result.Conflicts, 1)
+		c := result.Conflicts[0]
+		assert.Equal(t, "app version", c.AppContent)
+		assert.Equal(t, "file version", c.FileContent)
+		assert.Equal(t, "file version", f.readFile(t, "Session One.md"), "Expected the file to be left alone")
+		assert.Equal(t, "app version", f.content(t, s.ID), "Expected the session to be left alone")
+		return f, c
+	}
+
+	t.Run("keep the app version", func(t *testing.T) {
+		f, c := setupConflict(t)
+		require.NoError(t, f.svc.KeepApp(c))
+		assert.Equal(t, "app version", f.readFile(t, "Session One.md"))
+		assert.Equal(t, &Result{}, f.sync(t))
+	})
+
+	t.Run("keep the file version", func(t *testing.T) {
+		f, c := setupConflict(t)
+		require.NoError(t, f.svc.KeepFile(c))
+		assert.Equal(t, "file version", f.content(t, c.SessionID))
+		assert.Equal(t, &Result{}, f.sync(t))
+	})
+
+	t.Run("the same edit on both sides is not a conflict", func(t *testing.T) {
+		f := setup(t)
+		s := f.addSession(t, "Session One", "base")
+		f.sync(t)
+		s.Content = "same"
+		_, err := f.sessionService.Save(s)
+		require.NoError(t, err)
+		f.writeFile(t, "Session One.md", "same")
+
+		assert.Equal(t, &Result{}, f.sync(t))
+	})
+}
+
+func TestSync_TrashedAndPurgedSessions(t *testing.T) {
+	f := setup(t)
+	s := f.addSession(t, "Session One", "content")
+	f.sync(t)
+
+	require.NoError(t, f.sessionService.Delete(s.ID))
+	assert.Equal(t, &Result{}, f.sync(t), "Expected a trashed session's file to be left alone")
+	assert.Equal(t, "content", f.readFile(t, "Session One.md"))
+
+	repo := session.NewRepository(f.db)
+	require.NoError(t, repo.Purge(s.ID))
+	assert.Equal(t, &Result{}, f.sync(t), "Expected a purged session's file not to come back as a new session")
+	_, err := os.Stat(filepath.Join(f.dir, "Session One.md"))
+	assert.True(t, os.IsNotExist(err), "Expected a purged session's file to be removed")
+}
+
+func TestSyncAll(t *testing.T) {
+	f := setup(t)
+	f.addSession(t, "Session One", "content")
+	_, err := f.gameService.Save(&game.Game{Name: "Unlinked"})
+	require.NoError(t, err)
+
+	result, err := f.svc.SyncAll()
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Written)
+	assert.Equal(t, "1 file written", result.String())
+}
+
+func TestHasSyncDirs(t *testing.T) {
+	f := setup(t)
+	has, err := f.svc.HasSyncDirs()
+	require.NoError(t, err)
+	assert.True(t, has)
+
+	f.game.SyncDir = " "
+	_, err = f.gameService.Save(f.game)
+	require.NoError(t, err)
+	has, err = f.svc.HasSyncDirs()
+	require.NoError(t, err)
+	assert.False(t, has, "Expected a blank sync folder not to count")
+}
+
+func TestResult_String(t *testing.T) {
+	r := &Result{Written: 2, Imported: 1, Created: 3, Conflicts: []*Conflict{{}}}
+	assert.Equal(t, "2 files written, 1 session updated, 3 sessions added, 1 conflict", r.String())
+	assert.Empty(t, (&Result{}).String())
+}
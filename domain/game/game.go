@@ -12,16 +12,21 @@ const (
 	MaxNameLength        = 50
 	MinDescriptionLength = 3
 	MaxDescriptionLength = 100
+
+	MaxSessionTemplateLength = 5000
+	MaxSyncDirLength         = 500
 )
 
 // Game represents a game in the system
 type Game struct {
-	ID          int64     `db:"id"`
-	Name        string    `db:"name"`
-	Description *string   `db:"description"` // May be nil
-	Notes       string    `db:"notes"`
-	CreatedAt   time.Time `db:"created_at"`
-	UpdatedAt   time.Time `db:"updated_at"`
+	ID              int64      `db:"id"`
+	Name            string     `db:"name"`
+	Description     *string    `db:"description"`      // May be nil
+	SessionTemplate string     `db:"session_template"` // Offered when starting a new session
+	SyncDir         string     `db:"sync_dir"`         // Folder the sessions are mirrored to, if any
+	CreatedAt       time.Time  `db:"created_at"`
+	UpdatedAt       time.Time  `db:"updated_at"`
+	DeletedAt       *time.Time `db:"deleted_at"` // Set while the game is in the trash
 }
 
 func NewGame(name string) (*Game, error) {
@@ -40,6 +45,8 @@ func (g *Game) Validate() *validation.Validator {
 	if g.Description != nil {
 		v.Check("description", len(*g.Description) >= MinDescriptionLength && len(*g.Description) <= MaxDescriptionLength, "must be between %d and %d characters", MinDescriptionLength, MaxDescriptionLength)
 	}
+	v.Check("session_template", len(g.SessionTemplate) <= MaxSessionTemplateLength, "must be at most %d characters", MaxSessionTemplateLength)
+	v.Check("sync_dir", len(g.SyncDir) <= MaxSyncDirLength, "must be at most %d characters", MaxSyncDirLength)
 	return v
 }
 
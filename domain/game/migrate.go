@@ -16,7 +16,16 @@ func Migrate(dbStore *database.DBStore) error {
 		return err
 	}
 
-	if err := addNotesToGamesTable(dbStore); err != nil {
+	if err := addSessionTemplateToGamesTable(dbStore); err != nil {
+		return err
+	}
+
+	// Migration: Soft delete games into the trash
+	if err := database.AddDeletedAtColumn(dbStore.Connection, "games"); err != nil {
+		return err
+	}
+
+	if err := addSyncDirToGamesTable(dbStore); err != nil {
 		return err
 	}
 
@@ -30,7 +39,6 @@ func createGamesTable(dbStore *database.DBStore) error {
 			id INTEGER PRIMARY KEY AUTOINCREMENT,
 			name STRING NOT NULL,
 			description TEXT,
-			notes TEXT default '',
 			created_at DATETIME NOT NULL,
 			updated_at DATETIME NOT NULL
 		);
@@ -41,7 +49,12 @@ func createGamesTable(dbStore *database.DBStore) error {
 	return err
 }
 
-func addNotesToGamesTable(dbStore *database.DBStore) error {
+func addSessionTemplateToGamesTable(dbStore *database.DBStore) error {
+	defaultValue := "''"
+	return database.AddColumn(dbStore.Connection, "games", "session_template", "text", true, &defaultValue)
+}
+
+func addSyncDirToGamesTable(dbStore *database.DBStore) error {
 	defaultValue := "''"
-	return database.AddColumn(dbStore.Connection, "games", "notes", "text", false, &defaultValue)
+	return database.AddColumn(dbStore.Connection, "games", "sync_dir", "text", true, &defaultValue)
 }
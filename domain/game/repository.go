@@ -3,7 +3,6 @@ package game
 import (
 	"database/sql"
 	"errors"
-	"fmt"
 	"soloterm/database"
 )
 
@@ -30,32 +29,36 @@ func (r *Repository) Save(game *Game) error {
 	}
 }
 
-// Delete removes a game by id
+// Delete moves a game to the trash. Its sessions stay with it.
 // Returns the number of rows deleted and an error if the id doesn't exist
 func (r *Repository) Delete(id int64) (int64, error) {
 	if id == 0 {
 		return 0, errors.New("id cannot be empty")
 	}
 
-	query := `DELETE FROM games WHERE id = ?`
-
-	result, err := r.db.Connection.Exec(query, id)
+	return database.SoftDelete(r.db.Connection, "games", id)
+}
 
-	if err != nil {
-		return 0, err
-	}
+// Restore brings a game back from the trash
+func (r *Repository) Restore(id int64) error {
+	return database.Restore(r.db.Connection, "games", id)
+}
 
-	// Check if a row was actually deleted
-	rows, err := result.RowsAffected()
-	if err != nil {
-		return 0, err
-	}
+// Purge permanently removes a game in the trash, along with its sessions
+func (r *Repository) Purge(id int64) error {
+	return database.Purge(r.db.Connection, "games", id)
+}
 
-	if rows == 0 {
-		return 0, fmt.Errorf("id '%d' not found", id)
-	}
+// PurgeExpired permanently removes games that have been in the trash for more than days
+func (r *Repository) PurgeExpired(days int) (int64, error) {
+	return database.PurgeExpired(r.db.Connection, "games", days)
+}
 
-	return rows, nil
+// GetDeleted retrieves the games in the trash, most recently deleted first
+func (r *Repository) GetDeleted() ([]*Game, error) {
+	var games []*Game
+	err := r.db.Connection.Select(&games, "SELECT * FROM games WHERE deleted_at IS NOT NULL ORDER BY deleted_at DESC")
+	return games, err
 }
 
 // GetByID retrieves a game by ID
@@ -65,7 +68,7 @@ func (r *Repository) GetByID(id int64) (*Game, error) {
 	}
 
 	var game Game
-	err := r.db.Connection.Get(&game, "SELECT * FROM games WHERE id = ?", id)
+	err := r.db.Connection.Get(&game, "SELECT * FROM games WHERE id = ? AND deleted_at IS NULL", id)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, errors.New("game not found")
@@ -79,40 +82,18 @@ func (r *Repository) GetByID(id int64) (*Game, error) {
 // GetAll retrieves all games ordered by name
 func (r *Repository) GetAll() ([]*Game, error) {
 	var games []*Game
-	err := r.db.Connection.Select(&games, "SELECT * FROM games ORDER BY name ASC")
+	err := r.db.Connection.Select(&games, "SELECT * FROM games WHERE deleted_at IS NULL ORDER BY name ASC")
 	if err != nil {
 		return nil, err
 	}
 	return games, nil
 }
 
-func (r *Repository) SaveNotes(gameID int64, notes string) error {
-	query := `UPDATE games SET notes = ? WHERE id = ?`
-
-	result, err := r.db.Connection.Exec(query, notes, gameID)
-
-	if err != nil {
-		return err
-	}
-
-	// Check if the game was updated
-	rows, err := result.RowsAffected()
-	if err != nil {
-		return err
-	}
-
-	if rows == 0 {
-		return fmt.Errorf("id '%d' not found", gameID)
-	}
-
-	return nil
-}
-
 // Inserts a new record
 func (r *Repository) insert(game *Game) error {
 	query := `
-		INSERT INTO games (name, description, created_at, updated_at)
-		VALUES (?, ?, datetime('now', 'subsec'), datetime('now', 'subsec'))
+		INSERT INTO games (name, description, session_template, sync_dir, created_at, updated_at)
+		VALUES (?, ?, ?, ?, datetime('now', 'subsec'), datetime('now', 'subsec'))
 		RETURNING id, created_at, updated_at
 	`
 
@@ -120,6 +101,8 @@ func (r *Repository) insert(game *Game) error {
 	err := r.db.Connection.QueryRowx(query,
 		game.Name,
 		game.Description,
+		game.SessionTemplate,
+		game.SyncDir,
 	).StructScan(game)
 
 	return err
@@ -128,7 +111,7 @@ func (r *Repository) insert(game *Game) error {
 // Updates an existing record
 func (r *Repository) update(game *Game) error {
 	query := `
-		UPDATE games SET name = ?, description = ?, updated_at = datetime('now','subsec')
+		UPDATE games SET name = ?, description = ?, session_template = ?, sync_dir = ?, updated_at = datetime('now','subsec')
 		WHERE id = ?
 		RETURNING created_at, updated_at
 	`
@@ -137,6 +120,8 @@ func (r *Repository) update(game *Game) error {
 	err := r.db.Connection.QueryRowx(query,
 		game.Name,
 		game.Description,
+		game.SessionTemplate,
+		game.SyncDir,
 		game.ID,
 	).StructScan(game)
 
@@ -78,6 +78,27 @@ func TestRepository_Save(t *testing.T) {
 		}
 
 	})
+
+	t.Run("saves session template", func(t *testing.T) {
+		game, _ := NewGame("Template Game")
+		game.SessionTemplate = "S1 *{{cursor}}*\n{{previously}}"
+		if err := repo.Save(game); err != nil {
+			t.Fatalf("Save() insert failed: %v", err)
+		}
+
+		loaded, err := repo.GetByID(game.ID)
+		if err != nil {
+			t.Fatalf("GetByID() failed: %v", err)
+		}
+		assert.Equal(t, game.SessionTemplate, loaded.SessionTemplate)
+
+		game.SessionTemplate = ""
+		if err := repo.Save(game); err != nil {
+			t.Fatalf("Save() update failed: %v", err)
+		}
+		loaded, _ = repo.GetByID(game.ID)
+		assert.Empty(t, loaded.SessionTemplate)
+	})
 }
 
 func TestRepository_GetByID(t *testing.T) {
@@ -211,71 +232,3 @@ func TestRepository_Delete(t *testing.T) {
 	})
 
 }
-
-func TestRepository_SaveNotes(t *testing.T) {
-	// Setup
-	db := testhelper.SetupTestDB(t)
-	defer testhelper.TeardownTestDB(t, db)
-
-	repo := NewRepository(db)
-
-	// Create game to delete
-	game, _ := NewGame("Test Game")
-	err := repo.Save(game)
-	if err != nil {
-		t.Fatalf("Save() failed: %v", err)
-	}
-
-	t.Run("add some notes", func(t *testing.T) {
-		err := repo.SaveNotes(game.ID, "these are some notes")
-		if err != nil {
-			t.Fatalf("SaveNotes() failed: %v", err)
-		}
-
-		// Verify it was Updated
-		updatedGame, err := repo.GetByID(game.ID)
-		if err != nil {
-			t.Fatalf("Retrieving notes for id %d failed", game.ID)
-		}
-		assert.Equal(t, "these are some notes", updatedGame.Notes)
-	})
-
-	t.Run("update the notes", func(t *testing.T) {
-		err := repo.SaveNotes(game.ID, "these are some notes")
-		if err != nil {
-			t.Fatalf("SaveNotes() failed: %v", err)
-		}
-
-		// Verify it was Updated
-		updatedGame, err := repo.GetByID(game.ID)
-		if err != nil {
-			t.Fatalf("Retrieving notes for id %d failed", game.ID)
-		}
-		assert.Equal(t, "these are some notes", updatedGame.Notes)
-
-		err = repo.SaveNotes(game.ID, updatedGame.Notes+". And this is an update")
-		if err != nil {
-			t.Fatalf("SaveNotes() failed: %v", err)
-		}
-
-		updatedGame, err = repo.GetByID(game.ID)
-		if err != nil {
-			t.Fatalf("Retrieving notes for id %d failed", game.ID)
-		}
-		assert.Equal(t, "these are some notes. And this is an update", updatedGame.Notes)
-	})
-
-	t.Run("wipe them out", func(t *testing.T) {
-		err := repo.SaveNotes(game.ID, "")
-		if err != nil {
-			t.Fatalf("SaveNotes() failed: %v", err)
-		}
-
-		// Verify it was Updated
-		updatedGame, err := repo.GetByID(game.ID)
-		if err != nil {
-			t.Fatalf("Retrieving notes for id %d failed", game.ID)
-		}
-		assert.Equal(t, "", updatedGame.Notes)
-	})
-}
@@ -0,0 +1,203 @@
+// Package graph pictures how a game's tags and codex entries connect, as
+// Graphviz DOT or Mermaid text.
+package graph
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// maxLineWidth caps how thick the heaviest edges are drawn
+const maxLineWidth = 8
+
+// Format is the text a graph is written as
+type Format int
+
+const (
+	FormatDOT      Format = iota // Graphviz DOT
+	FormatMermaid                // a Mermaid flowchart
+	FormatMarkdown               // a Mermaid flowchart in a Markdown code block
+)
+
+// FormatForPath picks the format from a file's extension: .mmd and .mermaid
+// are Mermaid, .md is Markdown and anything else, such as .dot or .gv, is DOT.
+func FormatForPath(path string) Format {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".mmd", ".mermaid":
+		return FormatMermaid
+	case ".md", ".markdown":
+		return FormatMarkdown
+	}
+	return FormatDOT
+}
+
+// Node is a tag or codex entry
+type Node struct {
+	Key   string // the identifier in lower case, which edges refer to
+	Label string // the identifier as first written, such as "N:Captain Vex"
+}
+
+// Edge joins two nodes, either because their tags share scenes and sessions
+// or through a codex relation
+type Edge struct {
+	From     string // key of the node the edge starts at
+	To       string // key of the node the edge ends at
+	Scenes   int    // scenes that mention both tags
+	Sessions int    // sessions that mention both tags
+	Relation string // label of the codex relation, such as "member of"; empty for shared tags
+}
+
+// Weight is how closely two tags are tied: one for each shared session and
+// one more for each shared scene, so tags met together outweigh tags that
+// only share a session
+func (e Edge) Weight() int {
+	return e.Scenes + e.Sessions
+}
+
+// IsRelation reports whether the edge is a codex relation
+func (e Edge) IsRelation() bool {
+	return e.Relation != ""
+}
+
+// Graph is a set of nodes and the edges between them
+type Graph struct {
+	Nodes []Node
+	Edges []Edge
+
+	index map[string]int // node key -> position in Nodes
+}
+
+// New creates an empty graph
+func New() *Graph {
+	return &Graph{index: make(map[string]int)}
+}
+
+// AddNode adds a node for the identifier unless one differing only in case
+// exists, and returns its key
+func (g *Graph) AddNode(identifier string) string {
+	key := strings.ToLower(identifier)
+	if _, exists := g.index[key]; !exists {
+		g.index[key] = len(g.Nodes)
+		g.Nodes = append(g.Nodes, Node{Key: key, Label: identifier})
+	}
+	return key
+}
+
+// AddEdge adds an edge between the identifiers, adding their nodes as needed
+func (g *Graph) AddEdge(from, to string, edge Edge) {
+	edge.From = g.AddNode(from)
+	edge.To = g.AddNode(to)
+	g.Edges = append(g.Edges, edge)
+}
+
+// IsEmpty reports whether the graph has no edges
+func (g *Graph) IsEmpty() bool {
+	return len(g.Edges) == 0
+}
+
+// Sort orders the nodes by label, ignoring case
+func (g *Graph) Sort() {
+	sort.SliceStable(g.Nodes, func(i, j int) bool {
+		return strings.ToLower(g.Nodes[i].Label) < strings.ToLower(g.Nodes[j].Label)
+	})
+	for i, n := range g.Nodes {
+		g.index[n.Key] = i
+	}
+}
+
+// Render writes the graph in the format, using title to name it where the
+// format allows
+func (g *Graph) Render(format Format, title string) string {
+	switch format {
+	case FormatMermaid:
+		return g.Mermaid()
+	case FormatMarkdown:
+		return "```mermaid\n" + g.Mermaid() + "```\n"
+	}
+	return g.DOT(title)
+}
+
+// DOT writes the graph in Graphviz DOT. Shared tags are undirected edges
+// labelled and thickened by their weight; codex relations are dashed arrows
+// labelled with the relation.
+func (g *Graph) DOT(title string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "graph %s {\n", dotQuote(title))
+	b.WriteString("  node [shape=box];\n")
+	for _, n := range g.Nodes {
+		fmt.Fprintf(&b, "  %s [label=%s];\n", g.nodeID(n.Key), dotQuote(n.Label))
+	}
+	for _, e := range g.Edges {
+		from, to := g.nodeID(e.From), g.nodeID(e.To)
+		if e.IsRelation() {
+			fmt.Fprintf(&b, "  %s -- %s [label=%s, dir=forward, style=dashed];\n", from, to, dotQuote(e.Relation))
+			continue
+		}
+		fmt.Fprintf(&b, "  %s -- %s [label=\"%d\", weight=%d, penwidth=%d, tooltip=%s];\n",
+			from, to, e.Weight(), e.Weight(), lineWidth(e), dotQuote(e.summary()))
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// Mermaid writes the graph as a Mermaid flowchart. Shared tags are plain
+// links labelled and thickened by their weight; codex relations are dotted
+// arrows labelled with the relation.
+func (g *Graph) Mermaid() string {
+	var b strings.Builder
+	b.WriteString("graph LR\n")
+	for _, n := range g.Nodes {
+		fmt.Fprintf(&b, "  %s[\"%s\"]\n", g.nodeID(n.Key), mermaidEscape(n.Label))
+	}
+	var styles []string
+	for i, e := range g.Edges {
+		from, to := g.nodeID(e.From), g.nodeID(e.To)
+		if e.IsRelation() {
+			fmt.Fprintf(&b, "  %s -.->|%s| %s\n", from, mermaidEscape(e.Relation), to)
+			continue
+		}
+		fmt.Fprintf(&b, "  %s ---|%d| %s\n", from, e.Weight(), to)
+		styles = append(styles, fmt.Sprintf("  linkStyle %d stroke-width:%dpx\n", i, lineWidth(e)))
+	}
+	for _, s := range styles {
+		b.WriteString(s)
+	}
+	return b.String()
+}
+
+// nodeID is the node's name in the written graph. Identifiers hold spaces
+// and punctuation, so nodes are numbered in order instead.
+func (g *Graph) nodeID(key string) string {
+	return fmt.Sprintf("n%d", g.index[key]+1)
+}
+
+// summary describes how often the edge's tags met, such as "2 scenes, 1 session"
+func (e Edge) summary() string {
+	return plural(e.Scenes, "scene") + ", " + plural(e.Sessions, "session")
+}
+
+func lineWidth(e Edge) int {
+	return min(max(e.Weight(), 1), maxLineWidth)
+}
+
+func plural(n int, word string) string {
+	if n == 1 {
+		return "1 " + word
+	}
+	return fmt.Sprintf("%d %ss", n, word)
+}
+
+// dotQuote writes s as a quoted DOT string
+func dotQuote(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	return `"` + s + `"`
+}
+
+// mermaidEscape replaces the characters that end a Mermaid label with their
+// entity codes
+func mermaidEscape(s string) string {
+	return strings.NewReplacer(`"`, "#quot;", "|", "#124;").Replace(s)
+}
@@ -0,0 +1,69 @@
+package graph
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testGraph() *Graph {
+	g := New()
+	g.AddEdge("N:Vex", "L:Docks", Edge{Scenes: 2, Sessions: 1})
+	g.AddEdge("n:vex", `F:The "Iron" Guild`, Edge{Relation: "member of"})
+	g.Sort()
+	return g
+}
+
+func TestGraph_AddNodeIgnoresCase(t *testing.T) {
+	g := testGraph()
+	assert.Len(t, g.Nodes, 3)
+	assert.Equal(t, "N:Vex", g.Nodes[2].Label, "Expected the first spelling to be kept")
+	assert.False(t, g.IsEmpty())
+	assert.True(t, New().IsEmpty())
+}
+
+func TestGraph_DOT(t *testing.T) {
+	expected := `graph "Vex's \"Game\"" {
+  node [shape=box];
+  n1 [label="F:The \"Iron\" Guild"];
+  n2 [label="L:Docks"];
+  n3 [label="N:Vex"];
+  n3 -- n2 [label="3", weight=3, penwidth=3, tooltip="2 scenes, 1 session"];
+  n3 -- n1 [label="member of", dir=forward, style=dashed];
+}
+`
+	assert.Equal(t, expected, testGraph().DOT(`Vex's "Game"`))
+}
+
+func TestGraph_Mermaid(t *testing.T) {
+	expected := `graph LR
+  n1["F:The #quot;Iron#quot; Guild"]
+  n2["L:Docks"]
+  n3["N:Vex"]
+  n3 ---|3| n2
+  n3 -.->|member of| n1
+  linkStyle 0 stroke-width:3px
+`
+	assert.Equal(t, expected, testGraph().Mermaid())
+	assert.Equal(t, "```mermaid\n"+expected+"```\n", testGraph().Render(FormatMarkdown, ""))
+}
+
+func TestGraph_LineWidthIsCapped(t *testing.T) {
+	g := New()
+	g.AddEdge("A", "B", Edge{Scenes: 20, Sessions: 10})
+	assert.Contains(t, g.DOT("Game"), `label="30", weight=30, penwidth=8`)
+}
+
+func TestFormatForPath(t *testing.T) {
+	tests := map[string]Format{
+		"graph.dot":     FormatDOT,
+		"graph.gv":      FormatDOT,
+		"graph":         FormatDOT,
+		"graph.mmd":     FormatMermaid,
+		"graph.Mermaid": FormatMermaid,
+		"graph.md":      FormatMarkdown,
+	}
+	for path, expected := range tests {
+		assert.Equal(t, expected, FormatForPath(path), path)
+	}
+}
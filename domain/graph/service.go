@@ -0,0 +1,56 @@
+package graph
+
+import (
+	"soloterm/domain/codex"
+	"soloterm/domain/tag"
+)
+
+// Service builds a game's graph from its tags and codex
+type Service struct {
+	tagService   *tag.Service
+	codexService *codex.Service
+}
+
+// NewService creates a new graph service
+func NewService(tagService *tag.Service, codexService *codex.Service) *Service {
+	return &Service{tagService: tagService, codexService: codexService}
+}
+
+// Build joins every pair of tags that share a scene or session in the game,
+// and every pair of codex entries linked by a relation. Codex entries are
+// joined to the tags naming them, such as "N:Captain Vex" to [N:captain vex].
+func (s *Service) Build(gameID int64) (*Graph, error) {
+	g := New()
+
+	pairs, err := s.tagService.CoOccurrences(gameID)
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range pairs {
+		g.AddEdge(p.A, p.B, Edge{Scenes: p.Scenes, Sessions: p.Sessions})
+	}
+
+	entries, err := s.codexService.GetAllForGame(gameID)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[int64]*codex.Entry, len(entries))
+	for _, e := range entries {
+		byID[e.ID] = e
+	}
+
+	relations, err := s.codexService.RelationsForGame(gameID)
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range relations {
+		from, to := byID[r.FromID], byID[r.ToID]
+		if from == nil || to == nil {
+			continue
+		}
+		g.AddEdge(from.Identifier(), to.Identifier(), Edge{Relation: r.Type.Label()})
+	}
+
+	g.Sort()
+	return g, nil
+}
@@ -0,0 +1,53 @@
+package graph
+
+import (
+	"soloterm/domain/codex"
+	"soloterm/domain/notes"
+	"soloterm/domain/session"
+	"soloterm/domain/tag"
+	testhelper "soloterm/shared/testing"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService_Build(t *testing.T) {
+	db := testhelper.SetupTestDB(t)
+	defer testhelper.TeardownTestDB(t, db)
+
+	tagRepo := tag.NewRepository(db)
+	codexRepo := codex.NewRepository(db)
+	svc := NewService(
+		tag.NewService(tagRepo, session.NewRepository(db), notes.NewRepository(db)),
+		codex.NewService(codexRepo, tagRepo),
+	)
+
+	gameID := testhelper.CreateTestGame(t, db, "Game")
+	testhelper.CreateTestSession(t, db, gameID, "First", "S1 *Docks*\n[N:Captain Vex] meets [F:Guild]")
+	testhelper.CreateTestSession(t, db, gameID, "Second", "[n:captain vex] and [F:Guild] again")
+	vex := testhelper.CreateTestCodexEntry(t, db, gameID, string(codex.KindNPC), "Captain Vex")
+	keep := testhelper.CreateTestCodexEntry(t, db, gameID, string(codex.KindLocation), "Keep")
+	require.NoError(t, codexRepo.SaveRelation(&codex.Relation{FromID: vex, ToID: keep, Type: codex.LocatedAt}))
+
+	g, err := svc.Build(gameID)
+	require.NoError(t, err)
+
+	labels := []string{}
+	for _, n := range g.Nodes {
+		labels = append(labels, n.Label)
+	}
+	assert.Equal(t, []string{"F:Guild", "L:Keep", "N:Captain Vex"}, labels, "Expected the codex entry to join its tag")
+
+	require.Len(t, g.Edges, 2)
+	assert.Equal(t, Edge{From: "f:guild", To: "n:captain vex", Scenes: 2, Sessions: 2}, g.Edges[0])
+	assert.Equal(t, 4, g.Edges[0].Weight())
+	assert.Equal(t, Edge{From: "n:captain vex", To: "l:keep", Relation: "located at"}, g.Edges[1])
+
+	t.Run("a game without tags or links is empty", func(t *testing.T) {
+		otherID := testhelper.CreateTestGame(t, db, "Other")
+		g, err := svc.Build(otherID)
+		require.NoError(t, err)
+		assert.True(t, g.IsEmpty())
+	})
+}
@@ -0,0 +1,119 @@
+// Package link indexes the wiki-style links written in sessions and game
+// notes, such as [[Session 2]] or [[Notes#Factions]], so they can be followed
+// and each session's backlinks listed.
+package link
+
+import (
+	"regexp"
+	"strings"
+)
+
+// NotesTarget is the link target naming the game's notes as a whole
+const NotesTarget = "Notes"
+
+// Link is a single [[link]] stored in the link index
+type Link struct {
+	ID          int64  `db:"id"`
+	GameID      int64  `db:"game_id"`
+	SessionID   *int64 `db:"session_id"` // nil for links in the game's notes
+	PageID      *int64 `db:"page_id"`    // the notes page holding the link, nil for links in sessions
+	StartOffset int    `db:"start_offset"`
+	Raw         string `db:"raw"`     // the full link as written
+	Target      string `db:"target"`  // a session name, notes page name or "Notes"; empty links to the same document
+	Heading     string `db:"heading"` // the heading after "#", if any
+}
+
+// IsNotes reports whether the link names the game's notes as a whole
+func (l *Link) IsNotes() bool {
+	return strings.EqualFold(l.Target, NotesTarget)
+}
+
+// End returns the offset just past the link
+func (l *Link) End() int {
+	return l.StartOffset + len(l.Raw)
+}
+
+// linkRegex matches [[Target]], [[Target#Heading]] and either with a
+// "|Label" shown in place of the target
+var linkRegex = regexp.MustCompile(`\[\[([^\[\]\n|#]*)(?:#([^\[\]\n|]*))?(?:\|[^\[\]\n]*)?\]\]`)
+
+// Parse returns the links in content, in order. Links with neither a target
+// nor a heading are ignored.
+func Parse(content string) []*Link {
+	var links []*Link
+	for _, m := range linkRegex.FindAllStringSubmatchIndex(content, -1) {
+		l := &Link{
+			StartOffset: m[0],
+			Raw:         content[m[0]:m[1]],
+			Target:      strings.TrimSpace(content[m[2]:m[3]]),
+		}
+		if m[4] != -1 {
+			l.Heading = strings.TrimSpace(content[m[4]:m[5]])
+		}
+		if l.Target == "" && l.Heading == "" {
+			continue
+		}
+		links = append(links, l)
+	}
+	return links
+}
+
+// At returns the link in content that contains offset, or nil when there is none
+func At(content string, offset int) *Link {
+	for _, l := range Parse(content) {
+		if offset >= l.StartOffset && offset <= l.End() {
+			return l
+		}
+		if l.StartOffset > offset {
+			break
+		}
+	}
+	return nil
+}
+
+var (
+	headingRegex = regexp.MustCompile(`^ {0,3}#{1,6}\s+(.*?)(?:\s+#+)?\s*$`)
+	sceneRegex   = regexp.MustCompile(`^(?:#+\s*)?(S\d+[a-z]?)(?:\s+(.*))?$`)
+)
+
+// FindHeading returns the offset of the line in content headed by heading,
+// matched case-insensitively against Markdown headings and Lonelog scene
+// headers. A scene header matches its number ("S2"), its title or both.
+// Returns false when there is no such heading.
+func FindHeading(content, heading string) (int, bool) {
+	want := normalize(heading)
+	if want == "" {
+		return 0, false
+	}
+
+	offset := 0
+	for _, line := range strings.Split(content, "\n") {
+		trimmed := strings.TrimSpace(line)
+		var candidates []string
+		if m := sceneRegex.FindStringSubmatch(trimmed); m != nil {
+			candidates = append(candidates, m[1], m[2], m[1]+" "+m[2])
+		}
+		if m := headingRegex.FindStringSubmatch(line); m != nil {
+			candidates = append(candidates, m[1])
+		}
+		for _, c := range candidates {
+			if normalize(c) == want {
+				return offset, true
+			}
+		}
+		offset += len(line) + 1
+	}
+	return 0, false
+}
+
+// normalize lowercases text and drops emphasis markers and extra spaces so
+// headings compare as they read
+func normalize(text string) string {
+	text = strings.Map(func(r rune) rune {
+		if r == '*' || r == '_' || r == '`' {
+			return -1
+		}
+		return r
+	}, text)
+	return strings.ToLower(strings.Join(strings.Fields(text), " "))
+}
@@ -0,0 +1,63 @@
+package link
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	content := "See [[Session 2]], [[ Notes # Factions ]] and [[Session 1#S2|the ambush]].\n[[]] [[#Scene]] [not a link]"
+	links := Parse(content)
+	require.Len(t, links, 4)
+
+	assert.Equal(t, "[[Session 2]]", links[0].Raw)
+	assert.Equal(t, "Session 2", links[0].Target)
+	assert.Equal(t, "", links[0].Heading)
+	assert.Equal(t, links[0].Raw, content[links[0].StartOffset:links[0].End()])
+
+	assert.Equal(t, "Notes", links[1].Target)
+	assert.Equal(t, "Factions", links[1].Heading)
+	assert.True(t, links[1].IsNotes())
+
+	assert.Equal(t, "Session 1", links[2].Target)
+	assert.Equal(t, "S2", links[2].Heading)
+
+	assert.Equal(t, "", links[3].Target, "Expected an empty target to link to the same document")
+	assert.Equal(t, "Scene", links[3].Heading)
+}
+
+func TestAt(t *testing.T) {
+	content := "Back to [[Session 2]] later"
+	assert.Nil(t, At(content, 3))
+	assert.Equal(t, "Session 2", At(content, 8).Target)
+	assert.Equal(t, "Session 2", At(content, 14).Target)
+	assert.Equal(t, "Session 2", At(content, 21).Target, "Expected the cursor just past the link to count")
+	assert.Nil(t, At(content, 23))
+}
+
+func TestFindHeading(t *testing.T) {
+	content := "Intro\n## The **Docks**\nS2 *Into the Sewers*\n# Factions #\n"
+
+	tests := []struct {
+		heading string
+		offset  int
+		found   bool
+	}{
+		{"the docks", 6, true},
+		{"S2", 23, true},
+		{"Into the Sewers", 23, true},
+		{"s2 into the sewers", 23, true},
+		{"Factions", 44, true},
+		{"Intro", 0, false},
+		{"", 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.heading, func(t *testing.T) {
+			offset, found := FindHeading(content, tt.heading)
+			assert.Equal(t, tt.found, found)
+			assert.Equal(t, tt.offset, offset)
+		})
+	}
+}
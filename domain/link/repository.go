@@ -0,0 +1,90 @@
+package link
+
+import (
+	"soloterm/database"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// Backlink is a link to a session or notes page from elsewhere in the game
+type Backlink struct {
+	SessionID *int64 `db:"session_id"`   // nil for links in the game's notes
+	PageID    *int64 `db:"page_id"`      // nil for links in sessions
+	Name      string `db:"name"`         // the name of the session or notes page holding the link
+	Offset    int    `db:"start_offset"` // byte offset of the link in its session or page
+	Raw       string `db:"raw"`          // the full link as written
+}
+
+// Repository handles database operations for the link index
+type Repository struct {
+	db *database.DBStore
+}
+
+// NewRepository creates a new Repository
+func NewRepository(db *database.DBStore) *Repository {
+	return &Repository{db: db}
+}
+
+// ReplaceForSession replaces the indexed links of a session with those found in content
+func (r *Repository) ReplaceForSession(gameID int64, sessionID int64, content string) error {
+	return r.replace(gameID, &sessionID, nil, Parse(content))
+}
+
+// ReplaceForPage replaces the indexed links of a notes page with those found in content
+func (r *Repository) ReplaceForPage(gameID int64, pageID int64, content string) error {
+	return r.replace(gameID, nil, &pageID, Parse(content))
+}
+
+// GetBacklinks returns the links in the game whose target is one of targets,
+// ignoring case, apart from those in the document exclude. Links in the notes
+// pages come first, then sessions, each in order and links in source order
+// within each. Sessions and pages in the trash are left out.
+func (r *Repository) GetBacklinks(gameID int64, targets []string, exclude Document) ([]Backlink, error) {
+	var backlinks []Backlink
+	query, args, err := sqlx.In(`SELECT l.session_id, l.page_id, COALESCE(s.name, p.name, '') AS name, l.start_offset, l.raw
+		FROM links l
+		LEFT JOIN sessions s ON l.session_id = s.id
+		LEFT JOIN notes_pages p ON l.page_id = p.id
+		WHERE l.game_id = ? AND l.target COLLATE NOCASE IN (?)
+			AND (l.session_id IS NULL OR (s.deleted_at IS NULL AND l.session_id != ?))
+			AND (l.page_id IS NULL OR (p.deleted_at IS NULL AND l.page_id != ?))
+		ORDER BY l.session_id IS NOT NULL, p.position ASC, p.id ASC, s.position ASC, s.id ASC, l.start_offset ASC`,
+		gameID, targets, exclude.SessionID, exclude.PageID)
+	if err != nil {
+		return nil, err
+	}
+	err = r.db.Connection.Select(&backlinks, r.db.Connection.Rebind(query), args...)
+	if err != nil {
+		return nil, err
+	}
+	return backlinks, nil
+}
+
+// replace deletes the existing rows for the session (or notes page when
+// sessionID is nil) and inserts links in a single transaction.
+func (r *Repository) replace(gameID int64, sessionID *int64, pageID *int64, links []*Link) error {
+	tx, err := r.db.Connection.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if sessionID != nil {
+		_, err = tx.Exec("DELETE FROM links WHERE session_id = ?", *sessionID)
+	} else {
+		_, err = tx.Exec("DELETE FROM links WHERE page_id = ?", *pageID)
+	}
+	if err != nil {
+		return err
+	}
+
+	query := `INSERT INTO links (game_id, session_id, page_id, start_offset, raw, target, heading)
+		VALUES (?, ?, ?, ?, ?, ?, ?)`
+	for _, l := range links {
+		if _, err := tx.Exec(query, gameID, sessionID, pageID, l.StartOffset, l.Raw, l.Target, l.Heading); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,176 @@
+package link
+
+import (
+	"errors"
+	"fmt"
+	"soloterm/domain/notes"
+	"soloterm/domain/session"
+	"strings"
+)
+
+// ErrNotFound is returned when a link's target doesn't exist in the game
+var ErrNotFound = errors.New("link target not found")
+
+// Service handles link-related business logic
+type Service struct {
+	repo        *Repository
+	sessionRepo *session.Repository
+	notesRepo   *notes.Repository
+}
+
+// NewService creates a new link service
+func NewService(repo *Repository, sessionRepo *session.Repository, notesRepo *notes.Repository) *Service {
+	return &Service{repo: repo, sessionRepo: sessionRepo, notesRepo: notesRepo}
+}
+
+// Document is a session or a notes page of a game
+type Document struct {
+	SessionID int64 // zero for a notes page
+	PageID    int64 // zero for a session
+}
+
+// IsNotes reports whether the document is a notes page
+func (d Document) IsNotes() bool {
+	return d.PageID != 0
+}
+
+// Destination is where a link leads
+type Destination struct {
+	Document
+	Offset       int  // byte offset of the heading, or 0 without one
+	HeadingFound bool // false when the link names a heading the target doesn't have
+}
+
+// Resolve finds where l leads in the game. from is the document holding the
+// link, and is the target of links without one, like [[#Heading]]. Targets
+// match notes page names first and then session names, ignoring case; when
+// several share a name the first in the game wins. [[Notes]] leads to the
+// first notes page, or with a heading to the first page that has it. Returns
+// ErrNotFound when the target doesn't exist.
+func (s *Service) Resolve(gameID int64, from Document, l *Link) (*Destination, error) {
+	dest := &Destination{}
+	var content string
+
+	switch {
+	case l.Target == "" && from.IsNotes():
+		page, err := s.notesRepo.GetByID(from.PageID)
+		if err != nil {
+			return nil, err
+		}
+		dest.PageID = page.ID
+		content = page.Content
+	case l.Target == "":
+		sess, err := s.sessionRepo.GetByID(from.SessionID)
+		if err != nil {
+			return nil, err
+		}
+		dest.SessionID = sess.ID
+		content = sess.Content
+	default:
+		pages, err := s.notesRepo.GetAllForGame(gameID)
+		if err != nil {
+			return nil, err
+		}
+		if page := findPage(pages, l.Target); page != nil {
+			dest.PageID = page.ID
+			content = page.Content
+			break
+		}
+		if l.IsNotes() {
+			return resolveNotes(pages, l.Heading)
+		}
+
+		sess, err := s.findSession(gameID, l.Target)
+		if err != nil {
+			return nil, err
+		}
+		dest.SessionID = sess.ID
+		content = sess.Content
+	}
+
+	if l.Heading == "" {
+		dest.HeadingFound = true
+		return dest, nil
+	}
+	dest.Offset, dest.HeadingFound = FindHeading(content, l.Heading)
+	return dest, nil
+}
+
+// findPage returns the first of pages named name, ignoring case
+func findPage(pages []*notes.Page, name string) *notes.Page {
+	for _, page := range pages {
+		if strings.EqualFold(strings.TrimSpace(page.Name), name) {
+			return page
+		}
+	}
+	return nil
+}
+
+// resolveNotes leads to the first of pages with heading, or to the first page
+// when there's no heading or no page has it
+func resolveNotes(pages []*notes.Page, heading string) (*Destination, error) {
+	if len(pages) == 0 {
+		return nil, fmt.Errorf("%w: the game has no notes", ErrNotFound)
+	}
+
+	if heading != "" {
+		for _, page := range pages {
+			if offset, ok := FindHeading(page.Content, heading); ok {
+				return &Destination{Document: Document{PageID: page.ID}, Offset: offset, HeadingFound: true}, nil
+			}
+		}
+	}
+	return &Destination{Document: Document{PageID: pages[0].ID}, HeadingFound: heading == ""}, nil
+}
+
+// findSession returns the game's first session named name, ignoring case
+func (s *Service) findSession(gameID int64, name string) (*session.Session, error) {
+	sessions, err := s.sessionRepo.GetAllForGame(gameID)
+	if err != nil {
+		return nil, err
+	}
+	for _, sess := range sessions {
+		if strings.EqualFold(strings.TrimSpace(sess.Name), name) {
+			return s.sessionRepo.GetByID(sess.ID)
+		}
+	}
+	return nil, fmt.Errorf("%w: no session named %q", ErrNotFound, name)
+}
+
+// Backlinks returns the links to a session or notes page from the rest of its
+// game. Links to [[Notes]] count as links to the game's first page.
+func (s *Service) Backlinks(gameID int64, doc Document) ([]Backlink, error) {
+	if !doc.IsNotes() {
+		sess, err := s.sessionRepo.GetByID(doc.SessionID)
+		if err != nil {
+			return nil, err
+		}
+		return s.repo.GetBacklinks(gameID, []string{strings.TrimSpace(sess.Name)}, doc)
+	}
+
+	pages, err := s.notesRepo.GetAllForGame(gameID)
+	if err != nil {
+		return nil, err
+	}
+	for i, page := range pages {
+		if page.ID != doc.PageID {
+			continue
+		}
+		targets := []string{strings.TrimSpace(page.Name)}
+		if i == 0 {
+			targets = append(targets, NotesTarget)
+		}
+		return s.repo.GetBacklinks(gameID, targets, doc)
+	}
+	return nil, fmt.Errorf("%w: no notes page %d", ErrNotFound, doc.PageID)
+}
+
+// IndexSession replaces the indexed links of a session. It satisfies session.Indexer.
+func (s *Service) IndexSession(sess *session.Session) error {
+	return s.repo.ReplaceForSession(sess.GameID, sess.ID, sess.Content)
+}
+
+// IndexPage replaces the indexed links of a notes page. It satisfies notes.Indexer.
+func (s *Service) IndexPage(page *notes.Page) error {
+	return s.repo.ReplaceForPage(page.GameID, page.ID, page.Content)
+}
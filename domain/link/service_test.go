@@ -0,0 +1,133 @@
+package link
+
+import (
+	"errors"
+	"soloterm/domain/notes"
+	"soloterm/domain/session"
+	testhelper "soloterm/shared/testing"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService(t *testing.T) {
+	db := testhelper.SetupTestDB(t)
+	defer testhelper.TeardownTestDB(t, db)
+
+	sessionRepo := session.NewRepository(db)
+	notesRepo := notes.NewRepository(db)
+	svc := NewService(NewRepository(db), sessionRepo, notesRepo)
+	sessionRepo.AddIndexer(svc)
+	notesRepo.AddIndexer(svc)
+
+	gameID := testhelper.CreateTestGame(t, db, "Game")
+	save := func(name, content string) *session.Session {
+		s := &session.Session{GameID: gameID, Name: name, Content: content, PlayedAt: session.Today()}
+		require.NoError(t, sessionRepo.Save(s))
+		return s
+	}
+	first := save("Session 1", "S1 *Arrival*\nWe reach [[Notes#Factions]].\n## Loot")
+	second := save("Session 2", "Picking up from [[session 1#Loot]] and [[Session 2]]")
+	third := save("Session 3", "Like in [[Session 1]]")
+	addPage := func(name, content string) *notes.Page {
+		p := &notes.Page{GameID: gameID, Name: name, Content: content}
+		require.NoError(t, notesRepo.Save(p))
+		return p
+	}
+	people := addPage("People", "First met in [[Session 1#S1]]")
+	factions := addPage("Factions", "# Factions\nSee [[People]] and [[#Factions]]")
+
+	t.Run("resolves sessions and headings", func(t *testing.T) {
+		dest, err := svc.Resolve(gameID, Document{SessionID: second.ID}, Parse(second.Content)[0])
+		require.NoError(t, err)
+		assert.Equal(t, first.ID, dest.SessionID)
+		assert.Equal(t, 42, dest.Offset)
+		assert.True(t, dest.HeadingFound)
+	})
+
+	t.Run("resolves notes pages", func(t *testing.T) {
+		dest, err := svc.Resolve(gameID, Document{PageID: factions.ID}, Parse(factions.Content)[0])
+		require.NoError(t, err)
+		assert.Equal(t, people.ID, dest.PageID)
+		assert.True(t, dest.HeadingFound)
+	})
+
+	t.Run("resolves the notes to the page with the heading", func(t *testing.T) {
+		dest, err := svc.Resolve(gameID, Document{SessionID: first.ID}, Parse(first.Content)[0])
+		require.NoError(t, err)
+		assert.True(t, dest.IsNotes())
+		assert.Equal(t, factions.ID, dest.PageID)
+		assert.Equal(t, 0, dest.Offset)
+		assert.True(t, dest.HeadingFound)
+
+		dest, err = svc.Resolve(gameID, Document{SessionID: first.ID}, &Link{Target: "Notes"})
+		require.NoError(t, err)
+		assert.Equal(t, people.ID, dest.PageID, "Expected the first page without a heading")
+	})
+
+	t.Run("links without a target stay in the document", func(t *testing.T) {
+		dest, err := svc.Resolve(gameID, Document{SessionID: first.ID}, &Link{Heading: "Loot"})
+		require.NoError(t, err)
+		assert.Equal(t, first.ID, dest.SessionID)
+		assert.Equal(t, 42, dest.Offset)
+
+		dest, err = svc.Resolve(gameID, Document{PageID: factions.ID}, Parse(factions.Content)[1])
+		require.NoError(t, err)
+		assert.Equal(t, factions.ID, dest.PageID)
+		assert.True(t, dest.HeadingFound)
+	})
+
+	t.Run("reports missing targets and headings", func(t *testing.T) {
+		_, err := svc.Resolve(gameID, Document{SessionID: first.ID}, &Link{Target: "Session 9"})
+		assert.True(t, errors.Is(err, ErrNotFound))
+
+		dest, err := svc.Resolve(gameID, Document{SessionID: first.ID}, &Link{Target: "Session 2", Heading: "Nowhere"})
+		require.NoError(t, err)
+		assert.Equal(t, second.ID, dest.SessionID)
+		assert.False(t, dest.HeadingFound)
+	})
+
+	t.Run("lists backlinks", func(t *testing.T) {
+		backlinks, err := svc.Backlinks(gameID, Document{SessionID: first.ID})
+		require.NoError(t, err)
+		require.Len(t, backlinks, 3)
+		assert.Nil(t, backlinks[0].SessionID, "Expected links from the notes first")
+		assert.Equal(t, people.ID, *backlinks[0].PageID)
+		assert.Equal(t, "People", backlinks[0].Name)
+		assert.Equal(t, "[[Session 1#S1]]", backlinks[0].Raw)
+		assert.Equal(t, "Session 2", backlinks[1].Name)
+		assert.Equal(t, 16, backlinks[1].Offset)
+		assert.Equal(t, third.ID, *backlinks[2].SessionID)
+
+		backlinks, err = svc.Backlinks(gameID, Document{SessionID: second.ID})
+		require.NoError(t, err)
+		assert.Empty(t, backlinks, "Expected links to itself to be left out")
+
+		backlinks, err = svc.Backlinks(gameID, Document{PageID: people.ID})
+		require.NoError(t, err)
+		require.Len(t, backlinks, 2, "Expected links to the page and to the notes")
+		assert.Equal(t, factions.ID, *backlinks[0].PageID)
+		assert.Equal(t, first.ID, *backlinks[1].SessionID)
+
+		backlinks, err = svc.Backlinks(gameID, Document{PageID: factions.ID})
+		require.NoError(t, err)
+		assert.Empty(t, backlinks, "Expected [[Notes]] to count only for the first page")
+	})
+
+	t.Run("forgets the links of deleted pages", func(t *testing.T) {
+		_, err := notesRepo.Delete(factions.ID)
+		require.NoError(t, err)
+		backlinks, err := svc.Backlinks(gameID, Document{PageID: people.ID})
+		require.NoError(t, err)
+		assert.Len(t, backlinks, 1)
+	})
+
+	t.Run("leaves out sessions in the trash", func(t *testing.T) {
+		_, err := sessionRepo.Delete(third.ID)
+		require.NoError(t, err)
+		backlinks, err := svc.Backlinks(gameID, Document{SessionID: first.ID})
+		require.NoError(t, err)
+		assert.Len(t, backlinks, 2)
+	})
+}
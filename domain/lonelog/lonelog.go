@@ -0,0 +1,308 @@
+// Package lonelog parses session content written in Lonelog notation into a
+// flat, ordered list of typed nodes. Every node carries the byte offset of its
+// source text so callers can highlight, index, or jump back to it.
+package lonelog
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Kind identifies the type of a parsed node
+type Kind int
+
+const (
+	KindAction      Kind = iota // "@ ..." a character action
+	KindOracle                  // "? ..." an oracle question
+	KindDice                    // "d: ..." a dice roll
+	KindTable                   // "tbl: ..." a table roll
+	KindConsequence             // "-> ..." or "=> ..." an outcome or consequence
+	KindScene                   // "S1 *Title*" a scene header
+	KindTag                     // "[Type:Name | data]" an inline tag
+)
+
+// String returns a human readable name for the kind
+func (k Kind) String() string {
+	switch k {
+	case KindAction:
+		return "Action"
+	case KindOracle:
+		return "Oracle"
+	case KindDice:
+		return "Dice"
+	case KindTable:
+		return "Table"
+	case KindConsequence:
+		return "Consequence"
+	case KindScene:
+		return "Scene"
+	case KindTag:
+		return "Tag"
+	}
+	return "Unknown"
+}
+
+// Node is a single element of a parsed session.
+//
+// Offset and End are byte offsets into the source, so source[Offset:End] == Raw.
+// Line nodes (actions, oracles, dice, tables, consequences and scenes) span the
+// whole line without its trailing newline. Tag nodes span the bracketed tag only.
+type Node struct {
+	Kind   Kind
+	Offset int
+	End    int
+	Raw    string
+
+	// Marker is the notation that introduced the node ("@", "?", "d:", "tbl:",
+	// "->", "=>"), or empty for scenes and tags.
+	Marker string
+	// Text is the body of the node with the marker removed and any inline
+	// result split off. For scenes it is the scene title.
+	Text string
+	// Result is the text following an inline "->" on action, oracle, dice and
+	// table lines (e.g. "d: 2d6 -> 8" has Text "2d6" and Result "8").
+	Result string
+
+	// Scene is the scene number ("1", "2a") for scene headers.
+	Scene string
+	// Tag is populated for tag nodes.
+	Tag *Tag
+}
+
+// Tag is the parsed form of a bracketed Lonelog tag such as [N:Captain Vex | wary].
+type Tag struct {
+	// Identifier is everything before the first "|", trimmed (e.g. "N:Captain Vex").
+	Identifier string
+	// Type is the part of the identifier before the first ":" (e.g. "N"),
+	// or empty when the identifier has no ":".
+	Type string
+	// Name is the part of the identifier after the first ":" (e.g. "Captain Vex"),
+	// or the whole identifier when it has no ":".
+	Name string
+	// Data is the text after the first "|", trimmed. Empty when there is no data section.
+	Data string
+	// HasData reports whether the tag had a "|" data section, even an empty one.
+	HasData bool
+}
+
+// Document is the result of parsing a session
+type Document struct {
+	Source string
+	Nodes  []Node
+}
+
+// Of returns the nodes of the given kind in source order
+func (d *Document) Of(kind Kind) []Node {
+	var nodes []Node
+	for _, n := range d.Nodes {
+		if n.Kind == kind {
+			nodes = append(nodes, n)
+		}
+	}
+	return nodes
+}
+
+// Counts returns the number of nodes of each kind
+func (d *Document) Counts() map[Kind]int {
+	counts := make(map[Kind]int)
+	for _, n := range d.Nodes {
+		counts[n.Kind]++
+	}
+	return counts
+}
+
+// SceneAt returns the scene header node that contains offset, or nil when the
+// offset comes before the first scene header.
+func (d *Document) SceneAt(offset int) *Node {
+	var scene *Node
+	for i := range d.Nodes {
+		n := &d.Nodes[i]
+		if n.Offset > offset {
+			break
+		}
+		if n.Kind == KindScene {
+			scene = n
+		}
+	}
+	return scene
+}
+
+var (
+	// tagRegex matches tags: [TagIdentifier | data]
+	// Captures tag identifier and optional data section separately.
+	// The data section allows nested [...] sequences (e.g. for tracking boxes like [ ])
+	tagRegex = regexp.MustCompile(`\[([^\]|]+)(\|[^\[\]]*(?:\[[^\]]*\][^\[\]]*)*)?\]`)
+
+	// Dice breakdown values like [3 3 3] or [1] should not be treated as tags
+	numericOnlyRegex = regexp.MustCompile(`^[\d\s]+$`)
+
+	// Scene headers: "S1 *Title*", "S2a Title", optionally as a Markdown heading ("## S3 ...")
+	sceneRegex = regexp.MustCompile(`^(?:#+\s*)?S(\d+[a-z]?)(?:\s+(.*))?$`)
+)
+
+// lineMarkers are checked in order, so longer markers must come before any
+// marker they start with.
+var lineMarkers = []struct {
+	marker string
+	kind   Kind
+}{
+	{"tbl:", KindTable},
+	{"d:", KindDice},
+	{"->", KindConsequence},
+	{"=>", KindConsequence},
+	{"@", KindAction},
+	{"?", KindOracle},
+}
+
+// Parse parses content into a Document. Nodes are ordered by offset; a line
+// node precedes any tags found on the same line.
+func Parse(content string) *Document {
+	doc := &Document{Source: content}
+
+	lineStart := 0
+	for lineStart <= len(content) {
+		lineEnd := strings.IndexByte(content[lineStart:], '\n')
+		if lineEnd == -1 {
+			lineEnd = len(content)
+		} else {
+			lineEnd += lineStart
+		}
+
+		line := strings.TrimSuffix(content[lineStart:lineEnd], "\r")
+		if node, ok := parseLine(line, lineStart); ok {
+			doc.Nodes = append(doc.Nodes, node)
+		}
+		doc.Nodes = append(doc.Nodes, parseTags(line, lineStart)...)
+
+		lineStart = lineEnd + 1
+	}
+
+	return doc
+}
+
+// Tags returns just the tag nodes found in content, in source order.
+func Tags(content string) []Node {
+	var tags []Node
+	for _, m := range tagRegex.FindAllStringSubmatchIndex(content, -1) {
+		if node, ok := tagNode(content, m, 0); ok {
+			tags = append(tags, node)
+		}
+	}
+	return tags
+}
+
+// TagAt returns the tag node in content that contains offset, or nil when
+// there is none. An offset just past the closing bracket still counts, so a
+// cursor left after typing a tag finds it.
+func TagAt(content string, offset int) *Node {
+	for _, node := range Tags(content) {
+		if offset >= node.Offset && offset <= node.End {
+			return &node
+		}
+		if node.Offset > offset {
+			break
+		}
+	}
+	return nil
+}
+
+// ParseTag parses a single bracketed tag such as "[N:Vex | wary]".
+// Returns false when text is not exactly one tag.
+func ParseTag(text string) (*Tag, bool) {
+	m := tagRegex.FindStringSubmatchIndex(text)
+	if m == nil || m[0] != 0 || m[1] != len(text) {
+		return nil, false
+	}
+	node, ok := tagNode(text, m, 0)
+	if !ok {
+		return nil, false
+	}
+	return node.Tag, true
+}
+
+// parseLine recognises the line-level notations. base is the offset of the
+// line within the source.
+func parseLine(line string, base int) (Node, bool) {
+	trimmed := strings.TrimLeft(line, " \t")
+	indent := len(line) - len(trimmed)
+	if trimmed == "" {
+		return Node{}, false
+	}
+
+	node := Node{
+		Offset: base + indent,
+		End:    base + len(strings.TrimRight(line, " \t")),
+	}
+	if node.End < node.Offset {
+		node.End = node.Offset
+	}
+	node.Raw = line[indent : node.End-base]
+
+	if m := sceneRegex.FindStringSubmatch(node.Raw); m != nil {
+		node.Kind = KindScene
+		node.Scene = m[1]
+		node.Text = strings.Trim(strings.TrimSpace(m[2]), "*_")
+		return node, true
+	}
+
+	for _, lm := range lineMarkers {
+		if !strings.HasPrefix(node.Raw, lm.marker) {
+			continue
+		}
+		node.Kind = lm.kind
+		node.Marker = lm.marker
+		body := strings.TrimSpace(node.Raw[len(lm.marker):])
+		if lm.kind != KindConsequence {
+			if idx := strings.Index(body, "->"); idx != -1 {
+				node.Result = strings.TrimSpace(body[idx+2:])
+				body = strings.TrimSpace(body[:idx])
+			}
+		}
+		node.Text = body
+		return node, true
+	}
+
+	return Node{}, false
+}
+
+// parseTags finds the tags on a single line. base is the offset of the line within the source.
+func parseTags(line string, base int) []Node {
+	var tags []Node
+	for _, m := range tagRegex.FindAllStringSubmatchIndex(line, -1) {
+		if node, ok := tagNode(line, m, base); ok {
+			tags = append(tags, node)
+		}
+	}
+	return tags
+}
+
+// tagNode builds a tag node from a tagRegex submatch index into text.
+func tagNode(text string, m []int, base int) (Node, bool) {
+	identifier := strings.TrimSpace(text[m[2]:m[3]])
+	if identifier == "" || numericOnlyRegex.MatchString(identifier) {
+		return Node{}, false
+	}
+	// [[Wiki links]] between sessions and notes aren't tags
+	if strings.HasPrefix(text[m[0]:], "[[") {
+		return Node{}, false
+	}
+
+	tag := &Tag{Identifier: identifier, Name: identifier}
+	if idx := strings.Index(identifier, ":"); idx != -1 {
+		tag.Type = strings.TrimSpace(identifier[:idx])
+		tag.Name = strings.TrimSpace(identifier[idx+1:])
+	}
+	if m[4] != -1 {
+		tag.HasData = true
+		tag.Data = strings.TrimSpace(text[m[4]+1 : m[5]])
+	}
+
+	return Node{
+		Kind:   KindTag,
+		Offset: base + m[0],
+		End:    base + m[1],
+		Raw:    text[m[0]:m[1]],
+		Text:   identifier,
+		Tag:    tag,
+	}, true
+}
@@ -0,0 +1,195 @@
+package lonelog
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse_LineNodes(t *testing.T) {
+	tests := []struct {
+		name       string
+		content    string
+		wantKind   Kind
+		wantMarker string
+		wantText   string
+		wantResult string
+	}{
+		{"action", "@ Pick the lock", KindAction, "@", "Pick the lock", ""},
+		{"oracle with answer", "? Is anyone home? -> No, but", KindOracle, "?", "Is anyone home?", "No, but"},
+		{"dice with result", "d: 2d6+1 -> 9", KindDice, "d:", "2d6+1", "9"},
+		{"table roll", "tbl: @Fantasy/names -> Aldric", KindTable, "tbl:", "@Fantasy/names", "Aldric"},
+		{"outcome", "-> Success", KindConsequence, "->", "Success", ""},
+		{"consequence", "=> The guard hears me", KindConsequence, "=>", "The guard hears me", ""},
+		{"indented action", "   @ Run", KindAction, "@", "Run", ""},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			doc := Parse(tc.content)
+			require.Len(t, doc.Nodes, 1)
+			n := doc.Nodes[0]
+			assert.Equal(t, tc.wantKind, n.Kind)
+			assert.Equal(t, tc.wantMarker, n.Marker)
+			assert.Equal(t, tc.wantText, n.Text)
+			assert.Equal(t, tc.wantResult, n.Result)
+			assert.Equal(t, n.Raw, tc.content[n.Offset:n.End], "Raw should match the source slice")
+		})
+	}
+}
+
+func TestParse_SceneHeaders(t *testing.T) {
+	tests := []struct {
+		name      string
+		content   string
+		wantScene string
+		wantTitle string
+	}{
+		{"plain", "S1 *Arrival at the keep*", "1", "Arrival at the keep"},
+		{"markdown heading", "## S12 The Vault", "12", "The Vault"},
+		{"sub scene", "S3a", "3a", ""},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			doc := Parse(tc.content)
+			require.Len(t, doc.Nodes, 1)
+			assert.Equal(t, KindScene, doc.Nodes[0].Kind)
+			assert.Equal(t, tc.wantScene, doc.Nodes[0].Scene)
+			assert.Equal(t, tc.wantTitle, doc.Nodes[0].Text)
+		})
+	}
+
+	t.Run("ordinary words are not scenes", func(t *testing.T) {
+		doc := Parse("Suddenly a door opens")
+		assert.Empty(t, doc.Nodes)
+	})
+}
+
+func TestParse_Tags(t *testing.T) {
+	content := "Met [N:Captain Vex | wary; HP 3] at [L:Docks].\nDamage: 2d6 = 9 [4 5]"
+	doc := Parse(content)
+
+	tags := doc.Of(KindTag)
+	require.Len(t, tags, 2, "dice breakdowns should not be parsed as tags")
+
+	vex := tags[0]
+	assert.Equal(t, "[N:Captain Vex | wary; HP 3]", vex.Raw)
+	assert.Equal(t, vex.Raw, content[vex.Offset:vex.End])
+	assert.Equal(t, "N:Captain Vex", vex.Tag.Identifier)
+	assert.Equal(t, "N", vex.Tag.Type)
+	assert.Equal(t, "Captain Vex", vex.Tag.Name)
+	assert.Equal(t, "wary; HP 3", vex.Tag.Data)
+	assert.True(t, vex.Tag.HasData)
+
+	docks := tags[1]
+	assert.Equal(t, "L:Docks", docks.Tag.Identifier)
+	assert.Equal(t, "", docks.Tag.Data)
+	assert.False(t, docks.Tag.HasData)
+}
+
+func TestParse_WikiLinksAreNotTags(t *testing.T) {
+	doc := Parse("See [[Session 2#The Docks]] and [N:Vex]")
+	tags := doc.Of(KindTag)
+	require.Len(t, tags, 1)
+	assert.Equal(t, "N:Vex", tags[0].Tag.Identifier)
+}
+
+func TestParse_NestedBracketsInData(t *testing.T) {
+	doc := Parse("[Clock:Alarm | [x][x][ ][ ]]")
+	tags := doc.Of(KindTag)
+	require.Len(t, tags, 1)
+	assert.Equal(t, "[x][x][ ][ ]", tags[0].Tag.Data)
+}
+
+func TestParse_OffsetsAcrossLines(t *testing.T) {
+	content := "S1 *Start*\r\n@ Sneak in [L:Keep]\n? Seen? -> No\n\n=> Safe"
+	doc := Parse(content)
+
+	kinds := make([]Kind, len(doc.Nodes))
+	for i, n := range doc.Nodes {
+		kinds[i] = n.Kind
+		assert.Equal(t, n.Raw, content[n.Offset:n.End], "node %d offsets should slice the source", i)
+	}
+	assert.Equal(t, []Kind{KindScene, KindAction, KindTag, KindOracle, KindConsequence}, kinds)
+
+	counts := doc.Counts()
+	assert.Equal(t, 1, counts[KindAction])
+	assert.Equal(t, 1, counts[KindTag])
+
+	scene := doc.SceneAt(doc.Nodes[4].Offset)
+	require.NotNil(t, scene)
+	assert.Equal(t, "Start", scene.Text)
+}
+
+func TestParseTag(t *testing.T) {
+	tag, ok := ParseTag("[Thread:Find the heir | open]")
+	require.True(t, ok)
+	assert.Equal(t, "Thread", tag.Type)
+	assert.Equal(t, "Find the heir", tag.Name)
+	assert.Equal(t, "open", tag.Data)
+
+	_, ok = ParseTag("text [N:Vex]")
+	assert.False(t, ok, "surrounding text is not a single tag")
+}
+
+func TestTagAt(t *testing.T) {
+	content := "Met [N:Vex | wary] at [L:The Keep]"
+
+	node := TagAt(content, 6)
+	require.NotNil(t, node)
+	assert.Equal(t, "N:Vex", node.Tag.Identifier)
+
+	node = TagAt(content, len(content))
+	require.NotNil(t, node, "the end of a tag is still inside it")
+	assert.Equal(t, "The Keep", node.Tag.Name)
+
+	assert.Nil(t, TagAt(content, 1))
+}
+
+func TestParseProgress(t *testing.T) {
+	tests := []struct {
+		name        string
+		content     string
+		wantType    string
+		wantName    string
+		wantCurrent int
+		wantMax     int
+	}{
+		{"value in name", "[Clock: Reinforcements 3/6]", "Clock", "Reinforcements", 3, 6},
+		{"value in data", "[Track:Escape | 2 / 8; hurry]", "Track", "Escape", 2, 8},
+		{"lowercase type", "[clock:Alarm 0/4]", "clock", "Alarm", 0, 4},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tags := Tags(tc.content)
+			require.Len(t, tags, 1)
+			p, ok := ParseProgress(tags[0])
+			require.True(t, ok)
+			assert.Equal(t, tc.wantType, p.Type)
+			assert.Equal(t, tc.wantName, p.Name)
+			assert.Equal(t, tc.wantCurrent, p.Current)
+			assert.Equal(t, tc.wantMax, p.Max)
+		})
+	}
+
+	t.Run("non progress tags are ignored", func(t *testing.T) {
+		for _, content := range []string{"[N:Vex | 3/6]", "[Clock:Alarm]", "[Clock:Broken 1/0]"} {
+			_, ok := ParseProgress(Tags(content)[0])
+			assert.False(t, ok, content)
+		}
+	})
+}
+
+func TestProgress_Step(t *testing.T) {
+	p, ok := ParseProgress(Tags("[Clock: Reinforcements 5/6 | arriving]")[0])
+	require.True(t, ok)
+	assert.False(t, p.IsFull())
+
+	assert.Equal(t, "[Clock: Reinforcements 6/6 | arriving]", p.Step(1))
+	assert.Equal(t, "[Clock: Reinforcements 6/6 | arriving]", p.Step(5), "should clamp at max")
+	assert.Equal(t, "[Clock: Reinforcements 4/6 | arriving]", p.Step(-1))
+	assert.Equal(t, "[Clock: Reinforcements 0/6 | arriving]", p.Step(-10), "should clamp at zero")
+}
@@ -0,0 +1,92 @@
+package lonelog
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// ProgressTypes are the tag types that carry numeric progress, matched case-insensitively
+var ProgressTypes = []string{"Clock", "Track"}
+
+// progressRegex matches a "current/max" pair such as 3/6
+var progressRegex = regexp.MustCompile(`(\d+)\s*/\s*(\d+)`)
+
+// Progress is a clock or track tag with a numeric value, e.g. [Clock:Reinforcements 3/6]
+// or [Track:Escape | 2/8].
+type Progress struct {
+	// Type is the tag type as written (e.g. "Clock").
+	Type string
+	// Name is the tag name with the progress value removed (e.g. "Reinforcements").
+	Name    string
+	Current int
+	Max     int
+	// Raw is the full tag the progress was read from.
+	Raw string
+
+	// currentStart/currentEnd locate the current value within Raw so it can be
+	// rewritten without disturbing the rest of the tag.
+	currentStart int
+	currentEnd   int
+}
+
+// ParseProgress reads the progress value from a clock or track tag node.
+// The value is taken from the tag name first, then from the data section.
+func ParseProgress(node Node) (*Progress, bool) {
+	if node.Kind != KindTag || node.Tag == nil || !isProgressType(node.Tag.Type) {
+		return nil, false
+	}
+
+	// The identifier precedes the data section in the raw tag, so the first
+	// match in Raw is the name's value when it has one, otherwise the data's.
+	m := progressRegex.FindStringSubmatchIndex(node.Raw)
+	if m == nil {
+		return nil, false
+	}
+	current, _ := strconv.Atoi(node.Raw[m[2]:m[3]])
+	maximum, _ := strconv.Atoi(node.Raw[m[4]:m[5]])
+	if maximum <= 0 {
+		return nil, false
+	}
+
+	name := node.Tag.Name
+	if loc := progressRegex.FindStringIndex(name); loc != nil {
+		name = strings.TrimSpace(name[:loc[0]] + name[loc[1]:])
+	}
+
+	return &Progress{
+		Type:         node.Tag.Type,
+		Name:         name,
+		Current:      current,
+		Max:          maximum,
+		Raw:          node.Raw,
+		currentStart: m[2],
+		currentEnd:   m[3],
+	}, true
+}
+
+// Key identifies a clock or track across occurrences, ignoring case and its value
+func (p *Progress) Key() string {
+	return strings.ToLower(p.Type + ":" + p.Name)
+}
+
+// IsFull reports whether the progress has reached its maximum
+func (p *Progress) IsFull() bool {
+	return p.Current >= p.Max
+}
+
+// Step returns the tag rewritten with the current value moved by delta,
+// clamped between 0 and Max. The rest of the tag is left as written.
+func (p *Progress) Step(delta int) string {
+	value := min(max(p.Current+delta, 0), p.Max)
+	return p.Raw[:p.currentStart] + strconv.Itoa(value) + p.Raw[p.currentEnd:]
+}
+
+func isProgressType(tagType string) bool {
+	for _, t := range ProgressTypes {
+		if strings.EqualFold(t, tagType) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,75 @@
+package notes
+
+import (
+	"soloterm/database"
+
+	// Pages belong to games, so the games table must be created first
+	_ "soloterm/domain/game"
+)
+
+func init() {
+	// Register this package's migrations with the database package
+	database.RegisterMigration(Migrate)
+}
+
+// Migrate runs all migrations for the notes domain
+func Migrate(db *database.DBStore) error {
+	// Migration: Create notes pages table
+	if err := createPagesTable(db); err != nil {
+		return err
+	}
+
+	// Migration: Move each game's single notes column into a page
+	if err := moveGameNotesToPages(db); err != nil {
+		return err
+	}
+
+	// Migration: Soft delete pages into the trash
+	if err := database.AddDeletedAtColumn(db.Connection, "notes_pages"); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// createPagesTable creates the notes pages table and index
+func createPagesTable(db *database.DBStore) error {
+	schema := `
+		CREATE TABLE IF NOT EXISTS notes_pages (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			game_id INTEGER NOT NULL,
+			name STRING NOT NULL,
+			content TEXT NOT NULL DEFAULT '',
+			position INTEGER NOT NULL DEFAULT 0,
+			created_at DATETIME NOT NULL,
+			updated_at DATETIME NOT NULL,
+			FOREIGN KEY (game_id) REFERENCES games(id) ON DELETE CASCADE
+		);
+
+		CREATE INDEX IF NOT EXISTS idx_notes_pages_by_game_id ON notes_pages (game_id, position);
+	`
+	_, err := db.Connection.Exec(schema)
+	return err
+}
+
+// moveGameNotesToPages copies the notes of games written before pages existed
+// into a page named "Notes" and drops the old games.notes column. Games that
+// already have pages are skipped, so a run that stopped before dropping the
+// column doesn't copy the notes twice.
+func moveGameNotesToPages(db *database.DBStore) error {
+	exists, err := database.ColumnExists(db.Connection, "games", "notes")
+	if err != nil || !exists {
+		return err
+	}
+
+	_, err = db.Connection.Exec(`
+		INSERT INTO notes_pages (game_id, name, content, position, created_at, updated_at)
+		SELECT id, ?, notes, 1, created_at, updated_at FROM games
+		WHERE COALESCE(notes, '') != ''
+		AND NOT EXISTS (SELECT 1 FROM notes_pages p WHERE p.game_id = games.id)`, DefaultPageName)
+	if err != nil {
+		return err
+	}
+
+	return database.RemoveColumn(db.Connection, "games", "notes")
+}
@@ -0,0 +1,50 @@
+// Package notes provides the named notes pages kept for each game, such as
+// NPCs, Locations or Rumours, and their persistence.
+package notes
+
+import (
+	"soloterm/shared/validation"
+	"time"
+)
+
+const (
+	MinNameLength = 1
+	MaxNameLength = 50
+)
+
+// DefaultPageName names the page created for a game's notes when it has none
+const DefaultPageName = "Notes"
+
+// Page is one named page of a game's notes
+type Page struct {
+	ID        int64      `db:"id"`
+	GameID    int64      `db:"game_id"`
+	Name      string     `db:"name"`
+	Content   string     `db:"content"`
+	Position  int        `db:"position"`
+	GameName  string     `db:"game_name"`
+	CreatedAt time.Time  `db:"created_at"`
+	UpdatedAt time.Time  `db:"updated_at"`
+	DeletedAt *time.Time `db:"deleted_at"` // Set while the page is in the trash
+}
+
+func NewPage(gameID int64, name string) (*Page, error) {
+	page := &Page{
+		ID:     0,
+		GameID: gameID,
+		Name:   name,
+	}
+
+	return page, nil
+}
+
+func (p *Page) Validate() *validation.Validator {
+	v := validation.NewValidator()
+	v.Check("name", p.Name != "", "is required")
+	v.Check("name", len(p.Name) >= MinNameLength && len(p.Name) <= MaxNameLength, "must be between %d and %d characters", MinNameLength, MaxNameLength)
+	return v
+}
+
+func (p *Page) IsNew() bool {
+	return p.ID == 0
+}
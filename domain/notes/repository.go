@@ -0,0 +1,176 @@
+package notes
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"soloterm/database"
+)
+
+// Indexer keeps data derived from notes pages current. Indexers are called
+// after every successful save.
+type Indexer interface {
+	IndexPage(page *Page) error
+}
+
+// ErrIndexing is wrapped by the error of a save that succeeded when an
+// indexer then failed, leaving the index out of date
+var ErrIndexing = errors.New("indexing failed")
+
+// Repository handles database operations for notes pages
+type Repository struct {
+	db       *database.DBStore
+	indexers []Indexer
+}
+
+// NewRepository creates a new Repository
+func NewRepository(db *database.DBStore) *Repository {
+	return &Repository{db: db}
+}
+
+// Save creates or updates a page
+// Automatically manages created_at, and updated_at
+// The page pointer is updated with the current values after save
+func (r *Repository) Save(page *Page) error {
+	var err error
+	if page.ID == 0 {
+		// INSERT - new page
+		err = r.insert(page)
+	} else {
+		// UPDATE - existing page
+		err = r.update(page)
+	}
+	if err != nil {
+		return err
+	}
+
+	return r.index(page)
+}
+
+// index passes the page to every indexer
+func (r *Repository) index(page *Page) error {
+	for _, indexer := range r.indexers {
+		if err := indexer.IndexPage(page); err != nil {
+			return fmt.Errorf("page saved but %w: %w", ErrIndexing, err)
+		}
+	}
+	return nil
+}
+
+// AddIndexer registers an indexer to be called after each save
+func (r *Repository) AddIndexer(indexer Indexer) {
+	r.indexers = append(r.indexers, indexer)
+}
+
+// Delete moves a page to the trash. Its indexed tags and links are left out
+// of the game until it is restored.
+// Returns the number of rows deleted and an error if the id doesn't exist
+func (r *Repository) Delete(id int64) (int64, error) {
+	if id == 0 {
+		return 0, errors.New("id cannot be empty")
+	}
+
+	return database.SoftDelete(r.db.Connection, "notes_pages", id)
+}
+
+// Restore brings a page back from the trash. It returns to its old place in
+// the game and its tags and links are indexed again.
+func (r *Repository) Restore(id int64) error {
+	if err := database.Restore(r.db.Connection, "notes_pages", id); err != nil {
+		return err
+	}
+	page, err := r.GetByID(id)
+	if err != nil {
+		return err
+	}
+	return r.index(page)
+}
+
+// Purge permanently removes a page in the trash, along with its tags and links
+func (r *Repository) Purge(id int64) error {
+	return database.Purge(r.db.Connection, "notes_pages", id)
+}
+
+// PurgeExpired permanently removes pages that have been in the trash for more than days
+func (r *Repository) PurgeExpired(days int) (int64, error) {
+	return database.PurgeExpired(r.db.Connection, "notes_pages", days)
+}
+
+// GetDeleted retrieves the pages in the trash with the names of their games,
+// most recently deleted first. Excludes content for performance reasons
+func (r *Repository) GetDeleted() ([]*Page, error) {
+	var pages []*Page
+	query := `SELECT p.id, p.game_id, p.name, p.position, p.created_at, p.updated_at, p.deleted_at, g.name AS game_name
+		FROM notes_pages p
+		JOIN games g ON p.game_id = g.id
+		WHERE p.deleted_at IS NOT NULL
+		ORDER BY p.deleted_at DESC`
+	err := r.db.Connection.Select(&pages, query)
+	return pages, err
+}
+
+// GetByID retrieves a page by ID
+func (r *Repository) GetByID(id int64) (*Page, error) {
+	if id == 0 {
+		return nil, errors.New("id cannot be zero")
+	}
+
+	var page Page
+	err := r.db.Connection.Get(&page, "SELECT * FROM notes_pages WHERE id = ? AND deleted_at IS NULL", id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.New("page not found")
+		}
+		return nil, err
+	}
+
+	return &page, nil
+}
+
+// GetAllForGame retrieves all pages for the game, including content, ordered by position
+func (r *Repository) GetAllForGame(gameID int64) ([]*Page, error) {
+	var pages []*Page
+	err := r.db.Connection.Select(&pages, "SELECT * FROM notes_pages WHERE game_id = ? AND deleted_at IS NULL ORDER BY position ASC, id ASC", gameID)
+	if err != nil {
+		return nil, err
+	}
+	return pages, nil
+}
+
+// Inserts a new record. Pages go after the game's last page.
+func (r *Repository) insert(page *Page) error {
+	query := `
+		INSERT INTO notes_pages (game_id, name, content, position, created_at, updated_at)
+		VALUES (?, ?, ?, (SELECT COALESCE(MAX(position), 0) + 1 FROM notes_pages WHERE game_id = ?),
+			datetime('now', 'subsec'), datetime('now', 'subsec'))
+		RETURNING id, position, created_at, updated_at
+	`
+
+	// Execute and scan the returned values back into page
+	err := r.db.Connection.QueryRowx(query,
+		page.GameID,
+		page.Name,
+		page.Content,
+		page.GameID,
+	).StructScan(page)
+
+	return err
+}
+
+// Updates an existing record
+func (r *Repository) update(page *Page) error {
+	query := `
+		UPDATE notes_pages SET name = ?, content = ?, updated_at = datetime('now','subsec')
+		WHERE id = ?
+		RETURNING game_id, position, created_at, updated_at
+	`
+
+	// Execute and scan the returned values back into page
+	err := r.db.Connection.QueryRowx(query,
+		page.Name,
+		page.Content,
+		page.ID,
+	).StructScan(page)
+
+	return err
+}
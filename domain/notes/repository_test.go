@@ -0,0 +1,159 @@
+package notes
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	testhelper "soloterm/shared/testing"
+)
+
+// recordingIndexer remembers the pages it was asked to index
+type recordingIndexer struct {
+	pages []*Page
+}
+
+func (ri *recordingIndexer) IndexPage(page *Page) error {
+	ri.pages = append(ri.pages, page)
+	return nil
+}
+
+func TestRepository_Save(t *testing.T) {
+	db := testhelper.SetupTestDB(t)
+	defer testhelper.TeardownTestDB(t, db)
+	repo := NewRepository(db)
+	indexer := &recordingIndexer{}
+	repo.AddIndexer(indexer)
+
+	gameID := testhelper.CreateTestGame(t, db, "Game")
+
+	t.Run("insert places pages after the last", func(t *testing.T) {
+		first := &Page{GameID: gameID, Name: "People", Content: "[N:Vex]"}
+		require.NoError(t, repo.Save(first))
+		second := &Page{GameID: gameID, Name: "Places"}
+		require.NoError(t, repo.Save(second))
+
+		assert.NotZero(t, first.ID)
+		assert.Equal(t, 1, first.Position)
+		assert.Equal(t, 2, second.Position)
+		assert.False(t, first.CreatedAt.IsZero())
+		assert.Len(t, indexer.pages, 2)
+	})
+
+	t.Run("update existing page", func(t *testing.T) {
+		page := &Page{GameID: gameID, Name: "Rumours"}
+		require.NoError(t, repo.Save(page))
+		firstUpdatedAt := page.UpdatedAt
+		time.Sleep(10 * time.Millisecond)
+
+		page.Content = "The duke is ill"
+		require.NoError(t, repo.Save(page))
+
+		stored, err := repo.GetByID(page.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "The duke is ill", stored.Content)
+		assert.Equal(t, 3, stored.Position, "Expected the position to be kept")
+		assert.NotEqual(t, firstUpdatedAt, page.UpdatedAt)
+	})
+}
+
+func TestRepository_GetAllForGame(t *testing.T) {
+	db := testhelper.SetupTestDB(t)
+	defer testhelper.TeardownTestDB(t, db)
+	repo := NewRepository(db)
+
+	gameID := testhelper.CreateTestGame(t, db, "Game")
+	otherID := testhelper.CreateTestGame(t, db, "Other")
+	testhelper.CreateTestNotesPage(t, db, gameID, "People", "")
+	testhelper.CreateTestNotesPage(t, db, otherID, "Elsewhere", "")
+	testhelper.CreateTestNotesPage(t, db, gameID, "Places", "")
+
+	pages, err := repo.GetAllForGame(gameID)
+	require.NoError(t, err)
+	require.Len(t, pages, 2)
+	assert.Equal(t, "People", pages[0].Name)
+	assert.Equal(t, "Places", pages[1].Name)
+}
+
+func TestRepository_Delete(t *testing.T) {
+	db := testhelper.SetupTestDB(t)
+	defer testhelper.TeardownTestDB(t, db)
+	repo := NewRepository(db)
+
+	gameID := testhelper.CreateTestGame(t, db, "Game")
+	id := testhelper.CreateTestNotesPage(t, db, gameID, "People", "")
+
+	count, err := repo.Delete(id)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+
+	_, err = repo.GetByID(id)
+	assert.Error(t, err, "Expected deleted pages to be hidden")
+	pages, err := repo.GetAllForGame(gameID)
+	require.NoError(t, err)
+	assert.Empty(t, pages)
+
+	deleted, err := repo.GetDeleted()
+	require.NoError(t, err)
+	require.Len(t, deleted, 1)
+	assert.Equal(t, "Game", deleted[0].GameName)
+
+	_, err = repo.Delete(id)
+	assert.Error(t, err, "Expected an error deleting a page already in the trash")
+
+	require.NoError(t, repo.Restore(id))
+	_, err = repo.GetByID(id)
+	assert.NoError(t, err, "Expected the restored page to be back")
+}
+
+func TestMigrate_MovesGameNotesToPages(t *testing.T) {
+	db := testhelper.SetupTestDB(t)
+	defer testhelper.TeardownTestDB(t, db)
+
+	withNotes := testhelper.CreateTestGame(t, db, "With Notes")
+	testhelper.CreateTestGame(t, db, "Without Notes")
+
+	// Simulate upgrading from a database where each game had one notes column
+	_, err := db.Connection.Exec("ALTER TABLE games ADD COLUMN notes TEXT DEFAULT ''")
+	require.NoError(t, err)
+	_, err = db.Connection.Exec("UPDATE games SET notes = '[N:Vex | wary]' WHERE id = ?", withNotes)
+	require.NoError(t, err)
+
+	require.NoError(t, Migrate(db))
+
+	var count int
+	require.NoError(t, db.Connection.Get(&count, "SELECT COUNT(*) FROM notes_pages"))
+	assert.Equal(t, 1, count, "Expected pages only for games with notes")
+
+	pages, err := NewRepository(db).GetAllForGame(withNotes)
+	require.NoError(t, err)
+	require.Len(t, pages, 1)
+	assert.Equal(t, DefaultPageName, pages[0].Name)
+	assert.Equal(t, "[N:Vex | wary]", pages[0].Content)
+
+	var columns int
+	require.NoError(t, db.Connection.Get(&columns, "SELECT COUNT(*) FROM pragma_table_info('games') WHERE name = 'notes'"))
+	assert.Zero(t, columns, "Expected the notes column to be dropped")
+}
+
+func TestMigrate_MovesGameNotesToPagesOnce(t *testing.T) {
+	db := testhelper.SetupTestDB(t)
+	defer testhelper.TeardownTestDB(t, db)
+
+	gameID := testhelper.CreateTestGame(t, db, "Game")
+
+	// Simulate a run that copied the notes but stopped before dropping the column
+	_, err := db.Connection.Exec("ALTER TABLE games ADD COLUMN notes TEXT DEFAULT ''")
+	require.NoError(t, err)
+	_, err = db.Connection.Exec("UPDATE games SET notes = '[N:Vex | wary]' WHERE id = ?", gameID)
+	require.NoError(t, err)
+	testhelper.CreateTestNotesPage(t, db, gameID, DefaultPageName, "[N:Vex | wary]")
+
+	require.NoError(t, Migrate(db))
+
+	pages, err := NewRepository(db).GetAllForGame(gameID)
+	require.NoError(t, err)
+	assert.Len(t, pages, 1, "Expected the notes not to be copied twice")
+}
@@ -0,0 +1,89 @@
+package notes
+
+import (
+	"errors"
+	"strings"
+)
+
+// Service handles notes page business logic
+type Service struct {
+	repo *Repository
+}
+
+// NewService creates a new notes service
+func NewService(repo *Repository) *Service {
+	return &Service{repo: repo}
+}
+
+// Save validates and saves a page (create or update). When only indexing
+// fails, the saved page is returned along with the error.
+func (s *Service) Save(page *Page) (*Page, error) {
+	page.Name = strings.TrimSpace(page.Name)
+
+	// Validate
+	validator := page.Validate()
+	if validator.HasErrors() {
+		return nil, validator
+	}
+
+	// Save to database
+	err := s.repo.Save(page)
+	if err != nil {
+		// The page was saved even though indexing it failed
+		if errors.Is(err, ErrIndexing) {
+			return page, err
+		}
+		return nil, err
+	}
+
+	return page, nil
+}
+
+// Delete removes a page by ID
+func (s *Service) Delete(id int64) error {
+	_, err := s.repo.Delete(id)
+	return err
+}
+
+// GetByID retrieves a page by ID
+func (s *Service) GetByID(id int64) (*Page, error) {
+	return s.repo.GetByID(id)
+}
+
+// GetAllForGame retrieves the game's pages in order
+func (s *Service) GetAllForGame(gameID int64) ([]*Page, error) {
+	return s.repo.GetAllForGame(gameID)
+}
+
+// FirstPage returns the game's first page, creating an empty one named
+// "Notes" when the game has none yet
+func (s *Service) FirstPage(gameID int64) (*Page, error) {
+	pages, err := s.repo.GetAllForGame(gameID)
+	if err != nil {
+		return nil, err
+	}
+	if len(pages) > 0 {
+		return pages[0], nil
+	}
+
+	page, _ := NewPage(gameID, DefaultPageName)
+	return s.Save(page)
+}
+
+// Export joins the game's pages into one document, each page under a
+// heading with its name
+func (s *Service) Export(gameID int64) (string, error) {
+	pages, err := s.repo.GetAllForGame(gameID)
+	if err != nil {
+		return "", err
+	}
+	if len(pages) == 0 {
+		return "", nil
+	}
+
+	sections := make([]string, len(pages))
+	for i, page := range pages {
+		sections[i] = "# " + page.Name + "\n\n" + strings.TrimRight(page.Content, "\n")
+	}
+	return strings.Join(sections, "\n\n") + "\n", nil
+}
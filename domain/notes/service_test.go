@@ -0,0 +1,93 @@
+package notes
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	testhelper "soloterm/shared/testing"
+	"soloterm/shared/validation"
+)
+
+func TestService_Save(t *testing.T) {
+	db := testhelper.SetupTestDB(t)
+	defer testhelper.TeardownTestDB(t, db)
+	svc := NewService(NewRepository(db))
+
+	gameID := testhelper.CreateTestGame(t, db, "Game")
+
+	t.Run("trims the name", func(t *testing.T) {
+		page, err := svc.Save(&Page{GameID: gameID, Name: "  People "})
+		require.NoError(t, err)
+		assert.Equal(t, "People", page.Name)
+	})
+
+	t.Run("requires a name", func(t *testing.T) {
+		_, err := svc.Save(&Page{GameID: gameID, Name: "  "})
+		require.Error(t, err)
+		v, ok := err.(*validation.Validator)
+		require.True(t, ok, "Expected a validation error")
+		assert.True(t, v.HasError("name"))
+	})
+
+	t.Run("returns the saved page when indexing fails", func(t *testing.T) {
+		repo := NewRepository(db)
+		repo.AddIndexer(failingIndexer{})
+		page, err := NewService(repo).Save(&Page{GameID: gameID, Name: "Places"})
+		assert.ErrorIs(t, err, ErrIndexing)
+		require.NotNil(t, page)
+
+		stored, err := repo.GetByID(page.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "Places", stored.Name)
+	})
+}
+
+// failingIndexer fails to index every page
+type failingIndexer struct{}
+
+func (failingIndexer) IndexPage(*Page) error {
+	return errors.New("index is locked")
+}
+
+func TestService_FirstPage(t *testing.T) {
+	db := testhelper.SetupTestDB(t)
+	defer testhelper.TeardownTestDB(t, db)
+	svc := NewService(NewRepository(db))
+
+	gameID := testhelper.CreateTestGame(t, db, "Game")
+
+	page, err := svc.FirstPage(gameID)
+	require.NoError(t, err)
+	assert.Equal(t, DefaultPageName, page.Name, "Expected a page to be created")
+
+	testhelper.CreateTestNotesPage(t, db, gameID, "People", "")
+	again, err := svc.FirstPage(gameID)
+	require.NoError(t, err)
+	assert.Equal(t, page.ID, again.ID)
+
+	pages, err := svc.GetAllForGame(gameID)
+	require.NoError(t, err)
+	assert.Len(t, pages, 2)
+}
+
+func TestService_Export(t *testing.T) {
+	db := testhelper.SetupTestDB(t)
+	defer testhelper.TeardownTestDB(t, db)
+	svc := NewService(NewRepository(db))
+
+	gameID := testhelper.CreateTestGame(t, db, "Game")
+
+	exported, err := svc.Export(gameID)
+	require.NoError(t, err)
+	assert.Empty(t, exported)
+
+	testhelper.CreateTestNotesPage(t, db, gameID, "People", "[N:Vex]\n")
+	testhelper.CreateTestNotesPage(t, db, gameID, "Places", "[L:Keep]")
+
+	exported, err = svc.Export(gameID)
+	require.NoError(t, err)
+	assert.Equal(t, "# People\n\n[N:Vex]\n\n# Places\n\n[L:Keep]\n", exported)
+}
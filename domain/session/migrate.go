@@ -16,6 +16,26 @@ func Migrate(db *database.DBStore) error {
 		return err
 	}
 
+	// Migration: Add an optional summary to each session
+	if err := addSummaryColumn(db); err != nil {
+		return err
+	}
+
+	// Migration: Add an editable play date, starting from when each session was created
+	if err := addPlayedAtColumn(db); err != nil {
+		return err
+	}
+
+	// Migration: Add a position for ordering sessions within their game
+	if err := addPositionColumn(db); err != nil {
+		return err
+	}
+
+	// Migration: Soft delete sessions into the trash
+	if err := database.AddDeletedAtColumn(db.Connection, "sessions"); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -37,3 +57,38 @@ func createTable(db *database.DBStore) error {
 	_, err := db.Connection.Exec(schema)
 	return err
 }
+
+// addSummaryColumn adds the optional session summary used by the campaign recap
+func addSummaryColumn(db *database.DBStore) error {
+	defaultValue := "''"
+	return database.AddColumn(db.Connection, "sessions", "summary", "TEXT", true, &defaultValue)
+}
+
+// addPlayedAtColumn adds the play date shown in the game tree and fills it in
+// from created_at for existing sessions
+func addPlayedAtColumn(db *database.DBStore) error {
+	defaultValue := "''"
+	if err := database.AddColumn(db.Connection, "sessions", "played_at", "DATE", true, &defaultValue); err != nil {
+		return err
+	}
+	_, err := db.Connection.Exec(`UPDATE sessions SET played_at = date(created_at) WHERE played_at = ''`)
+	return err
+}
+
+// addPositionColumn adds the session position and numbers existing sessions
+// from 1 in the order they were created. Position 0 marks a session that has
+// not been placed yet.
+func addPositionColumn(db *database.DBStore) error {
+	defaultValue := "0"
+	if err := database.AddColumn(db.Connection, "sessions", "position", "INTEGER", true, &defaultValue); err != nil {
+		return err
+	}
+	_, err := db.Connection.Exec(`
+		UPDATE sessions SET position = (
+			SELECT COUNT(*) FROM sessions s
+			WHERE s.game_id = sessions.game_id
+				AND (s.created_at, s.id) <= (sessions.created_at, sessions.id)
+		)
+		WHERE position = 0`)
+	return err
+}
@@ -0,0 +1,25 @@
+package session
+
+import (
+	"soloterm/shared/text"
+	"strings"
+)
+
+// recap compiles the summaries of sessions, in the order given, into a
+// Markdown document. Sessions without a summary are left out. Returns ""
+// when none of the sessions have a summary.
+func recap(sessions []*Session) string {
+	var b strings.Builder
+	for _, s := range sessions {
+		summary := strings.TrimSpace(s.Summary)
+		if summary == "" {
+			continue
+		}
+		if b.Len() == 0 {
+			b.WriteString("# Recap: " + s.GameName + "\n")
+		}
+		b.WriteString("\n## " + s.Name + " (" + s.PlayedAt.Format(text.DateFormat) + ")\n\n")
+		b.WriteString(summary + "\n")
+	}
+	return b.String()
+}
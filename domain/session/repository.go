@@ -5,11 +5,25 @@ import (
 	"errors"
 	"fmt"
 	"soloterm/database"
+	"soloterm/shared/text"
+
+	"github.com/jmoiron/sqlx"
 )
 
+// Indexer keeps data derived from session content current. Indexers are called
+// after every successful save.
+type Indexer interface {
+	IndexSession(session *Session) error
+}
+
+// ErrIndexing is wrapped by the error of a save that succeeded when an
+// indexer then failed, leaving the index out of date
+var ErrIndexing = errors.New("indexing failed")
+
 // Repository handles database operations for sessions
 type Repository struct {
-	db *database.DBStore
+	db       *database.DBStore
+	indexers []Indexer
 }
 
 // NewRepository creates a new Repository
@@ -21,41 +35,177 @@ func NewRepository(db *database.DBStore) *Repository {
 // Automatically manages created_at, and updated_at
 // The session pointer is updated with the current values after save
 func (r *Repository) Save(session *Session) error {
+	var err error
 	if session.ID == 0 {
 		// INSERT - new session
-		return r.insert(session)
+		err = r.insert(r.db.Connection, session)
 	} else {
 		// UPDATE - existing session
-		return r.update(session)
+		err = r.update(r.db.Connection, session)
 	}
+	if err != nil {
+		return err
+	}
+
+	return r.index(session)
 }
 
-// Delete removes a session by id
-// Returns the number of rows deleted and an error if the id doesn't exist
-func (r *Repository) Delete(id int64) (int64, error) {
-	if id == 0 {
-		return 0, errors.New("id cannot be empty")
+// Split updates first and inserts second right after it in a single
+// transaction, moving the game's later sessions down to make room.
+func (r *Repository) Split(first *Session, second *Session) error {
+	tx, err := r.db.Connection.Beginx()
+	if err != nil {
+		return err
 	}
+	defer tx.Rollback()
 
-	query := `DELETE FROM sessions WHERE id = ?`
+	if err := r.update(tx, first); err != nil {
+		return err
+	}
+	_, err = tx.Exec(`UPDATE sessions SET position = position + 1 WHERE game_id = ? AND position > ?`,
+		first.GameID, first.Position)
+	if err != nil {
+		return err
+	}
+	second.Position = first.Position + 1
+	if err := r.insert(tx, second); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
 
-	result, err := r.db.Connection.Exec(query, id)
+	return r.index(first, second)
+}
 
+// Move updates the session, which has been given a new game, and renumbers
+// the positions of that game's sessions in the order given
+func (r *Repository) Move(session *Session, order []int64) error {
+	tx, err := r.db.Connection.Beginx()
 	if err != nil {
-		return 0, err
+		return err
 	}
+	defer tx.Rollback()
 
-	// Check if a row was actually deleted
-	rows, err := result.RowsAffected()
+	if err := r.update(tx, session); err != nil {
+		return err
+	}
+	if err := r.setPositions(tx, order); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	return r.index(session)
+}
+
+// Merge updates into and deletes from in a single transaction
+func (r *Repository) Merge(into *Session, from *Session) error {
+	tx, err := r.db.Connection.Beginx()
 	if err != nil {
-		return 0, err
+		return err
 	}
+	defer tx.Rollback()
 
-	if rows == 0 {
-		return 0, fmt.Errorf("id '%d' not found", id)
+	if err := r.update(tx, into); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(`DELETE FROM sessions WHERE id = ?`, from.ID); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return err
 	}
 
-	return rows, nil
+	return r.index(into)
+}
+
+// SetPositions numbers the sessions from 1 in the order given
+func (r *Repository) SetPositions(order []int64) error {
+	tx, err := r.db.Connection.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := r.setPositions(tx, order); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (r *Repository) setPositions(e sqlx.Execer, order []int64) error {
+	for i, id := range order {
+		_, err := e.Exec(`UPDATE sessions SET position = ?, updated_at = datetime('now','subsec') WHERE id = ?`, i+1, id)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// index runs the indexers over the saved sessions
+func (r *Repository) index(sessions ...*Session) error {
+	for _, session := range sessions {
+		for _, indexer := range r.indexers {
+			if err := indexer.IndexSession(session); err != nil {
+				return fmt.Errorf("session saved but %w: %w", ErrIndexing, err)
+			}
+		}
+	}
+	return nil
+}
+
+// AddIndexer registers an indexer to be called after each save
+func (r *Repository) AddIndexer(indexer Indexer) {
+	r.indexers = append(r.indexers, indexer)
+}
+
+// Delete moves a session to the trash
+// Returns the number of rows deleted and an error if the id doesn't exist
+func (r *Repository) Delete(id int64) (int64, error) {
+	if id == 0 {
+		return 0, errors.New("id cannot be empty")
+	}
+
+	return database.SoftDelete(r.db.Connection, "sessions", id)
+}
+
+// Restore brings a session back from the trash. It returns to its old place
+// in the game and its tags are indexed again.
+func (r *Repository) Restore(id int64) error {
+	if err := database.Restore(r.db.Connection, "sessions", id); err != nil {
+		return err
+	}
+	session, err := r.GetByID(id)
+	if err != nil {
+		return err
+	}
+	return r.index(session)
+}
+
+// Purge permanently removes a session in the trash
+func (r *Repository) Purge(id int64) error {
+	return database.Purge(r.db.Connection, "sessions", id)
+}
+
+// PurgeExpired permanently removes sessions that have been in the trash for more than days
+func (r *Repository) PurgeExpired(days int) (int64, error) {
+	return database.PurgeExpired(r.db.Connection, "sessions", days)
+}
+
+// GetDeleted retrieves the sessions in the trash, most recently deleted first.
+// Excludes content for performance reasons
+func (r *Repository) GetDeleted() ([]*Session, error) {
+	var sessions []*Session
+	query := `SELECT s.id, s.game_id, s.name, s.summary, s.played_at, s.position, s.created_at, s.updated_at, s.deleted_at, g.name AS game_name
+		FROM sessions s
+		JOIN games g ON s.game_id = g.id
+		WHERE s.deleted_at IS NOT NULL
+		ORDER BY s.deleted_at DESC`
+	err := r.db.Connection.Select(&sessions, query)
+	return sessions, err
 }
 
 func (r *Repository) DeleteAllForGame(gameID int64) (int64, error) {
@@ -94,7 +244,7 @@ func (r *Repository) GetByID(id int64) (*Session, error) {
 	var session Session
 	query := `SELECT s.*, g.name AS game_name FROM sessions s
 		JOIN games g ON s.game_id = g.id
-		WHERE s.id = ?`
+		WHERE s.id = ? AND s.deleted_at IS NULL`
 	err := r.db.Connection.Get(&session, query, id)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -106,13 +256,13 @@ func (r *Repository) GetByID(id int64) (*Session, error) {
 	return &session, nil
 }
 
-// GetAllForGame retrieves all sessions for the game ordered by created_at. Excludes content for performance reasons
+// GetAllForGame retrieves all sessions for the game ordered by position. Excludes content for performance reasons
 func (r *Repository) GetAllForGame(gameID int64) ([]*Session, error) {
 	var sessions []*Session
-	query := `SELECT s.id, s.game_id, s.name, s.created_at, s.updated_at, g.name AS game_name
+	query := `SELECT s.id, s.game_id, s.name, s.summary, s.played_at, s.position, s.created_at, s.updated_at, s.deleted_at, g.name AS game_name
 		FROM sessions s
 		JOIN games g ON s.game_id = g.id
-		WHERE s.game_id = ? ORDER BY s.created_at ASC`
+		WHERE s.game_id = ? AND s.deleted_at IS NULL ORDER BY s.position ASC, s.id ASC`
 	err := r.db.Connection.Select(&sessions, query, gameID)
 	if err != nil {
 		return nil, err
@@ -123,20 +273,76 @@ func (r *Repository) GetAllForGame(gameID int64) ([]*Session, error) {
 // GetAllContentForGame retrieves content from all sessions for a game
 func (r *Repository) GetAllContentForGame(gameID int64) ([]string, error) {
 	var contents []string
-	err := r.db.Connection.Select(&contents, "SELECT content FROM sessions WHERE game_id = ?", gameID)
+	err := r.db.Connection.Select(&contents, "SELECT content FROM sessions WHERE game_id = ? AND deleted_at IS NULL", gameID)
 	if err != nil {
 		return nil, err
 	}
 	return contents, nil
 }
 
+// GetAllWithContentForGame retrieves all sessions for the game, including content, ordered by position
+func (r *Repository) GetAllWithContentForGame(gameID int64) ([]*Session, error) {
+	var sessions []*Session
+	query := `SELECT s.*, g.name AS game_name
+		FROM sessions s
+		JOIN games g ON s.game_id = g.id
+		WHERE s.game_id = ? AND s.deleted_at IS NULL ORDER BY s.position ASC, s.id ASC`
+	err := r.db.Connection.Select(&sessions, query, gameID)
+	if err != nil {
+		return nil, err
+	}
+	return sessions, nil
+}
+
+// GetPrevious retrieves the session just before the given one in the same game.
+// Returns nil when it is the game's first session.
+func (r *Repository) GetPrevious(id int64) (*Session, error) {
+	var sessions []*Session
+	query := `SELECT s.*, g.name AS game_name
+		FROM sessions s
+		JOIN games g ON s.game_id = g.id
+		WHERE s.game_id = (SELECT game_id FROM sessions WHERE id = ?) AND s.deleted_at IS NULL
+			AND (s.position, s.id) < (SELECT position, id FROM sessions WHERE id = ?)
+		ORDER BY s.position DESC, s.id DESC
+		LIMIT 1`
+	err := r.db.Connection.Select(&sessions, query, id, id)
+	if err != nil {
+		return nil, err
+	}
+	if len(sessions) == 0 {
+		return nil, nil
+	}
+	return sessions[0], nil
+}
+
+// GetNext retrieves the session just after the given one in the same game.
+// Returns nil when it is the game's last session.
+func (r *Repository) GetNext(id int64) (*Session, error) {
+	var sessions []*Session
+	query := `SELECT s.*, g.name AS game_name
+		FROM sessions s
+		JOIN games g ON s.game_id = g.id
+		WHERE s.game_id = (SELECT game_id FROM sessions WHERE id = ?) AND s.deleted_at IS NULL
+			AND (s.position, s.id) > (SELECT position, id FROM sessions WHERE id = ?)
+		ORDER BY s.position ASC, s.id ASC
+		LIMIT 1`
+	err := r.db.Connection.Select(&sessions, query, id, id)
+	if err != nil {
+		return nil, err
+	}
+	if len(sessions) == 0 {
+		return nil, nil
+	}
+	return sessions[0], nil
+}
+
 func (r *Repository) SearchByGame(gameID int64, term string) ([]*Session, error) {
 	var sessions []*Session
 	query := `
 		SELECT s.*, g.name AS game_name FROM sessions s
 		JOIN games g ON s.game_id = g.id
-		WHERE s.game_id = ? AND LOWER(s.content) LIKE LOWER('%' || ? || '%')
-		ORDER BY s.created_at
+		WHERE s.game_id = ? AND s.deleted_at IS NULL AND LOWER(s.content) LIKE LOWER('%' || ? || '%')
+		ORDER BY s.position
 	`
 	err := r.db.Connection.Select(&sessions, query, gameID, term)
 	if err != nil {
@@ -145,37 +351,51 @@ func (r *Repository) SearchByGame(gameID int64, term string) ([]*Session, error)
 	return sessions, nil
 }
 
-// Inserts a new record
-func (r *Repository) insert(session *Session) error {
+// Inserts a new record. Sessions without a position go after the game's last
+// session, and sessions without a play date are played today.
+func (r *Repository) insert(q sqlx.Queryer, session *Session) error {
+	if session.PlayedAt.IsZero() {
+		session.PlayedAt = Today()
+	}
+
 	query := `
-		INSERT INTO sessions (game_id, name, content, created_at, updated_at)
-		VALUES (?, ?, ?, datetime('now', 'subsec'), datetime('now', 'subsec'))
-		RETURNING id, created_at, updated_at
+		INSERT INTO sessions (game_id, name, content, summary, played_at, position, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?,
+			CASE WHEN ? > 0 THEN ? ELSE (SELECT COALESCE(MAX(position), 0) + 1 FROM sessions WHERE game_id = ?) END,
+			datetime('now', 'subsec'), datetime('now', 'subsec'))
+		RETURNING id, position, created_at, updated_at
 	`
 
 	// Execute and scan the returned values back into session
-	err := r.db.Connection.QueryRowx(query,
+	err := q.QueryRowx(query,
 		session.GameID,
 		session.Name,
 		session.Content,
+		session.Summary,
+		session.PlayedAt.Format(text.DateFormat),
+		session.Position,
+		session.Position,
+		session.GameID,
 	).StructScan(session)
 
 	return err
 }
 
-// Updates an existing record
-func (r *Repository) update(session *Session) error {
+// Updates an existing record. The position is left alone; it only changes when sessions are reordered.
+func (r *Repository) update(q sqlx.Queryer, session *Session) error {
 	query := `
-		UPDATE sessions SET game_id = ?, name = ?, content = ?, updated_at = datetime('now','subsec')
+		UPDATE sessions SET game_id = ?, name = ?, content = ?, summary = ?, played_at = ?, updated_at = datetime('now','subsec')
 		WHERE id = ?
-		RETURNING created_at, updated_at
+		RETURNING position, created_at, updated_at
 	`
 
 	// Execute and scan the returned values back into session
-	err := r.db.Connection.QueryRowx(query,
+	err := q.QueryRowx(query,
 		session.GameID,
 		session.Name,
 		session.Content,
+		session.Summary,
+		session.PlayedAt.Format(text.DateFormat),
 		session.ID,
 	).StructScan(session)
 
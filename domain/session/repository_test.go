@@ -5,10 +5,12 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	// Blank import to register the games table migration (GetByID / GetAllForGame JOIN games)
 	_ "soloterm/domain/game"
 	testhelper "soloterm/shared/testing"
+	"soloterm/shared/text"
 )
 
 func TestRepository_Save(t *testing.T) {
@@ -36,6 +38,15 @@ func TestRepository_Save(t *testing.T) {
 
 	})
 
+	t.Run("new session without a play date is played today", func(t *testing.T) {
+		session := &Session{GameID: gameID, Name: "no date"}
+		require.NoError(t, repo.Save(session))
+
+		stored, err := repo.GetByID(session.ID)
+		require.NoError(t, err)
+		assert.Equal(t, Today().Format(text.DateFormat), stored.PlayedAt.Format(text.DateFormat))
+	})
+
 	t.Run("update existing session", func(t *testing.T) {
 		// Create initial session
 		session, _ := NewSession(gameID)
@@ -324,3 +335,47 @@ func TestRepository_SearchByGame(t *testing.T) {
 		assert.Equal(t, "Game 1", results[0].GameName)
 	})
 }
+
+func TestRepository_GetAllWithContentForGame(t *testing.T) {
+	db := testhelper.SetupTestDB(t)
+	defer testhelper.TeardownTestDB(t, db)
+	repo := NewRepository(db)
+
+	gameID1 := testhelper.CreateTestGame(t, db, "Game 1")
+	gameID2 := testhelper.CreateTestGame(t, db, "Game 2")
+
+	testhelper.CreateTestSession(t, db, gameID1, "Session One", "First content")
+	testhelper.CreateTestSession(t, db, gameID1, "Session Two", "Second content")
+	testhelper.CreateTestSession(t, db, gameID2, "Other Game Session", "Other content")
+
+	sessions, err := repo.GetAllWithContentForGame(gameID1)
+	assert.NoError(t, err)
+	assert.Len(t, sessions, 2)
+	assert.Equal(t, "Session One", sessions[0].Name)
+	assert.Equal(t, "First content", sessions[0].Content)
+	assert.Equal(t, "Second content", sessions[1].Content)
+	assert.Equal(t, "Game 1", sessions[0].GameName)
+}
+
+func TestRepository_GetPrevious(t *testing.T) {
+	db := testhelper.SetupTestDB(t)
+	defer testhelper.TeardownTestDB(t, db)
+	repo := NewRepository(db)
+
+	gameID1 := testhelper.CreateTestGame(t, db, "Game 1")
+	gameID2 := testhelper.CreateTestGame(t, db, "Game 2")
+
+	first := testhelper.CreateTestSession(t, db, gameID1, "Session One", "First content")
+	testhelper.CreateTestSession(t, db, gameID2, "Other Game Session", "Other content")
+	second := testhelper.CreateTestSession(t, db, gameID1, "Session Two", "Second content")
+
+	prev, err := repo.GetPrevious(second)
+	require.NoError(t, err)
+	require.NotNil(t, prev)
+	assert.Equal(t, first, prev.ID)
+	assert.Equal(t, "First content", prev.Content)
+
+	prev, err = repo.GetPrevious(first)
+	require.NoError(t, err)
+	assert.Nil(t, prev, "Expected no previous session for the game's first session")
+}
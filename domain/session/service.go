@@ -1,5 +1,11 @@
 package session
 
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
 // Service handles session business logic
 type Service struct {
 	repo *Repository
@@ -10,8 +16,14 @@ func NewService(repo *Repository) *Service {
 	return &Service{repo: repo}
 }
 
-// Save validates and saves a session entry (create or update)
+// Save validates and saves a session entry (create or update). When only
+// indexing fails, the saved session is returned along with the error.
 func (s *Service) Save(l *Session) (*Session, error) {
+	// New sessions are played today unless given a date
+	if l.IsNew() && l.PlayedAt.IsZero() {
+		l.PlayedAt = Today()
+	}
+
 	// Validate
 	validator := l.Validate()
 	if validator.HasErrors() {
@@ -21,12 +33,171 @@ func (s *Service) Save(l *Session) (*Session, error) {
 	// Save to database
 	err := s.repo.Save(l)
 	if err != nil {
+		// The session was saved even though indexing it failed
+		if errors.Is(err, ErrIndexing) {
+			return l, err
+		}
 		return nil, err
 	}
 
 	return l, nil
 }
 
+// Move moves a session to another game. It takes its place among the other
+// game's sessions by its play date, after any played the same day.
+func (s *Service) Move(id int64, gameID int64) (*Session, error) {
+	session, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if session.GameID == gameID {
+		return nil, errors.New("session is already in that game")
+	}
+
+	others, err := s.repo.GetAllForGame(gameID)
+	if err != nil {
+		return nil, err
+	}
+	order := make([]int64, 0, len(others)+1)
+	placed := false
+	for _, other := range others {
+		if !placed && other.PlayedAt.After(session.PlayedAt) {
+			order = append(order, session.ID)
+			placed = true
+		}
+		order = append(order, other.ID)
+	}
+	if !placed {
+		order = append(order, session.ID)
+	}
+
+	session.GameID = gameID
+	// The session is moved even when indexing it fails
+	err = s.repo.Move(session, order)
+	if err != nil && !errors.Is(err, ErrIndexing) {
+		return nil, err
+	}
+	moved, getErr := s.repo.GetByID(id)
+	if getErr != nil {
+		return nil, getErr
+	}
+	return moved, err
+}
+
+// Split splits a session at offset, a byte offset into its content. The text
+// from offset on moves to a new session called name, placed right after the
+// original and played the same day.
+func (s *Service) Split(id int64, offset int, name string) (first *Session, second *Session, err error) {
+	first, err = s.repo.GetByID(id)
+	if err != nil {
+		return nil, nil, err
+	}
+	if offset <= 0 || offset >= len(first.Content) {
+		return nil, nil, errors.New("split point must be inside the session's content")
+	}
+
+	second = &Session{
+		GameID:   first.GameID,
+		GameName: first.GameName,
+		Name:     name,
+		Content:  strings.TrimLeft(first.Content[offset:], "\n"),
+		PlayedAt: first.PlayedAt,
+	}
+	if validator := second.Validate(); validator.HasErrors() {
+		return nil, nil, validator
+	}
+	first.Content = strings.TrimRight(first.Content[:offset], "\n")
+
+	if err := s.repo.Split(first, second); err != nil {
+		// The session was split even though indexing it failed
+		if errors.Is(err, ErrIndexing) {
+			return first, second, err
+		}
+		return nil, nil, err
+	}
+	return first, second, nil
+}
+
+// Reorder moves a session up (-1) or down (1) among its game's sessions.
+// Returns the ID of the moved session, or 0 when it is already at that end.
+func (s *Service) Reorder(sessionID int64, direction int) (int64, error) {
+	curr, err := s.repo.GetByID(sessionID)
+	if err != nil {
+		return 0, fmt.Errorf("session %d not found", sessionID)
+	}
+
+	sessions, err := s.repo.GetAllForGame(curr.GameID)
+	if err != nil {
+		return 0, err
+	}
+
+	idx := -1
+	for i, session := range sessions {
+		if session.ID == sessionID {
+			idx = i
+			break
+		}
+	}
+	if idx == -1 {
+		return 0, fmt.Errorf("session %d not found in its game", sessionID)
+	}
+
+	neighborIdx := idx + direction
+	if neighborIdx < 0 || neighborIdx >= len(sessions) {
+		return 0, nil // List boundary
+	}
+
+	// Renumber the whole game so positions left behind by deletes can't clash
+	order := make([]int64, len(sessions))
+	for i, session := range sessions {
+		order[i] = session.ID
+	}
+	order[idx], order[neighborIdx] = order[neighborIdx], order[idx]
+	if err := s.repo.SetPositions(order); err != nil {
+		return 0, err
+	}
+	return curr.ID, nil
+}
+
+// Merge appends the content and summary of the game's next session onto the
+// session, then deletes the next session.
+func (s *Service) Merge(id int64) (*Session, error) {
+	into, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	from, err := s.repo.GetNext(id)
+	if err != nil {
+		return nil, err
+	}
+	if from == nil {
+		return nil, errors.New("there is no later session to merge")
+	}
+
+	into.Content = joinNonEmpty(strings.TrimRight(into.Content, "\n"), from.Content, "\n\n")
+	into.Summary = joinNonEmpty(strings.TrimSpace(into.Summary), strings.TrimSpace(from.Summary), "\n")
+	if validator := into.Validate(); validator.HasErrors() {
+		return nil, validator
+	}
+
+	if err := s.repo.Merge(into, from); err != nil {
+		// The sessions were merged even though indexing them failed
+		if errors.Is(err, ErrIndexing) {
+			return into, err
+		}
+		return nil, err
+	}
+	return into, nil
+}
+
+// joinNonEmpty joins a and b with sep, leaving out sep when either is empty
+func joinNonEmpty(a, b, sep string) string {
+	if a == "" || b == "" {
+		return a + b
+	}
+	return a + sep + b
+}
+
 // Delete removes a session entry by ID
 func (s *Service) Delete(id int64) error {
 	_, err := s.repo.Delete(id)
@@ -43,6 +214,31 @@ func (s *Service) GetAllForGame(gameID int64) ([]*Session, error) {
 	return s.repo.GetAllForGame(gameID)
 }
 
+// GetAllWithContentForGame retrieves all sessions for a game with their content
+func (s *Service) GetAllWithContentForGame(gameID int64) ([]*Session, error) {
+	return s.repo.GetAllWithContentForGame(gameID)
+}
+
+// Previously returns a recap of the session before the given one, for the
+// {{previously}} template placeholder. Returns "" for a game's first session.
+func (s *Service) Previously(id int64) (string, error) {
+	prev, err := s.repo.GetPrevious(id)
+	if err != nil || prev == nil {
+		return "", err
+	}
+	return previously(prev), nil
+}
+
+// Recap compiles the summaries of the game's sessions, oldest first, into a
+// Markdown document. Returns "" when no session has a summary.
+func (s *Service) Recap(gameID int64) (string, error) {
+	sessions, err := s.repo.GetAllForGame(gameID)
+	if err != nil {
+		return "", err
+	}
+	return recap(sessions), nil
+}
+
 // SearchByGame returns sessions for a game whose content contains the search term (case-insensitive)
 func (s *Service) SearchByGame(gameID int64, term string) ([]*Session, error) {
 	return s.repo.SearchByGame(gameID, term)
@@ -1,13 +1,16 @@
 package session
 
 import (
+	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	_ "soloterm/domain/game"
 	testhelper "soloterm/shared/testing"
+	"soloterm/shared/text"
 )
 
 func TestService_Save(t *testing.T) {
@@ -32,4 +35,281 @@ func TestService_Save(t *testing.T) {
 		require.NoError(t, err)
 		assert.NotEqual(t, int64(0), result.ID)
 	})
+
+	t.Run("returns the saved session when indexing fails", func(t *testing.T) {
+		repo := NewRepository(db)
+		repo.AddIndexer(failingIndexer{})
+		s, _ := NewSession(gameID)
+		s.Name = "Session Two"
+		result, err := NewService(repo).Save(s)
+		assert.ErrorIs(t, err, ErrIndexing)
+		require.NotNil(t, result)
+
+		stored, err := repo.GetByID(result.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "Session Two", stored.Name)
+	})
+}
+
+// failingIndexer fails to index every session
+type failingIndexer struct{}
+
+func (failingIndexer) IndexSession(*Session) error {
+	return errors.New("index is locked")
+}
+
+func TestService_Previously(t *testing.T) {
+	db := testhelper.SetupTestDB(t)
+	defer testhelper.TeardownTestDB(t, db)
+	svc := NewService(NewRepository(db))
+	gameID := testhelper.CreateTestGame(t, db, "Test Game")
+
+	first := testhelper.CreateTestSession(t, db, gameID, "Session One", "@ Open the gate\nd: 2d6 -> 9\n=> The gate is open\n-> It creaks\n=> Vance follows us in")
+	second := testhelper.CreateTestSession(t, db, gameID, "Session Two", "")
+
+	recap, err := svc.Previously(second)
+	require.NoError(t, err)
+	assert.Equal(t, "Previously, in Session One:\n- The gate is open\n- Vance follows us in", recap)
+
+	recap, err = svc.Previously(first)
+	require.NoError(t, err)
+	assert.Empty(t, recap)
+}
+
+func TestService_PreviouslyUsesSummary(t *testing.T) {
+	db := testhelper.SetupTestDB(t)
+	defer testhelper.TeardownTestDB(t, db)
+	svc := NewService(NewRepository(db))
+	gameID := testhelper.CreateTestGame(t, db, "Test Game")
+
+	first := &Session{GameID: gameID, Name: "Session One", Content: "=> The gate is open", Summary: "We broke into the keep."}
+	_, err := svc.Save(first)
+	require.NoError(t, err)
+	second := &Session{GameID: gameID, Name: "Session Two"}
+	_, err = svc.Save(second)
+	require.NoError(t, err)
+
+	recap, err := svc.Previously(second.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Previously, in Session One:\nWe broke into the keep.", recap)
+}
+
+func TestService_Recap(t *testing.T) {
+	db := testhelper.SetupTestDB(t)
+	defer testhelper.TeardownTestDB(t, db)
+	svc := NewService(NewRepository(db))
+	gameID := testhelper.CreateTestGame(t, db, "Test Game")
+	otherGameID := testhelper.CreateTestGame(t, db, "Other Game")
+
+	t.Run("empty without summaries", func(t *testing.T) {
+		testhelper.CreateTestSession(t, db, gameID, "No Summary", "content")
+		recap, err := svc.Recap(gameID)
+		require.NoError(t, err)
+		assert.Empty(t, recap)
+	})
+
+	t.Run("compiles summaries oldest first", func(t *testing.T) {
+		for _, s := range []*Session{
+			{GameID: gameID, Name: "Session One", Summary: "We met Vance.\n"},
+			{GameID: otherGameID, Name: "Elsewhere", Summary: "Not this game."},
+			{GameID: gameID, Name: "Session Two", Summary: "Vance betrayed us."},
+		} {
+			_, err := svc.Save(s)
+			require.NoError(t, err)
+		}
+
+		recap, err := svc.Recap(gameID)
+		require.NoError(t, err)
+
+		date := time.Now().Format(text.DateFormat)
+		expected := "# Recap: Test Game\n" +
+			"\n## Session One (" + date + ")\n\nWe met Vance.\n" +
+			"\n## Session Two (" + date + ")\n\nVance betrayed us.\n"
+		assert.Equal(t, expected, recap)
+	})
+}
+
+func TestService_Move(t *testing.T) {
+	db := testhelper.SetupTestDB(t)
+	defer testhelper.TeardownTestDB(t, db)
+	svc := NewService(NewRepository(db))
+	gameID := testhelper.CreateTestGame(t, db, "Game 1")
+	otherGameID := testhelper.CreateTestGame(t, db, "Game 2")
+
+	day := func(d int) time.Time { return time.Date(2024, 3, d, 0, 0, 0, 0, time.UTC) }
+	earlier := &Session{GameID: otherGameID, Name: "Earlier", PlayedAt: day(1)}
+	later := &Session{GameID: otherGameID, Name: "Later", PlayedAt: day(20)}
+	s := &Session{GameID: gameID, Name: "Wrong Game", Content: "content", PlayedAt: day(10)}
+	for _, session := range []*Session{earlier, later, s} {
+		_, err := svc.Save(session)
+		require.NoError(t, err)
+	}
+	createdAt := s.CreatedAt
+
+	moved, err := svc.Move(s.ID, otherGameID)
+	require.NoError(t, err)
+	assert.Equal(t, otherGameID, moved.GameID)
+	assert.Equal(t, "Game 2", moved.GameName)
+	assert.True(t, createdAt.Equal(moved.CreatedAt), "Expected created_at to be preserved")
+
+	sessions, err := svc.GetAllForGame(otherGameID)
+	require.NoError(t, err)
+	require.Len(t, sessions, 3)
+	assert.Equal(t, []int64{earlier.ID, s.ID, later.ID}, []int64{sessions[0].ID, sessions[1].ID, sessions[2].ID},
+		"Expected the session to be placed by its play date")
+
+	_, err = svc.Move(s.ID, otherGameID)
+	assert.Error(t, err, "Expected an error moving a session to its own game")
+
+	_, err = svc.Move(s.ID, 9999)
+	assert.Error(t, err, "Expected an error moving a session to a missing game")
+}
+
+func TestService_Split(t *testing.T) {
+	db := testhelper.SetupTestDB(t)
+	defer testhelper.TeardownTestDB(t, db)
+	svc := NewService(NewRepository(db))
+	gameID := testhelper.CreateTestGame(t, db, "Test Game")
+
+	saveSession := func(name, content string) *Session {
+		s := &Session{GameID: gameID, Name: name, Content: content, Summary: name + " summary"}
+		_, err := svc.Save(s)
+		require.NoError(t, err)
+		return s
+	}
+	s := saveSession("Session One", "S1 *Gate*\n\nS2 *Keep*")
+	next := saveSession("Session Two", "later")
+
+	t.Run("rejects split points outside the content", func(t *testing.T) {
+		_, _, err := svc.Split(s.ID, 0, "Part Two")
+		assert.Error(t, err)
+		_, _, err = svc.Split(s.ID, len(s.Content), "Part Two")
+		assert.Error(t, err)
+	})
+
+	t.Run("rejects a blank name", func(t *testing.T) {
+		_, _, err := svc.Split(s.ID, 10, "")
+		assert.Error(t, err)
+	})
+
+	t.Run("splits between the session and the next", func(t *testing.T) {
+		first, second, err := svc.Split(s.ID, 10, "Part Two")
+		require.NoError(t, err)
+		assert.Equal(t, "S1 *Gate*", first.Content)
+		assert.Equal(t, "S2 *Keep*", second.Content)
+		assert.Equal(t, "Session One summary", first.Summary)
+		assert.Empty(t, second.Summary)
+		assert.Equal(t, s.PlayedAt, second.PlayedAt)
+
+		sessions, err := svc.GetAllForGame(gameID)
+		require.NoError(t, err)
+		require.Len(t, sessions, 3)
+		assert.Equal(t, []int64{s.ID, second.ID, next.ID}, []int64{sessions[0].ID, sessions[1].ID, sessions[2].ID})
+
+		saved, err := svc.GetByID(s.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "S1 *Gate*", saved.Content)
+	})
+}
+
+func TestService_Merge(t *testing.T) {
+	db := testhelper.SetupTestDB(t)
+	defer testhelper.TeardownTestDB(t, db)
+	svc := NewService(NewRepository(db))
+	gameID := testhelper.CreateTestGame(t, db, "Test Game")
+
+	first := &Session{GameID: gameID, Name: "Session One", Content: "S1 *Gate*\n", Summary: "Gate."}
+	second := &Session{GameID: gameID, Name: "Session Two", Content: "S2 *Keep*"}
+	for _, s := range []*Session{first, second} {
+		_, err := svc.Save(s)
+		require.NoError(t, err)
+	}
+
+	merged, err := svc.Merge(first.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "S1 *Gate*\n\nS2 *Keep*", merged.Content)
+	assert.Equal(t, "Gate.", merged.Summary)
+
+	_, err = svc.GetByID(second.ID)
+	assert.Error(t, err, "Expected the merged session to be deleted")
+
+	_, err = svc.Merge(first.ID)
+	assert.Error(t, err, "Expected an error merging the last session")
+}
+
+func TestService_PlayedAt(t *testing.T) {
+	db := testhelper.SetupTestDB(t)
+	defer testhelper.TeardownTestDB(t, db)
+	svc := NewService(NewRepository(db))
+	gameID := testhelper.CreateTestGame(t, db, "Test Game")
+
+	t.Run("defaults new sessions to today", func(t *testing.T) {
+		s := &Session{GameID: gameID, Name: "Session One"}
+		_, err := svc.Save(s)
+		require.NoError(t, err)
+		assert.Equal(t, Today().Format(text.DateFormat), s.PlayedAt.Format(text.DateFormat))
+	})
+
+	t.Run("keeps an edited play date", func(t *testing.T) {
+		s := &Session{GameID: gameID, Name: "Imported", PlayedAt: time.Date(2023, 5, 14, 0, 0, 0, 0, time.UTC)}
+		_, err := svc.Save(s)
+		require.NoError(t, err)
+
+		saved, err := svc.GetByID(s.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "2023-05-14", saved.PlayedAt.Format(text.DateFormat))
+	})
+}
+
+func TestService_Reorder(t *testing.T) {
+	db := testhelper.SetupTestDB(t)
+	defer testhelper.TeardownTestDB(t, db)
+	svc := NewService(NewRepository(db))
+	gameID := testhelper.CreateTestGame(t, db, "Test Game")
+
+	var ids []int64
+	for _, name := range []string{"One", "Two", "Three"} {
+		s := &Session{GameID: gameID, Name: name}
+		_, err := svc.Save(s)
+		require.NoError(t, err)
+		ids = append(ids, s.ID)
+	}
+	order := func() []int64 {
+		sessions, err := svc.GetAllForGame(gameID)
+		require.NoError(t, err)
+		var got []int64
+		for _, s := range sessions {
+			got = append(got, s.ID)
+		}
+		return got
+	}
+
+	// Deleting leaves a gap in the positions
+	require.NoError(t, svc.Delete(ids[1]))
+	s := &Session{GameID: gameID, Name: "Four"}
+	_, err := svc.Save(s)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{ids[0], ids[2], s.ID}, order())
+
+	moved, err := svc.Reorder(s.ID, -1)
+	require.NoError(t, err)
+	assert.Equal(t, s.ID, moved)
+	assert.Equal(t, []int64{ids[0], s.ID, ids[2]}, order())
+
+	moved, err = svc.Reorder(ids[0], 1)
+	require.NoError(t, err)
+	assert.Equal(t, ids[0], moved)
+	assert.Equal(t, []int64{s.ID, ids[0], ids[2]}, order())
+
+	moved, err = svc.Reorder(s.ID, -1)
+	require.NoError(t, err)
+	assert.Zero(t, moved, "Expected nothing to move at the top")
+
+	// Editing a session keeps its place
+	saved, err := svc.GetByID(ids[0])
+	require.NoError(t, err)
+	saved.Name = "One, renamed"
+	_, err = svc.Save(saved)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{s.ID, ids[0], ids[2]}, order())
 }
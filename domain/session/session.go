@@ -6,19 +6,26 @@ import (
 )
 
 type Session struct {
-	ID        int64     `db:"id"`
-	GameID    int64     `db:"game_id"`
-	Name      string    `db:"name"`
-	Content   string    `db:"content"`
-	GameName  string    `db:"game_name"`
-	CreatedAt time.Time `db:"created_at"`
-	UpdatedAt time.Time `db:"updated_at"`
+	ID        int64      `db:"id"`
+	GameID    int64      `db:"game_id"`
+	Name      string     `db:"name"`
+	Content   string     `db:"content"`
+	Summary   string     `db:"summary"`
+	PlayedAt  time.Time  `db:"played_at"`
+	Position  int        `db:"position"`
+	GameName  string     `db:"game_name"`
+	CreatedAt time.Time  `db:"created_at"`
+	UpdatedAt time.Time  `db:"updated_at"`
+	DeletedAt *time.Time `db:"deleted_at"` // Set while the session is in the trash
 }
 
+const MaxSummaryLength = 2000
+
 func NewSession(gameID int64) (*Session, error) {
 	session := &Session{
-		ID:     0,
-		GameID: gameID,
+		ID:       0,
+		GameID:   gameID,
+		PlayedAt: Today(),
 	}
 
 	return session, nil
@@ -27,9 +34,17 @@ func NewSession(gameID int64) (*Session, error) {
 func (s *Session) Validate() *validation.Validator {
 	v := validation.NewValidator()
 	v.Check("name", len(s.Name) > 0, "cannot be blank")
+	v.Check("played_at", !s.PlayedAt.IsZero(), "cannot be blank")
+	v.Check("summary", len(s.Summary) <= MaxSummaryLength, "must be at most %d characters", MaxSummaryLength)
 	return v
 }
 
 func (s *Session) IsNew() bool {
 	return s.ID == 0
 }
+
+// Today returns the current date, used as the play date of new sessions
+func Today() time.Time {
+	y, m, d := time.Now().Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,29 @@
+package session
+
+import (
+	"soloterm/domain/lonelog"
+	"strings"
+)
+
+// Template is a named scaffold for the content of a new session
+type Template struct {
+	Name    string `yaml:"name"`
+	Content string `yaml:"content"`
+}
+
+// previously builds a recap of s: its name followed by its summary or, when
+// it has none, the consequences ("=> ..." lines) logged in it.
+func previously(s *Session) string {
+	var b strings.Builder
+	b.WriteString("Previously, in " + s.Name + ":")
+	if summary := strings.TrimSpace(s.Summary); summary != "" {
+		b.WriteString("\n" + summary)
+		return b.String()
+	}
+	for _, n := range lonelog.Parse(s.Content).Of(lonelog.KindConsequence) {
+		if n.Marker == "=>" && n.Text != "" {
+			b.WriteString("\n- " + n.Text)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,133 @@
+package tag
+
+import (
+	"soloterm/database"
+
+	// The index references games, sessions and notes pages, so their tables must be created first
+	_ "soloterm/domain/game"
+	_ "soloterm/domain/notes"
+	_ "soloterm/domain/session"
+)
+
+func init() {
+	// Register this package's migrations with the database package
+	database.RegisterMigration(Migrate)
+}
+
+// Migrate runs all migrations for the tags domain
+func Migrate(db *database.DBStore) error {
+	var exists bool
+	err := db.Connection.Get(&exists, "SELECT COUNT(*) > 0 FROM sqlite_master WHERE type = 'table' AND name = 'tag_index'")
+	if err != nil {
+		return err
+	}
+
+	// Migration: Create tag index table
+	if err := createIndexTable(db); err != nil {
+		return err
+	}
+
+	// Migration: Record the notes page of each notes tag, re-indexing the
+	// notes indexed before games had pages
+	if err := addPageIDColumn(db, exists); err != nil {
+		return err
+	}
+
+	// Migration: Index the existing sessions and notes when the table is new
+	if !exists {
+		if err := backfillIndex(db); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// createIndexTable creates the tag index table. Rows with a NULL session_id
+// come from the game's notes pages.
+func createIndexTable(db *database.DBStore) error {
+	schema := `
+		CREATE TABLE IF NOT EXISTS tag_index (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			game_id INTEGER NOT NULL,
+			session_id INTEGER,
+			start_offset INTEGER NOT NULL,
+			identifier TEXT NOT NULL,
+			raw TEXT NOT NULL,
+			data TEXT NOT NULL,
+			FOREIGN KEY (game_id) REFERENCES games(id) ON DELETE CASCADE,
+			FOREIGN KEY (session_id) REFERENCES sessions(id) ON DELETE CASCADE
+		);
+
+		CREATE INDEX IF NOT EXISTS idx_tag_index_by_game_id ON tag_index (game_id);
+		CREATE INDEX IF NOT EXISTS idx_tag_index_by_session_id ON tag_index (session_id);
+		CREATE INDEX IF NOT EXISTS idx_tag_index_by_identifier ON tag_index (game_id, identifier);
+	`
+	_, err := db.Connection.Exec(schema)
+	return err
+}
+
+// backfillIndex indexes every session and every notes page
+func backfillIndex(db *database.DBStore) error {
+	repo := NewRepository(db)
+
+	var sessions []struct {
+		ID      int64  `db:"id"`
+		GameID  int64  `db:"game_id"`
+		Content string `db:"content"`
+	}
+	if err := db.Connection.Select(&sessions, "SELECT id, game_id, content FROM sessions"); err != nil {
+		return err
+	}
+	for _, s := range sessions {
+		if err := repo.ReplaceForSession(s.GameID, s.ID, s.Content); err != nil {
+			return err
+		}
+	}
+
+	return indexPages(db, repo)
+}
+
+// addPageIDColumn adds the notes page of each row, removed along with its page.
+// When reindex is set the notes rows, which had no page, are indexed again.
+func addPageIDColumn(db *database.DBStore, reindex bool) error {
+	exists, err := database.ColumnExists(db.Connection, "tag_index", "page_id")
+	if err != nil || exists {
+		return err
+	}
+
+	err = database.AddColumn(db.Connection, "tag_index", "page_id", "INTEGER REFERENCES notes_pages(id) ON DELETE CASCADE", false, nil)
+	if err != nil {
+		return err
+	}
+	if _, err := db.Connection.Exec("CREATE INDEX IF NOT EXISTS idx_tag_index_by_page_id ON tag_index (page_id)"); err != nil {
+		return err
+	}
+	if !reindex {
+		return nil
+	}
+
+	if _, err := db.Connection.Exec("DELETE FROM tag_index WHERE session_id IS NULL"); err != nil {
+		return err
+	}
+	return indexPages(db, NewRepository(db))
+}
+
+// indexPages indexes every notes page
+func indexPages(db *database.DBStore, repo *Repository) error {
+	var pages []struct {
+		ID      int64  `db:"id"`
+		GameID  int64  `db:"game_id"`
+		Content string `db:"content"`
+	}
+	if err := db.Connection.Select(&pages, "SELECT id, game_id, content FROM notes_pages"); err != nil {
+		return err
+	}
+	for _, p := range pages {
+		if err := repo.ReplaceForPage(p.GameID, p.ID, p.Content); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,147 @@
+package tag
+
+import (
+	"soloterm/database"
+	"soloterm/domain/lonelog"
+)
+
+// Entry is a single tag occurrence stored in the tag index
+type Entry struct {
+	ID          int64  `db:"id"`
+	GameID      int64  `db:"game_id"`
+	SessionID   *int64 `db:"session_id"` // nil for tags in the game's notes
+	PageID      *int64 `db:"page_id"`    // the notes page holding the tag, nil for tags in sessions
+	StartOffset int    `db:"start_offset"`
+	Identifier  string `db:"identifier"`
+	Raw         string `db:"raw"`
+	Data        string `db:"data"`
+}
+
+// entriesFor extracts the index entries for every tag in content
+func entriesFor(content string) []*Entry {
+	nodes := lonelog.Tags(content)
+	entries := make([]*Entry, 0, len(nodes))
+	for _, n := range nodes {
+		entries = append(entries, &Entry{
+			StartOffset: n.Offset,
+			Identifier:  n.Tag.Identifier,
+			Raw:         n.Raw,
+			Data:        n.Tag.Data,
+		})
+	}
+	return entries
+}
+
+// Repository handles database operations for the tag index
+type Repository struct {
+	db *database.DBStore
+}
+
+// NewRepository creates a new Repository
+func NewRepository(db *database.DBStore) *Repository {
+	return &Repository{db: db}
+}
+
+// ReplaceForSession replaces the indexed tags of a session with those found in content
+func (r *Repository) ReplaceForSession(gameID int64, sessionID int64, content string) error {
+	return r.replace(gameID, &sessionID, nil, entriesFor(content))
+}
+
+// ReplaceForPage replaces the indexed tags of a notes page with those found in content
+func (r *Repository) ReplaceForPage(gameID int64, pageID int64, content string) error {
+	return r.replace(gameID, nil, &pageID, entriesFor(content))
+}
+
+// DeleteAllForGame removes every indexed tag for the game
+func (r *Repository) DeleteAllForGame(gameID int64) error {
+	_, err := r.db.Connection.Exec("DELETE FROM tag_index WHERE game_id = ?", gameID)
+	return err
+}
+
+// GetSessionEntriesForGame returns the indexed session tags for the game, newest first:
+// sessions by descending position, and tags by descending offset within a session.
+func (r *Repository) GetSessionEntriesForGame(gameID int64) ([]*Entry, error) {
+	var entries []*Entry
+	query := `SELECT t.id, t.game_id, t.session_id, t.page_id, t.start_offset, t.identifier, t.raw, t.data
+		FROM tag_index t
+		JOIN sessions s ON t.session_id = s.id
+		WHERE t.game_id = ? AND s.deleted_at IS NULL
+		ORDER BY s.position DESC, s.id DESC, t.start_offset DESC`
+	err := r.db.Connection.Select(&entries, query, gameID)
+	if err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// GetNotesEntriesForGame returns the indexed tags of the game's notes pages, newest
+// first: pages by descending position, and tags by descending offset within a page.
+// Pages in the trash are left out.
+func (r *Repository) GetNotesEntriesForGame(gameID int64) ([]*Entry, error) {
+	var entries []*Entry
+	query := `SELECT t.id, t.game_id, t.session_id, t.page_id, t.start_offset, t.identifier, t.raw, t.data
+		FROM tag_index t
+		JOIN notes_pages p ON t.page_id = p.id
+		WHERE t.game_id = ? AND p.deleted_at IS NULL
+		ORDER BY p.position DESC, p.id DESC, t.start_offset DESC`
+	err := r.db.Connection.Select(&entries, query, gameID)
+	if err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// GetOccurrences returns every session occurrence of identifier in the game,
+// oldest session first and in source order within each session.
+func (r *Repository) GetOccurrences(gameID int64, identifier string) ([]Occurrence, error) {
+	return r.getOccurrences(gameID, "t.identifier = ?", identifier)
+}
+
+// GetOccurrencesIgnoringCase is GetOccurrences with identifier matched
+// regardless of case, so [N:vex] and [N:Vex] are both found.
+func (r *Repository) GetOccurrencesIgnoringCase(gameID int64, identifier string) ([]Occurrence, error) {
+	return r.getOccurrences(gameID, "t.identifier = ? COLLATE NOCASE", identifier)
+}
+
+func (r *Repository) getOccurrences(gameID int64, match string, identifier string) ([]Occurrence, error) {
+	var occurrences []Occurrence
+	query := `SELECT t.session_id, s.name AS session_name, s.played_at, t.start_offset, t.raw, t.data
+		FROM tag_index t
+		JOIN sessions s ON t.session_id = s.id
+		WHERE t.game_id = ? AND ` + match + ` AND s.deleted_at IS NULL
+		ORDER BY s.position ASC, s.id ASC, t.start_offset ASC`
+	err := r.db.Connection.Select(&occurrences, query, gameID, identifier)
+	if err != nil {
+		return nil, err
+	}
+	return occurrences, nil
+}
+
+// replace deletes the existing rows for the session (or notes page when sessionID
+// is nil) and inserts entries in a single transaction.
+func (r *Repository) replace(gameID int64, sessionID *int64, pageID *int64, entries []*Entry) error {
+	tx, err := r.db.Connection.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if sessionID != nil {
+		_, err = tx.Exec("DELETE FROM tag_index WHERE session_id = ?", *sessionID)
+	} else {
+		_, err = tx.Exec("DELETE FROM tag_index WHERE page_id = ?", *pageID)
+	}
+	if err != nil {
+		return err
+	}
+
+	query := `INSERT INTO tag_index (game_id, session_id, page_id, start_offset, identifier, raw, data)
+		VALUES (?, ?, ?, ?, ?, ?, ?)`
+	for _, e := range entries {
+		if _, err := tx.Exec(query, gameID, sessionID, pageID, e.StartOffset, e.Identifier, e.Raw, e.Data); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
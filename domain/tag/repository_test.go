@@ -0,0 +1,139 @@
+package tag
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	testhelper "soloterm/shared/testing"
+)
+
+func TestRepository_ReplaceForSession(t *testing.T) {
+	db := testhelper.SetupTestDB(t)
+	defer testhelper.TeardownTestDB(t, db)
+	repo := NewRepository(db)
+
+	gameID := testhelper.CreateTestGame(t, db, "Game")
+	sessionID := testhelper.CreateTestSession(t, db, gameID, "Session", "")
+
+	require.NoError(t, repo.ReplaceForSession(gameID, sessionID, "[N:Vex | wary] and [L:Docks]"))
+	entries, err := repo.GetSessionEntriesForGame(gameID)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, "L:Docks", entries[0].Identifier, "later tags in a session come first")
+	assert.Equal(t, "wary", entries[1].Data)
+	assert.Equal(t, sessionID, *entries[1].SessionID)
+
+	t.Run("replaces previous entries", func(t *testing.T) {
+		require.NoError(t, repo.ReplaceForSession(gameID, sessionID, "[N:Vex | ally]"))
+		entries, err := repo.GetSessionEntriesForGame(gameID)
+		require.NoError(t, err)
+		require.Len(t, entries, 1)
+		assert.Equal(t, "ally", entries[0].Data)
+	})
+
+	t.Run("deleting the session removes its entries", func(t *testing.T) {
+		_, err := db.Connection.Exec("DELETE FROM sessions WHERE id = ?", sessionID)
+		require.NoError(t, err)
+		entries, err := repo.GetSessionEntriesForGame(gameID)
+		require.NoError(t, err)
+		assert.Empty(t, entries)
+	})
+}
+
+func TestRepository_ReplaceForPage(t *testing.T) {
+	db := testhelper.SetupTestDB(t)
+	defer testhelper.TeardownTestDB(t, db)
+	repo := NewRepository(db)
+
+	gameID := testhelper.CreateTestGame(t, db, "Game")
+	sessionID := testhelper.CreateTestSession(t, db, gameID, "Session", "")
+	require.NoError(t, repo.ReplaceForSession(gameID, sessionID, "[N:Vex]"))
+	places := testhelper.CreateTestNotesPage(t, db, gameID, "Places", "")
+	people := testhelper.CreateTestNotesPage(t, db, gameID, "People", "")
+
+	require.NoError(t, repo.ReplaceForPage(gameID, places, "[L:Keep] [L:Tower]"))
+	require.NoError(t, repo.ReplaceForPage(gameID, places, "[L:Keep | ruined]"))
+	require.NoError(t, repo.ReplaceForPage(gameID, people, "[N:Ash]"))
+
+	notes, err := repo.GetNotesEntriesForGame(gameID)
+	require.NoError(t, err)
+	require.Len(t, notes, 2)
+	assert.Equal(t, "N:Ash", notes[0].Identifier, "tags on later pages come first")
+	assert.Nil(t, notes[1].SessionID)
+	assert.Equal(t, places, *notes[1].PageID)
+	assert.Equal(t, "ruined", notes[1].Data)
+
+	sessions, err := repo.GetSessionEntriesForGame(gameID)
+	require.NoError(t, err)
+	assert.Len(t, sessions, 1, "notes should not replace session entries")
+
+	t.Run("deleting the page removes its entries", func(t *testing.T) {
+		_, err := db.Connection.Exec("DELETE FROM notes_pages WHERE id = ?", people)
+		require.NoError(t, err)
+		notes, err := repo.GetNotesEntriesForGame(gameID)
+		require.NoError(t, err)
+		require.Len(t, notes, 1)
+		assert.Equal(t, "L:Keep", notes[0].Identifier)
+	})
+}
+
+func TestRepository_GetOccurrences(t *testing.T) {
+	db := testhelper.SetupTestDB(t)
+	defer testhelper.TeardownTestDB(t, db)
+	repo := NewRepository(db)
+
+	gameID := testhelper.CreateTestGame(t, db, "Game")
+	first := testhelper.CreateTestSession(t, db, gameID, "First", "")
+	second := testhelper.CreateTestSession(t, db, gameID, "Second", "")
+	require.NoError(t, repo.ReplaceForSession(gameID, first, "Met [N:Vex | wary] at the docks"))
+	require.NoError(t, repo.ReplaceForSession(gameID, second, "[N:Other]\n[N:Vex | ally]\nLater [N:Vex | wounded]"))
+	pageID := testhelper.CreateTestNotesPage(t, db, gameID, "Notes", "")
+	require.NoError(t, repo.ReplaceForPage(gameID, pageID, "[N:Vex | notes]"))
+
+	occurrences, err := repo.GetOccurrences(gameID, "N:Vex")
+	require.NoError(t, err)
+	require.Len(t, occurrences, 3, "notes are not part of the timeline")
+
+	assert.Equal(t, first, occurrences[0].SessionID)
+	assert.Equal(t, "First", occurrences[0].SessionName)
+	assert.Equal(t, 4, occurrences[0].Offset)
+	assert.Equal(t, "wary", occurrences[0].Data)
+	assert.Equal(t, "ally", occurrences[1].Data)
+	assert.Equal(t, "wounded", occurrences[2].Data)
+	assert.Equal(t, "[N:Vex | wounded]", occurrences[2].Raw)
+
+	occurrences, err = repo.GetOccurrences(gameID, "N:VEX")
+	require.NoError(t, err)
+	assert.Empty(t, occurrences, "Expected the identifier to match case")
+
+	occurrences, err = repo.GetOccurrencesIgnoringCase(gameID, "N:VEX")
+	require.NoError(t, err)
+	assert.Len(t, occurrences, 3)
+}
+
+func TestMigrate_BackfillsExistingContent(t *testing.T) {
+	db := testhelper.SetupTestDB(t)
+	defer testhelper.TeardownTestDB(t, db)
+
+	gameID := testhelper.CreateTestGame(t, db, "Game")
+	testhelper.CreateTestSession(t, db, gameID, "Session", "[N:Vex | wary]")
+	testhelper.CreateTestNotesPage(t, db, gameID, "Places", "[L:Keep]")
+
+	// Simulate upgrading from a database without the index
+	_, err := db.Connection.Exec("DROP TABLE tag_index")
+	require.NoError(t, err)
+	require.NoError(t, Migrate(db))
+
+	repo := NewRepository(db)
+	sessions, err := repo.GetSessionEntriesForGame(gameID)
+	require.NoError(t, err)
+	require.Len(t, sessions, 1)
+	assert.Equal(t, "N:Vex", sessions[0].Identifier)
+
+	notes, err := repo.GetNotesEntriesForGame(gameID)
+	require.NoError(t, err)
+	require.Len(t, notes, 1)
+	assert.Equal(t, "L:Keep", notes[0].Identifier)
+}
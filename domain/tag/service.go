@@ -1,32 +1,36 @@
 package tag
 
 import (
-	"regexp"
+	"soloterm/domain/lonelog"
+	"soloterm/domain/notes"
 	"soloterm/domain/session"
 	"sort"
 	"strings"
+	"time"
 )
 
 // Service handles tag-related business logic
 type Service struct {
+	repo        *Repository
 	sessionRepo *session.Repository
+	notesRepo   *notes.Repository
 }
 
 // NewService creates a new tag service
-func NewService(sessionRepo *session.Repository) *Service {
-	return &Service{sessionRepo: sessionRepo}
+func NewService(repo *Repository, sessionRepo *session.Repository, notesRepo *notes.Repository) *Service {
+	return &Service{repo: repo, sessionRepo: sessionRepo, notesRepo: notesRepo}
 }
 
 // TagsForGame holds the three categories of tags for a game.
 type TagsForGame struct {
 	Config []TagType // configured tag types (from config file)
 	Active []TagType // extracted from session content, filtered by exclude words
-	Notes  []TagType // extracted from notes content, filtered by exclude words
+	Notes  []TagType // extracted from every notes page, filtered by exclude words
 }
 
 // LoadTagsForGame loads configured tags, active tags from sessions, and notes tags.
-// notesContent is the raw text of the game's notes document.
-func (s *Service) LoadTagsForGame(gameID int64, notesContent string, configTags []TagType, excludeWords []string) (*TagsForGame, error) {
+// Active and notes tags are read from the tag index.
+func (s *Service) LoadTagsForGame(gameID int64, configTags []TagType, excludeWords []string) (*TagsForGame, error) {
 	// Sort the config tags by label
 	sort.Slice(configTags, func(i, j int) bool {
 		return configTags[i].Label < configTags[j].Label
@@ -39,76 +43,235 @@ func (s *Service) LoadTagsForGame(gameID int64, notesContent string, configTags
 		return result, nil
 	}
 
-	// Get all session content for the game
-	contents, err := s.sessionRepo.GetAllContentForGame(gameID)
+	sessionEntries, err := s.repo.GetSessionEntriesForGame(gameID)
 	if err != nil {
-		return result, nil
+		return result, err
 	}
+	result.Active = s.latestTags(sessionEntries, excludeWords)
 
-	result.Active = s.extractRecentTags(contents, excludeWords)
-
-	if notesContent != "" {
-		result.Notes = s.extractRecentTags([]string{notesContent}, excludeWords)
+	notesEntries, err := s.repo.GetNotesEntriesForGame(gameID)
+	if err != nil {
+		return result, err
 	}
+	result.Notes = s.latestTags(notesEntries, excludeWords)
 
 	return result, nil
 }
 
-// extractRecentTags extracts tags from session content, deduplicates by type, keeps most recent
-func (s *Service) extractRecentTags(contents []string, excludeWords []string) []TagType {
+// LoadProgressForGame returns the latest value of every open clock and track in the
+// game's sessions, sorted by type then name. Clocks whose data section contains an
+// exclude word are considered closed and left out.
+func (s *Service) LoadProgressForGame(gameID int64, excludeWords []string) ([]*lonelog.Progress, error) {
+	if gameID == 0 {
+		return nil, nil
+	}
+
+	entries, err := s.repo.GetSessionEntriesForGame(gameID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.latestProgress(entries, excludeWords), nil
+}
+
+// Occurrence is a single appearance of a tag in a session
+type Occurrence struct {
+	SessionID   int64     `db:"session_id"`
+	SessionName string    `db:"session_name"`
+	PlayedAt    time.Time `db:"played_at"`
+	Offset      int       `db:"start_offset"` // byte offset of the tag within the session content
+	Raw         string    `db:"raw"`          // the full tag as written
+	Data        string    `db:"data"`         // the tag's data section at this point
+}
+
+// Timeline returns every occurrence of the tag identifier across the game's sessions,
+// oldest session first and in source order within each session.
+func (s *Service) Timeline(gameID int64, identifier string) ([]Occurrence, error) {
+	if gameID == 0 || identifier == "" {
+		return nil, nil
+	}
+	return s.repo.GetOccurrences(gameID, identifier)
+}
+
+// CoOccurrence counts how often two tags are mentioned together in a game's sessions
+type CoOccurrence struct {
+	A        string // the identifier sorting first, as first written
+	B        string // the other identifier, as first written
+	Scenes   int    // scenes that mention both
+	Sessions int    // sessions that mention both
+}
+
+// CoOccurrences returns every pair of tags mentioned in the same scene or session,
+// most shared scenes first. Text before a session's first scene header counts as a
+// scene of its own. Identifiers are matched ignoring case, and clocks and tracks
+// ignoring their value, so [Clock:Alarm 1/4] and [clock:alarm 3/4] are one tag.
+func (s *Service) CoOccurrences(gameID int64) ([]CoOccurrence, error) {
+	if gameID == 0 {
+		return nil, nil
+	}
+
+	sessions, err := s.sessionRepo.GetAllWithContentForGame(gameID)
+	if err != nil {
+		return nil, err
+	}
+
+	spellings := make(map[string]string) // key -> identifier as first written
+	scenes := make(map[[2]string]int)
+	sessionCounts := make(map[[2]string]int)
+
+	for _, sess := range sessions {
+		var sessionKeys, sceneKeys []string
+		for _, node := range lonelog.Parse(sess.Content).Nodes {
+			if node.Kind == lonelog.KindScene {
+				countPairs(sceneKeys, scenes)
+				sceneKeys = nil
+				continue
+			}
+			if node.Kind != lonelog.KindTag {
+				continue
+			}
+
+			identifier := node.Tag.Identifier
+			if p, ok := lonelog.ParseProgress(node); ok {
+				identifier = p.Type + ":" + p.Name
+			}
+			key := strings.ToLower(identifier)
+			if _, exists := spellings[key]; !exists {
+				spellings[key] = identifier
+			}
+			sceneKeys = appendUnique(sceneKeys, key)
+			sessionKeys = appendUnique(sessionKeys, key)
+		}
+		countPairs(sceneKeys, scenes)
+		countPairs(sessionKeys, sessionCounts)
+	}
+
+	pairs := make([]CoOccurrence, 0, len(sessionCounts))
+	for pair, count := range sessionCounts {
+		pairs = append(pairs, CoOccurrence{
+			A:        spellings[pair[0]],
+			B:        spellings[pair[1]],
+			Scenes:   scenes[pair],
+			Sessions: count,
+		})
+	}
+	sort.Slice(pairs, func(i, j int) bool {
+		if pairs[i].Scenes != pairs[j].Scenes {
+			return pairs[i].Scenes > pairs[j].Scenes
+		}
+		if pairs[i].Sessions != pairs[j].Sessions {
+			return pairs[i].Sessions > pairs[j].Sessions
+		}
+		if !strings.EqualFold(pairs[i].A, pairs[j].A) {
+			return strings.ToLower(pairs[i].A) < strings.ToLower(pairs[j].A)
+		}
+		return strings.ToLower(pairs[i].B) < strings.ToLower(pairs[j].B)
+	})
+
+	return pairs, nil
+}
+
+// IndexSession replaces the indexed tags of a session. It satisfies session.Indexer.
+func (s *Service) IndexSession(sess *session.Session) error {
+	return s.repo.ReplaceForSession(sess.GameID, sess.ID, sess.Content)
+}
+
+// IndexPage replaces the indexed tags of a notes page. It satisfies notes.Indexer.
+func (s *Service) IndexPage(page *notes.Page) error {
+	return s.repo.ReplaceForPage(page.GameID, page.ID, page.Content)
+}
+
+// RebuildIndex discards the game's indexed tags and re-indexes all of its sessions and notes pages
+func (s *Service) RebuildIndex(gameID int64) error {
+	sessions, err := s.sessionRepo.GetAllWithContentForGame(gameID)
+	if err != nil {
+		return err
+	}
+	pages, err := s.notesRepo.GetAllForGame(gameID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.repo.DeleteAllForGame(gameID); err != nil {
+		return err
+	}
+	for _, sess := range sessions {
+		if err := s.IndexSession(sess); err != nil {
+			return err
+		}
+	}
+	for _, page := range pages {
+		if err := s.IndexPage(page); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// latestProgress keeps the newest occurrence of each clock or track, dropping any that were closed.
+// entries must be ordered newest first.
+func (s *Service) latestProgress(entries []*Entry, excludeWords []string) []*lonelog.Progress {
+	latest := make(map[string]*lonelog.Progress)
+	closed := make(map[string]bool)
+
+	for _, e := range entries {
+		tags := lonelog.Tags(e.Raw)
+		if len(tags) != 1 {
+			continue
+		}
+		p, ok := lonelog.ParseProgress(tags[0])
+		if !ok {
+			continue
+		}
+		key := p.Key()
+		if hasExcludeWord(e.Data, excludeWords) {
+			closed[key] = true
+			delete(latest, key)
+			continue
+		}
+		if _, exists := latest[key]; !exists && !closed[key] {
+			latest[key] = p
+		}
+	}
+
+	progress := make([]*lonelog.Progress, 0, len(latest))
+	for _, p := range latest {
+		progress = append(progress, p)
+	}
+	sort.Slice(progress, func(i, j int) bool {
+		if !strings.EqualFold(progress[i].Type, progress[j].Type) {
+			return strings.ToLower(progress[i].Type) < strings.ToLower(progress[j].Type)
+		}
+		return strings.ToLower(progress[i].Name) < strings.ToLower(progress[j].Name)
+	})
+
+	return progress
+}
+
+// latestTags deduplicates tags by identifier, keeping the most recent.
+// entries must be ordered newest first.
+func (s *Service) latestTags(entries []*Entry, excludeWords []string) []TagType {
 	// Map of tag type (identifier) -> most recent full tag
-	// We iterate in reverse order (most recent first) to keep the newest
 	tagMap := make(map[string]string)
 
 	// Track identifiers that should be excluded (any identifier where we find an exclude word)
 	excludedIdentifiers := make(map[string]bool)
 
-	// Regex to match tags: [TagIdentifier | data]
-	// Captures tag identifier and optional data section separately
-	// The data section allows nested [...] sequences (e.g. for tracking boxes like [ ])
-	tagRegex := regexp.MustCompile(`\[([^\]|]+)(\|[^\[\]]*(?:\[[^\]]*\][^\[\]]*)*)?\]`)
-
-	// Dice breakdown values like [3 3 3] or [1] should not be treated as tags
-	numericOnlyRegex := regexp.MustCompile(`^[\d\s]+$`)
-
-	// Process contents in reverse order (newest first)
-	for i := len(contents) - 1; i >= 0; i-- {
-		content := contents[i]
-
-		// Find all tags in this content
-		matches := tagRegex.FindAllStringSubmatch(content, -1)
-
-		// Iterate matches in reverse so the last occurrence in a session wins
-		for j := len(matches) - 1; j >= 0; j-- {
-			match := matches[j]
-			if len(match) >= 2 {
-				// match[1] is the tag identifier (everything from [ to first | or ])
-				identifier := strings.TrimSpace(match[1])
-				if numericOnlyRegex.MatchString(identifier) {
-					continue
-				}
-				// match[0] is the entire tag including brackets
-				fullTag := match[0]
-
-				// Check if there's a data section (match[2]) and if it contains any exclude words
-				if len(match) >= 3 && match[2] != "" && len(excludeWords) > 0 {
-					dataSection := strings.ToLower(match[2])
-					for _, word := range excludeWords {
-						if strings.Contains(dataSection, strings.ToLower(word)) {
-							// Mark this identifier for exclusion
-							excludedIdentifiers[identifier] = true
-							// Remove from map if we already added it
-							delete(tagMap, identifier)
-							break
-						}
-					}
-				}
-
-				// Only store if we haven't seen this identifier yet and it's not excluded
-				if _, exists := tagMap[identifier]; !exists && !excludedIdentifiers[identifier] {
-					tagMap[identifier] = fullTag
-				}
-			}
+	for _, e := range entries {
+		identifier := e.Identifier
+
+		// Check if the data section contains any exclude words
+		if hasExcludeWord(e.Data, excludeWords) {
+			// Mark this identifier for exclusion
+			excludedIdentifiers[identifier] = true
+			// Remove from map if we already added it
+			delete(tagMap, identifier)
+			continue
+		}
+
+		// Only store if we haven't seen this identifier yet and it's not excluded
+		if _, exists := tagMap[identifier]; !exists && !excludedIdentifiers[identifier] {
+			tagMap[identifier] = e.Raw
 		}
 	}
 
@@ -128,3 +291,41 @@ func (s *Service) extractRecentTags(contents []string, excludeWords []string) []
 
 	return recentTags
 }
+
+// hasExcludeWord reports whether a tag's data section contains any of the exclude words (case-insensitive)
+func hasExcludeWord(data string, excludeWords []string) bool {
+	if data == "" {
+		return false
+	}
+	data = strings.ToLower(data)
+	for _, word := range excludeWords {
+		if strings.Contains(data, strings.ToLower(word)) {
+			return true
+		}
+	}
+	return false
+}
+
+// countPairs adds one to the count of every pair of distinct keys, each pair
+// ordered so the smaller key comes first
+func countPairs(keys []string, counts map[[2]string]int) {
+	for i := range keys {
+		for j := i + 1; j < len(keys); j++ {
+			a, b := keys[i], keys[j]
+			if b < a {
+				a, b = b, a
+			}
+			counts[[2]string{a, b}]++
+		}
+	}
+}
+
+// appendUnique appends key unless keys already holds it
+func appendUnique(keys []string, key string) []string {
+	for _, k := range keys {
+		if k == key {
+			return keys
+		}
+	}
+	return append(keys, key)
+}
@@ -1,17 +1,35 @@
 package tag
 
 import (
+	"soloterm/domain/game"
+	"soloterm/domain/notes"
+	"soloterm/domain/session"
+	testhelper "soloterm/shared/testing"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
-// extractRecentTags doesn't use sessionRepo, so a bare &Service{} is sufficient.
+// latestTags and latestProgress don't use the repositories, so a bare &Service{} is sufficient.
 func newTestService() *Service {
 	return &Service{}
 }
 
-func TestExtractRecentTags_DiceBreakdownsIgnored(t *testing.T) {
+// newestFirst builds index entries from session contents given oldest first,
+// ordered newest first the way the repository returns them.
+func newestFirst(contents []string) []*Entry {
+	var entries []*Entry
+	for i := len(contents) - 1; i >= 0; i-- {
+		sessionEntries := entriesFor(contents[i])
+		for j := len(sessionEntries) - 1; j >= 0; j-- {
+			entries = append(entries, sessionEntries[j])
+		}
+	}
+	return entries
+}
+
+func TestLatestTags_DiceBreakdownsIgnored(t *testing.T) {
 	svc := newTestService()
 
 	tests := []struct {
@@ -38,13 +56,13 @@ func TestExtractRecentTags_DiceBreakdownsIgnored(t *testing.T) {
 
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
-			tags := svc.extractRecentTags(tc.contents, nil)
+			tags := svc.latestTags(newestFirst(tc.contents), nil)
 			assert.Empty(t, tags, "dice breakdown values should not produce tags")
 		})
 	}
 }
 
-func TestExtractRecentTags_RealTagsStillExtracted(t *testing.T) {
+func TestLatestTags_RealTagsStillExtracted(t *testing.T) {
 	svc := newTestService()
 
 	tests := []struct {
@@ -69,7 +87,7 @@ func TestExtractRecentTags_RealTagsStillExtracted(t *testing.T) {
 
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
-			tags := svc.extractRecentTags(tc.contents, nil)
+			tags := svc.latestTags(newestFirst(tc.contents), nil)
 			assert.Len(t, tags, len(tc.wantLabels))
 			for i, tag := range tags {
 				assert.Equal(t, tc.wantLabels[i], tag.Label)
@@ -79,7 +97,7 @@ func TestExtractRecentTags_RealTagsStillExtracted(t *testing.T) {
 	}
 }
 
-func TestExtractRecentTags_MixedContentWithDiceResults(t *testing.T) {
+func TestLatestTags_MixedContentWithDiceResults(t *testing.T) {
 	svc := newTestService()
 
 	// Simulates a session log where dice results were inserted alongside real tags
@@ -88,7 +106,7 @@ Met [N:Skeleton | HP: 3].
 Attacked: 1d20+3 = 17 [14]
 Damage: 2d6 = 9 [4 5]`
 
-	tags := svc.extractRecentTags([]string{content}, nil)
+	tags := svc.latestTags(newestFirst([]string{content}), nil)
 
 	labels := make([]string, len(tags))
 	for i, t := range tags {
@@ -99,3 +117,98 @@ Damage: 2d6 = 9 [4 5]`
 	assert.Contains(t, labels, "L:Dungeon")
 	assert.Contains(t, labels, "N:Skeleton")
 }
+
+func TestLatestProgress(t *testing.T) {
+	svc := newTestService()
+
+	contents := []string{
+		"[Clock:Reinforcements 1/6] [Track:Escape 2/8] [Clock:Alarm 3/4]",
+		"[Clock: Reinforcements 3/6] [Clock:Alarm 4/4 | closed]",
+		"[N:Vex | 3/6]",
+	}
+
+	progress := svc.latestProgress(newestFirst(contents), []string{"closed"})
+
+	if assert.Len(t, progress, 2) {
+		assert.Equal(t, "Reinforcements", progress[0].Name)
+		assert.Equal(t, 3, progress[0].Current, "newest occurrence should win")
+		assert.Equal(t, "[Clock: Reinforcements 3/6]", progress[0].Raw)
+		assert.Equal(t, "Escape", progress[1].Name)
+		assert.Equal(t, 8, progress[1].Max)
+	}
+}
+
+func TestService_IndexFollowsSaves(t *testing.T) {
+	db := testhelper.SetupTestDB(t)
+	defer testhelper.TeardownTestDB(t, db)
+
+	sessionRepo := session.NewRepository(db)
+	gameRepo := game.NewRepository(db)
+	notesRepo := notes.NewRepository(db)
+	svc := NewService(NewRepository(db), sessionRepo, notesRepo)
+	sessionRepo.AddIndexer(svc)
+	notesRepo.AddIndexer(svc)
+
+	g := &game.Game{Name: "Game"}
+	require.NoError(t, gameRepo.Save(g))
+
+	first := &session.Session{GameID: g.ID, Name: "First", Content: "[N:Vex | wary] [L:Docks]"}
+	require.NoError(t, sessionRepo.Save(first))
+	second := &session.Session{GameID: g.ID, Name: "Second", Content: "[N:Vex | ally] [L:Docks | burned; closed]"}
+	require.NoError(t, sessionRepo.Save(second))
+	require.NoError(t, notesRepo.Save(&notes.Page{GameID: g.ID, Name: "Places", Content: "[L:Keep]"}))
+
+	result, err := svc.LoadTagsForGame(g.ID, nil, []string{"closed"})
+	require.NoError(t, err)
+	require.Len(t, result.Active, 1, "closed identifiers are excluded")
+	assert.Equal(t, "[N:Vex | ally]", result.Active[0].Template, "newest occurrence wins")
+	require.Len(t, result.Notes, 1)
+	assert.Equal(t, "L:Keep", result.Notes[0].Label)
+
+	t.Run("rebuild picks up changes made outside the repositories", func(t *testing.T) {
+		_, err := db.Connection.Exec("UPDATE sessions SET content = '[N:Vex | gone]' WHERE id = ?", second.ID)
+		require.NoError(t, err)
+
+		require.NoError(t, svc.RebuildIndex(g.ID))
+
+		result, err := svc.LoadTagsForGame(g.ID, nil, []string{"closed"})
+		require.NoError(t, err)
+		labels := []string{}
+		for _, tt := range result.Active {
+			labels = append(labels, tt.Template)
+		}
+		assert.Equal(t, []string{"[L:Docks]", "[N:Vex | gone]"}, labels)
+		assert.Len(t, result.Notes, 1)
+	})
+}
+
+func TestService_CoOccurrences(t *testing.T) {
+	db := testhelper.SetupTestDB(t)
+	defer testhelper.TeardownTestDB(t, db)
+
+	svc := NewService(NewRepository(db), session.NewRepository(db), notes.NewRepository(db))
+	gameID := testhelper.CreateTestGame(t, db, "Game")
+	testhelper.CreateTestSession(t, db, gameID, "First",
+		"[N:Vex] waits.\nS1 *Docks*\n[N:Vex] meets [F:Guild] at [L:Docks]\n[Clock:Alarm 1/4]\nS2 *Keep*\n[n:vex] flees to [L:Keep]")
+	testhelper.CreateTestSession(t, db, gameID, "Second", "[F:Guild] burns the [L:Docks] [clock:alarm 2/4]")
+	testhelper.CreateTestNotesPage(t, db, gameID, "Notes", "[N:Vex] [L:Keep] [L:Nowhere]")
+
+	pairs, err := svc.CoOccurrences(gameID)
+	require.NoError(t, err)
+
+	counts := make(map[string][2]int)
+	for _, p := range pairs {
+		counts[p.A+" + "+p.B] = [2]int{p.Scenes, p.Sessions}
+	}
+	assert.Equal(t, [2]int{2, 2}, counts["F:Guild + L:Docks"], "shared a scene in both sessions")
+	assert.Equal(t, [2]int{1, 1}, counts["L:Docks + N:Vex"])
+	assert.Equal(t, [2]int{1, 1}, counts["L:Keep + N:Vex"], "identifiers ignore case")
+	assert.Equal(t, [2]int{0, 1}, counts["L:Docks + L:Keep"], "different scenes of one session")
+	assert.Equal(t, [2]int{2, 2}, counts["Clock:Alarm + F:Guild"], "clocks ignore their value")
+	assert.NotContains(t, counts, "L:Keep + L:Nowhere", "notes pages are not sessions")
+	assert.Equal(t, "Clock:Alarm", pairs[0].A, "most shared scenes first")
+
+	empty, err := svc.CoOccurrences(0)
+	require.NoError(t, err)
+	assert.Empty(t, empty)
+}
@@ -0,0 +1,141 @@
+package tag
+
+import (
+	"regexp"
+	"soloterm/domain/dice"
+	"soloterm/shared/text"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// placeholderRegex matches a template placeholder such as {{date}}, {{roll:2d6}} or {{@Weather}}
+var placeholderRegex = regexp.MustCompile(`\{\{\s*([^{}:]+?)\s*(?::([^{}]*))?\}\}`)
+
+// TemplateContext supplies the values placeholders expand to
+type TemplateContext struct {
+	Now     time.Time
+	Session string            // name of the session being edited, empty in notes
+	Game    string            // name of the game being edited
+	Oracles dice.OracleLookup // resolves {{@table}} placeholders
+	Prompts map[string]string // answers to {{prompt:Name}} placeholders, keyed by name
+
+	// ActiveTags, Previously and Recap are only called when the template uses
+	// {{active_tags}}, {{previously}} or {{recap}}, as they query the database
+	ActiveTags func() string
+	Previously func() string
+	Recap      func() string
+}
+
+// Expansion is the result of expanding a template
+type Expansion struct {
+	Text   string
+	Cursor int // byte offset of {{cursor}} in Text, or len(Text) when there is none
+}
+
+// TemplatePrompts returns the names of the {{prompt:Name}} placeholders in template,
+// in the order they first appear and without duplicates.
+func TemplatePrompts(template string) []string {
+	var names []string
+	seen := make(map[string]bool)
+	for _, m := range placeholderRegex.FindAllStringSubmatch(template, -1) {
+		if strings.ToLower(m[1]) != "prompt" {
+			continue
+		}
+		name := strings.TrimSpace(m[2])
+		if name != "" && !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// ExpandTemplate replaces the placeholders in template with their values:
+//
+//	{{cursor}}       where the cursor is left after insertion (first one wins)
+//	{{date}}         today's date
+//	{{session}}      the session name
+//	{{game}}         the game name
+//	{{roll:2d6}}     the result of rolling the expression
+//	{{@table}}       an entry picked from the oracle table
+//	{{prompt:Name}}  the answer given for Name
+//	{{active_tags}}  the latest active tags, one per line
+//	{{previously}}   a recap of the previous session
+//	{{recap}}        the summaries of every session in the game
+//
+// Unknown placeholders are left as they are.
+func ExpandTemplate(template string, ctx TemplateContext) Expansion {
+	var b strings.Builder
+	cursor := -1
+	last := 0
+	for _, loc := range placeholderRegex.FindAllStringSubmatchIndex(template, -1) {
+		b.WriteString(template[last:loc[0]])
+		last = loc[1]
+
+		name := template[loc[2]:loc[3]]
+		var arg string
+		if loc[4] >= 0 {
+			arg = strings.TrimSpace(template[loc[4]:loc[5]])
+		}
+
+		switch {
+		case strings.EqualFold(name, "cursor"):
+			if cursor < 0 {
+				cursor = b.Len()
+			}
+		case strings.EqualFold(name, "date"):
+			b.WriteString(ctx.Now.Format(text.DateFormat))
+		case strings.EqualFold(name, "session"):
+			b.WriteString(ctx.Session)
+		case strings.EqualFold(name, "game"):
+			b.WriteString(ctx.Game)
+		case strings.EqualFold(name, "roll"):
+			b.WriteString(rollInline(arg, ctx.Oracles))
+		case strings.HasPrefix(name, "@"):
+			b.WriteString(rollInline(name, ctx.Oracles))
+		case strings.EqualFold(name, "prompt"):
+			b.WriteString(ctx.Prompts[arg])
+		case strings.EqualFold(name, "active_tags"):
+			if ctx.ActiveTags != nil {
+				b.WriteString(ctx.ActiveTags())
+			}
+		case strings.EqualFold(name, "previously"):
+			if ctx.Previously != nil {
+				b.WriteString(ctx.Previously())
+			}
+		case strings.EqualFold(name, "recap"):
+			if ctx.Recap != nil {
+				b.WriteString(ctx.Recap())
+			}
+		default:
+			b.WriteString(template[loc[0]:loc[1]])
+		}
+	}
+	b.WriteString(template[last:])
+
+	text := b.String()
+	if cursor < 0 {
+		cursor = len(text)
+	}
+	return Expansion{Text: text, Cursor: cursor}
+}
+
+// rollInline rolls expression and returns just the results: dice totals and
+// picked table entries, separated by ", ". Errors are returned as their message.
+func rollInline(expression string, oracles dice.OracleLookup) string {
+	var parts []string
+	for _, group := range dice.Roll(expression, oracles) {
+		for _, r := range group.Results {
+			switch {
+			case r.Err != nil:
+				parts = append(parts, r.Err.Error())
+			case r.Picked != "":
+				parts = append(parts, r.Picked)
+			default:
+				parts = append(parts, strconv.Itoa(r.Total))
+			}
+		}
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,72 @@
+package tag
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// stubOracles resolves table names from a fixed map
+type stubOracles map[string][]string
+
+func (s stubOracles) Lookup(name string) ([]string, bool) {
+	entries, ok := s[strings.ToLower(name)]
+	return entries, ok
+}
+
+func TestExpandTemplate(t *testing.T) {
+	ctx := TemplateContext{
+		Now:        time.Date(2026, 3, 14, 9, 0, 0, 0, time.UTC),
+		Session:    "Session 3",
+		Game:       "Ironsworn",
+		Oracles:    stubOracles{"weather": {"Rain"}},
+		Prompts:    map[string]string{"Name": "Vance"},
+		ActiveTags: func() string { return "[N:Vance | wary]" },
+		Previously: func() string { return "Previously, in Session 2:" },
+		Recap:      func() string { return "# Recap: Ironsworn\n" },
+	}
+
+	tests := []struct {
+		name     string
+		template string
+		text     string
+		cursor   int
+	}{
+		{"no placeholders", "[N: | ]", "[N: | ]", 7},
+		{"cursor", "[N:{{cursor}} | ]", "[N: | ]", 3},
+		{"first cursor wins", "{{cursor}}a{{cursor}}b", "ab", 0},
+		{"date", "## {{date}}", "## 2026-03-14", 13},
+		{"session and game", "{{game}} - {{session}}", "Ironsworn - Session 3", 21},
+		{"roll", "d: {{roll:1d1}}", "d: 1", 4},
+		{"table", "Weather: {{@Weather}}", "Weather: Rain", 13},
+		{"unknown table", "{{@Nope}}", "unknown oracle: nope", 20},
+		{"prompt", "[N:{{prompt:Name}} | {{cursor}}]", "[N:Vance | ]", 11},
+		{"unanswered prompt", "[L:{{prompt:Place}}]", "[L:]", 4},
+		{"case and spaces", "{{ Date }}", "2026-03-14", 10},
+		{"unknown placeholder", "{{mood}}", "{{mood}}", 8},
+		{"active tags", "{{active_tags}}", "[N:Vance | wary]", 16},
+		{"previously", "{{previously}}\n", "Previously, in Session 2:\n", 26},
+		{"recap", "{{recap}}", "# Recap: Ironsworn\n", 19},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ExpandTemplate(tt.template, ctx)
+			assert.Equal(t, tt.text, got.Text)
+			assert.Equal(t, tt.cursor, got.Cursor)
+		})
+	}
+}
+
+func TestTemplatePrompts(t *testing.T) {
+	prompts := TemplatePrompts("[N:{{prompt:Name}} | {{prompt:Role}}] {{prompt:Name}} {{prompt: }} {{date}}")
+	assert.Equal(t, []string{"Name", "Role"}, prompts)
+	assert.Empty(t, TemplatePrompts("[N: | ]"))
+}
+
+func TestExpandTemplate_NilLookupsExpandEmpty(t *testing.T) {
+	got := ExpandTemplate("{{active_tags}}{{previously}}{{recap}}", TemplateContext{})
+	assert.Equal(t, "", got.Text)
+}
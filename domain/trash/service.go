@@ -0,0 +1,127 @@
+package trash
+
+import (
+	"fmt"
+	"soloterm/domain/character"
+	"soloterm/domain/game"
+	"soloterm/domain/notes"
+	"soloterm/domain/session"
+	"sort"
+)
+
+// Service handles restoring and purging deleted items
+type Service struct {
+	gameRepo      *game.Repository
+	sessionRepo   *session.Repository
+	notesRepo     *notes.Repository
+	characterRepo *character.Repository
+}
+
+// NewService creates a new trash service
+func NewService(gameRepo *game.Repository, sessionRepo *session.Repository, notesRepo *notes.Repository, characterRepo *character.Repository) *Service {
+	return &Service{gameRepo: gameRepo, sessionRepo: sessionRepo, notesRepo: notesRepo, characterRepo: characterRepo}
+}
+
+// GetAll retrieves every deleted item, most recently deleted first
+func (s *Service) GetAll() ([]*Item, error) {
+	var items []*Item
+
+	games, err := s.gameRepo.GetDeleted()
+	if err != nil {
+		return nil, err
+	}
+	for _, g := range games {
+		items = append(items, &Item{Kind: KindGame, ID: g.ID, Name: g.Name, DeletedAt: *g.DeletedAt})
+	}
+
+	sessions, err := s.sessionRepo.GetDeleted()
+	if err != nil {
+		return nil, err
+	}
+	for _, sess := range sessions {
+		items = append(items, &Item{Kind: KindSession, ID: sess.ID, Name: sess.Name, Detail: sess.GameName, DeletedAt: *sess.DeletedAt, gameID: sess.GameID})
+	}
+
+	pages, err := s.notesRepo.GetDeleted()
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range pages {
+		items = append(items, &Item{Kind: KindNotesPage, ID: p.ID, Name: p.Name, Detail: p.GameName, DeletedAt: *p.DeletedAt, gameID: p.GameID})
+	}
+
+	characters, err := s.characterRepo.GetDeleted()
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range characters {
+		items = append(items, &Item{Kind: KindCharacter, ID: c.ID, Name: c.Name, Detail: c.System, DeletedAt: *c.DeletedAt})
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].DeletedAt.After(items[j].DeletedAt)
+	})
+	return items, nil
+}
+
+// Restore brings the item back. A session or notes page can't be restored
+// while its game is in the trash.
+func (s *Service) Restore(item *Item) error {
+	switch item.Kind {
+	case KindGame:
+		return s.gameRepo.Restore(item.ID)
+	case KindSession:
+		if _, err := s.gameRepo.GetByID(item.gameID); err != nil {
+			return fmt.Errorf("restore the game %s first", item.Detail)
+		}
+		return s.sessionRepo.Restore(item.ID)
+	case KindNotesPage:
+		if _, err := s.gameRepo.GetByID(item.gameID); err != nil {
+			return fmt.Errorf("restore the game %s first", item.Detail)
+		}
+		return s.notesRepo.Restore(item.ID)
+	case KindCharacter:
+		return s.characterRepo.Restore(item.ID)
+	}
+	return fmt.Errorf("unknown item kind %q", item.Kind)
+}
+
+// Purge permanently removes the item. Purging a game removes its sessions and
+// notes pages, and purging a character removes their sheet.
+func (s *Service) Purge(item *Item) error {
+	switch item.Kind {
+	case KindGame:
+		return s.gameRepo.Purge(item.ID)
+	case KindSession:
+		return s.sessionRepo.Purge(item.ID)
+	case KindNotesPage:
+		return s.notesRepo.Purge(item.ID)
+	case KindCharacter:
+		return s.characterRepo.Purge(item.ID)
+	}
+	return fmt.Errorf("unknown item kind %q", item.Kind)
+}
+
+// PurgeExpired permanently removes items that have been in the trash for
+// more than days. A retention of 0 keeps items until they are purged by hand.
+// Returns the number of items removed.
+func (s *Service) PurgeExpired(days int) (int64, error) {
+	if days <= 0 {
+		return 0, nil
+	}
+
+	var total int64
+	for _, purge := range []func(int) (int64, error){
+		s.gameRepo.PurgeExpired,
+		s.sessionRepo.PurgeExpired,
+		s.notesRepo.PurgeExpired,
+		s.characterRepo.PurgeExpired,
+	} {
+		n, err := purge(days)
+		if err != nil {
+			return total, err
+		}
+		total += n
+	}
+	return total, nil
+}
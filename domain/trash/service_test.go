@@ -0,0 +1,166 @@
+package trash
+
+import (
+	"soloterm/database"
+	"soloterm/domain/character"
+	"soloterm/domain/game"
+	"soloterm/domain/notes"
+	"soloterm/domain/session"
+	testhelper "soloterm/shared/testing"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupService(t *testing.T) (*Service, *database.DBStore) {
+	t.Helper()
+	db := testhelper.SetupTestDB(t)
+	t.Cleanup(func() { testhelper.TeardownTestDB(t, db) })
+	return NewService(game.NewRepository(db), session.NewRepository(db), notes.NewRepository(db), character.NewRepository(db)), db
+}
+
+func findItem(t *testing.T, svc *Service, kind Kind, id int64) *Item {
+	t.Helper()
+	items, err := svc.GetAll()
+	require.NoError(t, err)
+	for _, item := range items {
+		if item.Kind == kind && item.ID == id {
+			return item
+		}
+	}
+	return nil
+}
+
+func TestService_DeleteAndRestore(t *testing.T) {
+	svc, _ := setupService(t)
+	gameRepo, sessionRepo, characterRepo := svc.gameRepo, svc.sessionRepo, svc.characterRepo
+
+	g := &game.Game{Name: "Test Game"}
+	require.NoError(t, gameRepo.Save(g))
+	s := &session.Session{GameID: g.ID, Name: "Session One", PlayedAt: session.Today()}
+	require.NoError(t, sessionRepo.Save(s))
+	c := &character.Character{Name: "Vance", System: "Ironsworn", Role: "Scout", Species: "Human"}
+	require.NoError(t, characterRepo.Save(c))
+
+	_, err := sessionRepo.Delete(s.ID)
+	require.NoError(t, err)
+	_, err = characterRepo.Delete(c.ID)
+	require.NoError(t, err)
+
+	sessions, err := sessionRepo.GetAllForGame(g.ID)
+	require.NoError(t, err)
+	assert.Empty(t, sessions, "Expected deleted sessions to be hidden")
+	_, err = characterRepo.GetByID(c.ID)
+	assert.Error(t, err, "Expected deleted characters to be hidden")
+
+	item := findItem(t, svc, KindSession, s.ID)
+	require.NotNil(t, item)
+	assert.Equal(t, "Test Game", item.Detail)
+	require.NotNil(t, findItem(t, svc, KindCharacter, c.ID))
+
+	require.NoError(t, svc.Restore(item))
+	restored, err := sessionRepo.GetByID(s.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Session One", restored.Name)
+	assert.Nil(t, findItem(t, svc, KindSession, s.ID))
+}
+
+func TestService_SessionNeedsItsGame(t *testing.T) {
+	svc, _ := setupService(t)
+	gameRepo, sessionRepo := svc.gameRepo, svc.sessionRepo
+
+	g := &game.Game{Name: "Test Game"}
+	require.NoError(t, gameRepo.Save(g))
+	s := &session.Session{GameID: g.ID, Name: "Session One", PlayedAt: session.Today()}
+	require.NoError(t, sessionRepo.Save(s))
+
+	_, err := sessionRepo.Delete(s.ID)
+	require.NoError(t, err)
+	_, err = gameRepo.Delete(g.ID)
+	require.NoError(t, err)
+
+	assert.Error(t, svc.Restore(findItem(t, svc, KindSession, s.ID)), "Expected the game to need restoring first")
+
+	require.NoError(t, svc.Restore(findItem(t, svc, KindGame, g.ID)))
+	require.NoError(t, svc.Restore(findItem(t, svc, KindSession, s.ID)))
+}
+
+func TestService_NotesPage(t *testing.T) {
+	svc, _ := setupService(t)
+	gameRepo, notesRepo := svc.gameRepo, svc.notesRepo
+
+	g := &game.Game{Name: "Test Game"}
+	require.NoError(t, gameRepo.Save(g))
+	p := &notes.Page{GameID: g.ID, Name: "People", Content: "[N:Vex]"}
+	require.NoError(t, notesRepo.Save(p))
+
+	_, err := notesRepo.Delete(p.ID)
+	require.NoError(t, err)
+	_, err = gameRepo.Delete(g.ID)
+	require.NoError(t, err)
+
+	item := findItem(t, svc, KindNotesPage, p.ID)
+	require.NotNil(t, item)
+	assert.Equal(t, "People", item.Name)
+	assert.Equal(t, "Test Game", item.Detail)
+	assert.Error(t, svc.Restore(item), "Expected the game to need restoring first")
+
+	require.NoError(t, svc.Restore(findItem(t, svc, KindGame, g.ID)))
+	require.NoError(t, svc.Restore(item))
+	restored, err := notesRepo.GetByID(p.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "[N:Vex]", restored.Content)
+
+	_, err = notesRepo.Delete(p.ID)
+	require.NoError(t, err)
+	require.NoError(t, svc.Purge(findItem(t, svc, KindNotesPage, p.ID)))
+	assert.Nil(t, findItem(t, svc, KindNotesPage, p.ID))
+	assert.Error(t, notesRepo.Restore(p.ID), "Expected nothing left to restore")
+}
+
+func TestService_Purge(t *testing.T) {
+	svc, _ := setupService(t)
+	gameRepo, sessionRepo := svc.gameRepo, svc.sessionRepo
+
+	g := &game.Game{Name: "Test Game"}
+	require.NoError(t, gameRepo.Save(g))
+	s := &session.Session{GameID: g.ID, Name: "Session One", PlayedAt: session.Today()}
+	require.NoError(t, sessionRepo.Save(s))
+
+	_, err := gameRepo.Delete(g.ID)
+	require.NoError(t, err)
+	require.NoError(t, svc.Purge(findItem(t, svc, KindGame, g.ID)))
+
+	assert.Nil(t, findItem(t, svc, KindGame, g.ID))
+	sessions, err := sessionRepo.GetAllForGame(g.ID)
+	require.NoError(t, err)
+	assert.Empty(t, sessions, "Expected the game's sessions to be purged with it")
+	assert.Error(t, gameRepo.Restore(g.ID), "Expected nothing left to restore")
+}
+
+func TestService_PurgeExpired(t *testing.T) {
+	svc, db := setupService(t)
+
+	old := &game.Game{Name: "Old"}
+	recent := &game.Game{Name: "Recent"}
+	for _, g := range []*game.Game{old, recent} {
+		require.NoError(t, svc.gameRepo.Save(g))
+		_, err := svc.gameRepo.Delete(g.ID)
+		require.NoError(t, err)
+	}
+
+	// Backdate one deletion past the retention period
+	_, err := db.Connection.Exec(`UPDATE games SET deleted_at = datetime('now', '-31 days') WHERE id = ?`, old.ID)
+	require.NoError(t, err)
+
+	n, err := svc.PurgeExpired(0)
+	require.NoError(t, err)
+	assert.Zero(t, n, "Expected a retention of 0 to keep everything")
+
+	n, err = svc.PurgeExpired(30)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), n)
+	assert.Nil(t, findItem(t, svc, KindGame, old.ID))
+	assert.NotNil(t, findItem(t, svc, KindGame, recent.ID))
+}
@@ -0,0 +1,26 @@
+// Package trash lists soft deleted games, sessions, notes pages and characters
+// so they can be restored or purged for good.
+package trash
+
+import "time"
+
+// Kind is the type of a deleted item
+type Kind string
+
+const (
+	KindGame      Kind = "Game"
+	KindSession   Kind = "Session"
+	KindNotesPage Kind = "Notes Page"
+	KindCharacter Kind = "Character"
+)
+
+// Item is a single deleted game, session, notes page or character
+type Item struct {
+	Kind      Kind
+	ID        int64
+	Name      string
+	Detail    string // the session's or page's game, or the character's system
+	DeletedAt time.Time
+
+	gameID int64 // the session's or page's game
+}
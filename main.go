@@ -68,7 +68,8 @@ func main() {
 
 	// Create and run the TUI application
 	app := ui.NewApp(db, loadedCfg, info)
-	if err := app.EnableMouse(false).Run(); err != nil {
+	app.EnableMouse(false)
+	if err := app.Run(); err != nil {
 		log.SetOutput(os.Stdout)
 		log.Fatal("Application error:", err)
 	}
@@ -0,0 +1,222 @@
+package markdown
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/rivo/tview"
+)
+
+// style is the colour and attributes that text is rendered with, in tview's
+// tag syntax (e.g. attrs "bi" for bold italic)
+type style struct {
+	color string
+	attrs string
+}
+
+// tag returns the tview tag that switches to this style with extra attributes
+// added
+func (s style) tag(extra string) string {
+	color := s.color
+	if color == "" {
+		color = "-"
+	}
+	attrs := s.attrs + extra
+	if attrs == "" {
+		attrs = "-"
+	}
+	return "[" + color + "::" + attrs + "]"
+}
+
+// wrap surrounds already rendered text with the style, resetting afterwards
+func (s style) wrap(text string) string {
+	if s.color == "" && s.attrs == "" {
+		return text
+	}
+	return s.tag("") + text + "[-::-]"
+}
+
+// emphasis markers in the order they are tried, longest first
+var emphasis = []struct {
+	marker string
+	attr   string
+}{
+	{"**", "b"},
+	{"__", "b"},
+	{"~~", "s"},
+	{"*", "i"},
+	{"_", "i"},
+}
+
+// inline renders the inline Markdown in text: emphasis, code spans and links.
+// base is the style of the surrounding block, restored after each span.
+func inline(text string, base style, theme Theme) string {
+	var out, plain strings.Builder
+	flush := func() {
+		out.WriteString(tview.Escape(plain.String()))
+		plain.Reset()
+	}
+
+	// open holds the emphasis markers currently open, innermost last
+	var open []string
+	attrs := func() string {
+		var a strings.Builder
+		for _, marker := range open {
+			for _, e := range emphasis {
+				if e.marker == marker {
+					a.WriteString(e.attr)
+				}
+			}
+		}
+		return a.String()
+	}
+
+	for i := 0; i < len(text); {
+		c := text[i]
+
+		// Backslash escapes a punctuation character
+		if c == '\\' && i+1 < len(text) && unicode.IsPunct(rune(text[i+1])) {
+			plain.WriteByte(text[i+1])
+			i += 2
+			continue
+		}
+
+		if c == '`' {
+			ticks := len(text[i:]) - len(strings.TrimLeft(text[i:], "`"))
+			fence := text[i : i+ticks]
+			if end := strings.Index(text[i+ticks:], fence); end != -1 {
+				flush()
+				code := strings.TrimSpace(text[i+ticks : i+ticks+end])
+				out.WriteString(style{color: theme.Code, attrs: base.attrs}.tag(attrs()) + tview.Escape(code) + base.tag(attrs()))
+				i += ticks + end + ticks
+				continue
+			}
+		}
+
+		if c == '[' || (c == '!' && strings.HasPrefix(text[i:], "![")) {
+			if label, url, n, ok := link(text[i:]); ok {
+				flush()
+				out.WriteString("[" + orDefault(theme.Link) + "::" + base.attrs + attrs() + "u:" + url + "]" +
+					tview.Escape(label) + base.tag(attrs()) + "[:::-]")
+				i += n
+				continue
+			}
+		}
+
+		if marker, ok := emphasisAt(text, i, open); ok {
+			flush()
+			if len(open) > 0 && open[len(open)-1] == marker {
+				open = open[:len(open)-1]
+			} else {
+				open = append(open, marker)
+			}
+			out.WriteString(base.tag(attrs()))
+			i += len(marker)
+			continue
+		}
+
+		plain.WriteByte(c)
+		i++
+	}
+	flush()
+
+	// Close anything left open so it doesn't run into the next line
+	if len(open) > 0 {
+		out.WriteString(base.tag(""))
+	}
+	return out.String()
+}
+
+// emphasisAt returns the emphasis marker at text[i] when it opens or closes a
+// span. A marker opens a span when it is followed by text and closed later in
+// the line, and closes the innermost open span when it follows text.
+func emphasisAt(text string, i int, open []string) (string, bool) {
+	for _, e := range emphasis {
+		if !strings.HasPrefix(text[i:], e.marker) {
+			continue
+		}
+		after := i + len(e.marker)
+
+		if len(open) > 0 && open[len(open)-1] == e.marker {
+			if i > 0 && !unicode.IsSpace(runeBefore(text, i)) {
+				return e.marker, true
+			}
+			return "", false
+		}
+
+		if after >= len(text) || unicode.IsSpace(runeAt(text, after)) {
+			return "", false
+		}
+		// Underscores inside words (snake_case) aren't emphasis
+		if e.marker[0] == '_' && i > 0 && isWordChar(runeBefore(text, i)) {
+			return "", false
+		}
+		if !closes(text[after:], e.marker) {
+			return "", false
+		}
+		return e.marker, true
+	}
+	return "", false
+}
+
+// closes reports whether rest contains a closing marker that follows text
+func closes(rest, marker string) bool {
+	for j := 1; j < len(rest); j++ {
+		if strings.HasPrefix(rest[j:], marker) && !unicode.IsSpace(runeBefore(rest, j)) {
+			// A single marker can't close on half of a double one
+			if len(marker) == 1 && strings.HasPrefix(rest[j+1:], marker) {
+				j++
+				continue
+			}
+			return true
+		}
+	}
+	return false
+}
+
+// link parses a "[label](url)" or "![alt](url)" link at the start of text,
+// returning the label, the url and the length of the link
+func link(text string) (label, url string, n int, ok bool) {
+	start := 1
+	if text[0] == '!' {
+		start = 2
+	}
+	closeLabel := strings.Index(text, "](")
+	if closeLabel < start {
+		return "", "", 0, false
+	}
+	closeURL := strings.IndexByte(text[closeLabel:], ')')
+	if closeURL == -1 {
+		return "", "", 0, false
+	}
+	label = text[start:closeLabel]
+	url = strings.TrimSpace(text[closeLabel+2 : closeLabel+closeURL])
+	if strings.ContainsAny(label, "[]") || url == "" || strings.ContainsAny(url, " []") {
+		return "", "", 0, false
+	}
+	return label, url, closeLabel + closeURL + 1, true
+}
+
+func isWordChar(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// runeAt returns the rune starting at text[i]
+func runeAt(text string, i int) rune {
+	r, _ := utf8.DecodeRuneInString(text[i:])
+	return r
+}
+
+// runeBefore returns the rune ending just before text[i]
+func runeBefore(text string, i int) rune {
+	r, _ := utf8.DecodeLastRuneInString(text[:i])
+	return r
+}
+
+func orDefault(color string) string {
+	if color == "" {
+		return "-"
+	}
+	return color
+}
@@ -0,0 +1,255 @@
+// Package markdown renders Markdown as text with tview style tags so it can be
+// shown, read-only, in a TextView. It covers the Markdown people use in
+// session logs: headings, emphasis, lists, block quotes, code, links, rules
+// and tables. Line breaks are kept as written rather than reflowed.
+package markdown
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/rivo/tview"
+)
+
+// Theme holds the tview colour names used when rendering
+type Theme struct {
+	Heading string // headings
+	Quote   string // block quotes
+	Code    string // inline code and code blocks
+	Link    string // link text
+	Rule    string // horizontal rules and table borders
+}
+
+// ruleWidth is the width of a horizontal rule. The renderer doesn't know the
+// width of the view, so rules are a fixed length.
+const ruleWidth = 40
+
+var (
+	headingRegex   = regexp.MustCompile(`^ {0,3}(#{1,6})\s+(.*?)(?:\s+#+)?\s*$`)
+	ruleRegex      = regexp.MustCompile(`^ {0,3}(?:(?:-\s*){3,}|(?:\*\s*){3,}|(?:_\s*){3,})$`)
+	fenceRegex     = regexp.MustCompile("^ {0,3}(```+|~~~+)")
+	quoteRegex     = regexp.MustCompile(`^ {0,3}>`)
+	listRegex      = regexp.MustCompile(`^(\s*)([-*+]|\d+[.)])\s+(.*)$`)
+	taskRegex      = regexp.MustCompile(`^\[([ xX])\]\s+(.*)$`)
+	tableSeparator = regexp.MustCompile(`^\s*\|?\s*:?-+:?\s*(\|\s*:?-+:?\s*)*\|?\s*$`)
+)
+
+// Render converts src into tview tagged text
+func Render(src string, theme Theme) string {
+	lines := strings.Split(strings.ReplaceAll(src, "\r\n", "\n"), "\n")
+	out := make([]string, 0, len(lines))
+
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+
+		if m := fenceRegex.FindStringSubmatch(line); m != nil {
+			block, end := codeBlock(lines, i, m[1], theme)
+			out = append(out, block...)
+			i = end
+			continue
+		}
+
+		if isTableStart(lines, i) {
+			block, end := table(lines, i, theme)
+			out = append(out, block...)
+			i = end
+			continue
+		}
+
+		out = append(out, renderLine(line, theme))
+	}
+
+	return strings.Join(out, "\n")
+}
+
+// renderLine renders a single line that isn't part of a code block or table
+func renderLine(line string, theme Theme) string {
+	if m := headingRegex.FindStringSubmatch(line); m != nil {
+		s := style{color: theme.Heading, attrs: "b"}
+		if len(m[1]) == 1 {
+			s.attrs = "bu"
+		}
+		return s.wrap(inline(m[2], s, theme))
+	}
+
+	if ruleRegex.MatchString(line) {
+		return style{color: theme.Rule}.wrap(strings.Repeat("─", ruleWidth))
+	}
+
+	if quoteRegex.MatchString(line) {
+		// Count nested quotes such as "> > text" or ">> text"
+		depth := 0
+		rest := strings.TrimLeft(line, " ")
+		for strings.HasPrefix(rest, ">") {
+			depth++
+			rest = strings.TrimLeft(rest[1:], " ")
+		}
+		s := style{color: theme.Quote, attrs: "i"}
+		bars := style{color: theme.Quote}.wrap(strings.Repeat("│ ", depth))
+		return bars + s.wrap(inline(rest, s, theme))
+	}
+
+	if m := listRegex.FindStringSubmatch(line); m != nil {
+		indent := strings.Repeat("  ", len(strings.ReplaceAll(m[1], "\t", "  "))/2+1)
+		bullet := m[2]
+		text := m[3]
+		if bullet == "-" || bullet == "*" || bullet == "+" {
+			bullet = "•"
+			if t := taskRegex.FindStringSubmatch(text); t != nil {
+				bullet = "☐"
+				if t[1] != " " {
+					bullet = "☑"
+				}
+				text = t[2]
+			}
+		}
+		return indent + bullet + " " + inline(text, style{}, theme)
+	}
+
+	return inline(line, style{}, theme)
+}
+
+// codeBlock renders the fenced block opening at lines[start] and returns the
+// index of its closing fence, or the last line when it is never closed.
+func codeBlock(lines []string, start int, fence string, theme Theme) ([]string, int) {
+	s := style{color: theme.Code}
+	var out []string
+	for i := start + 1; i < len(lines); i++ {
+		if strings.HasPrefix(strings.TrimLeft(lines[i], " "), fence) {
+			return out, i
+		}
+		out = append(out, "  "+s.wrap(tview.Escape(lines[i])))
+	}
+	return out, len(lines) - 1
+}
+
+// isTableStart reports whether lines[i] is a table header row followed by its
+// separator row
+func isTableStart(lines []string, i int) bool {
+	if i+1 >= len(lines) || !strings.Contains(lines[i], "|") {
+		return false
+	}
+	sep := lines[i+1]
+	return tableSeparator.MatchString(sep) && strings.Contains(sep, "-") &&
+		(strings.Contains(sep, "|") || strings.Count(strings.Trim(strings.TrimSpace(lines[i]), "|"), "|") > 0)
+}
+
+// table renders the table whose header is at lines[start] and returns the
+// index of its last row
+func table(lines []string, start int, theme Theme) ([]string, int) {
+	aligns := tableAligns(splitRow(lines[start+1]))
+	header := splitRow(lines[start])
+
+	end := start + 1
+	var rows [][]string
+	for end+1 < len(lines) && strings.Contains(lines[end+1], "|") && strings.TrimSpace(lines[end+1]) != "" {
+		end++
+		rows = append(rows, splitRow(lines[end]))
+	}
+
+	cols := len(header)
+	for _, row := range rows {
+		cols = max(cols, len(row))
+	}
+
+	// Render every cell first so the column widths account for the markup
+	render := func(cells []string, s style) []string {
+		rendered := make([]string, cols)
+		for i := range rendered {
+			if i < len(cells) {
+				rendered[i] = s.wrap(inline(cells[i], s, theme))
+			}
+		}
+		return rendered
+	}
+	renderedHeader := render(header, style{attrs: "b"})
+	renderedRows := make([][]string, len(rows))
+	for i, row := range rows {
+		renderedRows[i] = render(row, style{})
+	}
+
+	widths := make([]int, cols)
+	for _, row := range append([][]string{renderedHeader}, renderedRows...) {
+		for i, cell := range row {
+			widths[i] = max(widths[i], tview.TaggedStringWidth(cell))
+		}
+	}
+
+	border := style{color: theme.Rule}
+	joinRow := func(cells []string) string {
+		padded := make([]string, cols)
+		for i, cell := range cells {
+			align := tview.AlignLeft
+			if i < len(aligns) {
+				align = aligns[i]
+			}
+			padded[i] = pad(cell, widths[i], align)
+		}
+		return strings.Join(padded, border.wrap(" │ "))
+	}
+
+	out := []string{joinRow(renderedHeader)}
+	dashes := make([]string, cols)
+	for i, w := range widths {
+		dashes[i] = strings.Repeat("─", w)
+	}
+	out = append(out, border.wrap(strings.Join(dashes, "─┼─")))
+	for _, row := range renderedRows {
+		out = append(out, joinRow(row))
+	}
+	return out, end
+}
+
+// splitRow splits a table row into its trimmed cells. Escaped pipes ("\|")
+// stay in the cell.
+func splitRow(line string) []string {
+	line = strings.TrimSpace(line)
+	line = strings.TrimPrefix(line, "|")
+	if strings.HasSuffix(line, "|") && !strings.HasSuffix(line, `\|`) {
+		line = line[:len(line)-1]
+	}
+
+	var cells []string
+	var cell strings.Builder
+	for i := 0; i < len(line); i++ {
+		switch {
+		case line[i] == '\\' && i+1 < len(line) && line[i+1] == '|':
+			cell.WriteByte('|')
+			i++
+		case line[i] == '|':
+			cells = append(cells, strings.TrimSpace(cell.String()))
+			cell.Reset()
+		default:
+			cell.WriteByte(line[i])
+		}
+	}
+	return append(cells, strings.TrimSpace(cell.String()))
+}
+
+// tableAligns reads the column alignments from a separator row's cells
+func tableAligns(cells []string) []int {
+	aligns := make([]int, len(cells))
+	for i, c := range cells {
+		switch {
+		case strings.HasPrefix(c, ":") && strings.HasSuffix(c, ":"):
+			aligns[i] = tview.AlignCenter
+		case strings.HasSuffix(c, ":"):
+			aligns[i] = tview.AlignRight
+		default:
+			aligns[i] = tview.AlignLeft
+		}
+	}
+	return aligns
+}
+
+// pad pads tagged text with spaces to width
+func pad(text string, width, align int) string {
+	gap := max(0, width-tview.TaggedStringWidth(text))
+	switch align {
+	case tview.AlignRight:
+		return strings.Repeat(" ", gap) + text
+	case tview.AlignCenter:
+		return strings.Repeat(" ", gap/2) + text + strings.Repeat(" ", gap-gap/2)
+	}
+	return text + strings.Repeat(" ", gap)
+}
@@ -0,0 +1,88 @@
+package markdown
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testTheme = Theme{Heading: "yellow", Quote: "grey", Code: "aqua", Link: "blue", Rule: "grey"}
+
+func TestRender_Blocks(t *testing.T) {
+	tests := []struct {
+		name     string
+		src      string
+		expected string
+	}{
+		{"plain text", "Just prose", "Just prose"},
+		{"heading", "## The Keep", "[yellow::b]The Keep[-::-]"},
+		{"top heading is underlined", "# Campaign", "[yellow::bu]Campaign[-::-]"},
+		{"closing hashes are dropped", "### Notes ###", "[yellow::b]Notes[-::-]"},
+		{"not a heading without a space", "#hashtag", "#hashtag"},
+		{"rule", "---", "[grey::-]────────────────────────────────────────[-::-]"},
+		{"quote", "> Said Vance", "[grey::-]│ [-::-][grey::i]Said Vance[-::-]"},
+		{"nested quote", ">> Deeper", "[grey::-]│ │ [-::-][grey::i]Deeper[-::-]"},
+		{"bullet", "- Sword", "  • Sword"},
+		{"nested bullet", "  * Dagger", "    • Dagger"},
+		{"numbered", "2. Second", "  2. Second"},
+		{"task", "- [x] Done\n- [ ] Todo", "  ☑ Done\n  ☐ Todo"},
+		{"lonelog consequence is not a list", "-> It opens", "-> It opens"},
+		{
+			"fenced code is not formatted",
+			"```\n*not italic* [x]\n```\nafter",
+			"  [aqua::-]*not italic* [x[][-::-]\nafter",
+		},
+		{"unclosed fence runs to the end", "~~~\ncode", "  [aqua::-]code[-::-]"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, Render(tc.src, testTheme))
+		})
+	}
+}
+
+func TestRender_Inline(t *testing.T) {
+	tests := []struct {
+		name     string
+		src      string
+		expected string
+	}{
+		{"bold", "a **b** c", "a [-::b]b[-::-] c"},
+		{"italic", "a *b* c", "a [-::i]b[-::-] c"},
+		{"underscore italic", "_b_", "[-::i]b[-::-]"},
+		{"bold italic", "**_b_**", "[-::b][-::bi]b[-::b][-::-]"},
+		{"strikethrough", "~~gone~~", "[-::s]gone[-::-]"},
+		{"lone asterisk", "2 * 3 = 6", "2 * 3 = 6"},
+		{"snake_case", "snake_case_name", "snake_case_name"},
+		{"accented snake_case", "café_au_lait_", "café_au_lait_"},
+		{"accented bold", "**là**", "[-::b]là[-::-]"},
+		{"unclosed", "*open", "*open"},
+		{"escaped", `\*literal\*`, "*literal*"},
+		{"code", "run `go *test*`", "run [aqua::-]go *test*[-::-]"},
+		{"link", "[Lonelog](https://example.com)", "[blue::u:https://example.com]Lonelog[-::-][:::-]"},
+		{"brackets are escaped", "[N:Vance]", "[N:Vance[]"},
+		{"lonelog scene", "S1 *The Gate*", "S1 [-::i]The Gate[-::-]"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, Render(tc.src, testTheme))
+		})
+	}
+}
+
+func TestRender_Table(t *testing.T) {
+	src := "| Name | HP |\n|:-----|---:|\n| Vance | 12 |\n| Ada | 7 |\nafter"
+
+	expected := "[-::b]Name[-::-] [grey::-] │ [-::-][-::b]HP[-::-]\n" +
+		"[grey::-]──────┼───[-::-]\n" +
+		"Vance[grey::-] │ [-::-]12\n" +
+		"Ada  [grey::-] │ [-::-] 7\n" +
+		"after"
+	assert.Equal(t, expected, Render(src, testTheme))
+}
+
+func TestRender_NotATable(t *testing.T) {
+	assert.Equal(t, "a | b\nplain", Render("a | b\nplain", testTheme))
+}
@@ -52,6 +52,38 @@ func CreateTestGame(t *testing.T, db *database.DBStore, name string) int64 {
 	return id
 }
 
+// CreateTestNotesPage inserts a notes page row after the game's other pages and returns its ID.
+// Requires the notes_pages table to exist (blank-import soloterm/domain/notes in your test file).
+func CreateTestNotesPage(t *testing.T, db *database.DBStore, gameID int64, name string, content string) int64 {
+	t.Helper()
+	var id int64
+	err := db.Connection.QueryRow(
+		`INSERT INTO notes_pages (game_id, name, content, position, created_at, updated_at)
+		 VALUES (?, ?, ?, (SELECT COALESCE(MAX(position), 0) + 1 FROM notes_pages WHERE game_id = ?), datetime('now'), datetime('now')) RETURNING id`,
+		gameID, name, content, gameID,
+	).Scan(&id)
+	if err != nil {
+		t.Fatalf("CreateTestNotesPage: failed to create page %q: %v", name, err)
+	}
+	return id
+}
+
+// CreateTestCodexEntry inserts a codex entry row and returns its ID.
+// Requires the codex_entries table to exist (blank-import soloterm/domain/codex in your test file).
+func CreateTestCodexEntry(t *testing.T, db *database.DBStore, gameID int64, kind string, name string) int64 {
+	t.Helper()
+	var id int64
+	err := db.Connection.QueryRow(
+		`INSERT INTO codex_entries (game_id, kind, name, created_at, updated_at)
+		 VALUES (?, ?, ?, datetime('now'), datetime('now')) RETURNING id`,
+		gameID, kind, name,
+	).Scan(&id)
+	if err != nil {
+		t.Fatalf("CreateTestCodexEntry: failed to create entry %q: %v", name, err)
+	}
+	return id
+}
+
 // CreateTestOracle inserts an oracle row and returns its ID.
 // Requires the oracles table to exist (blank-import soloterm/domain/oracle in your test file).
 func CreateTestOracle(t *testing.T, db *database.DBStore, name string, content string) int64 {
@@ -74,9 +106,9 @@ func CreateTestSession(t *testing.T, db *database.DBStore, gameID int64, name st
 	t.Helper()
 	var id int64
 	err := db.Connection.QueryRow(
-		`INSERT INTO sessions (game_id, name, content, created_at, updated_at)
-		 VALUES (?, ?, ?, datetime('now'), datetime('now')) RETURNING id`,
-		gameID, name, content,
+		`INSERT INTO sessions (game_id, name, content, played_at, position, created_at, updated_at)
+		 VALUES (?, ?, ?, date('now'), (SELECT COALESCE(MAX(position), 0) + 1 FROM sessions WHERE game_id = ?), datetime('now'), datetime('now')) RETURNING id`,
+		gameID, name, content, gameID,
 	).Scan(&id)
 	if err != nil {
 		t.Fatalf("CreateTestSession: failed to create session %q: %v", name, err)
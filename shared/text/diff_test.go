@@ -0,0 +1,48 @@
+package text
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffLines(t *testing.T) {
+	tests := []struct {
+		name     string
+		old, new string
+		expected []DiffLine
+	}{
+		{
+			"identical",
+			"a\nb", "a\nb",
+			[]DiffLine{{DiffEqual, "a"}, {DiffEqual, "b"}},
+		},
+		{
+			"changed line",
+			"a\nb\nc", "a\nB\nc",
+			[]DiffLine{{DiffEqual, "a"}, {DiffDelete, "b"}, {DiffInsert, "B"}, {DiffEqual, "c"}},
+		},
+		{
+			"inserted and deleted lines",
+			"a\nb\nc\nd", "a\nc\nd\ne",
+			[]DiffLine{{DiffEqual, "a"}, {DiffDelete, "b"}, {DiffEqual, "c"}, {DiffEqual, "d"}, {DiffInsert, "e"}},
+		},
+		{
+			"aligns on common lines in the middle",
+			"x\n1\nkeep\n2\ny", "x\n3\nkeep\n4\ny",
+			[]DiffLine{
+				{DiffEqual, "x"},
+				{DiffDelete, "1"}, {DiffInsert, "3"},
+				{DiffEqual, "keep"},
+				{DiffDelete, "2"}, {DiffInsert, "4"},
+				{DiffEqual, "y"},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, DiffLines(tc.old, tc.new))
+		})
+	}
+}
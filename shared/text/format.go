@@ -1,5 +1,8 @@
 package text
 
+// DateFormat is how dates are shown, entered and stored, like 2026-03-14
+const DateFormat = "2006-01-02"
+
 // FormatWordList formats a list of words into a human-readable string
 // with the given quote character and "or" conjunction.
 // Examples:
@@ -90,6 +90,13 @@ func (fm *FormModal) SetHelpRows(n int) *FormModal {
 	return fm
 }
 
+// SetBaseHeight changes the height of the modal with no help and no errors
+// visible, for forms whose fields change while the modal is in use.
+func (fm *FormModal) SetBaseHeight(n int) {
+	fm.baseHeight = n
+	fm.updateContainerHeight()
+}
+
 // SetTitle sets the title shown on the modal's border.
 func (fm *FormModal) SetTitle(title string) {
 	fm.formContainer.SetTitle(title)
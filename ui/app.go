@@ -3,16 +3,24 @@
 package ui
 
 import (
+	"errors"
 	"fmt"
+	"log"
 	"slices"
 	"soloterm/config"
 	"soloterm/database"
 	"soloterm/domain/character"
+	"soloterm/domain/codex"
+	"soloterm/domain/filesync"
 	"soloterm/domain/game"
+	"soloterm/domain/graph"
+	"soloterm/domain/link"
+	"soloterm/domain/notes"
 	"soloterm/domain/oracle"
 	"soloterm/domain/session"
 	"soloterm/domain/snippet"
 	"soloterm/domain/tag"
+	"soloterm/domain/trash"
 	sharedui "soloterm/shared/ui"
 
 	"github.com/gdamore/tcell/v2"
@@ -22,13 +30,26 @@ import (
 const (
 	GAME_MODAL_ID        string = "gameModal"
 	TAG_MODAL_ID         string = "tagModal"
+	TAG_TIMELINE_MODAL_ID string = "tagTimelineModal"
+	ATTRIBUTE_HISTORY_MODAL_ID string = "attributeHistoryModal"
+	CLOCK_MODAL_ID       string = "clockModal"
+	RECAP_MODAL_ID       string = "recapModal"
+	TRASH_MODAL_ID       string = "trashModal"
 	CHARACTER_MODAL_ID   string = "characterModal"
 	ATTRIBUTE_MODAL_ID   string = "attributeModal"
 	FILE_MODAL_ID        string = "fileModal"
+	PROMPT_MODAL_ID      string = "promptModal"
+	SYNC_CONFLICT_MODAL_ID string = "syncConflictModal"
+	BACKLINKS_MODAL_ID   string = "backlinksModal"
+	NOTES_PAGE_MODAL_ID  string = "notesPageModal"
+	CODEX_MODAL_ID       string = "codexModal"
+	CODEX_FORM_MODAL_ID  string = "codexFormModal"
+	CODEX_RELATION_MODAL_ID string = "codexRelationModal"
 	CONFIRM_MODAL_ID     string = "confirm"
 	MAIN_PAGE_ID         string = "main"
 	ABOUT_MODAL_ID       string = "about"
 	SESSION_MODAL_ID     string = "sessionModal"
+	SESSION_MOVE_MODAL_ID  string = "sessionMoveModal"
 	HELP_MODAL_ID        string = "helpModal"
 	DICE_MODAL_ID        string = "diceModal"
 	SEARCH_MODAL_ID      string = "searchModal"
@@ -48,19 +69,34 @@ type AppInfo struct {
 type App struct {
 	*tview.Application
 
-	cfg *config.Config
+	cfg         *config.Config
+	syncService *filesync.Service
+	syncWatch   syncWatch
+	linkService *link.Service
+	notesService *notes.Service
 
 	// View helpers
 	gameView      *GameView
 	tagView       *TagView
+	timelineView  *TagTimelineView
+	clockView     *ClockView
+	recapView     *RecapView
+	trashView     *TrashView
 	sessionView   *SessionView
+	moveView      *SessionMoveView
 	characterView *CharacterView
 	attributeView *AttributeView
+	historyView   *AttributeHistoryView
 	diceView      *DiceView
 	searchView    *SearchView
 	oracleView    *OracleView
 	snippetView   *SnippetView
 	fileView      *FileView
+	promptView    *PromptView
+	syncConflictView *SyncConflictView
+	backlinksView    *BacklinksView
+	notesPageView    *NotesPageView
+	codexView        *CodexView
 
 	// Layout containers
 	mainFlex         *tview.Flex
@@ -79,36 +115,73 @@ type App struct {
 }
 
 func NewApp(db *database.DBStore, cfg *config.Config, info AppInfo) *App {
-	gameService := game.NewService(game.NewRepository(db))
+	gameRepo := game.NewRepository(db)
+	gameService := game.NewService(gameRepo)
 	charRepo := character.NewRepository(db)
 	attrRepo := character.NewAttributeRepository(db)
 	attrService := character.NewAttributeService(attrRepo)
 	charService := character.NewService(charRepo, attrService)
 	sessionRepo := session.NewRepository(db)
-	tagService := tag.NewService(sessionRepo)
+	notesRepo := notes.NewRepository(db)
+	tagRepo := tag.NewRepository(db)
+	tagService := tag.NewService(tagRepo, sessionRepo, notesRepo)
+	sessionRepo.AddIndexer(tagService)
+	notesRepo.AddIndexer(tagService)
+	linkService := link.NewService(link.NewRepository(db), sessionRepo, notesRepo)
+	sessionRepo.AddIndexer(linkService)
+	notesRepo.AddIndexer(linkService)
 	sessionService := session.NewService(sessionRepo)
+	notesService := notes.NewService(notesRepo)
 	oracleService := oracle.NewService(oracle.NewRepository(db))
 	snippetService := snippet.NewService(snippet.NewRepository(db))
+	codexService := codex.NewService(codex.NewRepository(db), tagRepo)
+	graphService := graph.NewService(tagService, codexService)
+	trashService := trash.NewService(gameRepo, sessionRepo, notesRepo, charRepo)
+	syncService := filesync.NewService(filesync.NewRepository(db), gameService, sessionService)
+
+	// Empty the trash of anything kept past the retention period
+	if n, err := trashService.PurgeExpired(cfg.TrashRetentionDays); err != nil {
+		log.Printf("Failed to purge expired trash: %v", err)
+	} else if n > 0 {
+		log.Printf("Purged %d expired items from the trash", n)
+	}
 
 	Style.Apply()
 
 	app := &App{
 		Application: tview.NewApplication(),
 		cfg:         cfg,
+		syncService: syncService,
+		linkService: linkService,
+		notesService: notesService,
 		info:        info,
 	}
 
 	// Initialize views
-	app.gameView = NewGameView(app, gameService, sessionService)
-	app.sessionView = NewSessionView(app, sessionService)
+	app.gameView = NewGameView(app, gameService, sessionService, notesService)
+	app.sessionView = NewSessionView(app, sessionService, oracleService, tagService)
+	app.moveView = NewSessionMoveView(app)
 	app.tagView = NewTagView(app, cfg, tagService)
+	app.timelineView = NewTagTimelineView(app, tagService)
+	app.clockView = NewClockView(app, tagService)
+	app.recapView = NewRecapView(app, sessionService)
+	app.trashView = NewTrashView(app, trashService)
 	app.attributeView = NewAttributeView(app, attrService)
+	app.historyView = NewAttributeHistoryView(app, attrService)
 	app.characterView = NewCharacterView(app, charService)
 	app.diceView = NewDiceView(app, oracleService)
 	app.searchView = NewSearchView(app, sessionService)
 	app.oracleView = NewOracleView(app, oracleService)
 	app.snippetView = NewSnippetView(app, snippetService)
 	app.fileView = NewFileView(app)
+	app.promptView = NewPromptView(app)
+	app.syncConflictView = NewSyncConflictView(app)
+	app.backlinksView = NewBacklinksView(app, linkService)
+	app.notesPageView = NewNotesPageView(app, notesService)
+	app.codexView = NewCodexView(app, codexService, graphService)
+
+	// Changes to sheet values are recorded against the session being played
+	attrService.SetActiveSession(func() *int64 { return app.sessionView.currentSessionID })
 
 	app.setupUI()
 	return app
@@ -168,8 +241,19 @@ func (a *App) setupUI() {
 		AddPage(GAME_MODAL_ID, a.gameView.Modal, true, false).
 		AddPage(CHARACTER_MODAL_ID, a.characterView.Modal, true, false).
 		AddPage(ATTRIBUTE_MODAL_ID, a.attributeView.Modal, true, false).
+		AddPage(ATTRIBUTE_HISTORY_MODAL_ID, a.historyView.Modal, true, false).
 		AddPage(SESSION_MODAL_ID, a.sessionView.Modal, true, false).
+		AddPage(SESSION_MOVE_MODAL_ID, a.moveView.Modal, true, false).
 		AddPage(TAG_MODAL_ID, a.tagView.Modal, true, false).
+		AddPage(TAG_TIMELINE_MODAL_ID, a.timelineView.Modal, true, false).
+		AddPage(BACKLINKS_MODAL_ID, a.backlinksView.Modal, true, false).
+		AddPage(NOTES_PAGE_MODAL_ID, a.notesPageView.Modal, true, false).
+		AddPage(CODEX_MODAL_ID, a.codexView.Modal, true, false).
+		AddPage(CODEX_FORM_MODAL_ID, a.codexView.FormModal, true, false).
+		AddPage(CODEX_RELATION_MODAL_ID, a.codexView.RelationModal, true, false).
+		AddPage(CLOCK_MODAL_ID, a.clockView.Modal, true, false).
+		AddPage(RECAP_MODAL_ID, a.recapView.Modal, true, false).
+		AddPage(TRASH_MODAL_ID, a.trashView.Modal, true, false).
 		AddPage(DICE_MODAL_ID, a.diceView.Modal, true, false).
 		AddPage(SEARCH_MODAL_ID, a.searchView.Modal, true, false).
 		AddPage(ORACLE_MODAL_ID, a.oracleView.Modal, true, false).
@@ -177,6 +261,8 @@ func (a *App) setupUI() {
 		AddPage(SNIPPET_MODAL_ID, a.snippetView.Modal, true, false).
 		AddPage(SNIPPET_FORM_MODAL_ID, a.snippetView.FormModal, true, false).
 		AddPage(FILE_MODAL_ID, a.fileView.Modal, true, false).
+		AddPage(PROMPT_MODAL_ID, a.promptView.Modal, true, false).
+		AddPage(SYNC_CONFLICT_MODAL_ID, a.syncConflictView.Modal, true, false).
 		AddPage(HELP_MODAL_ID, a.helpModal, true, false).
 		AddPage(CONFIRM_MODAL_ID, a.confirmModal, true, false) // Confirm always on top
 	// a.pages.SetBackgroundColor(tcell.ColorDefault)
@@ -228,7 +314,7 @@ func (a *App) SetModalHelpMessage(form sharedui.DataForm) {
 
 func (a *App) setupKeyBindings() {
 	a.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
-		switchable := []tview.Primitive{a.gameView.Tree, a.sessionView.TextArea, a.characterView.CharTree, a.attributeView.Table}
+		switchable := []tview.Primitive{a.gameView.Tree, a.sessionView.TextArea, a.sessionView.Preview, a.characterView.CharTree, a.attributeView.Table}
 		switch event.Key() {
 		case tcell.KeyCtrlP:
 			if !a.isPageVisible(ORACLE_MODAL_ID) {
@@ -282,6 +368,12 @@ func (a *App) setupKeyBindings() {
 				a.SetFocus(a.sessionView.TextArea)
 				return nil
 			case a.sessionView.TextArea:
+				if a.sessionView.acceptFirstHint() {
+					return nil
+				}
+				a.SetFocus(a.characterView.CharTree)
+				return nil
+			case a.sessionView.Preview:
 				a.SetFocus(a.characterView.CharTree)
 				return nil
 			case a.characterView.CharTree:
@@ -299,7 +391,7 @@ func (a *App) setupKeyBindings() {
 			case a.gameView.Tree:
 				a.SetFocus(a.attributeView.Table)
 				return nil
-			case a.sessionView.TextArea:
+			case a.sessionView.TextArea, a.sessionView.Preview:
 				a.SetFocus(a.gameView.Tree)
 				return nil
 			case a.characterView.CharTree:
@@ -344,22 +436,63 @@ func (a *App) CurrentSession() *session.Session {
 	return a.sessionView.currentSession
 }
 
+// openAt loads a session, or a notes page of the current game when pageID is
+// set, focuses the editor and selects length bytes starting at offset.
+func (a *App) openAt(sessionID, pageID int64, offset, length int) {
+	if pageID != 0 {
+		g := a.CurrentGame()
+		if g == nil {
+			return
+		}
+		a.HandleEvent(&GameNotesSelectedEvent{
+			BaseEvent: BaseEvent{action: GAME_NOTES_SELECTED},
+			GameID:    g.ID,
+			PageID:    pageID,
+		})
+	} else {
+		// Load the session and highlight it in the tree
+		a.sessionView.SelectSession(sessionID)
+		a.gameView.SelectSession(sessionID)
+	}
+
+	// Selecting a match needs the editor
+	a.sessionView.closePreview()
+	a.SetFocus(a.sessionView.TextArea)
+
+	// Defer Select to after the TextArea has rendered the new content.
+	// Calling Select immediately after SetText uses stale layout and misplaces
+	// the cursor (especially on the last line). QueueUpdateDraw must be called
+	// from a goroutine — calling it from the main event goroutine deadlocks.
+	//
+	// This was a big work around to get it to function properly
+	go a.QueueUpdateDraw(func() {
+		ta := a.sessionView.TextArea
+		a.sessionView.selectAtTop(offset, length)
+		// Re-apply the selection in the next draw cycle. When switching sessions
+		// SetText resets lineStarts; if reset() fires in this draw (e.g. due to
+		// a width change after the modal hides), findCursor collapses
+		// selectionStart=cursor. A second Select re-establishes it after the
+		// layout has stabilised. The scroll from SetOffset survives either way.
+		go a.QueueUpdateDraw(func() {
+			ta.Select(offset, offset+length)
+		})
+	})
+}
+
 func (a *App) Autosave() {
 	sv := a.sessionView
 	if !sv.isDirty {
 		return
 	}
 	if sv.IsNotesMode() {
-		g := a.CurrentGame()
-		if g == nil {
-			return
-		}
-		content := sv.TextArea.GetText()
-		if err := a.gameView.gameService.SaveNotes(g.ID, content); err != nil {
-			a.notification.ShowError(fmt.Sprintf("Autosave failed: %v", err))
-			return
+		sv.currentPage.Content = sv.TextArea.GetText()
+		if _, err := a.notesService.Save(sv.currentPage); err != nil {
+			if !isIndexingError(err) {
+				a.notification.ShowError(fmt.Sprintf("Autosave failed: %v", err))
+				return
+			}
+			a.showIndexingWarning(err)
 		}
-		g.Notes = content
 		sv.isDirty = false
 		sv.updateTitle()
 		sv.stopAutosave()
@@ -370,14 +503,28 @@ func (a *App) Autosave() {
 	}
 	sv.currentSession.Content = sv.TextArea.GetText()
 	if _, err := sv.sessionService.Save(sv.currentSession); err != nil {
-		a.notification.ShowError(fmt.Sprintf("Autosave failed: %v", err))
-		return
+		if !isIndexingError(err) {
+			a.notification.ShowError(fmt.Sprintf("Autosave failed: %v", err))
+			return
+		}
+		a.showIndexingWarning(err)
 	}
 	sv.isDirty = false
 	sv.updateTitle()
 	sv.stopAutosave()
 }
 
+// isIndexingError reports whether err only means that a session or notes page
+// was saved but the tag and link index wasn't updated
+func isIndexingError(err error) bool {
+	return errors.Is(err, session.ErrIndexing) || errors.Is(err, notes.ErrIndexing)
+}
+
+// showIndexingWarning warns that a change was saved but the index is out of date
+func (a *App) showIndexingWarning(err error) {
+	a.notification.ShowWarning(fmt.Sprintf("Saved, but the tag index wasn't updated (press r in the tag list to rebuild it): %v", err))
+}
+
 func (a *App) GetSelectedCharacterID() *int64 {
 	return a.characterView.GetSelectedCharacterID()
 }
@@ -400,6 +547,52 @@ func (a *App) HandleEvent(event Event) {
 		dispatch(event, a.handleGameShowNew)
 	case GAME_NOTES_SELECTED:
 		dispatch(event, a.handleGameNotesSelected)
+	case NOTES_PAGE_SHOW_NEW:
+		dispatch(event, a.handleNotesPageShowNew)
+	case NOTES_PAGE_SHOW_EDIT:
+		dispatch(event, a.handleNotesPageShowEdit)
+	case NOTES_PAGE_SAVED:
+		dispatch(event, a.handleNotesPageSaved)
+	case NOTES_PAGE_CANCEL:
+		dispatch(event, a.handleNotesPageCancel)
+	case NOTES_PAGE_DELETE_CONFIRM:
+		dispatch(event, a.handleNotesPageDeleteConfirm)
+	case NOTES_PAGE_DELETED:
+		dispatch(event, a.handleNotesPageDeleted)
+	case NOTES_PAGE_DELETE_FAILED:
+		dispatch(event, a.handleNotesPageDeleteFailed)
+	case CODEX_SHOW:
+		dispatch(event, a.handleCodexShow)
+	case CODEX_CANCEL:
+		dispatch(event, a.handleCodexCancel)
+	case CODEX_TAG_FOLLOW:
+		dispatch(event, a.handleCodexTagFollow)
+	case CODEX_APPEARANCE_SELECT:
+		dispatch(event, a.handleCodexAppearanceSelect)
+	case CODEX_ENTRY_SHOW_NEW:
+		dispatch(event, a.handleCodexEntryShowNew)
+	case CODEX_ENTRY_SHOW_EDIT:
+		dispatch(event, a.handleCodexEntryShowEdit)
+	case CODEX_ENTRY_FORM_CANCEL:
+		dispatch(event, a.handleCodexEntryFormCancel)
+	case CODEX_ENTRY_SAVED:
+		dispatch(event, a.handleCodexEntrySaved)
+	case CODEX_ENTRY_DELETE_CONFIRM:
+		dispatch(event, a.handleCodexEntryDeleteConfirm)
+	case CODEX_ENTRY_DELETED:
+		dispatch(event, a.handleCodexEntryDeleted)
+	case CODEX_ENTRY_DELETE_FAILED:
+		dispatch(event, a.handleCodexEntryDeleteFailed)
+	case CODEX_RELATION_SHOW_NEW:
+		dispatch(event, a.handleCodexRelationShowNew)
+	case CODEX_RELATION_FORM_CANCEL:
+		dispatch(event, a.handleCodexRelationFormCancel)
+	case CODEX_RELATION_SAVED:
+		dispatch(event, a.handleCodexRelationSaved)
+	case CODEX_RELATION_DELETE_CONFIRM:
+		dispatch(event, a.handleCodexRelationDeleteConfirm)
+	case CODEX_RELATION_DELETED:
+		dispatch(event, a.handleCodexRelationDeleted)
 	case CHARACTER_SAVED:
 		dispatch(event, a.handleCharacterSaved)
 	case CHARACTER_CANCEL:
@@ -420,6 +613,10 @@ func (a *App) HandleEvent(event Event) {
 		dispatch(event, a.handleCharacterShowNew)
 	case CHARACTER_SHOW_EDIT:
 		dispatch(event, a.handleCharacterShowEdit)
+	case CHARACTER_SHOW_IMPORT:
+		dispatch(event, a.handleCharacterShowImport)
+	case CHARACTER_SHOW_EXPORT:
+		dispatch(event, a.handleCharacterShowExport)
 	case ATTRIBUTE_SAVED:
 		dispatch(event, a.handleAttributeSaved)
 	case ATTRIBUTE_CANCEL:
@@ -436,12 +633,26 @@ func (a *App) HandleEvent(event Event) {
 		dispatch(event, a.handleAttributeShowEdit)
 	case ATTRIBUTE_REORDER:
 		dispatch(event, a.handleAttributeReorder)
+	case ATTRIBUTE_ADJUST:
+		dispatch(event, a.handleAttributeAdjust)
+	case ATTRIBUTE_HISTORY_SHOW:
+		dispatch(event, a.handleAttributeHistoryShow)
+	case ATTRIBUTE_HISTORY_CANCEL:
+		dispatch(event, a.handleAttributeHistoryCancel)
+	case ATTRIBUTE_HISTORY_SELECT:
+		dispatch(event, a.handleAttributeHistorySelect)
 	case TAG_SELECTED:
 		dispatch(event, a.handleTagSelected)
 	case TAG_CANCEL:
 		dispatch(event, a.handleTagCancelled)
 	case TAG_SHOW:
 		dispatch(event, a.handleTagShow)
+	case TAG_TIMELINE_SHOW:
+		dispatch(event, a.handleTagTimelineShow)
+	case TAG_TIMELINE_CANCEL:
+		dispatch(event, a.handleTagTimelineCancel)
+	case TAG_TIMELINE_SELECT:
+		dispatch(event, a.handleTagTimelineSelect)
 	case SHOW_HELP:
 		dispatch(event, a.handleShowHelp)
 	case CLOSE_HELP:
@@ -466,6 +677,24 @@ func (a *App) HandleEvent(event Event) {
 		dispatch(event, a.handleSessionShowImport)
 	case SESSION_SHOW_EXPORT:
 		dispatch(event, a.handleSessionShowExport)
+	case SESSION_SHOW_MOVE:
+		dispatch(event, a.handleSessionShowMove)
+	case SESSION_MOVE_CANCEL:
+		dispatch(event, a.handleSessionMoveCancelled)
+	case SESSION_MOVED:
+		dispatch(event, a.handleSessionMoved)
+	case SESSION_SPLIT_CONFIRM:
+		dispatch(event, a.handleSessionSplitConfirm)
+	case SESSION_SPLIT:
+		dispatch(event, a.handleSessionSplit)
+	case SESSION_MERGE_CONFIRM:
+		dispatch(event, a.handleSessionMergeConfirm)
+	case SESSION_MERGED:
+		dispatch(event, a.handleSessionMerged)
+	case SESSION_REORDER:
+		dispatch(event, a.handleSessionReorder)
+	case SESSION_EDITOR_CONFLICT:
+		dispatch(event, a.handleSessionEditorConflict)
 	case FILE_IMPORT:
 		dispatch(event, a.handleFileImport)
 	case FILE_EXPORT:
@@ -532,5 +761,43 @@ func (a *App) HandleEvent(event Event) {
 		dispatch(event, a.handleSnippetReorder)
 	case SNIPPET_USE:
 		dispatch(event, a.handleSnippetUse)
+	case CLOCK_SHOW:
+		dispatch(event, a.handleClockShow)
+	case CLOCK_CANCEL:
+		dispatch(event, a.handleClockCancel)
+	case CLOCK_ADJUST:
+		dispatch(event, a.handleClockAdjust)
+	case RECAP_SHOW:
+		dispatch(event, a.handleRecapShow)
+	case RECAP_CANCEL:
+		dispatch(event, a.handleRecapCancel)
+	case RECAP_INSERT:
+		dispatch(event, a.handleRecapInsert)
+	case TRASH_SHOW:
+		dispatch(event, a.handleTrashShow)
+	case TRASH_CANCEL:
+		dispatch(event, a.handleTrashCancel)
+	case TRASH_RESTORE:
+		dispatch(event, a.handleTrashRestore)
+	case TRASH_PURGE_CONFIRM:
+		dispatch(event, a.handleTrashPurgeConfirm)
+	case LINK_FOLLOW:
+		dispatch(event, a.handleLinkFollow)
+	case BACKLINKS_SHOW:
+		dispatch(event, a.handleBacklinksShow)
+	case BACKLINKS_CANCEL:
+		dispatch(event, a.handleBacklinksCancel)
+	case BACKLINKS_SELECT:
+		dispatch(event, a.handleBacklinksSelect)
+	case SYNC_NOW:
+		dispatch(event, a.handleSyncNow)
+	case SYNC_CONFLICT_RESOLVE:
+		dispatch(event, a.handleSyncConflictResolve)
+	case SYNC_CONFLICT_CANCEL:
+		dispatch(event, a.handleSyncConflictCancel)
+	case PROMPT_SUBMIT:
+		dispatch(event, a.handlePromptSubmit)
+	case PROMPT_CANCEL:
+		dispatch(event, a.handlePromptCancel)
 	}
 }
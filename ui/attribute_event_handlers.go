@@ -1,7 +1,9 @@
 package ui
 
 import (
+	"errors"
 	"log"
+	"soloterm/domain/character"
 )
 
 func (a *App) handleAttributeSaved(e *AttributeSavedEvent) {
@@ -10,6 +12,7 @@ func (a *App) handleAttributeSaved(e *AttributeSavedEvent) {
 	a.characterView.RefreshDisplay()
 	a.attributeView.Select(e.Attribute.ID)
 	a.SetFocus(a.attributeView.Table)
+	a.logAttributeChange(e.Attribute, e.Change)
 	a.notification.ShowSuccess("Entry saved successfully")
 }
 
@@ -92,3 +95,52 @@ func (a *App) handleAttributeReorder(e *AttributeReorderEvent) {
 	}
 	a.SetFocus(a.attributeView.Table)
 }
+
+func (a *App) handleAttributeAdjust(e *AttributeAdjustEvent) {
+	adjusted, change, err := a.attributeView.attrService.Adjust(e.AttributeID, e.Delta)
+	if err != nil {
+		if errors.Is(err, character.ErrNotNumeric) {
+			a.notification.ShowWarning("Edit the entry and set its Type to Number to adjust it with + and -")
+			return
+		}
+		log.Printf("Failed to adjust the entry: %s", err)
+		a.notification.ShowError("Failed to adjust entry: " + err.Error())
+		return
+	}
+	a.characterView.RefreshDisplay()
+	a.attributeView.Select(adjusted.ID)
+	a.SetFocus(a.attributeView.Table)
+	a.logAttributeChange(adjusted, change)
+}
+
+func (a *App) handleAttributeHistoryShow(e *AttributeHistoryShowEvent) {
+	a.historyView.Load(e.Attribute)
+	a.pages.ShowPage(ATTRIBUTE_HISTORY_MODAL_ID)
+	a.SetFocus(a.historyView.Table)
+}
+
+func (a *App) handleAttributeHistoryCancel(_ *AttributeHistoryCancelEvent) {
+	a.pages.HidePage(ATTRIBUTE_HISTORY_MODAL_ID)
+	a.SetFocus(a.attributeView.Table)
+}
+
+func (a *App) handleAttributeHistorySelect(e *AttributeHistorySelectEvent) {
+	a.pages.HidePage(ATTRIBUTE_HISTORY_MODAL_ID)
+	a.openAt(*e.Change.SessionID, 0, 0, 0)
+}
+
+// logAttributeChange inserts the change as a tag line, such as
+// [PC:Kira | HP 5→3], into the open session when sheet_change_tags is on
+func (a *App) logAttributeChange(attr *character.Attribute, change *character.AttributeChange) {
+	if change == nil || !a.cfg.SheetChangeTags || a.sessionView.currentSession == nil {
+		return
+	}
+
+	char, err := a.characterView.charService.GetByID(attr.CharacterID)
+	if err != nil {
+		log.Printf("Failed to load the character to log the change: %s", err)
+		return
+	}
+	a.sessionView.InsertLineAtCursor(change.Tag(char.Name))
+	a.Autosave()
+}
@@ -3,6 +3,9 @@ package ui
 import (
 	"soloterm/domain/character"
 	sharedui "soloterm/shared/ui"
+	"soloterm/shared/validation"
+	"strconv"
+	"strings"
 
 	"github.com/gdamore/tcell/v2"
 	"github.com/rivo/tview"
@@ -16,6 +19,9 @@ type AttributeForm struct {
 	characterID      int64
 	nameField        *tview.InputField
 	valueField       *tview.InputField
+	typeDropDown     *tview.DropDown
+	floorField       *tview.InputField
+	ceilingField     *tview.InputField
 	groupDropDown    *tview.DropDown
 	groupHeaders     []*character.Attribute // first attr per group, in display order
 	maxGroupNum      int                    // max group number across current attrs; -1 if none
@@ -39,6 +45,23 @@ func NewAttributeForm() *AttributeForm {
 		SetFieldBackgroundColor(tcell.ColorDefault).
 		SetFieldWidth(0)
 
+	af.typeDropDown = tview.NewDropDown().
+		SetLabel("Type").
+		SetOptions([]string{"Text", "Number"}, nil).
+		SetFieldBackgroundColor(tcell.ColorDefault)
+
+	af.floorField = tview.NewInputField().
+		SetLabel("Floor").
+		SetFieldBackgroundColor(tcell.ColorDefault).
+		SetAcceptanceFunc(tview.InputFieldInteger).
+		SetFieldWidth(0)
+
+	af.ceilingField = tview.NewInputField().
+		SetLabel("Ceiling").
+		SetFieldBackgroundColor(tcell.ColorDefault).
+		SetAcceptanceFunc(tview.InputFieldInteger).
+		SetFieldWidth(0)
+
 	af.groupDropDown = tview.NewDropDown().
 		SetLabel("Section").
 		SetFieldBackgroundColor(tcell.ColorDefault)
@@ -100,6 +123,18 @@ func (af *AttributeForm) setupFieldFocusHandlers() {
 		af.NotifyHelpTextChange("[" + Style.HelpKeyTextColor + "]Value:[" + Style.NormalTextColor + "] The current value of this entry (e.g., 18, 50/100, +5)")
 	})
 
+	af.typeDropDown.SetFocusFunc(func() {
+		af.NotifyHelpTextChange("[" + Style.HelpKeyTextColor + "]Type:[" + Style.NormalTextColor + "] Number entries hold a value like 3 or 7/12 (current/max) that + and - adjust on the sheet.")
+	})
+
+	af.floorField.SetFocusFunc(func() {
+		af.NotifyHelpTextChange("[" + Style.HelpKeyTextColor + "]Floor:[" + Style.NormalTextColor + "] The lowest value of a Number entry (e.g., 0). Leave blank for none.")
+	})
+
+	af.ceilingField.SetFocusFunc(func() {
+		af.NotifyHelpTextChange("[" + Style.HelpKeyTextColor + "]Ceiling:[" + Style.NormalTextColor + "] The highest value of a Number entry (e.g., 20). Leave blank for none.")
+	})
+
 	af.groupDropDown.SetFocusFunc(func() {
 		af.NotifyHelpTextChange("[" + Style.HelpKeyTextColor + "]Section:[" + Style.NormalTextColor + "] Choose an existing section to append to, or \"- New -\" to create a standalone section at the bottom of the sheet.")
 	})
@@ -112,14 +147,22 @@ func (af *AttributeForm) Reset(characterID int64, attrs []*character.Attribute)
 	af.characterID = characterID
 	af.nameField.SetText("")
 	af.valueField.SetText("")
+	af.typeDropDown.SetCurrentOption(0)
+	af.floorField.SetText("")
+	af.ceilingField.SetText("")
 	af.buildGroupHeaders(attrs)
-	af.rebuildItems([]tview.FormItem{af.nameField, af.valueField, af.groupDropDown})
+	af.rebuildItems(af.items())
 	af.RemoveDeleteButton()
 	af.ClearFieldErrors()
 	af.SetFocus(0)
 	af.NotifyHelpTextChange("[" + Style.HelpKeyTextColor + "]Name:[" + Style.NormalTextColor + "] The display name for this entry (e.g., Strength, HP, Armor Class)")
 }
 
+// items returns the form items in display order
+func (af *AttributeForm) items() []tview.FormItem {
+	return []tview.FormItem{af.nameField, af.valueField, af.typeDropDown, af.floorField, af.ceilingField, af.groupDropDown}
+}
+
 // SelectGroup pre-selects the dropdown to the option matching groupNum.
 // Called after Reset when there is a currently selected attribute.
 func (af *AttributeForm) SelectGroup(groupNum int) {
@@ -140,8 +183,15 @@ func (af *AttributeForm) PopulateForEdit(attr *character.Attribute, attrs []*cha
 	af.originalPosition = attr.PositionInGroup
 	af.nameField.SetText(attr.Name)
 	af.valueField.SetText(attr.Value)
+	if attr.Numeric {
+		af.typeDropDown.SetCurrentOption(1)
+	} else {
+		af.typeDropDown.SetCurrentOption(0)
+	}
+	af.floorField.SetText(formatBound(attr.Floor))
+	af.ceilingField.SetText(formatBound(attr.Ceiling))
 	af.buildGroupHeaders(attrs)
-	af.rebuildItems([]tview.FormItem{af.nameField, af.valueField, af.groupDropDown})
+	af.rebuildItems(af.items())
 	af.SelectGroup(attr.Group)
 	af.AddDeleteButton()
 	af.ClearFieldErrors()
@@ -168,6 +218,18 @@ func (af *AttributeForm) updateFieldLabels() {
 	} else {
 		af.valueField.SetLabel("Value")
 	}
+
+	if af.HasFieldError("floor") {
+		af.floorField.SetLabel("[" + Style.ErrorTextColor + "]Floor[" + Style.NormalTextColor + "]")
+	} else {
+		af.floorField.SetLabel("Floor")
+	}
+
+	if af.HasFieldError("ceiling") {
+		af.ceilingField.SetLabel("[" + Style.ErrorTextColor + "]Ceiling[" + Style.NormalTextColor + "]")
+	} else {
+		af.ceilingField.SetLabel("Ceiling")
+	}
 }
 
 // ClearFieldErrors removes all error highlights
@@ -205,6 +267,10 @@ func (af *AttributeForm) BuildDomain() *character.Attribute {
 		Group:           group,
 		PositionInGroup: position,
 	}
+	typeIdx, _ := af.typeDropDown.GetCurrentOption()
+	attr.Numeric = typeIdx == 1
+	attr.Floor, _ = parseBound(af.floorField.GetText())
+	attr.Ceiling, _ = parseBound(af.ceilingField.GetText())
 
 	if af.attributeID != nil {
 		attr.ID = *af.attributeID
@@ -212,3 +278,37 @@ func (af *AttributeForm) BuildDomain() *character.Attribute {
 
 	return attr
 }
+
+// ValidateBounds checks the floor and ceiling fields hold whole numbers. The
+// fields accept partial input such as "-" while typing, which BuildDomain
+// can't turn into a bound.
+func (af *AttributeForm) ValidateBounds() *validation.Validator {
+	v := validation.NewValidator()
+	_, ok := parseBound(af.floorField.GetText())
+	v.Check("floor", ok, "must be a whole number, or blank for none")
+	_, ok = parseBound(af.ceilingField.GetText())
+	v.Check("ceiling", ok, "must be a whole number, or blank for none")
+	return v
+}
+
+// parseBound reads a floor or ceiling field. A blank field is no bound; any
+// other text that isn't a whole number is not ok.
+func parseBound(text string) (*int, bool) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return nil, true
+	}
+	n, err := strconv.Atoi(text)
+	if err != nil {
+		return nil, false
+	}
+	return &n, true
+}
+
+// formatBound fills a floor or ceiling field, leaving it blank for none
+func formatBound(bound *int) string {
+	if bound == nil {
+		return ""
+	}
+	return strconv.Itoa(*bound)
+}
@@ -0,0 +1,147 @@
+package ui
+
+import (
+	"fmt"
+	"soloterm/domain/character"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// AttributeHistoryView shows the changes to a sheet entry's value and the
+// sessions they were made in
+type AttributeHistoryView struct {
+	app          *App
+	attrService  *character.AttributeService
+	Modal        *tview.Flex
+	historyFrame *tview.Frame
+	Table        *tview.Table
+	changes      []*character.AttributeChange
+}
+
+// NewAttributeHistoryView creates a new attribute history view
+func NewAttributeHistoryView(app *App, attrService *character.AttributeService) *AttributeHistoryView {
+	historyView := &AttributeHistoryView{app: app, attrService: attrService}
+	historyView.Setup()
+	return historyView
+}
+
+// Setup initializes all history UI components
+func (hv *AttributeHistoryView) Setup() {
+	hv.setupModal()
+	hv.setupKeyBindings()
+}
+
+func (hv *AttributeHistoryView) setupModal() {
+	hv.Table = tview.NewTable().
+		SetBorders(false).
+		SetSelectable(true, false).
+		SetFixed(1, 0)
+	hv.Table.SetSelectedStyle(tcell.Style{}.Background(tcell.ColorAqua).Foreground(tcell.ColorBlack))
+
+	hv.historyFrame = tview.NewFrame(hv.Table).
+		SetBorders(1, 1, 0, 0, 1, 1)
+	hv.historyFrame.SetBorder(true).
+		SetTitleAlign(tview.AlignLeft)
+
+	hv.Modal = tview.NewFlex().
+		AddItem(nil, 0, 1, false).
+		AddItem(
+			tview.NewFlex().
+				SetDirection(tview.FlexRow).
+				AddItem(nil, 0, 1, false).
+				AddItem(hv.historyFrame, 0, 4, true).
+				AddItem(nil, 0, 1, false),
+			0, 4, true,
+		).
+		AddItem(nil, 0, 1, false)
+
+	hv.Table.SetFocusFunc(func() {
+		hv.app.updateFooterHelp(helpBar("History", []helpEntry{
+			{"↑/↓", "Navigate"},
+			{"Enter", "Go To Session"},
+			{"Esc", "Back"},
+		}))
+		hv.historyFrame.SetBorderColor(Style.BorderFocusColor)
+	})
+	hv.Table.SetBlurFunc(func() {
+		hv.historyFrame.SetBorderColor(Style.BorderColor)
+	})
+}
+
+func (hv *AttributeHistoryView) setupKeyBindings() {
+	hv.Modal.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEsc {
+			hv.app.HandleEvent(&AttributeHistoryCancelEvent{
+				BaseEvent: BaseEvent{action: ATTRIBUTE_HISTORY_CANCEL},
+			})
+			return nil
+		}
+		return event
+	})
+
+	hv.Table.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEnter {
+			if change := hv.Selected(); change != nil && change.SessionID != nil {
+				hv.app.HandleEvent(&AttributeHistorySelectEvent{
+					BaseEvent: BaseEvent{action: ATTRIBUTE_HISTORY_SELECT},
+					Change:    change,
+				})
+			}
+			return nil
+		}
+		return event
+	})
+}
+
+// Load fetches the changes to the attribute and renders them, newest first
+func (hv *AttributeHistoryView) Load(attr *character.Attribute) {
+	hv.historyFrame.SetTitle("[::b] " + tview.Escape(attr.Name) + " History ([" + Style.HelpKeyTextColor + "]Esc[" + Style.NormalTextColor + "] Back) [-::-]")
+
+	changes, err := hv.attrService.History(attr.ID)
+	if err != nil {
+		hv.app.notification.ShowError(fmt.Sprintf("Error loading history: %v", err))
+	}
+	hv.changes = changes
+
+	hv.Table.Clear()
+	for col, label := range []string{"When", "Session", "Change"} {
+		hv.Table.SetCell(0, col, tview.NewTableCell(label).
+			SetTextColor(tcell.ColorYellow).
+			SetAlign(tview.AlignLeft).
+			SetSelectable(false))
+	}
+
+	if len(hv.changes) == 0 {
+		hv.Table.SetCell(1, 0, tview.NewTableCell("(No changes yet)").
+			SetTextColor(Style.EmptyStateMessageColor).
+			SetSelectable(false))
+		return
+	}
+
+	for i, c := range hv.changes {
+		row := i + 1
+		sessionName := c.SessionName
+		if c.SessionID == nil {
+			sessionName = "-"
+		}
+		hv.Table.SetCell(row, 0, tview.NewTableCell(c.CreatedAt.Local().Format("2006-01-02 15:04")).
+			SetTextColor(tcell.ColorWhite))
+		hv.Table.SetCell(row, 1, tview.NewTableCell(tview.Escape(sessionName)).
+			SetTextColor(tcell.ColorWhite).
+			SetMaxWidth(25))
+		hv.Table.SetCell(row, 2, tview.NewTableCell(tview.Escape(c.OldValue+" → "+c.NewValue)).
+			SetTextColor(tcell.ColorWhite).
+			SetExpansion(1))
+	}
+	hv.Table.Select(1, 0)
+}
+
+// Selected returns the change on the selected row, or nil if there is none
+func (hv *AttributeHistoryView) Selected() *character.AttributeChange {
+	row, _ := hv.Table.GetSelection()
+	if row < 1 || row > len(hv.changes) {
+		return nil
+	}
+	return hv.changes[row-1]
+}
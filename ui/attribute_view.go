@@ -93,6 +93,22 @@ func (av *AttributeView) setupTable() {
 					})
 				}
 				return nil
+			case 'h':
+				attr := av.GetSelected()
+				if attr != nil {
+					av.app.HandleEvent(&AttributeHistoryShowEvent{
+						BaseEvent: BaseEvent{action: ATTRIBUTE_HISTORY_SHOW},
+						Attribute: attr,
+					})
+				}
+				return nil
+			// +/-: step a number entry in place. = is + without shift.
+			case '+', '=':
+				av.adjustSelected(1)
+				return nil
+			case '-':
+				av.adjustSelected(-1)
+				return nil
 			}
 		}
 
@@ -109,7 +125,7 @@ func (av *AttributeView) setupModal() {
 		av.HandleDelete,
 	)
 
-	av.formModal = sharedui.NewFormModal(av.Form, 11).SetHelpRows(3)
+	av.formModal = sharedui.NewFormModal(av.Form, 17).SetHelpRows(3)
 	av.Modal = av.formModal.Modal
 
 	av.Form.SetFocusFunc(func() {
@@ -122,6 +138,19 @@ func (av *AttributeView) setupModal() {
 	})
 }
 
+// adjustSelected steps the selected number entry by delta
+func (av *AttributeView) adjustSelected(delta int) {
+	attr := av.GetSelected()
+	if attr == nil {
+		return
+	}
+	av.app.HandleEvent(&AttributeAdjustEvent{
+		BaseEvent:   BaseEvent{action: ATTRIBUTE_ADJUST},
+		AttributeID: attr.ID,
+		Delta:       delta,
+	})
+}
+
 // LoadAndDisplay loads and displays attributes for a character
 func (av *AttributeView) LoadAndDisplay(characterID int64) {
 	// Load attributes for this character
@@ -210,10 +239,14 @@ func (av *AttributeView) GetSelected() *character.Attribute {
 
 // HandleSave saves the attribute from the form
 func (av *AttributeView) HandleSave() {
+	if v := av.Form.ValidateBounds(); v.HasErrors() {
+		sharedui.HandleValidationError(v, av.Form)
+		return
+	}
 	attr := av.Form.BuildDomain()
 
 	// Validate and save
-	savedAttr, err := av.attrService.Save(attr)
+	savedAttr, change, err := av.attrService.SaveWithChange(attr)
 	if err != nil {
 		// Check if it's a validation error
 		if sharedui.HandleValidationError(err, av.Form) {
@@ -227,6 +260,7 @@ func (av *AttributeView) HandleSave() {
 	av.app.HandleEvent(&AttributeSavedEvent{
 		BaseEvent: BaseEvent{action: ATTRIBUTE_SAVED},
 		Attribute: savedAttr,
+		Change:    change,
 	})
 }
 
@@ -323,11 +357,25 @@ It's recommended to only track the bare essentials like health, or key pieces of
 
 [yellow]Name[white]: Name of the entry (HP, XP, Level). May be the name of a section header (Skills, Gear, Stats)
 [yellow]Value[white]: Value to assign to the entry (10/10, 2, +2). It may be blank.
+[yellow]Type[white]: Text, or Number for values like 3 or 7/12 (current/max) that can be adjusted in place.
+[yellow]Floor / Ceiling[white]: The lowest and highest value of a Number entry. Leave blank for none.
 [yellow]Section[white]: Pick "- New -" to create a new section or pick an existing section to add the entry too.
 
 [yellow]n[white]  Add a new entry
 [yellow]e[white]  Edit the selected entry
 
+[green]Adjusting Numbers[white]
+
+[yellow]+ / -[white]  Add or remove 1 from the selected Number entry.
+
+The current value never goes below the floor or above the ceiling, and stops at its max (the 12 in 7/12).
+
+[green]History[white]
+
+[yellow]h[white]  Show the changes to the selected entry's value
+
+Every change to a value is recorded with the session open at the time. Press Enter on a change to open its session. Set sheet_change_tags to true in the config file to also insert each change into the open session as a tag, such as [PC:Kira | HP 5→3].
+
 [green]Moving Entries[white]
 
 [yellow]u / d[white]  Move entry up or down.
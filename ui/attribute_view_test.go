@@ -365,3 +365,127 @@ func TestAttributeView_EditAttr_ChangingGroupAppendsToNewGroup(t *testing.T) {
 	assert.Equal(t, beta.Group, gammaAfter.Group, "Gamma should now be in Beta's group")
 	assert.Equal(t, 1, gammaAfter.PositionInGroup, "Gamma should be appended after Beta (pos 1)")
 }
+
+// --- +/- on a number entry: adjust in place ---
+
+func TestAttributeView_PlusMinus_AdjustsNumberEntry(t *testing.T) {
+	app := setupTestApp(t)
+	char := createCharacter(t, app, "Hero")
+
+	// Create HP as a number entry with a floor of 0
+	testHelper.SimulateRune(app.attributeView.Table, app.Application, 'n')
+	app.attributeView.Form.nameField.SetText("HP")
+	app.attributeView.Form.valueField.SetText("1/12")
+	app.attributeView.Form.typeDropDown.SetCurrentOption(1)
+	app.attributeView.Form.floorField.SetText("0")
+	testHelper.SimulateKey(app.attributeView.Form, app.Application, tcell.KeyCtrlS)
+	require.False(t, app.isPageVisible(ATTRIBUTE_MODAL_ID), "Expected attribute modal to close after save")
+
+	hp := reloadAttrs(t, app, char.ID)[0]
+	require.True(t, hp.Numeric)
+	app.attributeView.Select(hp.ID)
+
+	testHelper.SimulateRune(app.attributeView.Table, app.Application, '+')
+	assert.Equal(t, "2/12", reloadAttrs(t, app, char.ID)[0].Value)
+
+	testHelper.SimulateRune(app.attributeView.Table, app.Application, '-')
+	testHelper.SimulateRune(app.attributeView.Table, app.Application, '-')
+	testHelper.SimulateRune(app.attributeView.Table, app.Application, '-')
+	assert.Equal(t, "0/12", reloadAttrs(t, app, char.ID)[0].Value, "HP should stop at its floor")
+	assert.Equal(t, "0/12", app.attributeView.Table.GetCell(1, 1).Text, "The table should show the new value")
+}
+
+func TestAttributeView_PlusMinus_TextEntryIsUnchanged(t *testing.T) {
+	app := setupTestApp(t)
+	char := createCharacter(t, app, "Hero")
+
+	gear := createAttr(t, app, char.ID, "Gear", 0)
+	app.attributeView.Select(gear.ID)
+	testHelper.SimulateRune(app.attributeView.Table, app.Application, '+')
+
+	assert.Equal(t, "val", reloadAttrs(t, app, char.ID)[0].Value)
+}
+
+func TestAttributeView_NumberEntryOutsideBoundsIsRejected(t *testing.T) {
+	app := setupTestApp(t)
+	createCharacter(t, app, "Hero")
+
+	testHelper.SimulateRune(app.attributeView.Table, app.Application, 'n')
+	app.attributeView.Form.nameField.SetText("Supply")
+	app.attributeView.Form.valueField.SetText("6")
+	app.attributeView.Form.typeDropDown.SetCurrentOption(1)
+	app.attributeView.Form.ceilingField.SetText("5")
+	testHelper.SimulateKey(app.attributeView.Form, app.Application, tcell.KeyCtrlS)
+
+	assert.True(t, app.isPageVisible(ATTRIBUTE_MODAL_ID), "Expected the modal to stay open")
+	assert.True(t, app.attributeView.Form.HasFieldError("value"))
+}
+
+func TestAttributeView_BadBoundIsRejected(t *testing.T) {
+	app := setupTestApp(t)
+	createCharacter(t, app, "Hero")
+
+	testHelper.SimulateRune(app.attributeView.Table, app.Application, 'n')
+	app.attributeView.Form.nameField.SetText("Supply")
+	app.attributeView.Form.valueField.SetText("3")
+	app.attributeView.Form.typeDropDown.SetCurrentOption(1)
+	app.attributeView.Form.floorField.SetText("-")
+	testHelper.SimulateKey(app.attributeView.Form, app.Application, tcell.KeyCtrlS)
+
+	assert.True(t, app.isPageVisible(ATTRIBUTE_MODAL_ID), "Expected the modal to stay open")
+	assert.True(t, app.attributeView.Form.HasFieldError("floor"))
+	assert.False(t, app.attributeView.Form.HasFieldError("ceiling"), "A blank ceiling is no bound")
+}
+
+// --- h: history of value changes ---
+
+func TestAttributeView_History_ListsChangesWithTheirSession(t *testing.T) {
+	app := setupTestApp(t)
+	char := createCharacter(t, app, "Kira")
+	hp := createAttr(t, app, char.ID, "HP", 0)
+	sessionID := loadSessionWithContent(t, app, 0, "").ID
+
+	// Edit the value while the session is open
+	app.SetFocus(app.attributeView.Table)
+	app.attributeView.Select(hp.ID)
+	testHelper.SimulateRune(app.attributeView.Table, app.Application, 'e')
+	app.attributeView.Form.valueField.SetText("3")
+	testHelper.SimulateKey(app.attributeView.Form, app.Application, tcell.KeyCtrlS)
+	require.False(t, app.isPageVisible(ATTRIBUTE_MODAL_ID))
+
+	testHelper.SimulateRune(app.attributeView.Table, app.Application, 'h')
+	require.True(t, app.isPageVisible(ATTRIBUTE_HISTORY_MODAL_ID), "Expected the history modal to be visible")
+	require.Len(t, app.historyView.changes, 1)
+	assert.Equal(t, "Test Session", app.historyView.Table.GetCell(1, 1).Text)
+	assert.Equal(t, "val → 3", app.historyView.Table.GetCell(1, 2).Text)
+	assert.Equal(t, sessionID, *app.historyView.changes[0].SessionID)
+	assert.Equal(t, "", app.sessionView.TextArea.GetText(), "No tag should be inserted unless sheet_change_tags is on")
+
+	testHelper.SimulateKey(app.historyView.Modal, app.Application, tcell.KeyEsc)
+	assert.False(t, app.isPageVisible(ATTRIBUTE_HISTORY_MODAL_ID))
+	assert.Equal(t, app.attributeView.Table, app.GetFocus())
+}
+
+func TestAttributeView_SheetChangeTags_InsertsTagIntoSession(t *testing.T) {
+	app := setupTestApp(t)
+	app.cfg.SheetChangeTags = true
+	char := createCharacter(t, app, "Kira")
+
+	testHelper.SimulateRune(app.attributeView.Table, app.Application, 'n')
+	app.attributeView.Form.nameField.SetText("HP")
+	app.attributeView.Form.valueField.SetText("5")
+	app.attributeView.Form.typeDropDown.SetCurrentOption(1)
+	testHelper.SimulateKey(app.attributeView.Form, app.Application, tcell.KeyCtrlS)
+	hp := reloadAttrs(t, app, char.ID)[0]
+
+	sessionID := loadSessionWithContent(t, app, 0, "").ID
+	app.SetFocus(app.attributeView.Table)
+	app.attributeView.Select(hp.ID)
+	testHelper.SimulateRune(app.attributeView.Table, app.Application, '-')
+	testHelper.SimulateRune(app.attributeView.Table, app.Application, '-')
+
+	assert.Equal(t, "[PC:Kira | HP 5→4]\n[PC:Kira | HP 4→3]\n", app.sessionView.TextArea.GetText())
+	saved, err := app.sessionView.sessionService.GetByID(sessionID)
+	require.NoError(t, err)
+	assert.Equal(t, app.sessionView.TextArea.GetText(), saved.Content, "Change should be autosaved")
+}
@@ -0,0 +1,147 @@
+package ui
+
+import (
+	"fmt"
+	"soloterm/domain/link"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// BacklinksView lists the [[links]] to the open session or notes page from
+// the rest of the game
+type BacklinksView struct {
+	app            *App
+	linkService    *link.Service
+	Modal          *tview.Flex
+	backlinksFrame *tview.Frame
+	Table          *tview.Table
+	backlinks      []link.Backlink
+	returnFocus    tview.Primitive
+}
+
+// NewBacklinksView creates a new backlinks view
+func NewBacklinksView(app *App, linkService *link.Service) *BacklinksView {
+	backlinksView := &BacklinksView{app: app, linkService: linkService}
+	backlinksView.Setup()
+	return backlinksView
+}
+
+// Setup initializes all backlinks UI components
+func (bv *BacklinksView) Setup() {
+	bv.setupModal()
+	bv.setupKeyBindings()
+}
+
+func (bv *BacklinksView) setupModal() {
+	bv.Table = tview.NewTable().
+		SetBorders(false).
+		SetSelectable(true, false).
+		SetFixed(1, 0)
+	bv.Table.SetSelectedStyle(tcell.Style{}.Background(tcell.ColorAqua).Foreground(tcell.ColorBlack))
+
+	bv.backlinksFrame = tview.NewFrame(bv.Table).
+		SetBorders(1, 1, 0, 0, 1, 1)
+	bv.backlinksFrame.SetBorder(true).
+		SetTitleAlign(tview.AlignLeft)
+
+	bv.Modal = tview.NewFlex().
+		AddItem(nil, 0, 1, false).
+		AddItem(
+			tview.NewFlex().
+				SetDirection(tview.FlexRow).
+				AddItem(nil, 0, 1, false).
+				AddItem(bv.backlinksFrame, 0, 4, true).
+				AddItem(nil, 0, 1, false),
+			0, 3, true,
+		).
+		AddItem(nil, 0, 1, false)
+
+	bv.Table.SetFocusFunc(func() {
+		bv.app.updateFooterHelp(helpBar("Backlinks", []helpEntry{
+			{"↑/↓", "Navigate"},
+			{"Enter", "Go To"},
+			{"Esc", "Back"},
+		}))
+		bv.backlinksFrame.SetBorderColor(Style.BorderFocusColor)
+	})
+	bv.Table.SetBlurFunc(func() {
+		bv.backlinksFrame.SetBorderColor(Style.BorderColor)
+	})
+}
+
+func (bv *BacklinksView) setupKeyBindings() {
+	bv.Modal.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEsc {
+			bv.app.HandleEvent(&BacklinksCancelEvent{
+				BaseEvent: BaseEvent{action: BACKLINKS_CANCEL},
+			})
+			return nil
+		}
+		return event
+	})
+
+	bv.Table.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEnter {
+			if backlink := bv.Selected(); backlink != nil {
+				bv.app.HandleEvent(&BacklinksSelectEvent{
+					BaseEvent: BaseEvent{action: BACKLINKS_SELECT},
+					Backlink:  backlink,
+				})
+			}
+			return nil
+		}
+		return event
+	})
+}
+
+// Load fetches the links to the session or notes page and renders them.
+// name is shown in the title.
+func (bv *BacklinksView) Load(gameID int64, doc link.Document, name string) {
+	bv.backlinksFrame.SetTitle("[::b] Backlinks to " + tview.Escape(name) + " ([" + Style.HelpKeyTextColor + "]Esc[" + Style.NormalTextColor + "] Back) [-::-]")
+
+	backlinks, err := bv.linkService.Backlinks(gameID, doc)
+	if err != nil {
+		bv.app.notification.ShowError(fmt.Sprintf("Error loading backlinks: %v", err))
+	}
+	bv.backlinks = backlinks
+
+	bv.Table.Clear()
+	for col, label := range []string{"From", "Link"} {
+		bv.Table.SetCell(0, col, tview.NewTableCell(label).
+			SetTextColor(tcell.ColorYellow).
+			SetAlign(tview.AlignLeft).
+			SetSelectable(false))
+	}
+
+	if len(bv.backlinks) == 0 {
+		bv.Table.SetCell(1, 0, tview.NewTableCell("(Nothing links here yet)").
+			SetTextColor(Style.EmptyStateMessageColor).
+			SetSelectable(false))
+		return
+	}
+
+	for i, b := range bv.backlinks {
+		row := i + 1
+		from := b.Name
+		if b.PageID != nil {
+			from = link.NotesTarget + ": " + b.Name
+		}
+		bv.Table.SetCell(row, 0, tview.NewTableCell(tview.Escape(from)).
+			SetTextColor(tcell.ColorWhite).
+			SetMaxWidth(25))
+		bv.Table.SetCell(row, 1, tview.NewTableCell(tview.Escape(b.Raw)).
+			SetTextColor(tcell.ColorWhite).
+			SetExpansion(1))
+	}
+	bv.Table.Select(1, 0)
+}
+
+// Selected returns the backlink on the selected row, or nil if there is none
+func (bv *BacklinksView) Selected() *link.Backlink {
+	row, _ := bv.Table.GetSelection()
+	if row < 1 || row > len(bv.backlinks) {
+		return nil
+	}
+	return &bv.backlinks[row-1]
+}
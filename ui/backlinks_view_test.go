@@ -0,0 +1,89 @@
+package ui
+
+import (
+	"soloterm/domain/session"
+	testHelper "soloterm/shared/testing"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// createLinkedSessions is a test helper that creates a game whose second
+// session links to the first, and opens the second
+func createLinkedSessions(t *testing.T, app *App) (*session.Session, *session.Session) {
+	t.Helper()
+	g := createGame(t, app, "Test Game")
+	first := createSession(t, app, g.ID, "Arrival")
+	first.Content = "S1 *The Gate*\n## Loot\nA key"
+	_, err := app.sessionView.sessionService.Save(first)
+	require.NoError(t, err)
+	second := createSession(t, app, g.ID, "Session Two")
+	second.Content = "As in [[Arrival#Loot]], and [[Nowhere]]"
+	_, err = app.sessionView.sessionService.Save(second)
+	require.NoError(t, err)
+	addNotesToGame(t, app, g.ID, "Start at [[arrival]]")
+
+	app.gameView.Refresh()
+	require.NoError(t, app.gameView.SetCurrentGame(g.ID))
+	app.sessionView.SelectSession(second.ID)
+	app.SetFocus(app.sessionView.TextArea)
+	return first, second
+}
+
+func TestLinks_FollowLinkUnderCursor(t *testing.T) {
+	app := setupTestApp(t)
+	first, _ := createLinkedSessions(t, app)
+
+	app.sessionView.TextArea.Select(10, 10)
+	testHelper.SimulateKey(app.sessionView.TextArea, app.Application, tcell.KeyF9)
+
+	require.NotNil(t, app.sessionView.currentSessionID)
+	assert.Equal(t, first.ID, *app.sessionView.currentSessionID, "Expected the linked session to be opened")
+	assert.Equal(t, app.sessionView.TextArea, app.GetFocus())
+}
+
+func TestLinks_FollowMissingTarget(t *testing.T) {
+	app := setupTestApp(t)
+	_, second := createLinkedSessions(t, app)
+
+	app.sessionView.TextArea.Select(32, 32)
+	testHelper.SimulateKey(app.sessionView.TextArea, app.Application, tcell.KeyF9)
+
+	assert.Equal(t, second.ID, *app.sessionView.currentSessionID, "Expected to stay in the session")
+	assert.Contains(t, app.notification.GetText(true), "There is no session or notes page named Nowhere")
+}
+
+func TestBacklinksView_ListsAndOpens(t *testing.T) {
+	app := setupTestApp(t)
+	first, second := createLinkedSessions(t, app)
+	app.sessionView.SelectSession(first.ID)
+	app.SetFocus(app.sessionView.TextArea)
+
+	testHelper.SimulateKey(app.sessionView.TextArea, app.Application, tcell.KeyCtrlN)
+
+	assert.True(t, app.isPageVisible(BACKLINKS_MODAL_ID), "Expected the backlinks modal to be visible")
+	require.Len(t, app.backlinksView.backlinks, 2)
+	assert.Equal(t, "Notes: Notes", app.backlinksView.Table.GetCell(1, 0).Text)
+	assert.Equal(t, "Session Two", app.backlinksView.Table.GetCell(2, 0).Text)
+
+	app.backlinksView.Table.Select(2, 0)
+	testHelper.SimulateKey(app.backlinksView.Table, app.Application, tcell.KeyEnter)
+
+	assert.False(t, app.isPageVisible(BACKLINKS_MODAL_ID))
+	assert.Equal(t, second.ID, *app.sessionView.currentSessionID)
+	assert.Equal(t, app.sessionView.TextArea, app.GetFocus())
+}
+
+func TestBacklinksView_EscapeReturnsToEditor(t *testing.T) {
+	app := setupTestApp(t)
+	createLinkedSessions(t, app)
+
+	testHelper.SimulateKey(app.sessionView.TextArea, app.Application, tcell.KeyCtrlN)
+	assert.Empty(t, app.backlinksView.backlinks)
+
+	testHelper.SimulateKey(app.backlinksView.Modal, app.Application, tcell.KeyEsc)
+	assert.False(t, app.isPageVisible(BACKLINKS_MODAL_ID))
+	assert.Equal(t, app.sessionView.TextArea, app.GetFocus())
+}
@@ -1,5 +1,7 @@
 package ui
 
+import "soloterm/domain/character"
+
 func (a *App) handleCharacterSaved(e *CharacterSavedEvent) {
 	a.characterView.Form.ClearFieldErrors()
 	a.pages.HidePage(CHARACTER_MODAL_ID)
@@ -20,7 +22,7 @@ func (a *App) handleCharacterDeleteConfirm(e *CharacterDeleteConfirmEvent) {
 	returnFocus := a.GetFocus()
 
 	a.confirmModal.Configure(
-		"Are you sure you want to delete this character?\n\nThis will also delete their sheet.",
+		"Are you sure you want to delete this character and their sheet?\n\nThey can be restored from the Trash (t).",
 		func() {
 			a.characterView.ConfirmDelete(e.Character.ID)
 		},
@@ -41,7 +43,7 @@ func (a *App) handleCharacterDeleted(_ *CharacterDeletedEvent) {
 	a.attributeView.Table.Clear()
 	a.characterView.RefreshTree()
 	a.SetFocus(a.characterView.ReturnFocus)
-	a.notification.ShowSuccess("Character deleted successfully")
+	a.notification.ShowSuccess("Character moved to the Trash")
 }
 
 func (a *App) handleCharacterDeleteFailed(e *CharacterDeleteFailedEvent) {
@@ -83,6 +85,8 @@ func (a *App) handleCharacterDuplicateFailed(e *CharacterDuplicateFailedEvent) {
 
 func (a *App) handleCharacterShowNew(_ *CharacterShowNewEvent) {
 	a.characterView.Form.Reset()
+	a.characterView.Form.SetTemplates(character.SheetTemplates(a.cfg.SheetTemplates))
+	a.characterView.resizeFormModal()
 	a.pages.ShowPage(CHARACTER_MODAL_ID)
 	a.characterView.formModal.SetTitle(" New Character ")
 	a.SetFocus(a.characterView.Form)
@@ -90,7 +94,26 @@ func (a *App) handleCharacterShowNew(_ *CharacterShowNewEvent) {
 
 func (a *App) handleCharacterShowEdit(e *CharacterShowEditEvent) {
 	a.characterView.Form.PopulateForEdit(e.Character)
+	a.characterView.resizeFormModal()
 	a.pages.ShowPage(CHARACTER_MODAL_ID)
 	a.characterView.formModal.SetTitle(" Edit Character ")
 	a.SetFocus(a.characterView.Form)
 }
+
+func (a *App) handleCharacterShowImport(_ *CharacterShowImportEvent) {
+	a.fileView.ShowImport(NewCharacterImport(a.characterView), a.characterView.CharTree)
+}
+
+func (a *App) handleCharacterShowExport(_ *CharacterShowExportEvent) {
+	id := a.characterView.GetSelectedCharacterID()
+	if id == nil {
+		a.notification.ShowWarning("Select a character before exporting.")
+		return
+	}
+	sheet, err := a.characterView.charService.Export(*id)
+	if err != nil {
+		a.notification.ShowError("Failed to export character: " + err.Error())
+		return
+	}
+	a.fileView.ShowExport(NewCharacterExport(sheet), a.characterView.CharTree)
+}
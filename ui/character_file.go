@@ -0,0 +1,88 @@
+package ui
+
+import (
+	"soloterm/domain/character"
+)
+
+// CharacterExport writes a character and their sheet through the file export
+// flow. The file's extension picks the format: .json for JSON and anything
+// else, such as .yaml, for YAML.
+type CharacterExport struct {
+	sheet *character.SheetFile
+	path  string
+}
+
+// NewCharacterExport creates an export of the sheet file
+func NewCharacterExport(sheet *character.SheetFile) *CharacterExport {
+	return &CharacterExport{sheet: sheet}
+}
+
+// ====== RenderingFileTarget implementation ======
+
+func (ce *CharacterExport) RenderFileContent() (string, error) {
+	return ce.sheet.Render(character.SheetFormatForPath(ce.path))
+}
+
+func (ce *CharacterExport) GetFileContent() string { return "" } // unused; RenderFileContent exports
+
+func (ce *CharacterExport) SetFileContent(data string, position ImportPosition) {} // unused; see CharacterImport
+
+func (ce *CharacterExport) UsePositionField() bool { return false }
+
+func (ce *CharacterExport) FileDir() string { return "" } // unused; FileView uses dirs.ExportDir()
+
+func (ce *CharacterExport) OnFileDone() {}
+
+func (ce *CharacterExport) SetFilePath(path string) { ce.path = path }
+
+// CharacterImport reads a character sheet file, written as JSON or YAML,
+// through the file import flow. A character with the same name and system is
+// updated, anyone else is created and joins the active game.
+type CharacterImport struct {
+	cv       *CharacterView
+	imported *character.Character
+}
+
+// NewCharacterImport creates an import into the character view
+func NewCharacterImport(cv *CharacterView) *CharacterImport {
+	return &CharacterImport{cv: cv}
+}
+
+// ====== ParsingFileTarget implementation ======
+
+func (ci *CharacterImport) ParseFileContent(data string) error {
+	sheet, err := character.ParseSheetFile(data)
+	if err != nil {
+		return err
+	}
+
+	imported, updated, err := ci.cv.charService.Import(sheet)
+	if err != nil {
+		return err
+	}
+	ci.imported = imported
+
+	// A new character joins the active game's party
+	if g := ci.cv.app.CurrentGame(); !updated && g != nil {
+		if err := ci.cv.charService.AddToGame(imported.ID, g.ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (ci *CharacterImport) GetFileContent() string { return "" } // unused; see CharacterExport
+
+func (ci *CharacterImport) SetFileContent(data string, position ImportPosition) {} // unused; ParseFileContent imports
+
+func (ci *CharacterImport) UsePositionField() bool { return false }
+
+func (ci *CharacterImport) FileDir() string { return "" } // unused; FileView uses dirs.ExportDir()
+
+// OnFileDone shows the imported character in the tree
+func (ci *CharacterImport) OnFileDone() {
+	ci.cv.expandSystem = &ci.imported.System
+	ci.cv.RefreshTree()
+	ci.cv.SelectCharacter(ci.imported.ID)
+	ci.cv.RefreshDisplay()
+}
@@ -16,6 +16,9 @@ type CharacterForm struct {
 	systemField  *tview.InputField
 	roleField    *tview.InputField
 	speciesField *tview.InputField
+
+	templateField *tview.DropDown
+	templates     []character.SheetTemplate
 }
 
 // NewCharacterForm creates a new character form
@@ -48,6 +51,11 @@ func NewCharacterForm() *CharacterForm {
 		SetFieldBackgroundColor(tcell.ColorDefault).
 		SetFieldWidth(0)
 
+	// Template field, only shown for new characters
+	cf.templateField = tview.NewDropDown().
+		SetLabel("Template").
+		SetFieldBackgroundColor(tcell.ColorDefault)
+
 	cf.setupForm()
 	return cf
 }
@@ -60,6 +68,7 @@ func (cf *CharacterForm) PopulateForEdit(char *character.Character) {
 	cf.systemField.SetText(char.System)
 	cf.roleField.SetText(char.Role)
 	cf.speciesField.SetText(char.Species)
+	cf.SetTemplates(nil)
 
 	cf.AddDeleteButton()
 
@@ -98,6 +107,42 @@ func (cf *CharacterForm) Reset() {
 	cf.SetFocus(0)
 }
 
+// SetTemplates sets the sheet templates offered for the new character. The
+// template field is hidden when there are none.
+func (cf *CharacterForm) SetTemplates(templates []character.SheetTemplate) {
+	cf.templates = templates
+	if idx := cf.GetFormItemIndex("Template"); idx >= 0 {
+		cf.RemoveFormItem(idx)
+	}
+	if len(templates) == 0 {
+		return
+	}
+
+	options := []string{"None"}
+	for _, t := range templates {
+		options = append(options, t.Name)
+	}
+	cf.templateField.SetOptions(options, func(_ string, index int) {
+		// Fill in the template's system unless one was typed
+		if index > 0 && index <= len(cf.templates) && cf.systemField.GetText() == "" {
+			cf.systemField.SetText(cf.templates[index-1].SystemName())
+		}
+	}).SetCurrentOption(0)
+	cf.AddFormItem(cf.templateField)
+}
+
+// SelectedTemplate returns the chosen sheet template, or nil when none was chosen
+func (cf *CharacterForm) SelectedTemplate() *character.SheetTemplate {
+	if cf.GetFormItemIndex("Template") < 0 {
+		return nil
+	}
+	idx, _ := cf.templateField.GetCurrentOption()
+	if idx <= 0 || idx > len(cf.templates) {
+		return nil
+	}
+	return &cf.templates[idx-1]
+}
+
 // SetFieldErrors sets multiple field errors at once and updates labels
 func (cf *CharacterForm) SetFieldErrors(errors map[string]string) {
 	cf.DataForm.SetFieldErrors(errors)
@@ -16,13 +16,20 @@ func NewCharacterViewHelper(characterService *character.Service) *CharacterViewH
 	}
 }
 
-// LoadCharacters loads all characters
-func (cv *CharacterViewHelper) LoadCharacters() (map[string][]*character.Character, error) {
+// LoadCharacters loads the characters in the game, or every character when
+// gameID is 0
+func (cv *CharacterViewHelper) LoadCharacters(gameID int64) (map[string][]*character.Character, error) {
 	// Initialize the map
 	charsBySystem := make(map[string][]*character.Character)
 
 	// Load characters from database
-	chars, err := cv.characterService.GetAll()
+	var chars []*character.Character
+	var err error
+	if gameID != 0 {
+		chars, err = cv.characterService.GetAllForGame(gameID)
+	} else {
+		chars, err = cv.characterService.GetAll()
+	}
 	if err != nil {
 		return nil, err
 	}
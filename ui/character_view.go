@@ -7,6 +7,7 @@ import (
 	"soloterm/domain/character"
 	sharedui "soloterm/shared/ui"
 	"sort"
+	"strings"
 
 	"github.com/gdamore/tcell/v2"
 	"github.com/rivo/tview"
@@ -27,6 +28,9 @@ type CharacterView struct {
 	// Remember the selected character
 	selectedCharacterID *int64
 
+	// Show every character instead of the active game's party
+	showAll bool
+
 	// UI Components
 	CharTree  *tview.TreeView
 	InfoView  *tview.TextView
@@ -66,7 +70,7 @@ func (cv *CharacterView) setupCharacterPane() {
 	// Character pane combining info and attributes
 	charDetails := tview.NewFlex().
 		SetDirection(tview.FlexRow).
-		AddItem(cv.InfoView, 4, 1, false).               // Proportional height (smaller weight)
+		AddItem(cv.InfoView, 5, 1, false).               // Proportional height (smaller weight)
 		AddItem(cv.app.attributeView.Table, 0, 2, false) // Proportional height (larger weight - gets 2x space)
 
 	cv.CharPane = tview.NewFrame(charDetails).
@@ -117,6 +121,16 @@ func (cv *CharacterView) setupCharacterTree() {
 	// Set up input capture for character tree - Ctrl+N to add character
 	cv.CharTree.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
 		switch event.Key() {
+		case tcell.KeyCtrlO:
+			cv.app.HandleEvent(&CharacterShowImportEvent{
+				BaseEvent: BaseEvent{action: CHARACTER_SHOW_IMPORT},
+			})
+			return nil
+		case tcell.KeyCtrlX:
+			cv.app.HandleEvent(&CharacterShowExportEvent{
+				BaseEvent: BaseEvent{action: CHARACTER_SHOW_EXPORT},
+			})
+			return nil
 		case tcell.KeyRune:
 			switch event.Rune() {
 			case 'e':
@@ -134,6 +148,17 @@ func (cv *CharacterView) setupCharacterTree() {
 			case 'n':
 				cv.ShowModal()
 				return nil
+			case 'a':
+				cv.ToggleShowAll()
+				return nil
+			case 'g':
+				cv.ToggleGameMembership()
+				return nil
+			case 't':
+				cv.app.HandleEvent(&TrashShowEvent{
+					BaseEvent: BaseEvent{action: TRASH_SHOW},
+				})
+				return nil
 			}
 		}
 		return event
@@ -180,6 +205,11 @@ func (cv *CharacterView) setupFocusHandlers() {
 			{"n", "New"},
 			{"e", "Edit"},
 			{"d", "Duplicate"},
+			{"g", "Add/Remove from Game"},
+			{"a", "All/Game"},
+			{"t", "Trash"},
+			{"Ctrl+O", "Import"},
+			{"Ctrl+X", "Export"},
 		}))
 		cv.CharTree.SetBorderColor(Style.BorderFocusColor)
 	})
@@ -195,6 +225,8 @@ func (cv *CharacterView) setupFocusHandlers() {
 			{"e", "Edit"},
 			{"n", "New"},
 			{"u/d", "Move Entry or Section Up/Down"},
+			{"+/-", "Adjust Number"},
+			{"h", "History"},
 		}))
 		cv.CharPane.SetBorderColor(Style.BorderFocusColor)
 	})
@@ -225,8 +257,20 @@ func (cv *CharacterView) RefreshTree() {
 	}
 	root.ClearChildren()
 
-	// Load all characters from database
-	charsBySystem, err := cv.helper.LoadCharacters()
+	// Show the active game's party unless every character was asked for
+	var gameID int64
+	switch g := cv.app.CurrentGame(); {
+	case g == nil:
+		root.SetText("Systems")
+	case cv.showAll:
+		root.SetText("Systems (All Games)")
+	default:
+		gameID = g.ID
+		root.SetText("Systems in " + tview.Escape(g.Name))
+	}
+
+	// Load the characters from database
+	charsBySystem, err := cv.helper.LoadCharacters(gameID)
 	if err != nil {
 		// Show error in tree
 		errorNode := tview.NewTreeNode("Error loading characters: " + err.Error()).
@@ -237,7 +281,11 @@ func (cv *CharacterView) RefreshTree() {
 
 	if len(charsBySystem) == 0 {
 		// No characters yet
-		placeholder := tview.NewTreeNode("(No Characters Yet - Press n to Add)").
+		text := "(No Characters Yet - Press n to Add)"
+		if gameID != 0 {
+			text = "(No Characters in This Game - Press n to Add or a to Show All)"
+		}
+		placeholder := tview.NewTreeNode(text).
 			SetColor(Style.EmptyStateMessageColor)
 		root.AddChild(placeholder)
 		return
@@ -326,10 +374,24 @@ func (cv *CharacterView) displayCharacterInfo(char *character.Character) {
 	charInfo := "[aqua::b]" + char.Name + "[-::-]\n"
 	charInfo += "[" + Style.HelpKeyTextColor + "::bi]      System:[white::-] " + tview.Escape(char.System) + "\n"
 	charInfo += "[" + Style.HelpKeyTextColor + "::bi]  Role/Class:[white::-] " + tview.Escape(char.Role) + "\n"
-	charInfo += "[" + Style.HelpKeyTextColor + "::bi]Species/Race:[white::-] " + tview.Escape(char.Species)
+	charInfo += "[" + Style.HelpKeyTextColor + "::bi]Species/Race:[white::-] " + tview.Escape(char.Species) + "\n"
+	charInfo += "[" + Style.HelpKeyTextColor + "::bi]       Games:[white::-] " + tview.Escape(cv.gameNames(char.ID))
 	cv.InfoView.SetText(charInfo)
 }
 
+// gameNames lists the games the character is in, for the info view
+func (cv *CharacterView) gameNames(characterID int64) string {
+	names, err := cv.charService.GameNames(characterID)
+	if err != nil {
+		syslog.Printf("Problem loading the character's games: %s", err)
+		return ""
+	}
+	if len(names) == 0 {
+		return "(none)"
+	}
+	return strings.Join(names, ", ")
+}
+
 func (cv *CharacterView) GetSelectedCharacter() *character.Character {
 	selectedCharacterID := cv.GetSelectedCharacterID()
 	if selectedCharacterID == nil {
@@ -348,9 +410,17 @@ func (cv *CharacterView) GetSelectedCharacter() *character.Character {
 // HandleSave saves the character from the form
 func (cv *CharacterView) HandleSave() {
 	char := cv.Form.BuildDomain()
-
-	// Validate and save - get the saved character back from the service
-	savedChar, err := cv.charService.Save(char)
+	isNew := char.ID == 0
+
+	// Validate and save - get the saved character back from the service.
+	// A new character made from a template gets the template's sheet.
+	var savedChar *character.Character
+	var err error
+	if tmpl := cv.Form.SelectedTemplate(); isNew && tmpl != nil {
+		savedChar, err = cv.charService.CreateFromTemplate(char, tmpl)
+	} else {
+		savedChar, err = cv.charService.Save(char)
+	}
 	if err != nil {
 		// Check if it's a validation error
 		if sharedui.HandleValidationError(err, cv.Form) {
@@ -360,8 +430,15 @@ func (cv *CharacterView) HandleSave() {
 		return
 	}
 
+	// A new character joins the active game's party
+	if g := cv.app.CurrentGame(); isNew && g != nil {
+		if err := cv.charService.AddToGame(savedChar.ID, g.ID); err != nil {
+			cv.app.notification.ShowError("Failed to add the character to the game: " + err.Error())
+		}
+	}
+
 	// Remember the system so it can be expanded when the tree reloads
-	cv.expandSystem = &char.System
+	cv.expandSystem = &savedChar.System
 
 	// Dispatch event with saved character
 	cv.app.HandleEvent(&CharacterSavedEvent{
@@ -370,6 +447,17 @@ func (cv *CharacterView) HandleSave() {
 	})
 }
 
+// resizeFormModal fits the form modal to the form's fields, which change
+// depending on whether sheet templates are offered
+func (cv *CharacterView) resizeFormModal() {
+	// Each field takes its height plus padding, around the border, padding and buttons
+	height := 5
+	for i := range cv.Form.GetFormItemCount() {
+		height += cv.Form.GetFormItem(i).GetFieldHeight() + 1
+	}
+	cv.formModal.SetBaseHeight(height)
+}
+
 // HandleCancel cancels character editing
 func (cv *CharacterView) HandleCancel() {
 	cv.app.HandleEvent(&CharacterCancelledEvent{
@@ -483,3 +571,49 @@ func (cv *CharacterView) ShowEditCharacterModal() {
 		Character: cv.GetSelectedCharacter(),
 	})
 }
+
+// ToggleShowAll switches the tree between the active game's party and every character
+func (cv *CharacterView) ToggleShowAll() {
+	cv.showAll = !cv.showAll
+	cv.RefreshTree()
+	if cv.app.CurrentGame() == nil {
+		cv.app.notification.ShowInfo("Showing every character. Open a session to show only its game's characters.")
+	}
+}
+
+// ToggleGameMembership adds the selected character to the active game, or
+// takes them out of it when they are already in it
+func (cv *CharacterView) ToggleGameMembership() {
+	char := cv.GetSelectedCharacter()
+	if char == nil {
+		cv.app.notification.ShowWarning("Select a character to add to the game")
+		return
+	}
+	g := cv.app.CurrentGame()
+	if g == nil {
+		cv.app.notification.ShowWarning("Open a session of a game to add characters to it")
+		return
+	}
+
+	inGame, err := cv.charService.IsInGame(char.ID, g.ID)
+	if err == nil {
+		if inGame {
+			err = cv.charService.RemoveFromGame(char.ID, g.ID)
+		} else {
+			err = cv.charService.AddToGame(char.ID, g.ID)
+		}
+	}
+	if err != nil {
+		cv.app.notification.ShowError("Failed to update the character's games: " + err.Error())
+		return
+	}
+
+	cv.expandSystem = &char.System
+	cv.RefreshTree()
+	cv.displayCharacterInfo(char)
+	if inGame {
+		cv.app.notification.ShowSuccess(tview.Escape(char.Name) + " removed from " + tview.Escape(g.Name))
+	} else {
+		cv.app.notification.ShowSuccess(tview.Escape(char.Name) + " added to " + tview.Escape(g.Name))
+	}
+}
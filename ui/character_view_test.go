@@ -1,8 +1,11 @@
 package ui
 
 import (
+	"os"
+	"path/filepath"
 	"soloterm/domain/character"
 	testHelper "soloterm/shared/testing"
+	"strings"
 	"testing"
 
 	// Blank imports to trigger init() migration registration
@@ -300,3 +303,181 @@ func TestCharacterView_SelectingCharacterFocusesAttributeTable(t *testing.T) {
 	// createCharacter ends with SimulateCtrlS which switches focus to the attribute table
 	assert.Equal(t, app.attributeView.Table, app.GetFocus(), "Expected attribute table to have focus after character selection")
 }
+
+func TestCharacterView_TreeShowsTheActiveGamesParty(t *testing.T) {
+	app := setupTestApp(t)
+	aria := createCharacter(t, app, "Aria")
+	createCharacter(t, app, "Brom")
+	g := createGame(t, app, "Test Game")
+	require.NoError(t, app.characterView.charService.AddToGame(aria.ID, g.ID))
+
+	// Opening a session makes its game the active one
+	s := createSession(t, app, g.ID, "Session 1")
+	app.HandleEvent(&SessionSelectedEvent{BaseEvent: BaseEvent{action: SESSION_SELECTED}, SessionID: s.ID, GameID: g.ID})
+
+	root := app.characterView.CharTree.GetRoot()
+	assert.Equal(t, "Systems in Test Game", root.GetText())
+	require.Len(t, root.GetChildren(), 1)
+	chars := root.GetChildren()[0].GetChildren()
+	require.Len(t, chars, 1, "Expected only the game's party")
+	assert.Equal(t, "Aria", chars[0].GetText())
+
+	// a shows every character, and again the party
+	app.SetFocus(app.characterView.CharTree)
+	testHelper.SimulateRune(app.characterView.CharTree, app.Application, 'a')
+	assert.Equal(t, "Systems (All Games)", root.GetText())
+	assert.Len(t, root.GetChildren()[0].GetChildren(), 2)
+
+	testHelper.SimulateRune(app.characterView.CharTree, app.Application, 'a')
+	assert.Len(t, root.GetChildren()[0].GetChildren(), 1)
+}
+
+func TestCharacterView_ToggleGameMembership(t *testing.T) {
+	app := setupTestApp(t)
+	brom := createCharacter(t, app, "Brom")
+	g := createGame(t, app, "Test Game")
+	require.NoError(t, app.gameView.SetCurrentGame(g.ID))
+
+	root := app.characterView.CharTree.GetRoot()
+	require.Len(t, root.GetChildren(), 1)
+	assert.Equal(t, "(No Characters in This Game - Press n to Add or a to Show All)", root.GetChildren()[0].GetText())
+
+	// g adds the selected character to the active game
+	app.SetFocus(app.characterView.CharTree)
+	testHelper.SimulateRune(app.characterView.CharTree, app.Application, 'g')
+
+	inGame, err := app.characterView.charService.IsInGame(brom.ID, g.ID)
+	require.NoError(t, err)
+	assert.True(t, inGame)
+	assert.Equal(t, "Brom", root.GetChildren()[0].GetChildren()[0].GetText())
+	assert.Contains(t, app.characterView.InfoView.GetText(true), "Games: Test Game")
+
+	// and again takes them out of it
+	testHelper.SimulateRune(app.characterView.CharTree, app.Application, 'g')
+	inGame, err = app.characterView.charService.IsInGame(brom.ID, g.ID)
+	require.NoError(t, err)
+	assert.False(t, inGame)
+	assert.Contains(t, app.characterView.InfoView.GetText(true), "Games: (none)")
+}
+
+func TestCharacterView_NewCharacterJoinsTheActiveGame(t *testing.T) {
+	app := setupTestApp(t)
+	g := createGame(t, app, "Test Game")
+	require.NoError(t, app.gameView.SetCurrentGame(g.ID))
+	openCharacterModal(t, app)
+
+	app.characterView.Form.nameField.SetText("Aria")
+	app.characterView.Form.systemField.SetText("D&D 5e")
+	app.characterView.Form.roleField.SetText("Wizard")
+	app.characterView.Form.speciesField.SetText("Elf")
+	testHelper.SimulateKey(app.characterView.Form, app.Application, tcell.KeyCtrlS)
+
+	party, err := app.characterView.charService.GetAllForGame(g.ID)
+	require.NoError(t, err)
+	require.Len(t, party, 1)
+	assert.Equal(t, "Aria", party[0].Name)
+}
+
+func TestCharacterView_NewCharacterFromTemplate(t *testing.T) {
+	app := setupTestApp(t)
+	app.cfg.SheetTemplates = []character.SheetTemplate{{
+		Name:   "Knave",
+		System: "Knave 2e",
+		Sections: []character.SheetSection{
+			{Name: "Abilities", Attributes: []character.SheetEntry{{Name: "STR", Value: "+1"}, {Name: "DEX", Value: "+0"}}},
+			{Name: "HP", Value: "4"},
+		},
+	}}
+	openCharacterModal(t, app)
+
+	// The configured templates come before the built-in ones
+	require.GreaterOrEqual(t, app.characterView.Form.GetFormItemIndex("Template"), 0, "Expected the template field for a new character")
+	assert.Equal(t, 5, app.characterView.Form.templateField.GetOptionCount())
+	app.characterView.Form.templateField.SetCurrentOption(1)
+	assert.Equal(t, "Knave 2e", app.characterView.Form.systemField.GetText(), "Expected the template's system to be filled in")
+
+	app.characterView.Form.nameField.SetText("Odo")
+	app.characterView.Form.roleField.SetText("Thief")
+	app.characterView.Form.speciesField.SetText("Human")
+	testHelper.SimulateKey(app.characterView.Form, app.Application, tcell.KeyCtrlS)
+	require.False(t, app.isPageVisible(CHARACTER_MODAL_ID), "Expected character modal to be hidden after save")
+
+	chars, err := app.characterView.charService.GetAll()
+	require.NoError(t, err)
+	require.Len(t, chars, 1)
+	attrs, err := app.attributeView.attrService.GetForCharacter(chars[0].ID)
+	require.NoError(t, err)
+	require.Len(t, attrs, 4)
+	assert.Equal(t, "Abilities", attrs[0].Name)
+	assert.Equal(t, "DEX", attrs[2].Name)
+	assert.Equal(t, 2, attrs[2].PositionInGroup)
+	assert.Equal(t, "HP", attrs[3].Name)
+	assert.Equal(t, 1, attrs[3].Group)
+
+	// Editing doesn't offer templates
+	app.characterView.SelectCharacter(chars[0].ID)
+	app.characterView.ShowEditCharacterModal()
+	assert.Less(t, app.characterView.Form.GetFormItemIndex("Template"), 0)
+}
+
+func TestCharacterView_ExportAndReimport(t *testing.T) {
+	app := setupTestApp(t)
+	char, err := character.NewCharacter("Kira", "Cairn", "Scout", "Human")
+	require.NoError(t, err)
+	char, err = app.characterView.charService.Save(char)
+	require.NoError(t, err)
+	hp, _ := character.NewAttribute(char.ID, 0, 0, "HP", "4/4")
+	hp.Numeric = true
+	_, err = app.attributeView.attrService.Save(hp)
+	require.NoError(t, err)
+	app.characterView.RefreshTree()
+	app.characterView.SelectCharacter(char.ID)
+
+	// Export with Ctrl+X; the extension picks JSON
+	exportPath := filepath.Join(t.TempDir(), "kira.json")
+	app.SetFocus(app.characterView.CharTree)
+	testHelper.SimulateKey(app.characterView.CharTree, app.Application, tcell.KeyCtrlX)
+	require.True(t, app.isPageVisible(FILE_MODAL_ID), "Expected the export modal to open")
+	app.fileView.Form.pathField.SetText(exportPath)
+	testHelper.SimulateKey(app.fileView.Form, app.Application, tcell.KeyCtrlS)
+	require.False(t, app.isPageVisible(FILE_MODAL_ID))
+
+	data, err := os.ReadFile(exportPath)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"name": "Kira"`)
+	assert.Contains(t, string(data), `"numeric": true`)
+
+	// Re-importing an edited file updates Kira rather than adding a copy
+	edited := strings.Replace(string(data), `"4/4"`, `"2/4"`, 1)
+	require.NoError(t, os.WriteFile(exportPath, []byte(edited), 0644))
+	testHelper.SimulateKey(app.characterView.CharTree, app.Application, tcell.KeyCtrlO)
+	require.True(t, app.isPageVisible(FILE_MODAL_ID), "Expected the import modal to open")
+	app.fileView.Form.pathField.SetText(exportPath)
+	testHelper.SimulateKey(app.fileView.Form, app.Application, tcell.KeyCtrlS)
+	require.False(t, app.isPageVisible(FILE_MODAL_ID))
+
+	chars, err := app.characterView.charService.GetAll()
+	require.NoError(t, err)
+	require.Len(t, chars, 1)
+	attrs := reloadAttrs(t, app, char.ID)
+	require.Len(t, attrs, 1)
+	assert.Equal(t, "2/4", attrs[0].Value)
+	assert.Equal(t, char.ID, *app.characterView.GetSelectedCharacterID(), "Expected the imported character to be selected")
+}
+
+func TestCharacterView_ImportRejectsABadSheet(t *testing.T) {
+	app := setupTestApp(t)
+	path := filepath.Join(t.TempDir(), "notes.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("system: Cairn\n"), 0644))
+
+	app.SetFocus(app.characterView.CharTree)
+	testHelper.SimulateKey(app.characterView.CharTree, app.Application, tcell.KeyCtrlO)
+	app.fileView.Form.pathField.SetText(path)
+	testHelper.SimulateKey(app.fileView.Form, app.Application, tcell.KeyCtrlS)
+
+	assert.True(t, app.isPageVisible(FILE_MODAL_ID), "Expected the modal to stay open")
+	assert.True(t, app.fileView.Form.HasFieldError("path"))
+	chars, err := app.characterView.charService.GetAll()
+	require.NoError(t, err)
+	assert.Empty(t, chars)
+}
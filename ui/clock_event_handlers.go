@@ -0,0 +1,27 @@
+package ui
+
+func (a *App) handleClockShow(_ *ClockShowEvent) {
+	// Store current focus so we can restore it after closing
+	a.clockView.returnFocus = a.GetFocus()
+	a.clockView.Refresh()
+	a.pages.ShowPage(CLOCK_MODAL_ID)
+	a.SetFocus(a.clockView.Table)
+}
+
+func (a *App) handleClockCancel(_ *ClockCancelEvent) {
+	a.pages.HidePage(CLOCK_MODAL_ID)
+	a.SetFocus(a.clockView.returnFocus)
+}
+
+func (a *App) handleClockAdjust(e *ClockAdjustEvent) {
+	updated := e.Progress.Step(e.Delta)
+	if updated == e.Progress.Raw {
+		return
+	}
+
+	key := e.Progress.Key()
+	a.sessionView.InsertLineAtCursor(updated)
+	a.Autosave()
+	a.clockView.Refresh()
+	a.clockView.SelectKey(key)
+}
@@ -0,0 +1,217 @@
+package ui
+
+import (
+	"fmt"
+	"soloterm/domain/lonelog"
+	"soloterm/domain/tag"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// ClockView lists the open clocks and tracks of the current game and lets the
+// user step them up or down.
+type ClockView struct {
+	app         *App
+	tagService  *tag.Service
+	Modal       *tview.Flex
+	clockFrame  *tview.Frame
+	Table       *tview.Table
+	clocks      []*lonelog.Progress
+	returnFocus tview.Primitive
+}
+
+// NewClockView creates a new clock view
+func NewClockView(app *App, tagService *tag.Service) *ClockView {
+	clockView := &ClockView{app: app, tagService: tagService}
+	clockView.Setup()
+	return clockView
+}
+
+// Setup initializes all clock UI components
+func (cv *ClockView) Setup() {
+	cv.setupModal()
+	cv.setupKeyBindings()
+}
+
+func (cv *ClockView) setupModal() {
+	cv.Table = tview.NewTable().
+		SetBorders(false).
+		SetSelectable(true, false).
+		SetFixed(1, 0)
+	cv.Table.SetSelectedStyle(tcell.Style{}.Background(tcell.ColorAqua).Foreground(tcell.ColorBlack))
+
+	cv.clockFrame = tview.NewFrame(cv.Table).
+		SetBorders(1, 1, 0, 0, 1, 1)
+	cv.clockFrame.SetBorder(true).
+		SetTitleAlign(tview.AlignLeft).
+		SetTitle("[::b] Clocks & Tracks ([" + Style.HelpKeyTextColor + "]Esc[" + Style.NormalTextColor + "] Close) [-::-]")
+
+	cv.Modal = tview.NewFlex().
+		AddItem(nil, 0, 1, false).
+		AddItem(
+			tview.NewFlex().
+				SetDirection(tview.FlexRow).
+				AddItem(nil, 0, 1, false).
+				AddItem(cv.clockFrame, 0, 2, true).
+				AddItem(nil, 0, 1, false),
+			70, 1, true,
+		).
+		AddItem(nil, 0, 1, false)
+
+	cv.Table.SetFocusFunc(func() {
+		cv.app.updateFooterHelp(helpBar("Clocks", []helpEntry{
+			{"↑/↓", "Navigate"},
+			{"+/-", "Increment/Decrement"},
+			{"F12", "Help"},
+			{"Esc", "Close"},
+		}))
+		cv.clockFrame.SetBorderColor(Style.BorderFocusColor)
+	})
+	cv.Table.SetBlurFunc(func() {
+		cv.clockFrame.SetBorderColor(Style.BorderColor)
+	})
+}
+
+func (cv *ClockView) setupKeyBindings() {
+	cv.Modal.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEsc {
+			cv.app.HandleEvent(&ClockCancelEvent{
+				BaseEvent: BaseEvent{action: CLOCK_CANCEL},
+			})
+			return nil
+		}
+		return event
+	})
+
+	cv.Table.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Key() {
+		case tcell.KeyF12:
+			cv.app.HandleEvent(&ShowHelpEvent{
+				BaseEvent:   BaseEvent{action: SHOW_HELP},
+				Title:       "Clocks Help",
+				ReturnFocus: cv.Modal,
+				Text:        cv.buildHelpText(),
+			})
+			return nil
+		case tcell.KeyRune:
+			switch event.Rune() {
+			case '+', '=':
+				cv.adjustSelected(1)
+				return nil
+			case '-', '_':
+				cv.adjustSelected(-1)
+				return nil
+			}
+		}
+		return event
+	})
+}
+
+// Refresh reloads the open clocks for the game of the current session
+func (cv *ClockView) Refresh() {
+	cv.clocks = nil
+	if s := cv.app.CurrentSession(); s != nil {
+		clocks, err := cv.tagService.LoadProgressForGame(s.GameID, cv.app.cfg.TagExcludeWords)
+		if err != nil {
+			cv.app.notification.ShowError(fmt.Sprintf("Error loading clocks: %v", err))
+		}
+		cv.clocks = clocks
+	}
+
+	cv.Table.Clear()
+	for col, label := range []string{"Type", "Name", "Progress", ""} {
+		cv.Table.SetCell(0, col, tview.NewTableCell(label).
+			SetTextColor(tcell.ColorYellow).
+			SetAlign(tview.AlignLeft).
+			SetSelectable(false))
+	}
+
+	if len(cv.clocks) == 0 {
+		cv.Table.SetCell(1, 0, tview.NewTableCell("(No open clocks or tracks)").
+			SetTextColor(Style.EmptyStateMessageColor).
+			SetSelectable(false))
+		return
+	}
+
+	for i, p := range cv.clocks {
+		row := i + 1
+		cv.Table.SetCell(row, 0, tview.NewTableCell(tview.Escape(p.Type)).
+			SetTextColor(tcell.ColorWhite))
+		cv.Table.SetCell(row, 1, tview.NewTableCell(tview.Escape(p.Name)).
+			SetTextColor(tcell.ColorWhite).
+			SetExpansion(1))
+		cv.Table.SetCell(row, 2, tview.NewTableCell(progressBar(p)).
+			SetTextColor(tcell.ColorWhite))
+		full := ""
+		if p.IsFull() {
+			full = "[" + Style.ErrorTextColor + "::b]FULL[-::-]"
+		}
+		cv.Table.SetCell(row, 3, tview.NewTableCell(full))
+	}
+	cv.Table.Select(1, 0)
+}
+
+// Selected returns the clock on the selected row, or nil if there is none
+func (cv *ClockView) Selected() *lonelog.Progress {
+	row, _ := cv.Table.GetSelection()
+	if row < 1 || row > len(cv.clocks) {
+		return nil
+	}
+	return cv.clocks[row-1]
+}
+
+// SelectKey selects the row of the clock with the given key
+func (cv *ClockView) SelectKey(key string) {
+	for i, p := range cv.clocks {
+		if p.Key() == key {
+			cv.Table.Select(i+1, 0)
+			return
+		}
+	}
+}
+
+func (cv *ClockView) adjustSelected(delta int) {
+	p := cv.Selected()
+	if p == nil {
+		return
+	}
+	cv.app.HandleEvent(&ClockAdjustEvent{
+		BaseEvent: BaseEvent{action: CLOCK_ADJUST},
+		Progress:  p,
+		Delta:     delta,
+	})
+}
+
+// progressBar renders "3/6 ■■■□□□" for a clock
+func progressBar(p *lonelog.Progress) string {
+	bar := fmt.Sprintf("%d/%d", p.Current, p.Max)
+	if p.Max > 20 {
+		return bar
+	}
+	filled := min(p.Current, p.Max)
+	return bar + " " + strings.Repeat("■", filled) + strings.Repeat("□", p.Max-filled)
+}
+
+func (cv *ClockView) buildHelpText() string {
+	return strings.NewReplacer(
+		"[yellow]", "["+Style.HelpKeyTextColor+"]",
+		"[white]", "["+Style.NormalTextColor+"]",
+		"[green]", "["+Style.HelpSectionColor+"]",
+	).Replace(`[green]Clocks and Tracks[white]
+
+Clock and Track tags with a current/maximum value are listed here with their latest value from the game's sessions.
+
+  [yellow][Clock:Reinforcements 3/6][white]
+  [yellow][Track:Escape | 2/8][white]
+
+[green]Keys[white]
+
+[yellow]+[white]  Increment the selected clock
+[yellow]-[white]  Decrement the selected clock
+
+Each change inserts an updated tag line into the current session at the cursor. A clock that reaches its maximum is flagged as FULL.
+
+Add a close word to the tag's data section to remove it from this list.`)
+}
@@ -0,0 +1,67 @@
+package ui
+
+import (
+	testHelper "soloterm/shared/testing"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// openClockModal is a test helper that creates a game with a session holding
+// content, selects the session, and opens the clock modal via F6.
+func openClockModal(t *testing.T, app *App, content string) {
+	t.Helper()
+	g := createGame(t, app, "Test Game")
+	s := createSession(t, app, g.ID, "Test Session")
+	s.Content = content
+	_, err := app.sessionView.sessionService.Save(s)
+	require.NoError(t, err)
+	app.gameView.Refresh()
+	app.gameView.SelectSession(s.ID)
+	app.sessionView.currentSessionID = &s.ID
+	app.sessionView.Refresh()
+	app.SetFocus(app.sessionView.TextArea)
+	testHelper.SimulateKey(app.sessionView.TextArea, app.Application, tcell.KeyF6)
+}
+
+func TestClockView_OpenAndClose(t *testing.T) {
+	app := setupTestApp(t)
+	openClockModal(t, app, "")
+	assert.True(t, app.isPageVisible(CLOCK_MODAL_ID), "Expected clock modal to be visible")
+
+	testHelper.SimulateKey(app.clockView.Modal, app.Application, tcell.KeyEsc)
+	assert.False(t, app.isPageVisible(CLOCK_MODAL_ID), "Expected clock modal to be hidden after Escape")
+	assert.Equal(t, app.sessionView.TextArea, app.GetFocus(), "Expected focus to return to the session")
+}
+
+func TestClockView_ListsOpenClocks(t *testing.T) {
+	app := setupTestApp(t)
+	openClockModal(t, app, "[Clock:Alarm 2/4]\n[Track:Escape | 8/8]\n[Clock:Done 1/4 | closed]")
+
+	require.Len(t, app.clockView.clocks, 2, "Closed clocks should not be listed")
+	assert.Equal(t, "Alarm", app.clockView.Table.GetCell(1, 1).Text)
+	assert.Equal(t, "", app.clockView.Table.GetCell(1, 3).Text, "Alarm is not full")
+	assert.Contains(t, app.clockView.Table.GetCell(2, 3).Text, "FULL", "Escape should be flagged as full")
+}
+
+func TestClockView_IncrementInsertsUpdatedTag(t *testing.T) {
+	app := setupTestApp(t)
+	openClockModal(t, app, "[Clock:Alarm 2/4]")
+	app.sessionView.TextArea.Select(len("[Clock:Alarm 2/4]"), len("[Clock:Alarm 2/4]"))
+
+	testHelper.SimulateRune(app.clockView.Table, app.Application, '+')
+
+	assert.Equal(t, "[Clock:Alarm 2/4]\n[Clock:Alarm 3/4]\n", app.sessionView.TextArea.GetText())
+	require.Len(t, app.clockView.clocks, 1)
+	assert.Equal(t, 3, app.clockView.clocks[0].Current, "List should show the new value")
+
+	saved, err := app.sessionView.sessionService.GetByID(*app.sessionView.currentSessionID)
+	require.NoError(t, err)
+	assert.Equal(t, app.sessionView.TextArea.GetText(), saved.Content, "Change should be autosaved")
+
+	testHelper.SimulateRune(app.clockView.Table, app.Application, '-')
+	testHelper.SimulateRune(app.clockView.Table, app.Application, '-')
+	assert.Equal(t, 1, app.clockView.clocks[0].Current)
+}
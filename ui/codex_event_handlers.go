@@ -0,0 +1,193 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"soloterm/domain/codex"
+
+	"github.com/rivo/tview"
+)
+
+func (a *App) handleCodexShow(e *CodexShowEvent) {
+	if e.GameID == 0 {
+		a.notification.ShowWarning("Select a game to open its codex.")
+		return
+	}
+	a.codexView.returnFocus = a.GetFocus()
+	a.codexView.Load(e.GameID, e.EntryID)
+	a.pages.ShowPage(CODEX_MODAL_ID)
+	a.SetFocus(a.codexView.EntryTable)
+}
+
+func (a *App) handleCodexCancel(_ *CodexCancelEvent) {
+	a.pages.HidePage(CODEX_MODAL_ID)
+	if a.codexView.returnFocus != nil {
+		a.SetFocus(a.codexView.returnFocus)
+	}
+}
+
+// handleCodexTagFollow opens the entry the tag names. When there is none yet
+// the form to add it opens over the codex, filled in from the tag.
+func (a *App) handleCodexTagFollow(e *CodexTagFollowEvent) {
+	a.Autosave()
+
+	gameID := a.currentGameID()
+	entry, err := a.codexView.codexService.ResolveTag(gameID, e.Tag)
+	if errors.Is(err, codex.ErrNotFound) {
+		kind, ok := codex.KindForTagType(e.Tag.Type)
+		if !ok {
+			a.notification.ShowWarning(fmt.Sprintf("%s is not a codex tag. Use N, L, F or I tags, such as [N:Name[].", tview.Escape("["+e.Tag.Identifier+"]")))
+			return
+		}
+		a.HandleEvent(&CodexShowEvent{
+			BaseEvent: BaseEvent{action: CODEX_SHOW},
+			GameID:    gameID,
+		})
+		a.HandleEvent(&CodexEntryShowNewEvent{
+			BaseEvent: BaseEvent{action: CODEX_ENTRY_SHOW_NEW},
+			Kind:      kind,
+			Name:      e.Tag.Name,
+		})
+		return
+	}
+	if err != nil {
+		a.notification.ShowError(fmt.Sprintf("Error finding the codex entry: %v", err))
+		return
+	}
+
+	a.HandleEvent(&CodexShowEvent{
+		BaseEvent: BaseEvent{action: CODEX_SHOW},
+		GameID:    gameID,
+		EntryID:   entry.ID,
+	})
+}
+
+func (a *App) handleCodexAppearanceSelect(e *CodexAppearanceSelectEvent) {
+	a.pages.HidePage(CODEX_MODAL_ID)
+	a.openAt(e.Appearance.SessionID, 0, e.Appearance.Offset, len(e.Appearance.Raw))
+}
+
+func (a *App) handleCodexEntryShowNew(e *CodexEntryShowNewEvent) {
+	a.codexView.Form.Reset(a.codexView.gameID, e.Kind, e.Name)
+	a.codexView.formModal.SetTitle(" New Codex Entry ")
+	a.pages.ShowPage(CODEX_FORM_MODAL_ID)
+	a.SetFocus(a.codexView.Form)
+}
+
+func (a *App) handleCodexEntryShowEdit(e *CodexEntryShowEditEvent) {
+	a.codexView.Form.PopulateForEdit(e.Entry)
+	a.codexView.formModal.SetTitle(" Edit Codex Entry ")
+	a.pages.ShowPage(CODEX_FORM_MODAL_ID)
+	a.SetFocus(a.codexView.Form)
+}
+
+func (a *App) handleCodexEntryFormCancel(_ *CodexEntryFormCancelEvent) {
+	a.codexView.Form.ClearFieldErrors()
+	a.pages.HidePage(CODEX_FORM_MODAL_ID)
+	a.SetFocus(a.codexView.EntryTable)
+}
+
+func (a *App) handleCodexEntrySaved(e *CodexEntrySavedEvent) {
+	a.codexView.Form.ClearFieldErrors()
+	a.pages.HidePage(CODEX_FORM_MODAL_ID)
+	a.codexView.Load(e.Entry.GameID, e.Entry.ID)
+	a.SetFocus(a.codexView.EntryTable)
+	a.notification.ShowSuccess("Codex entry saved successfully")
+}
+
+func (a *App) handleCodexEntryDeleteConfirm(e *CodexEntryDeleteConfirmEvent) {
+	returnFocus := a.GetFocus()
+	a.confirmModal.Configure(
+		fmt.Sprintf("Are you sure you want to delete %s?\n\nIts fields and links will be deleted too.", tview.Escape(e.Entry.Name)),
+		func() {
+			err := a.codexView.codexService.Delete(e.Entry.ID)
+			if err != nil {
+				a.HandleEvent(&CodexEntryDeleteFailedEvent{
+					BaseEvent: BaseEvent{action: CODEX_ENTRY_DELETE_FAILED},
+					Error:     err,
+				})
+				return
+			}
+			a.HandleEvent(&CodexEntryDeletedEvent{
+				BaseEvent: BaseEvent{action: CODEX_ENTRY_DELETED},
+			})
+		},
+		func() {
+			a.pages.HidePage(CONFIRM_MODAL_ID)
+			a.SetFocus(returnFocus)
+		},
+	)
+	a.pages.ShowPage(CONFIRM_MODAL_ID)
+}
+
+func (a *App) handleCodexEntryDeleted(_ *CodexEntryDeletedEvent) {
+	a.pages.HidePage(CONFIRM_MODAL_ID)
+	a.pages.HidePage(CODEX_FORM_MODAL_ID)
+	a.codexView.Load(a.codexView.gameID, 0)
+	a.SetFocus(a.codexView.EntryTable)
+	a.notification.ShowSuccess("Codex entry deleted successfully")
+}
+
+func (a *App) handleCodexEntryDeleteFailed(e *CodexEntryDeleteFailedEvent) {
+	a.pages.HidePage(CONFIRM_MODAL_ID)
+	a.notification.ShowError("Failed to delete codex entry: " + e.Error.Error())
+}
+
+func (a *App) handleCodexRelationShowNew(e *CodexRelationShowNewEvent) {
+	a.codexView.RelationForm.Reset(e.Entry, a.codexView.entries)
+	if !a.codexView.RelationForm.HasTargets() {
+		a.notification.ShowWarning("Add another codex entry to link to first.")
+		return
+	}
+	a.codexView.relationModal.SetTitle(" Link " + tview.Escape(e.Entry.Name) + " ")
+	a.pages.ShowPage(CODEX_RELATION_MODAL_ID)
+	a.SetFocus(a.codexView.RelationForm)
+}
+
+func (a *App) handleCodexRelationFormCancel(_ *CodexRelationFormCancelEvent) {
+	a.codexView.RelationForm.ClearFieldErrors()
+	a.pages.HidePage(CODEX_RELATION_MODAL_ID)
+	a.SetFocus(a.codexView.EntryTable)
+}
+
+func (a *App) handleCodexRelationSaved(e *CodexRelationSavedEvent) {
+	a.codexView.RelationForm.ClearFieldErrors()
+	a.pages.HidePage(CODEX_RELATION_MODAL_ID)
+	a.codexView.Load(a.codexView.gameID, e.Relation.FromID)
+	a.SetFocus(a.codexView.EntryTable)
+	a.notification.ShowSuccess("Entries linked successfully")
+}
+
+func (a *App) handleCodexRelationDeleteConfirm(e *CodexRelationDeleteConfirmEvent) {
+	returnFocus := a.GetFocus()
+	a.confirmModal.Configure(
+		fmt.Sprintf("Remove the link to %s?", tview.Escape(e.Connection.OtherName)),
+		func() {
+			if err := a.codexView.codexService.Unrelate(e.Connection.ID); err != nil {
+				a.pages.HidePage(CONFIRM_MODAL_ID)
+				a.SetFocus(returnFocus)
+				a.notification.ShowError("Failed to remove the link: " + err.Error())
+				return
+			}
+			a.HandleEvent(&CodexRelationDeletedEvent{
+				BaseEvent: BaseEvent{action: CODEX_RELATION_DELETED},
+			})
+		},
+		func() {
+			a.pages.HidePage(CONFIRM_MODAL_ID)
+			a.SetFocus(returnFocus)
+		},
+	)
+	a.pages.ShowPage(CONFIRM_MODAL_ID)
+}
+
+func (a *App) handleCodexRelationDeleted(_ *CodexRelationDeletedEvent) {
+	a.pages.HidePage(CONFIRM_MODAL_ID)
+	var entryID int64
+	if a.codexView.entry != nil {
+		entryID = a.codexView.entry.ID
+	}
+	a.codexView.Load(a.codexView.gameID, entryID)
+	a.SetFocus(a.codexView.LinkTable)
+	a.notification.ShowSuccess("Link removed successfully")
+}
@@ -0,0 +1,156 @@
+package ui
+
+import (
+	"soloterm/domain/codex"
+	sharedui "soloterm/shared/ui"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// CodexForm represents a form for creating/editing codex entries
+type CodexForm struct {
+	*sharedui.DataForm
+	entry        *codex.Entry // the entry being edited, or nil for a new entry
+	gameID       int64
+	kindDropdown *tview.DropDown
+	nameField    *tview.InputField
+	summaryField *tview.TextArea
+	fieldsField  *tview.TextArea
+}
+
+// NewCodexForm creates a new codex entry form
+func NewCodexForm() *CodexForm {
+	f := &CodexForm{
+		DataForm: sharedui.NewDataForm(),
+	}
+
+	labels := make([]string, len(codex.Kinds))
+	for i, k := range codex.Kinds {
+		labels[i] = k.Label()
+	}
+	f.kindDropdown = tview.NewDropDown().
+		SetLabel("Kind").
+		SetOptions(labels, nil).
+		SetFieldBackgroundColor(tcell.ColorDefault)
+
+	f.nameField = tview.NewInputField().
+		SetLabel("Name").
+		SetFieldBackgroundColor(tcell.ColorDefault).
+		SetFieldWidth(0)
+
+	f.summaryField = tview.NewTextArea().
+		SetLabel("Summary").
+		SetMaxLength(codex.MaxSummaryLength).
+		SetSize(3, 0)
+
+	f.fieldsField = tview.NewTextArea().
+		SetLabel("Fields").
+		SetPlaceholder("One per line, e.g.\nAge: 42\nMotive: Revenge").
+		SetPlaceholderStyle(tcell.StyleDefault.Foreground(Style.EmptyStateMessageColor)).
+		SetSize(5, 0)
+
+	f.Clear(true)
+	f.AddFormItem(f.kindDropdown)
+	f.AddFormItem(f.nameField)
+	f.AddFormItem(f.summaryField)
+	f.AddFormItem(f.fieldsField)
+	f.SetBorder(false)
+	f.SetButtonsAlign(tview.AlignCenter)
+	f.SetItemPadding(1)
+
+	return f
+}
+
+// Reset clears the form for a new entry in the game, filled in with kind
+// and name when they are set
+func (f *CodexForm) Reset(gameID int64, kind codex.Kind, name string) {
+	f.entry = nil
+	f.gameID = gameID
+	f.selectKind(kind)
+	f.nameField.SetText(name)
+	f.summaryField.SetText("", false)
+	f.fieldsField.SetText("", false)
+	f.ClearFieldErrors()
+	f.RemoveDeleteButton()
+	f.SetFocus(0)
+}
+
+// PopulateForEdit fills the form with an existing entry's data
+func (f *CodexForm) PopulateForEdit(entry *codex.Entry) {
+	f.entry = entry
+	f.gameID = entry.GameID
+	f.selectKind(entry.Kind)
+	f.nameField.SetText(entry.Name)
+	f.summaryField.SetText(entry.Summary, false)
+	f.fieldsField.SetText(codex.FormatFields(entry.Fields), false)
+	f.ClearFieldErrors()
+	f.AddDeleteButton()
+	f.SetFocus(0)
+}
+
+// BuildDomain constructs an Entry from the current form values
+func (f *CodexForm) BuildDomain() *codex.Entry {
+	kind := codex.KindNPC
+	if idx, _ := f.kindDropdown.GetCurrentOption(); idx >= 0 && idx < len(codex.Kinds) {
+		kind = codex.Kinds[idx]
+	}
+
+	entry, _ := codex.NewEntry(f.gameID, kind, f.nameField.GetText())
+	if f.entry != nil {
+		entry.ID = f.entry.ID
+		entry.CreatedAt = f.entry.CreatedAt
+	}
+	entry.Summary = f.summaryField.GetText()
+	entry.Fields = codex.ParseFields(f.fieldsField.GetText())
+	return entry
+}
+
+// selectKind selects kind in the dropdown, or the first kind when it isn't set
+func (f *CodexForm) selectKind(kind codex.Kind) {
+	f.kindDropdown.SetCurrentOption(0)
+	for i, k := range codex.Kinds {
+		if k == kind {
+			f.kindDropdown.SetCurrentOption(i)
+			break
+		}
+	}
+}
+
+// SetFieldErrors sets errors and updates field labels
+func (f *CodexForm) SetFieldErrors(errors map[string]string) {
+	f.DataForm.SetFieldErrors(errors)
+	f.updateFieldLabels()
+}
+
+// ClearFieldErrors removes all error highlights
+func (f *CodexForm) ClearFieldErrors() {
+	f.DataForm.ClearFieldErrors()
+	f.updateFieldLabels()
+}
+
+func (f *CodexForm) updateFieldLabels() {
+	if f.HasFieldError("kind") {
+		f.kindDropdown.SetLabel("[" + Style.ErrorTextColor + "]Kind[" + Style.NormalTextColor + "]")
+	} else {
+		f.kindDropdown.SetLabel("Kind")
+	}
+
+	if f.HasFieldError("name") {
+		f.nameField.SetLabel("[" + Style.ErrorTextColor + "]Name[" + Style.NormalTextColor + "]")
+	} else {
+		f.nameField.SetLabel("Name")
+	}
+
+	if f.HasFieldError("summary") {
+		f.summaryField.SetLabel("[" + Style.ErrorTextColor + "]Summary[" + Style.NormalTextColor + "]")
+	} else {
+		f.summaryField.SetLabel("Summary")
+	}
+
+	if f.HasFieldError("fields") {
+		f.fieldsField.SetLabel("[" + Style.ErrorTextColor + "]Fields[" + Style.NormalTextColor + "]")
+	} else {
+		f.fieldsField.SetLabel("Fields")
+	}
+}
@@ -0,0 +1,109 @@
+package ui
+
+import (
+	"soloterm/domain/codex"
+	sharedui "soloterm/shared/ui"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// CodexRelationForm represents a form for linking one codex entry to another
+type CodexRelationForm struct {
+	*sharedui.DataForm
+	entry        *codex.Entry
+	targets      []*codex.Entry // parallel to targetField
+	typeDropdown *tview.DropDown
+	targetField  *tview.DropDown
+}
+
+// NewCodexRelationForm creates a new codex relation form
+func NewCodexRelationForm() *CodexRelationForm {
+	f := &CodexRelationForm{
+		DataForm: sharedui.NewDataForm(),
+	}
+
+	labels := make([]string, len(codex.RelationTypes))
+	for i, t := range codex.RelationTypes {
+		labels[i] = t.Label()
+	}
+	f.typeDropdown = tview.NewDropDown().
+		SetLabel("Relation").
+		SetOptions(labels, nil).
+		SetFieldBackgroundColor(tcell.ColorDefault)
+
+	f.targetField = tview.NewDropDown().
+		SetLabel("Entry").
+		SetFieldBackgroundColor(tcell.ColorDefault)
+
+	f.Clear(true)
+	f.AddFormItem(f.typeDropdown)
+	f.AddFormItem(f.targetField)
+	f.SetBorder(false)
+	f.SetButtonsAlign(tview.AlignCenter)
+	f.SetItemPadding(1)
+
+	return f
+}
+
+// Reset prepares the form to link entry to one of the other entries in
+// entries
+func (f *CodexRelationForm) Reset(entry *codex.Entry, entries []*codex.Entry) {
+	f.entry = entry
+	f.targets = nil
+	var options []string
+	for _, e := range entries {
+		if e.ID != entry.ID {
+			f.targets = append(f.targets, e)
+			options = append(options, e.Name+" ("+e.Kind.Label()+")")
+		}
+	}
+	f.targetField.SetOptions(options, nil)
+	f.targetField.SetCurrentOption(0)
+	f.typeDropdown.SetCurrentOption(0)
+	f.ClearFieldErrors()
+	f.SetFocus(0)
+}
+
+// HasTargets reports whether there is another entry to link to
+func (f *CodexRelationForm) HasTargets() bool {
+	return len(f.targets) > 0
+}
+
+// BuildDomain constructs a Relation from the current form values
+func (f *CodexRelationForm) BuildDomain() *codex.Relation {
+	relation := &codex.Relation{FromID: f.entry.ID}
+	if idx, _ := f.typeDropdown.GetCurrentOption(); idx >= 0 && idx < len(codex.RelationTypes) {
+		relation.Type = codex.RelationTypes[idx]
+	}
+	if idx, _ := f.targetField.GetCurrentOption(); idx >= 0 && idx < len(f.targets) {
+		relation.ToID = f.targets[idx].ID
+	}
+	return relation
+}
+
+// SetFieldErrors sets errors and updates field labels
+func (f *CodexRelationForm) SetFieldErrors(errors map[string]string) {
+	f.DataForm.SetFieldErrors(errors)
+	f.updateFieldLabels()
+}
+
+// ClearFieldErrors removes all error highlights
+func (f *CodexRelationForm) ClearFieldErrors() {
+	f.DataForm.ClearFieldErrors()
+	f.updateFieldLabels()
+}
+
+func (f *CodexRelationForm) updateFieldLabels() {
+	if f.HasFieldError("type") {
+		f.typeDropdown.SetLabel("[" + Style.ErrorTextColor + "]Relation[" + Style.NormalTextColor + "]")
+	} else {
+		f.typeDropdown.SetLabel("Relation")
+	}
+
+	if f.HasFieldError("to_id") {
+		f.targetField.SetLabel("[" + Style.ErrorTextColor + "]Entry[" + Style.NormalTextColor + "]")
+	} else {
+		f.targetField.SetLabel("Entry")
+	}
+}
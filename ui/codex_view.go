@@ -0,0 +1,557 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"soloterm/domain/codex"
+	"soloterm/domain/graph"
+	"soloterm/shared/text"
+	sharedui "soloterm/shared/ui"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// CodexView lists a game's NPCs, locations, factions and items, with the
+// fields, links and session appearances of the selected entry
+type CodexView struct {
+	app          *App
+	codexService *codex.Service
+	graphService *graph.Service
+
+	Modal         *tview.Flex // list modal — registered with pages
+	FormModal     *tview.Flex // edit/new modal — registered with pages
+	RelationModal *tview.Flex // link modal — registered with pages
+	frame         *tview.Frame
+	EntryTable    *tview.Table
+	Detail        *tview.TextView
+	LinkTable     *tview.Table
+	Form          *CodexForm
+	formModal     *sharedui.FormModal
+	RelationForm  *CodexRelationForm
+	relationModal *sharedui.FormModal
+
+	gameID      int64
+	entries     []*codex.Entry
+	entry       *codex.Entry // the selected entry, with its fields
+	connections []*codex.Connection
+	appearances []codex.Appearance
+
+	returnFocus tview.Primitive
+}
+
+// NewCodexView creates a new codex view
+func NewCodexView(app *App, codexService *codex.Service, graphService *graph.Service) *CodexView {
+	cv := &CodexView{app: app, codexService: codexService, graphService: graphService}
+	cv.setup()
+	return cv
+}
+
+func (cv *CodexView) setup() {
+	cv.setupTables()
+	cv.setupLayout()
+	cv.setupFormModals()
+	cv.setupKeyBindings()
+}
+
+func (cv *CodexView) setupTables() {
+	cv.EntryTable = tview.NewTable().
+		SetSelectable(true, false).
+		SetSelectedStyle(tcell.Style{}.Background(tcell.ColorAqua).Foreground(tcell.ColorBlack))
+	cv.EntryTable.SetBorder(false)
+	cv.EntryTable.SetSelectionChangedFunc(func(row, _ int) {
+		cv.showEntry(row)
+	})
+
+	cv.Detail = tview.NewTextView().
+		SetDynamicColors(true).
+		SetWordWrap(true)
+
+	cv.LinkTable = tview.NewTable().
+		SetSelectable(true, false).
+		SetSelectedStyle(tcell.Style{}.Background(tcell.ColorAqua).Foreground(tcell.ColorBlack))
+	cv.LinkTable.SetBorder(false)
+}
+
+func (cv *CodexView) setupLayout() {
+	details := tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(cv.Detail, 0, 1, false).
+		AddItem(cv.LinkTable, 0, 1, false)
+
+	content := tview.NewFlex().
+		AddItem(cv.EntryTable, 0, 1, true).
+		AddItem(nil, 2, 0, false).
+		AddItem(details, 0, 2, false)
+
+	cv.frame = tview.NewFrame(content).
+		SetBorders(1, 0, 0, 0, 1, 1)
+	cv.frame.SetBorder(true).
+		SetTitleAlign(tview.AlignLeft).
+		SetTitle("[::b] Codex ([" + Style.HelpKeyTextColor + "]Esc[" + Style.NormalTextColor + "] Close) [-::-]")
+
+	cv.Modal = tview.NewFlex().
+		AddItem(nil, 0, 1, false).
+		AddItem(
+			tview.NewFlex().
+				SetDirection(tview.FlexRow).
+				AddItem(nil, 0, 1, false).
+				AddItem(cv.frame, 0, 4, true).
+				AddItem(nil, 0, 1, false),
+			0, 4, true,
+		).
+		AddItem(nil, 0, 1, false)
+
+	cv.EntryTable.SetFocusFunc(func() {
+		cv.app.updateFooterHelp(helpBar("Codex", []helpEntry{
+			{"↑/↓", "Navigate"},
+			{"Tab", "Links"},
+			{"n", "New"},
+			{"e", "Edit"},
+			{"l", "Link"},
+			{"Ctrl+X", "Export Graph"},
+			{"F12", "Help"},
+			{"Esc", "Close"},
+		}))
+		cv.frame.SetBorderColor(Style.BorderFocusColor)
+	})
+	cv.EntryTable.SetBlurFunc(func() {
+		cv.frame.SetBorderColor(Style.BorderColor)
+	})
+
+	cv.LinkTable.SetFocusFunc(func() {
+		cv.app.updateFooterHelp(helpBar("Codex", []helpEntry{
+			{"↑/↓", "Navigate"},
+			{"Enter", "Go To"},
+			{"d", "Remove Link"},
+			{"Tab", "Entries"},
+			{"F12", "Help"},
+			{"Esc", "Close"},
+		}))
+		cv.frame.SetBorderColor(Style.BorderFocusColor)
+	})
+	cv.LinkTable.SetBlurFunc(func() {
+		cv.frame.SetBorderColor(Style.BorderColor)
+	})
+}
+
+func (cv *CodexView) setupFormModals() {
+	cv.Form = NewCodexForm()
+	cv.Form.SetupHandlers(cv.HandleSave, cv.HandleCancel, cv.HandleDelete)
+	cv.formModal = sharedui.NewFormModal(cv.Form, 21)
+	cv.FormModal = cv.formModal.Modal
+
+	cv.Form.SetFocusFunc(func() {
+		cv.app.SetModalHelpMessage(*cv.Form.DataForm)
+		cv.formModal.SetBorderColor(Style.BorderFocusColor)
+	})
+	cv.Form.SetBlurFunc(func() {
+		cv.formModal.SetBorderColor(Style.BorderColor)
+	})
+
+	cv.RelationForm = NewCodexRelationForm()
+	cv.RelationForm.SetupHandlers(cv.HandleRelationSave, cv.HandleRelationCancel, nil)
+	cv.RelationForm.GetButton(0).SetLabel("Link")
+	cv.relationModal = sharedui.NewFormModal(cv.RelationForm, 9)
+	cv.RelationModal = cv.relationModal.Modal
+
+	cv.RelationForm.SetFocusFunc(func() {
+		cv.app.updateFooterHelp(helpBar("Link Entry", []helpEntry{{"Ctrl+S", "Link"}, {"Esc", "Cancel"}}))
+		cv.relationModal.SetBorderColor(Style.BorderFocusColor)
+	})
+	cv.RelationForm.SetBlurFunc(func() {
+		cv.relationModal.SetBorderColor(Style.BorderColor)
+	})
+}
+
+func (cv *CodexView) setupKeyBindings() {
+	cv.EntryTable.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Key() {
+		case tcell.KeyEnter:
+			cv.app.SetFocus(cv.LinkTable)
+			return nil
+		case tcell.KeyRune:
+			switch event.Rune() {
+			case 'e':
+				if cv.entry != nil {
+					cv.app.HandleEvent(&CodexEntryShowEditEvent{
+						BaseEvent: BaseEvent{action: CODEX_ENTRY_SHOW_EDIT},
+						Entry:     cv.entry,
+					})
+				}
+				return nil
+			}
+		}
+		return event
+	})
+
+	cv.LinkTable.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Key() {
+		case tcell.KeyEnter:
+			cv.handleFollow()
+			return nil
+		case tcell.KeyRune:
+			if event.Rune() == 'd' {
+				if c, ok := cv.selectedReference().(*codex.Connection); ok {
+					cv.app.HandleEvent(&CodexRelationDeleteConfirmEvent{
+						BaseEvent:  BaseEvent{action: CODEX_RELATION_DELETE_CONFIRM},
+						Connection: c,
+					})
+				}
+				return nil
+			}
+		}
+		return event
+	})
+
+	cv.Modal.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Key() {
+		case tcell.KeyTab:
+			if cv.LinkTable.HasFocus() {
+				cv.app.SetFocus(cv.EntryTable)
+			} else {
+				cv.app.SetFocus(cv.LinkTable)
+			}
+			return nil
+		case tcell.KeyRune:
+			switch event.Rune() {
+			case 'n':
+				cv.app.HandleEvent(&CodexEntryShowNewEvent{
+					BaseEvent: BaseEvent{action: CODEX_ENTRY_SHOW_NEW},
+				})
+				return nil
+			case 'l':
+				if cv.entry != nil {
+					cv.app.HandleEvent(&CodexRelationShowNewEvent{
+						BaseEvent: BaseEvent{action: CODEX_RELATION_SHOW_NEW},
+						Entry:     cv.entry,
+					})
+				}
+				return nil
+			}
+		case tcell.KeyCtrlX:
+			cv.exportGraph()
+			return nil
+		case tcell.KeyEsc:
+			cv.app.HandleEvent(&CodexCancelEvent{
+				BaseEvent: BaseEvent{action: CODEX_CANCEL},
+			})
+			return nil
+		case tcell.KeyF12:
+			cv.app.HandleEvent(&ShowHelpEvent{
+				BaseEvent:   BaseEvent{action: SHOW_HELP},
+				Title:       "Codex Help",
+				ReturnFocus: cv.Modal,
+				Text:        cv.buildHelpText(),
+			})
+			return nil
+		}
+		return event
+	})
+}
+
+// exportGraph opens the file export for the graph of how the game's tags
+// and entries connect
+func (cv *CodexView) exportGraph() {
+	g, err := cv.graphService.Build(cv.gameID)
+	if err != nil {
+		cv.app.notification.ShowError(fmt.Sprintf("Error building the graph: %v", err))
+		return
+	}
+	if g.IsEmpty() {
+		cv.app.notification.ShowWarning("There are no shared tags or links to export.")
+		return
+	}
+
+	title := "Codex"
+	if game, err := cv.app.gameView.gameService.GetByID(cv.gameID); err == nil {
+		title = game.Name
+	}
+	cv.app.fileView.ShowExport(NewGraphExport(g, title), cv.app.GetFocus())
+}
+
+// HandleSave saves the entry from the form.
+func (cv *CodexView) HandleSave() {
+	entry := cv.Form.BuildDomain()
+	isNew := entry.IsNew()
+	saved, err := cv.codexService.Save(entry)
+	if err != nil {
+		if sharedui.HandleValidationError(err, cv.Form) {
+			return
+		}
+		cv.app.notification.ShowError("Failed to save codex entry: " + err.Error())
+		return
+	}
+	cv.app.HandleEvent(&CodexEntrySavedEvent{
+		BaseEvent: BaseEvent{action: CODEX_ENTRY_SAVED},
+		Entry:     saved,
+		IsNew:     isNew,
+	})
+}
+
+// HandleCancel closes the form modal without saving.
+func (cv *CodexView) HandleCancel() {
+	cv.app.HandleEvent(&CodexEntryFormCancelEvent{
+		BaseEvent: BaseEvent{action: CODEX_ENTRY_FORM_CANCEL},
+	})
+}
+
+// HandleDelete fires a delete confirmation for the entry currently in the form.
+func (cv *CodexView) HandleDelete() {
+	entry := cv.Form.BuildDomain()
+	if entry.IsNew() {
+		cv.HandleCancel()
+		return
+	}
+	cv.app.HandleEvent(&CodexEntryDeleteConfirmEvent{
+		BaseEvent: BaseEvent{action: CODEX_ENTRY_DELETE_CONFIRM},
+		Entry:     entry,
+	})
+}
+
+// HandleRelationSave links the selected entry as chosen in the relation form.
+func (cv *CodexView) HandleRelationSave() {
+	relation, err := cv.codexService.Relate(cv.RelationForm.BuildDomain())
+	if err != nil {
+		if sharedui.HandleValidationError(err, cv.RelationForm) {
+			return
+		}
+		cv.app.notification.ShowError("Failed to link entries: " + err.Error())
+		return
+	}
+	cv.app.HandleEvent(&CodexRelationSavedEvent{
+		BaseEvent: BaseEvent{action: CODEX_RELATION_SAVED},
+		Relation:  relation,
+	})
+}
+
+// HandleRelationCancel closes the relation modal without saving.
+func (cv *CodexView) HandleRelationCancel() {
+	cv.app.HandleEvent(&CodexRelationFormCancelEvent{
+		BaseEvent: BaseEvent{action: CODEX_RELATION_FORM_CANCEL},
+	})
+}
+
+// handleFollow goes to the entry at the other end of the selected connection,
+// or to the session of the selected appearance
+func (cv *CodexView) handleFollow() {
+	switch ref := cv.selectedReference().(type) {
+	case *codex.Connection:
+		cv.selectEntry(ref.OtherID)
+		cv.app.SetFocus(cv.EntryTable)
+	case *codex.Appearance:
+		cv.app.HandleEvent(&CodexAppearanceSelectEvent{
+			BaseEvent:  BaseEvent{action: CODEX_APPEARANCE_SELECT},
+			Appearance: ref,
+		})
+	}
+}
+
+// selectedReference returns the connection or appearance on the selected
+// link row, or nil for headings and empty rows
+func (cv *CodexView) selectedReference() any {
+	row, _ := cv.LinkTable.GetSelection()
+	return cv.LinkTable.GetCell(row, 0).GetReference()
+}
+
+// Load reads the game's entries into the list and selects the entry with
+// entryID, or the first entry when it isn't found
+func (cv *CodexView) Load(gameID int64, entryID int64) {
+	cv.gameID = gameID
+	entries, err := cv.codexService.GetAllForGame(gameID)
+	if err != nil {
+		cv.app.notification.ShowError(fmt.Sprintf("Error loading codex: %v", err))
+	}
+	cv.entries = entries
+
+	cv.EntryTable.Clear()
+	cv.entry = nil
+	if len(cv.entries) == 0 {
+		cv.EntryTable.SetCell(0, 0, tview.NewTableCell("No entries yet. Press n to add one.").
+			SetTextColor(Style.EmptyStateMessageColor).
+			SetSelectable(false))
+		cv.renderEntry()
+		return
+	}
+
+	for row, e := range cv.entries {
+		cv.EntryTable.SetCell(row, 0, tview.NewTableCell(e.Kind.Label()).
+			SetTextColor(tcell.ColorYellow).
+			SetReference(e.ID))
+		cv.EntryTable.SetCell(row, 1, tview.NewTableCell(tview.Escape(e.Name)).
+			SetExpansion(1))
+	}
+
+	cv.EntryTable.Select(0, 0)
+	cv.selectEntry(entryID)
+	if cv.entry == nil {
+		cv.showEntry(0)
+	}
+}
+
+// selectEntry selects the entry with id in the list, when it is there
+func (cv *CodexView) selectEntry(id int64) {
+	for row := 0; row < cv.EntryTable.GetRowCount(); row++ {
+		ref := cv.EntryTable.GetCell(row, 0).GetReference()
+		if ref != nil && ref.(int64) == id {
+			cv.EntryTable.Select(row, 0)
+			cv.showEntry(row)
+			return
+		}
+	}
+}
+
+// showEntry loads the entry on row with its fields, links and appearances
+func (cv *CodexView) showEntry(row int) {
+	ref := cv.EntryTable.GetCell(row, 0).GetReference()
+	if ref == nil {
+		return
+	}
+	if cv.entry != nil && cv.entry.ID == ref.(int64) {
+		return
+	}
+
+	entry, err := cv.codexService.GetByID(ref.(int64))
+	if err != nil {
+		cv.app.notification.ShowError(fmt.Sprintf("Error loading codex entry: %v", err))
+		return
+	}
+	cv.entry = entry
+	cv.renderEntry()
+}
+
+// renderEntry shows the selected entry's details and links
+func (cv *CodexView) renderEntry() {
+	cv.Detail.Clear()
+	cv.LinkTable.Clear()
+	cv.connections = nil
+	cv.appearances = nil
+	if cv.entry == nil {
+		return
+	}
+
+	var err error
+	if cv.connections, err = cv.codexService.Connections(cv.entry.ID); err != nil {
+		cv.app.notification.ShowError(fmt.Sprintf("Error loading links: %v", err))
+	}
+	if cv.appearances, err = cv.codexService.Appearances(cv.entry); err != nil {
+		cv.app.notification.ShowError(fmt.Sprintf("Error loading appearances: %v", err))
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "[%s::b]%s[-::-] [%s]%s[%s]\n",
+		Style.HelpKeyTextColor, tview.Escape(cv.entry.Name),
+		Style.LonelogTagColor, tview.Escape("["+cv.entry.Identifier()+"]"), Style.NormalTextColor)
+	if cv.entry.Summary != "" {
+		b.WriteString("\n" + tview.Escape(cv.entry.Summary) + "\n")
+	}
+	if len(cv.entry.Fields) > 0 {
+		b.WriteString("\n")
+		for _, f := range cv.entry.Fields {
+			fmt.Fprintf(&b, "[%s]%s:[%s] %s\n", Style.HelpKeyTextColor, tview.Escape(f.Name), Style.NormalTextColor, tview.Escape(f.Value))
+		}
+	}
+	cv.Detail.SetText(b.String())
+	cv.Detail.ScrollToBeginning()
+
+	row := 0
+	row = cv.addHeading(row, "Links")
+	if len(cv.connections) == 0 {
+		row = cv.addEmptyRow(row, "(No links yet. Press l to add one.)")
+	}
+	for _, c := range cv.connections {
+		cv.LinkTable.SetCell(row, 0, tview.NewTableCell(c.Label(cv.entry.ID)).
+			SetReference(c))
+		cv.LinkTable.SetCell(row, 1, tview.NewTableCell(tview.Escape(c.OtherName)+" ("+c.OtherKind.Label()+")").
+			SetExpansion(1))
+		row++
+	}
+
+	row = cv.addHeading(row+1, "Appearances")
+	if len(cv.appearances) == 0 {
+		cv.addEmptyRow(row, "(Not tagged in any session yet)")
+	}
+	for i := range cv.appearances {
+		a := &cv.appearances[i]
+		cv.LinkTable.SetCell(row, 0, tview.NewTableCell(tview.Escape(a.SessionName)).
+			SetMaxWidth(25).
+			SetReference(a))
+		mentions := "1 mention"
+		if a.Mentions != 1 {
+			mentions = fmt.Sprintf("%d mentions", a.Mentions)
+		}
+		cv.LinkTable.SetCell(row, 1, tview.NewTableCell(a.PlayedAt.Format(text.DateFormat)+"  "+mentions).
+			SetExpansion(1))
+		row++
+	}
+
+	// Start on the first link or appearance, skipping headings
+	for r := 0; r < cv.LinkTable.GetRowCount(); r++ {
+		if cv.LinkTable.GetCell(r, 0).GetReference() != nil {
+			cv.LinkTable.Select(r, 0)
+			break
+		}
+	}
+	cv.LinkTable.ScrollToBeginning()
+}
+
+func (cv *CodexView) addHeading(row int, label string) int {
+	cv.LinkTable.SetCell(row, 0, tview.NewTableCell(label).
+		SetTextColor(tcell.ColorYellow).
+		SetSelectable(false))
+	return row + 1
+}
+
+func (cv *CodexView) addEmptyRow(row int, message string) int {
+	cv.LinkTable.SetCell(row, 0, tview.NewTableCell(message).
+		SetTextColor(Style.EmptyStateMessageColor).
+		SetSelectable(false))
+	return row + 1
+}
+
+func (cv *CodexView) buildHelpText() string {
+	return strings.NewReplacer(
+		"[yellow]", "["+Style.HelpKeyTextColor+"]",
+		"[white]", "["+Style.NormalTextColor+"]",
+		"[green]", "["+Style.HelpSectionColor+"]",
+	).Replace(`[green]What is the Codex?[white]
+
+The codex keeps the NPCs, locations, factions and items of a game, each with a summary and fields of your own such as Age or Motive.
+
+[green]Entries[white]
+
+  [yellow]n[white]           Add an entry
+  [yellow]e[white]           Edit or delete the selected entry
+  [yellow]l[white]           Link the selected entry to another one
+  [yellow]Tab[white]         Move between the entries and their links
+
+Write one field per line in the Fields box, as Name: Value.
+
+[green]Links and Appearances[white]
+
+Links connect entries, such as an NPC who is a member of a faction or a faction located at a location. Each link is listed on both entries.
+
+Appearances are the sessions that tag the entry.
+
+  [yellow]Enter[white]       Go to the linked entry, or open the session
+  [yellow]d[white]           Remove the selected link
+
+[green]Tags[white]
+
+Lonelog tags resolve to entries of the same name:
+
+  [yellow][N:Name[][white]    NPC
+  [yellow][L:Name[][white]    Location
+  [yellow][F:Name[][white]    Faction
+  [yellow][I:Name[][white]    Item
+
+Press [yellow]Ctrl+][white] in the editor with the cursor on a tag to open its entry, or to add it when there is none yet.
+
+[green]Graph[white]
+
+  [yellow]Ctrl+X[white]      Export how the game's tags and entries connect
+
+Tags mentioned in the same scene or session are joined, weighted by how often they meet, and links are drawn as arrows. Name the file .dot for Graphviz, .mmd for Mermaid or .md for Mermaid in Markdown.
+`)
+}
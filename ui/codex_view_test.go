@@ -0,0 +1,221 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"soloterm/domain/codex"
+	testHelper "soloterm/shared/testing"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// createCodexEntry is a test helper that adds an entry to the game's codex
+func createCodexEntry(t *testing.T, app *App, gameID int64, kind codex.Kind, name string) *codex.Entry {
+	t.Helper()
+	entry, err := app.codexView.codexService.Save(&codex.Entry{GameID: gameID, Kind: kind, Name: name})
+	require.NoError(t, err, "Failed to create codex entry")
+	return entry
+}
+
+func TestCodexView_OpenFromGameTree(t *testing.T) {
+	app := setupTestApp(t)
+	createGame(t, app, "Test Game")
+	app.SetFocus(app.gameView.Tree)
+
+	testHelper.SimulateRune(app.gameView.Tree, app.Application, 'c')
+
+	assert.True(t, app.isPageVisible(CODEX_MODAL_ID), "Expected the codex to be visible")
+	assert.Equal(t, app.codexView.EntryTable, app.GetFocus())
+	assert.Contains(t, app.codexView.EntryTable.GetCell(0, 0).Text, "No entries yet")
+
+	testHelper.SimulateKey(app.codexView.Modal, app.Application, tcell.KeyEsc)
+	assert.False(t, app.isPageVisible(CODEX_MODAL_ID))
+	assert.Equal(t, app.gameView.Tree, app.GetFocus())
+}
+
+func TestCodexView_AddEntry(t *testing.T) {
+	app := setupTestApp(t)
+	g := createGame(t, app, "Test Game")
+	createCodexEntry(t, app, g.ID, codex.KindLocation, "The Keep")
+	app.HandleEvent(&CodexShowEvent{BaseEvent: BaseEvent{action: CODEX_SHOW}, GameID: g.ID})
+
+	testHelper.SimulateRune(app.codexView.Modal, app.Application, 'n')
+	require.True(t, app.isPageVisible(CODEX_FORM_MODAL_ID), "Expected the entry form to be visible")
+
+	app.codexView.Form.nameField.SetText("Captain Vex")
+	app.codexView.Form.summaryField.SetText("Harbour master", false)
+	app.codexView.Form.fieldsField.SetText("Age: 42\nMotive: Revenge", false)
+	app.codexView.HandleSave()
+
+	assert.False(t, app.isPageVisible(CODEX_FORM_MODAL_ID))
+	require.Len(t, app.codexView.entries, 2)
+	require.NotNil(t, app.codexView.entry)
+	assert.Equal(t, "Captain Vex", app.codexView.entry.Name, "Expected the new entry to be selected")
+	assert.Equal(t, codex.KindNPC, app.codexView.entry.Kind)
+	require.Len(t, app.codexView.entry.Fields, 2)
+	assert.Contains(t, app.codexView.Detail.GetText(true), "Motive: Revenge")
+	assert.Equal(t, "NPC", app.codexView.EntryTable.GetCell(0, 0).Text, "Expected NPCs to be listed first")
+}
+
+func TestCodexView_DuplicateNameShowsError(t *testing.T) {
+	app := setupTestApp(t)
+	g := createGame(t, app, "Test Game")
+	createCodexEntry(t, app, g.ID, codex.KindNPC, "Vex")
+	app.HandleEvent(&CodexShowEvent{BaseEvent: BaseEvent{action: CODEX_SHOW}, GameID: g.ID})
+
+	testHelper.SimulateRune(app.codexView.Modal, app.Application, 'n')
+	app.codexView.Form.nameField.SetText("vex")
+	app.codexView.HandleSave()
+
+	assert.True(t, app.isPageVisible(CODEX_FORM_MODAL_ID), "Expected the form to stay open")
+	assert.True(t, app.codexView.Form.HasFieldError("name"))
+}
+
+func TestCodexView_TagOpensEntry(t *testing.T) {
+	app := setupTestApp(t)
+	g := createGame(t, app, "Test Game")
+	vex := createCodexEntry(t, app, g.ID, codex.KindNPC, "Captain Vex")
+	createCodexEntry(t, app, g.ID, codex.KindNPC, "Aldric")
+	s := loadSessionWithContent(t, app, g.ID, "We meet [N:captain vex | wary] at dawn.")
+
+	app.sessionView.TextArea.Select(12, 12)
+	testHelper.SimulateKey(app.sessionView.TextArea, app.Application, tcell.KeyCtrlRightSq)
+
+	require.True(t, app.isPageVisible(CODEX_MODAL_ID), "Expected the codex to be visible")
+	require.NotNil(t, app.codexView.entry)
+	assert.Equal(t, vex.ID, app.codexView.entry.ID, "Expected the tagged entry to be selected")
+	require.Len(t, app.codexView.appearances, 1)
+	assert.Equal(t, s.ID, app.codexView.appearances[0].SessionID)
+
+	// The first appearance opens the session with the tag selected
+	app.SetFocus(app.codexView.LinkTable)
+	testHelper.SimulateKey(app.codexView.LinkTable, app.Application, tcell.KeyEnter)
+
+	assert.False(t, app.isPageVisible(CODEX_MODAL_ID))
+	assert.Equal(t, s.ID, *app.sessionView.currentSessionID)
+	assert.Equal(t, app.sessionView.TextArea, app.GetFocus())
+}
+
+func TestCodexView_UnknownTagOpensNewEntry(t *testing.T) {
+	app := setupTestApp(t)
+	g := createGame(t, app, "Test Game")
+	loadSessionWithContent(t, app, g.ID, "We reach [L:The Keep].")
+
+	app.sessionView.TextArea.Select(12, 12)
+	testHelper.SimulateKey(app.sessionView.TextArea, app.Application, tcell.KeyCtrlRightSq)
+
+	require.True(t, app.isPageVisible(CODEX_FORM_MODAL_ID), "Expected the entry form to be visible")
+	assert.Equal(t, "The Keep", app.codexView.Form.nameField.GetText())
+	entry := app.codexView.Form.BuildDomain()
+	assert.Equal(t, codex.KindLocation, entry.Kind)
+	assert.Equal(t, g.ID, entry.GameID)
+}
+
+func TestCodexView_OtherTagsWarn(t *testing.T) {
+	app := setupTestApp(t)
+	g := createGame(t, app, "Test Game")
+	loadSessionWithContent(t, app, g.ID, "[Thread:Find the heir]")
+
+	app.sessionView.TextArea.Select(3, 3)
+	testHelper.SimulateKey(app.sessionView.TextArea, app.Application, tcell.KeyCtrlRightSq)
+
+	assert.False(t, app.isPageVisible(CODEX_MODAL_ID))
+	assert.Contains(t, app.notification.GetText(true), "is not a codex tag")
+}
+
+func TestCodexView_LinkEntries(t *testing.T) {
+	app := setupTestApp(t)
+	g := createGame(t, app, "Test Game")
+	vex := createCodexEntry(t, app, g.ID, codex.KindNPC, "Vex")
+	guild := createCodexEntry(t, app, g.ID, codex.KindFaction, "Iron Guild")
+	app.HandleEvent(&CodexShowEvent{BaseEvent: BaseEvent{action: CODEX_SHOW}, GameID: g.ID, EntryID: vex.ID})
+
+	testHelper.SimulateRune(app.codexView.Modal, app.Application, 'l')
+	require.True(t, app.isPageVisible(CODEX_RELATION_MODAL_ID), "Expected the link form to be visible")
+	app.codexView.HandleRelationSave()
+
+	assert.False(t, app.isPageVisible(CODEX_RELATION_MODAL_ID))
+	require.Len(t, app.codexView.connections, 1)
+	assert.Equal(t, "member of", app.codexView.LinkTable.GetCell(1, 0).Text)
+	assert.Equal(t, "Iron Guild (Faction)", app.codexView.LinkTable.GetCell(1, 1).Text)
+
+	// Following the link selects the other entry, where it reads the other way
+	app.SetFocus(app.codexView.LinkTable)
+	testHelper.SimulateKey(app.codexView.LinkTable, app.Application, tcell.KeyEnter)
+	require.Equal(t, guild.ID, app.codexView.entry.ID)
+	assert.Equal(t, "has member", app.codexView.LinkTable.GetCell(1, 0).Text)
+
+	// d removes the link once confirmed
+	app.SetFocus(app.codexView.LinkTable)
+	testHelper.SimulateRune(app.codexView.LinkTable, app.Application, 'd')
+	require.True(t, app.isPageVisible(CONFIRM_MODAL_ID))
+	app.confirmModal.onConfirm()
+
+	assert.False(t, app.isPageVisible(CONFIRM_MODAL_ID))
+	assert.Empty(t, app.codexView.connections)
+}
+
+func TestCodexView_DeleteEntry(t *testing.T) {
+	app := setupTestApp(t)
+	g := createGame(t, app, "Test Game")
+	vex := createCodexEntry(t, app, g.ID, codex.KindNPC, "Vex")
+	app.HandleEvent(&CodexShowEvent{BaseEvent: BaseEvent{action: CODEX_SHOW}, GameID: g.ID})
+
+	testHelper.SimulateRune(app.codexView.EntryTable, app.Application, 'e')
+	require.True(t, app.isPageVisible(CODEX_FORM_MODAL_ID))
+	assert.Equal(t, "Vex", app.codexView.Form.nameField.GetText())
+
+	testHelper.SimulateKey(app.codexView.Form.GetButton(2), app.Application, tcell.KeyEnter)
+	require.True(t, app.isPageVisible(CONFIRM_MODAL_ID))
+	app.confirmModal.onConfirm()
+
+	assert.False(t, app.isPageVisible(CODEX_FORM_MODAL_ID))
+	assert.Empty(t, app.codexView.entries)
+	_, err := app.codexView.codexService.GetByID(vex.ID)
+	assert.Error(t, err, "deleted entry should not be found in DB")
+}
+
+func TestCodexView_ExportGraph(t *testing.T) {
+	app := setupTestApp(t)
+	g := createGame(t, app, "Test Game")
+	app.HandleEvent(&CodexShowEvent{BaseEvent: BaseEvent{action: CODEX_SHOW}, GameID: g.ID})
+
+	testHelper.SimulateKey(app.codexView.Modal, app.Application, tcell.KeyCtrlX)
+	assert.False(t, app.isPageVisible(FILE_MODAL_ID), "Expected nothing to export yet")
+	assert.Contains(t, app.notification.GetText(true), "no shared tags or links")
+
+	vex := createCodexEntry(t, app, g.ID, codex.KindNPC, "Vex")
+	guild := createCodexEntry(t, app, g.ID, codex.KindFaction, "Iron Guild")
+	_, err := app.codexView.codexService.Relate(&codex.Relation{FromID: vex.ID, ToID: guild.ID, Type: codex.MemberOf})
+	require.NoError(t, err)
+	loadSessionWithContent(t, app, g.ID, "[N:Vex] sails to [L:The Keep]")
+	app.HandleEvent(&CodexShowEvent{BaseEvent: BaseEvent{action: CODEX_SHOW}, GameID: g.ID})
+
+	testHelper.SimulateKey(app.codexView.Modal, app.Application, tcell.KeyCtrlX)
+	require.True(t, app.isPageVisible(FILE_MODAL_ID), "Expected the export modal to open")
+
+	// The extension picks the format
+	exportPath := filepath.Join(t.TempDir(), "graph.mmd")
+	app.fileView.Form.pathField.SetText(exportPath)
+	testHelper.SimulateKey(app.fileView.Form, app.Application, tcell.KeyCtrlS)
+
+	assert.False(t, app.isPageVisible(FILE_MODAL_ID))
+	assert.Equal(t, app.codexView.EntryTable, app.GetFocus())
+	data, err := os.ReadFile(exportPath)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "graph LR")
+	assert.Contains(t, string(data), `["L:The Keep"]`)
+	assert.Contains(t, string(data), "-.->|member of|")
+
+	testHelper.SimulateKey(app.codexView.Modal, app.Application, tcell.KeyCtrlX)
+	exportPath = filepath.Join(t.TempDir(), "graph.dot")
+	app.fileView.Form.pathField.SetText(exportPath)
+	testHelper.SimulateKey(app.fileView.Form, app.Application, tcell.KeyCtrlS)
+
+	data, err = os.ReadFile(exportPath)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `graph "Test Game" {`)
+}
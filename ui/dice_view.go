@@ -336,21 +336,41 @@ func (dv *DiceView) setupKeyBindings() {
 
 func (dv *DiceView) roll() {
 	resultGroups := dice.Roll(dv.TextArea.GetText(), dv.oracleService)
+	dv.resultView.SetText(dv.formatRoll(resultGroups, false))
+	dv.rebuildButtons()
+}
+
+// RollText rolls expression and returns the result as plain text, the same
+// way the dice modal inserts it into a session.
+func (dv *DiceView) RollText(expression string) string {
+	formatted := dv.formatRoll(dice.Roll(expression, dv.oracleService), true)
+	return strings.TrimRight(formatted, "\r\n")
+}
 
+// colored wraps text in a color tag, escaping it, unless plain is set
+func colored(color string, text string, plain bool) string {
+	if plain {
+		return text
+	}
+	return "[" + color + "]" + tview.Escape(text) + "[" + Style.NormalTextColor + "]"
+}
+
+// formatRoll renders roll groups one per line, with color tags unless plain is set
+func (dv *DiceView) formatRoll(resultGroups []dice.RollGroup, plain bool) string {
 	var output strings.Builder
 	for _, group := range resultGroups {
 		if group.Label != "" {
-			output.WriteString("[" + Style.HelpKeyTextColor + "]" + tview.Escape(group.Label) + ":[" + Style.NormalTextColor + "] ")
+			output.WriteString(colored(Style.HelpKeyTextColor, group.Label+":", plain) + " ")
 		}
 
 		for i, result := range group.Results {
 			if result.Err != nil {
-				output.WriteString("[" + Style.ErrorTextColor + "]" + tview.Escape(result.Err.Error()) + "[" + Style.NormalTextColor + "]")
+				output.WriteString(colored(Style.ErrorTextColor, result.Err.Error(), plain))
 			} else if result.Picked != "" {
 				label := strings.TrimPrefix(result.Notation, "@")
-				output.WriteString("[" + Style.SuccessTextColor + "]" + tview.Escape(label) + "[" + Style.NormalTextColor + "] -> " + dv.formatDiceResult(result))
+				output.WriteString(colored(Style.SuccessTextColor, label, plain) + " -> " + dv.formatDiceResult(result, plain))
 			} else {
-				output.WriteString("[" + Style.SuccessTextColor + "]" + tview.Escape(result.Notation) + "[" + Style.NormalTextColor + "] -> " + dv.formatDiceResult(result))
+				output.WriteString(colored(Style.SuccessTextColor, result.Notation, plain) + " -> " + dv.formatDiceResult(result, plain))
 			}
 
 			if i < len(group.Results)-1 {
@@ -361,15 +381,14 @@ func (dv *DiceView) roll() {
 		output.WriteString("\n")
 	}
 
-	dv.resultView.SetText(output.String())
-	dv.rebuildButtons()
+	return output.String()
 }
 
 // formatDiceResult renders a roll result. For list picks it shows the chosen
 // item; for dice rolls it shows "total {d1 d2 d3}" with dropped dice in grey.
-func (dv *DiceView) formatDiceResult(result dice.RollResult) string {
+func (dv *DiceView) formatDiceResult(result dice.RollResult, plain bool) string {
 	if result.Picked != "" {
-		return "[" + Style.SuccessTextColor + "]" + tview.Escape(result.Picked) + "[" + Style.NormalTextColor + "]"
+		return colored(Style.SuccessTextColor, result.Picked, plain)
 	}
 	var b strings.Builder
 	b.WriteString(strconv.Itoa(result.Total))
@@ -409,7 +428,7 @@ func (dv *DiceView) formatDiceResult(result dice.RollResult) string {
 			b.WriteString(" ")
 		}
 		if d.dropped {
-			b.WriteString("[grey](" + strconv.Itoa(d.val) + ")[" + Style.NormalTextColor + "]")
+			b.WriteString(colored("grey", "("+strconv.Itoa(d.val)+")", plain))
 		} else {
 			b.WriteString(strconv.Itoa(d.val))
 		}
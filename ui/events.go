@@ -2,11 +2,17 @@ package ui
 
 import (
 	"soloterm/domain/character"
+	"soloterm/domain/codex"
+	"soloterm/domain/filesync"
 	"soloterm/domain/game"
+	"soloterm/domain/link"
+	"soloterm/domain/lonelog"
+	"soloterm/domain/notes"
 	"soloterm/domain/oracle"
 	"soloterm/domain/session"
 	"soloterm/domain/snippet"
 	"soloterm/domain/tag"
+	"soloterm/domain/trash"
 
 	"github.com/rivo/tview"
 )
@@ -33,6 +39,8 @@ const (
 	CHARACTER_CANCEL            UserAction = "character_cancel"
 	CHARACTER_SHOW_NEW          UserAction = "character_show_new"
 	CHARACTER_SHOW_EDIT         UserAction = "character_show_edit"
+	CHARACTER_SHOW_IMPORT       UserAction = "character_show_import"
+	CHARACTER_SHOW_EXPORT       UserAction = "character_show_export"
 	ATTRIBUTE_SAVED             UserAction = "attribute_saved"
 	ATTRIBUTE_DELETED           UserAction = "attribute_deleted"
 	ATTRIBUTE_DELETE_CONFIRM    UserAction = "attribute_delete_confirm"
@@ -41,9 +49,16 @@ const (
 	ATTRIBUTE_SHOW_NEW          UserAction = "attribute_show_new"
 	ATTRIBUTE_SHOW_EDIT         UserAction = "attribute_show_edit"
 	ATTRIBUTE_REORDER           UserAction = "attribute_reorder"
+	ATTRIBUTE_ADJUST            UserAction = "attribute_adjust"
+	ATTRIBUTE_HISTORY_SHOW      UserAction = "attribute_history_show"
+	ATTRIBUTE_HISTORY_CANCEL    UserAction = "attribute_history_cancel"
+	ATTRIBUTE_HISTORY_SELECT    UserAction = "attribute_history_select"
 	TAG_SELECTED                UserAction = "tag_selected"
 	TAG_CANCEL                  UserAction = "tag_cancel"
 	TAG_SHOW                    UserAction = "tag_show"
+	TAG_TIMELINE_SHOW           UserAction = "tag_timeline_show"
+	TAG_TIMELINE_CANCEL         UserAction = "tag_timeline_cancel"
+	TAG_TIMELINE_SELECT         UserAction = "tag_timeline_select"
 	SESSION_SHOW_NEW            UserAction = "session_show_new"
 	SESSION_SHOW_EDIT           UserAction = "session_show_edit"
 	SESSION_SAVED               UserAction = "session_saved"
@@ -54,6 +69,15 @@ const (
 	SESSION_DELETED             UserAction = "session_deleted"
 	SESSION_SHOW_IMPORT         UserAction = "session_show_import"
 	SESSION_SHOW_EXPORT         UserAction = "session_show_export"
+	SESSION_SHOW_MOVE           UserAction = "session_show_move"
+	SESSION_MOVE_CANCEL         UserAction = "session_move_cancel"
+	SESSION_MOVED               UserAction = "session_moved"
+	SESSION_SPLIT_CONFIRM       UserAction = "session_split_confirm"
+	SESSION_SPLIT               UserAction = "session_split"
+	SESSION_MERGE_CONFIRM       UserAction = "session_merge_confirm"
+	SESSION_MERGED              UserAction = "session_merged"
+	SESSION_REORDER             UserAction = "session_reorder"
+	SESSION_EDITOR_CONFLICT     UserAction = "session_editor_conflict"
 	FILE_IMPORT                 UserAction = "file_import"
 	FILE_EXPORT                 UserAction = "file_export"
 	FILE_IMPORT_DONE            UserAction = "file_import_done"
@@ -90,6 +114,55 @@ const (
 	SNIPPET_DELETE_FAILED  UserAction = "snippet_delete_failed"
 	SNIPPET_USE            UserAction = "snippet_use"
 	SNIPPET_REORDER        UserAction = "snippet_reorder"
+
+	CLOCK_SHOW   UserAction = "clock_show"
+	CLOCK_CANCEL UserAction = "clock_cancel"
+	CLOCK_ADJUST UserAction = "clock_adjust"
+
+	RECAP_SHOW          UserAction = "recap_show"
+	RECAP_CANCEL        UserAction = "recap_cancel"
+	RECAP_INSERT        UserAction = "recap_insert"
+	TRASH_SHOW          UserAction = "trash_show"
+	TRASH_CANCEL        UserAction = "trash_cancel"
+	TRASH_RESTORE       UserAction = "trash_restore"
+	TRASH_PURGE_CONFIRM UserAction = "trash_purge_confirm"
+
+	LINK_FOLLOW      UserAction = "link_follow"
+	BACKLINKS_SHOW   UserAction = "backlinks_show"
+	BACKLINKS_CANCEL UserAction = "backlinks_cancel"
+	BACKLINKS_SELECT UserAction = "backlinks_select"
+
+	NOTES_PAGE_SHOW_NEW       UserAction = "notes_page_show_new"
+	NOTES_PAGE_SHOW_EDIT      UserAction = "notes_page_show_edit"
+	NOTES_PAGE_SAVED          UserAction = "notes_page_saved"
+	NOTES_PAGE_CANCEL         UserAction = "notes_page_cancel"
+	NOTES_PAGE_DELETE_CONFIRM UserAction = "notes_page_delete_confirm"
+	NOTES_PAGE_DELETED        UserAction = "notes_page_deleted"
+	NOTES_PAGE_DELETE_FAILED  UserAction = "notes_page_delete_failed"
+
+	CODEX_SHOW                    UserAction = "codex_show"
+	CODEX_CANCEL                  UserAction = "codex_cancel"
+	CODEX_TAG_FOLLOW              UserAction = "codex_tag_follow"
+	CODEX_APPEARANCE_SELECT       UserAction = "codex_appearance_select"
+	CODEX_ENTRY_SHOW_NEW          UserAction = "codex_entry_show_new"
+	CODEX_ENTRY_SHOW_EDIT         UserAction = "codex_entry_show_edit"
+	CODEX_ENTRY_FORM_CANCEL       UserAction = "codex_entry_form_cancel"
+	CODEX_ENTRY_SAVED             UserAction = "codex_entry_saved"
+	CODEX_ENTRY_DELETE_CONFIRM    UserAction = "codex_entry_delete_confirm"
+	CODEX_ENTRY_DELETED           UserAction = "codex_entry_deleted"
+	CODEX_ENTRY_DELETE_FAILED     UserAction = "codex_entry_delete_failed"
+	CODEX_RELATION_SHOW_NEW       UserAction = "codex_relation_show_new"
+	CODEX_RELATION_FORM_CANCEL    UserAction = "codex_relation_form_cancel"
+	CODEX_RELATION_SAVED          UserAction = "codex_relation_saved"
+	CODEX_RELATION_DELETE_CONFIRM UserAction = "codex_relation_delete_confirm"
+	CODEX_RELATION_DELETED        UserAction = "codex_relation_deleted"
+
+	SYNC_NOW              UserAction = "sync_now"
+	SYNC_CONFLICT_RESOLVE UserAction = "sync_conflict_resolve"
+	SYNC_CONFLICT_CANCEL  UserAction = "sync_conflict_cancel"
+
+	PROMPT_SUBMIT UserAction = "prompt_submit"
+	PROMPT_CANCEL UserAction = "prompt_cancel"
 )
 
 // Base event interface
@@ -146,9 +219,134 @@ type GameShowNewEvent struct {
 	BaseEvent
 }
 
+// GameNotesSelectedEvent opens the game's notes page PageID, or its first
+// page when PageID is zero.
 type GameNotesSelectedEvent struct {
 	BaseEvent
 	GameID int64
+	PageID int64
+}
+
+// ====== NOTES PAGE SPECIFIC EVENTS ======
+type NotesPageShowNewEvent struct {
+	BaseEvent
+	GameID int64
+}
+
+type NotesPageShowEditEvent struct {
+	BaseEvent
+	PageID int64
+}
+
+type NotesPageSavedEvent struct {
+	BaseEvent
+	Page *notes.Page
+}
+
+type NotesPageCancelledEvent struct {
+	BaseEvent
+}
+
+type NotesPageDeleteConfirmEvent struct {
+	BaseEvent
+	Page *notes.Page
+}
+
+type NotesPageDeletedEvent struct {
+	BaseEvent
+	Page *notes.Page
+}
+
+type NotesPageDeleteFailedEvent struct {
+	BaseEvent
+	Error error
+}
+
+// ====== CODEX SPECIFIC EVENTS ======
+
+// CodexShowEvent opens the codex of the game, with EntryID selected when set.
+type CodexShowEvent struct {
+	BaseEvent
+	GameID  int64
+	EntryID int64
+}
+
+type CodexCancelEvent struct {
+	BaseEvent
+}
+
+// CodexTagFollowEvent opens the codex entry a tag such as [N:Captain Vex]
+// names, or the form to add it when there is none.
+type CodexTagFollowEvent struct {
+	BaseEvent
+	Tag *lonelog.Tag
+}
+
+// CodexAppearanceSelectEvent opens the session Appearance refers to, with the
+// entry's first tag selected.
+type CodexAppearanceSelectEvent struct {
+	BaseEvent
+	Appearance *codex.Appearance
+}
+
+// CodexEntryShowNewEvent opens the form for a new entry, filled in with Kind
+// and Name when they are set.
+type CodexEntryShowNewEvent struct {
+	BaseEvent
+	Kind codex.Kind
+	Name string
+}
+
+type CodexEntryShowEditEvent struct {
+	BaseEvent
+	Entry *codex.Entry
+}
+
+type CodexEntryFormCancelEvent struct {
+	BaseEvent
+}
+
+type CodexEntrySavedEvent struct {
+	BaseEvent
+	Entry *codex.Entry
+	IsNew bool
+}
+
+type CodexEntryDeleteConfirmEvent struct {
+	BaseEvent
+	Entry *codex.Entry
+}
+
+type CodexEntryDeletedEvent struct {
+	BaseEvent
+}
+
+type CodexEntryDeleteFailedEvent struct {
+	BaseEvent
+	Error error
+}
+
+type CodexRelationShowNewEvent struct {
+	BaseEvent
+	Entry *codex.Entry
+}
+
+type CodexRelationFormCancelEvent struct {
+	BaseEvent
+}
+
+type CodexRelationSavedEvent struct {
+	BaseEvent
+	Relation *codex.Relation
+}
+
+type CodexRelationDeleteConfirmEvent struct {
+	BaseEvent
+	Connection *codex.Connection
+}
+
+type CodexRelationDeletedEvent struct {
+	BaseEvent
 }
 
 // ====== CHARACTER SPECIFIC EVENTS ======
@@ -199,10 +397,19 @@ type CharacterShowEditEvent struct {
 	Character *character.Character
 }
 
+type CharacterShowImportEvent struct {
+	BaseEvent
+}
+
+type CharacterShowExportEvent struct {
+	BaseEvent
+}
+
 // ====== ATTRIBUTE SPECIFIC EVENTS ======
 type AttributeSavedEvent struct {
 	BaseEvent
 	Attribute *character.Attribute
+	Change    *character.AttributeChange // nil when the value didn't change
 }
 
 type AttributeCancelledEvent struct {
@@ -241,10 +448,31 @@ type AttributeReorderEvent struct {
 	Direction   int // -1 up, +1 down
 }
 
+type AttributeAdjustEvent struct {
+	BaseEvent
+	AttributeID int64
+	Delta       int // -1 to decrement, +1 to increment
+}
+
+type AttributeHistoryShowEvent struct {
+	BaseEvent
+	Attribute *character.Attribute
+}
+
+type AttributeHistoryCancelEvent struct {
+	BaseEvent
+}
+
+type AttributeHistorySelectEvent struct {
+	BaseEvent
+	Change *character.AttributeChange
+}
+
 // ====== TAG SPECIFIC EVENTS ======
 type TagSelectedEvent struct {
 	BaseEvent
-	TagType *tag.TagType
+	TagType    *tag.TagType
+	Configured bool // a tag template from the config rather than a tag used in the game
 }
 
 type TagCancelledEvent struct {
@@ -255,6 +483,20 @@ type TagShowEvent struct {
 	BaseEvent
 }
 
+type TagTimelineShowEvent struct {
+	BaseEvent
+	Identifier string
+}
+
+type TagTimelineCancelEvent struct {
+	BaseEvent
+}
+
+type TagTimelineSelectEvent struct {
+	BaseEvent
+	Occurrence *tag.Occurrence
+}
+
 // ====== HELP EVENTS ======
 type ShowHelpEvent struct {
 	BaseEvent
@@ -280,7 +522,8 @@ type SessionShowEditEvent struct {
 
 type SessionSavedEvent struct {
 	BaseEvent
-	Session session.Session
+	Session  session.Session
+	Template string // session template to insert into a new session, if one was chosen
 }
 
 type SessionCancelledEvent struct {
@@ -307,6 +550,57 @@ type SessionDeleteFailedEvent struct {
 	Error error
 }
 
+type SessionShowMoveEvent struct {
+	BaseEvent
+	SessionID int64
+}
+
+type SessionMoveCancelEvent struct {
+	BaseEvent
+}
+
+type SessionMovedEvent struct {
+	BaseEvent
+	Session *session.Session
+}
+
+type SessionSplitConfirmEvent struct {
+	BaseEvent
+	SessionID int64
+	Offset    int
+}
+
+type SessionSplitEvent struct {
+	BaseEvent
+	First  *session.Session
+	Second *session.Session
+}
+
+type SessionMergeConfirmEvent struct {
+	BaseEvent
+	SessionID int64
+}
+
+type SessionMergedEvent struct {
+	BaseEvent
+	Session *session.Session
+}
+
+// SessionEditorConflictEvent is raised when the session or notes changed in
+// the database while they were open in the external editor. Path is the temp
+// file holding the editor's copy.
+type SessionEditorConflictEvent struct {
+	BaseEvent
+	Content string
+	Path    string
+}
+
+type SessionReorderEvent struct {
+	BaseEvent
+	SessionID int64
+	Direction int // -1 up, +1 down
+}
+
 type SessionShowImportEvent struct {
 	BaseEvent
 }
@@ -468,3 +762,107 @@ type SnippetReorderEvent struct {
 	SnippetID int64
 	Direction int // -1 up, +1 down
 }
+
+// ====== CLOCK SPECIFIC EVENTS ======
+type ClockShowEvent struct {
+	BaseEvent
+}
+
+type ClockCancelEvent struct {
+	BaseEvent
+}
+
+// ClockAdjustEvent steps a clock or track by Delta and records the new value in the session.
+type ClockAdjustEvent struct {
+	BaseEvent
+	Progress *lonelog.Progress
+	Delta    int
+}
+
+// ====== RECAP SPECIFIC EVENTS ======
+type RecapShowEvent struct {
+	BaseEvent
+	GameID int64
+}
+
+type RecapCancelEvent struct {
+	BaseEvent
+}
+
+// RecapInsertEvent inserts Recap at the top of the open session.
+type RecapInsertEvent struct {
+	BaseEvent
+	Recap string
+}
+
+type TrashShowEvent struct {
+	BaseEvent
+}
+
+type TrashCancelEvent struct {
+	BaseEvent
+}
+
+type TrashRestoreEvent struct {
+	BaseEvent
+	Item *trash.Item
+}
+
+type TrashPurgeConfirmEvent struct {
+	BaseEvent
+	Item *trash.Item
+}
+
+// ====== LINK SPECIFIC EVENTS ======
+
+// LinkFollowEvent opens the session or notes page that Link leads to.
+type LinkFollowEvent struct {
+	BaseEvent
+	Link *link.Link
+}
+
+type BacklinksShowEvent struct {
+	BaseEvent
+}
+
+type BacklinksCancelEvent struct {
+	BaseEvent
+}
+
+// BacklinksSelectEvent opens the session or notes page holding Backlink, with the link selected.
+type BacklinksSelectEvent struct {
+	BaseEvent
+	Backlink *link.Backlink
+}
+
+// ====== SYNC SPECIFIC EVENTS ======
+
+// SyncNowEvent syncs every game with a sync folder straight away.
+type SyncNowEvent struct {
+	BaseEvent
+}
+
+// SyncConflictResolveEvent settles Conflict by keeping the file's copy when
+// KeepFile is set, otherwise the app's.
+type SyncConflictResolveEvent struct {
+	BaseEvent
+	Conflict *filesync.Conflict
+	KeepFile bool
+}
+
+type SyncConflictCancelEvent struct {
+	BaseEvent
+}
+
+// ====== PROMPT SPECIFIC EVENTS ======
+
+// PromptSubmitEvent inserts Template with its prompts answered by Values.
+type PromptSubmitEvent struct {
+	BaseEvent
+	Template string
+	Values   map[string]string
+}
+
+type PromptCancelEvent struct {
+	BaseEvent
+}
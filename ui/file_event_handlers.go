@@ -26,7 +26,14 @@ func (a *App) handleFileImport(_ *FileImportEvent) {
 		return
 	}
 
-	fv.target.SetFileContent(string(data), fv.Form.GetImportPosition())
+	if t, ok := fv.target.(ParsingFileTarget); ok {
+		if err := t.ParseFileContent(string(data)); err != nil {
+			fv.Form.ShowError(fmt.Sprintf("Cannot import file: %v", err))
+			return
+		}
+	} else {
+		fv.target.SetFileContent(string(data), fv.Form.GetImportPosition())
+	}
 	fv.target.OnFileDone()
 
 	a.HandleEvent(&FileImportDoneEvent{
@@ -42,7 +49,21 @@ func (a *App) handleFileExport(_ *FileExportEvent) {
 		return
 	}
 
-	if err := os.WriteFile(path, []byte(fv.target.GetFileContent()), 0644); err != nil {
+	if t, ok := fv.target.(PathFileTarget); ok {
+		t.SetFilePath(path)
+	}
+	var content string
+	if t, ok := fv.target.(RenderingFileTarget); ok {
+		var err error
+		content, err = t.RenderFileContent()
+		if err != nil {
+			fv.Form.ShowError(fmt.Sprintf("Cannot export file: %v", err))
+			return
+		}
+	} else {
+		content = fv.target.GetFileContent()
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
 		fv.Form.ShowError(fmt.Sprintf("Cannot write file: %v", err))
 		return
 	}
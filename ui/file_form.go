@@ -20,6 +20,29 @@ type FileTarget interface {
 	OnFileDone()
 }
 
+// PathFileTarget is implemented by export targets whose content depends on
+// the file written to, such as a format picked by the file's extension.
+type PathFileTarget interface {
+	FileTarget
+	SetFilePath(path string)
+}
+
+// ParsingFileTarget is implemented by import targets that parse the file
+// rather than take its text, such as a character sheet. The file is imported
+// with ParseFileContent, and its error is shown on the form.
+type ParsingFileTarget interface {
+	FileTarget
+	ParseFileContent(data string) error
+}
+
+// RenderingFileTarget is implemented by export targets whose content can fail
+// to render, such as a character sheet. The file is written from
+// RenderFileContent, and its error is shown on the form.
+type RenderingFileTarget interface {
+	FileTarget
+	RenderFileContent() (string, error)
+}
+
 const (
 	ImportReplace  ImportPosition = iota // Replace all current content (default)
 	ImportBefore                         // Insert before current content
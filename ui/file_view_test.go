@@ -1,6 +1,7 @@
 package ui
 
 import (
+	"errors"
 	"os"
 	"path/filepath"
 	"soloterm/domain/oracle"
@@ -131,3 +132,33 @@ func TestFileView_OracleExport_WritesOracleContent(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, o.Content, string(data))
 }
+
+// failingExport is an export target whose content fails to render
+type failingExport struct{}
+
+func (failingExport) RenderFileContent() (string, error) { return "", errors.New("render failed") }
+
+func (failingExport) GetFileContent() string { return "" }
+
+func (failingExport) SetFileContent(data string, position ImportPosition) {}
+
+func (failingExport) UsePositionField() bool { return false }
+
+func (failingExport) FileDir() string { return "" }
+
+func (failingExport) OnFileDone() {}
+
+func TestFileView_Export_RenderErrorIsShownAndWritesNothing(t *testing.T) {
+	app := setupTestApp(t)
+	exportPath := filepath.Join(t.TempDir(), "out.yaml")
+
+	app.fileView.ShowExport(failingExport{}, app.gameView.Tree)
+	require.True(t, app.isPageVisible(FILE_MODAL_ID))
+	app.fileView.Form.pathField.SetText(exportPath)
+	testHelper.SimulateKey(app.fileView.Form, app.Application, tcell.KeyCtrlS)
+
+	assert.True(t, app.isPageVisible(FILE_MODAL_ID), "modal should stay open")
+	assert.True(t, app.fileView.Form.HasFieldError("path"))
+	_, err := os.Stat(exportPath)
+	assert.True(t, os.IsNotExist(err), "no file should be written")
+}
@@ -1,5 +1,7 @@
 package ui
 
+import "strings"
+
 func (a *App) handleGameSaved(e *GameSavedEvent) {
 	a.gameView.Form.Reset()
 	a.pages.HidePage(GAME_MODAL_ID)
@@ -7,6 +9,12 @@ func (a *App) handleGameSaved(e *GameSavedEvent) {
 	a.gameView.SelectGame(&e.Game.ID)
 	a.SetFocus(a.gameView.Tree)
 	a.notification.ShowSuccess("Game saved successfully")
+
+	// Fill a newly linked sync folder straight away, and keep it in step
+	if strings.TrimSpace(e.Game.SyncDir) != "" {
+		a.SyncFiles()
+		a.startSyncWatch()
+	}
 }
 
 func (a *App) handleGameCancel(_ *GameCancelledEvent) {
@@ -20,7 +28,7 @@ func (a *App) handleGameDeleteConfirm(e *GameDeleteConfirmEvent) {
 
 	// Configure confirmation modal
 	a.confirmModal.Configure(
-		"Are you sure you want to delete this game and all associated sessions?\n\nThis action cannot be undone.",
+		"Are you sure you want to delete this game and all associated sessions?\n\nThey can be restored from the Trash (t).",
 		func() {
 			// On confirm, call handler method to perform deletion
 			a.gameView.ConfirmDelete(e.GameID)
@@ -46,7 +54,7 @@ func (a *App) handleGameDeleted(_ *GameDeletedEvent) {
 	a.SetFocus(a.gameView.Tree)
 
 	// Show success notification
-	a.notification.ShowSuccess("Game deleted successfully")
+	a.notification.ShowSuccess("Game moved to the Trash")
 }
 
 func (a *App) handleGameDeleteFailed(e *GameDeleteFailedEvent) {
@@ -3,6 +3,7 @@ package ui
 import (
 	"soloterm/domain/game"
 	sharedui "soloterm/shared/ui"
+	"strings"
 
 	"github.com/gdamore/tcell/v2"
 	"github.com/rivo/tview"
@@ -14,6 +15,8 @@ type GameForm struct {
 	gameID           *int64
 	nameField        *tview.InputField
 	descriptionField *tview.TextArea
+	templateField    *tview.TextArea
+	syncDirField     *tview.InputField
 	errorMessage     *tview.TextView
 }
 
@@ -39,6 +42,20 @@ func NewGameForm() *GameForm {
 		SetMaxLength(game.MaxDescriptionLength).
 		SetSize(3, 0)
 
+	// Session template field
+	gf.templateField = tview.NewTextArea().
+		SetLabel("Session Template").
+		SetMaxLength(game.MaxSessionTemplateLength).
+		SetSize(5, 0)
+
+	// Sync folder field
+	gf.syncDirField = tview.NewInputField().
+		SetLabel("Sync Folder").
+		SetPlaceholder("Optional: a folder to mirror the sessions to as Markdown").
+		SetPlaceholderStyle(tcell.StyleDefault.Foreground(Style.EmptyStateMessageColor)).
+		SetFieldBackgroundColor(tcell.ColorDefault).
+		SetFieldWidth(0)
+
 	gf.setupForm()
 	return gf
 }
@@ -54,6 +71,8 @@ func (gf *GameForm) PopulateForEdit(game *game.Game) {
 	}
 	gf.descriptionField.SetText(description, false)
 	gf.nameField.SetText(game.Name)
+	gf.templateField.SetText(game.SessionTemplate, false)
+	gf.syncDirField.SetText(game.SyncDir)
 
 	gf.AddDeleteButton()
 
@@ -65,6 +84,8 @@ func (gf *GameForm) setupForm() {
 
 	gf.AddFormItem(gf.nameField)
 	gf.AddFormItem(gf.descriptionField)
+	gf.AddFormItem(gf.templateField)
+	gf.AddFormItem(gf.syncDirField)
 
 	// Buttons will be set up when handlers are attached
 	gf.SetBorder(false)
@@ -80,6 +101,8 @@ func (gf *GameForm) Reset() {
 	gf.gameID = nil
 	gf.nameField.SetText("")
 	gf.descriptionField.SetText("", false)
+	gf.templateField.SetText("", false)
+	gf.syncDirField.SetText("")
 	gf.ClearFieldErrors()
 
 	gf.RemoveDeleteButton()
@@ -108,6 +131,20 @@ func (gf *GameForm) updateFieldLabels() {
 	} else {
 		gf.descriptionField.SetLabel("Description")
 	}
+
+	// Update session template field label
+	if gf.HasFieldError("session_template") {
+		gf.templateField.SetLabel("[" + Style.ErrorTextColor + "]Session Template[" + Style.NormalTextColor + "]")
+	} else {
+		gf.templateField.SetLabel("Session Template")
+	}
+
+	// Update sync folder field label
+	if gf.HasFieldError("sync_dir") {
+		gf.syncDirField.SetLabel("[" + Style.ErrorTextColor + "]Sync Folder[" + Style.NormalTextColor + "]")
+	} else {
+		gf.syncDirField.SetLabel("Sync Folder")
+	}
 }
 
 // ClearFieldErrors removes all error highlights
@@ -125,8 +162,10 @@ func (gf *GameForm) BuildDomain() *game.Game {
 	}
 
 	g := &game.Game{
-		Name:        gf.nameField.GetText(),
-		Description: desc,
+		Name:            gf.nameField.GetText(),
+		Description:     desc,
+		SessionTemplate: gf.templateField.GetText(),
+		SyncDir:         strings.TrimSpace(gf.syncDirField.GetText()),
 	}
 
 	// If editing an existing game, set the ID
@@ -3,7 +3,9 @@ package ui
 import (
 	"fmt"
 	"soloterm/domain/game"
+	"soloterm/domain/notes"
 	"soloterm/domain/session"
+	"soloterm/shared/text"
 	sharedui "soloterm/shared/ui"
 
 	"github.com/gdamore/tcell/v2"
@@ -27,15 +29,16 @@ type GameState struct {
 	GameID    *int64
 	GameName  string
 	SessionID *int64
-	IsNotes   bool
+	IsNotes   bool   // the Notes node or one of its pages
+	PageID    *int64 // set on a notes page node
 }
 
 // NewGameView creates a new game view helper
-func NewGameView(app *App, gameService *game.Service, sessionService *session.Service) *GameView {
+func NewGameView(app *App, gameService *game.Service, sessionService *session.Service, notesService *notes.Service) *GameView {
 	gv := &GameView{
 		app:         app,
 		gameService: gameService,
-		helper:      NewGameViewHelper(gameService, sessionService),
+		helper:      NewGameViewHelper(gameService, sessionService, notesService),
 	}
 
 	gv.Setup()
@@ -75,21 +78,29 @@ func (gv *GameView) setupTreeView() {
 			return
 		}
 
-		// If node has children (it's a game), expand/collapse it
-		if len(node.GetChildren()) > 0 {
-			node.SetExpanded(!node.IsExpanded())
-			return
-		}
-
-		// Selected the Notes node
+		// Selected the Notes node or one of its pages. The Notes node opens
+		// the first page and shows the rest beneath it.
 		if currentSelection.IsNotes {
+			var pageID int64
+			if currentSelection.PageID != nil {
+				pageID = *currentSelection.PageID
+			} else {
+				node.SetExpanded(true)
+			}
 			gv.app.HandleEvent(&GameNotesSelectedEvent{
 				BaseEvent: BaseEvent{action: GAME_NOTES_SELECTED},
 				GameID:    *currentSelection.GameID,
+				PageID:    pageID,
 			})
 			return
 		}
 
+		// If node has children (it's a game), expand/collapse it
+		if len(node.GetChildren()) > 0 {
+			node.SetExpanded(!node.IsExpanded())
+			return
+		}
+
 		// Selected a session, send the event
 		gv.app.HandleEvent(&SessionSelectedEvent{
 			BaseEvent: BaseEvent{action: SESSION_SELECTED},
@@ -108,7 +119,7 @@ func (gv *GameView) setupModal() {
 		gv.HandleDelete,
 	)
 
-	gv.formModal = sharedui.NewFormModal(gv.Form, 11)
+	gv.formModal = sharedui.NewFormModal(gv.Form, 19)
 	gv.Modal = gv.formModal.Modal
 
 	gv.Form.SetFocusFunc(func() {
@@ -138,6 +149,11 @@ func (gv *GameView) setupKeyBindings() {
 						BaseEvent: BaseEvent{action: SESSION_SHOW_EDIT},
 						SessionID: selection.SessionID,
 					})
+				} else if selection.PageID != nil {
+					gv.app.HandleEvent(&NotesPageShowEditEvent{
+						BaseEvent: BaseEvent{action: NOTES_PAGE_SHOW_EDIT},
+						PageID:    *selection.PageID,
+					})
 				} else {
 					game := gv.getSelectedGame()
 					if game != nil {
@@ -155,6 +171,75 @@ func (gv *GameView) setupKeyBindings() {
 					gv.ShowNewModal()
 				}
 				return nil
+			case 'p':
+				selection := gv.GetCurrentSelection()
+				if selection != nil && selection.GameID != nil {
+					gv.app.HandleEvent(&NotesPageShowNewEvent{
+						BaseEvent: BaseEvent{action: NOTES_PAGE_SHOW_NEW},
+						GameID:    *selection.GameID,
+					})
+				}
+				return nil
+			case 'c':
+				selection := gv.GetCurrentSelection()
+				if selection != nil && selection.GameID != nil {
+					gv.app.HandleEvent(&CodexShowEvent{
+						BaseEvent: BaseEvent{action: CODEX_SHOW},
+						GameID:    *selection.GameID,
+					})
+				}
+				return nil
+			case 'r':
+				selection := gv.GetCurrentSelection()
+				if selection != nil && selection.GameID != nil {
+					gv.app.HandleEvent(&RecapShowEvent{
+						BaseEvent: BaseEvent{action: RECAP_SHOW},
+						GameID:    *selection.GameID,
+					})
+				}
+				return nil
+			case 'm':
+				selection := gv.GetCurrentSelection()
+				if selection != nil && selection.SessionID != nil {
+					gv.app.HandleEvent(&SessionShowMoveEvent{
+						BaseEvent: BaseEvent{action: SESSION_SHOW_MOVE},
+						SessionID: *selection.SessionID,
+					})
+				}
+				return nil
+			case 't':
+				gv.app.HandleEvent(&TrashShowEvent{
+					BaseEvent: BaseEvent{action: TRASH_SHOW},
+				})
+				return nil
+			case 'y':
+				gv.app.HandleEvent(&SyncNowEvent{
+					BaseEvent: BaseEvent{action: SYNC_NOW},
+				})
+				return nil
+			case 'u', 'd':
+				selection := gv.GetCurrentSelection()
+				if selection != nil && selection.SessionID != nil {
+					direction := 1
+					if event.Rune() == 'u' {
+						direction = -1
+					}
+					gv.app.HandleEvent(&SessionReorderEvent{
+						BaseEvent: BaseEvent{action: SESSION_REORDER},
+						SessionID: *selection.SessionID,
+						Direction: direction,
+					})
+				}
+				return nil
+			case 'j':
+				selection := gv.GetCurrentSelection()
+				if selection != nil && selection.SessionID != nil {
+					gv.app.HandleEvent(&SessionMergeConfirmEvent{
+						BaseEvent: BaseEvent{action: SESSION_MERGE_CONFIRM},
+						SessionID: *selection.SessionID,
+					})
+				}
+				return nil
 			}
 		}
 		return event
@@ -167,8 +252,16 @@ func (gv *GameView) setupFocusHandlers() {
 		gv.app.updateFooterHelp(helpBar("Games", []helpEntry{
 			{"↑/↓", "Navigate"},
 			{"Space/Enter", "Select/Expand"},
-			{"e", "Edit"},
+			{"e", "Edit/Rename"},
 			{"n", "New"},
+			{"p", "New Notes Page"},
+			{"r", "Recap"},
+			{"c", "Codex"},
+			{"u/d", "Move Up/Down"},
+			{"m", "Move to Game"},
+			{"j", "Join Next"},
+			{"t", "Trash"},
+			{"y", "Sync"},
 		}))
 		gv.Tree.SetBorderColor(Style.BorderFocusColor)
 	})
@@ -219,7 +312,7 @@ func (gv *GameView) Refresh() {
 			gameNode.SetExpanded(true)
 		}
 
-		// Add the notes node
+		// Add the notes node with a child for each page
 		reference = &GameState{GameID: &g.Game.ID, GameName: g.Game.Name, IsNotes: true}
 		notesNode := tview.NewTreeNode("Notes").
 			SetReference(reference).
@@ -228,6 +321,29 @@ func (gv *GameView) Refresh() {
 			SetExpanded(false)
 		gameNode.AddChild(notesNode)
 
+		if currentSelection != nil && currentSelection.IsNotes && currentSelection.PageID == nil &&
+			g.Game.ID == *currentSelection.GameID {
+			gv.Tree.SetCurrentNode(notesNode)
+			gameNode.SetExpanded(true)
+		}
+
+		for _, p := range g.Pages {
+			reference = &GameState{GameID: &g.Game.ID, GameName: g.Game.Name, IsNotes: true, PageID: &p.ID}
+			pageNode := tview.NewTreeNode(tview.Escape(p.Name)).
+				SetReference(reference).
+				SetColor(Style.ChildTreeNodeColor).
+				SetSelectable(true).
+				SetExpanded(false)
+			notesNode.AddChild(pageNode)
+
+			// Check if this page was previously selected
+			if currentSelection != nil && currentSelection.PageID != nil && p.ID == *currentSelection.PageID {
+				gv.Tree.SetCurrentNode(pageNode)
+				gameNode.SetExpanded(true)
+				notesNode.SetExpanded(true)
+			}
+		}
+
 		// Load sessions for this game
 		if len(g.Sessions) == 0 {
 			sessionPlaceholder := tview.NewTreeNode("(No sessions yet)").
@@ -237,7 +353,7 @@ func (gv *GameView) Refresh() {
 		} else {
 			for _, s := range g.Sessions {
 				reference = &GameState{GameID: &g.Game.ID, GameName: g.Game.Name, SessionID: &s.ID}
-				sessionNode := tview.NewTreeNode(tview.Escape(s.Name) + " (" + s.CreatedAt.Format("2006-01-02") + ")").
+				sessionNode := tview.NewTreeNode(tview.Escape(s.Name) + " (" + s.PlayedAt.Format(text.DateFormat) + ")").
 					SetReference(reference).
 					SetColor(Style.ChildTreeNodeColor).
 					SetSelectable(true).
@@ -298,27 +414,36 @@ func (gv *GameView) SelectGame(gameID *int64) {
 	}
 }
 
-func (gv *GameView) SelectNotes(gameID int64) {
+// SelectPage moves the tree selection to a notes page, expanding its game and
+// the Notes node above it
+func (gv *GameView) SelectPage(pageID int64) {
 	if gv.Tree.GetRoot() == nil {
 		return
 	}
 
-	var foundNode *tview.TreeNode
+	var gameNode, foundNode *tview.TreeNode
 	gv.Tree.GetRoot().Walk(func(node, parent *tview.TreeNode) bool {
 		ref := node.GetReference()
 		if ref != nil {
-			if state, ok := ref.(*GameState); ok && state.IsNotes && state.GameID != nil && *state.GameID == gameID {
-				foundNode = node
-				if parent != nil {
+			if state, ok := ref.(*GameState); ok && state.IsNotes {
+				// The tree is walked in order, so the last Notes node seen
+				// belongs to the page's game
+				if state.PageID == nil {
+					gameNode = parent
+				} else if *state.PageID == pageID {
+					foundNode = node
 					parent.SetExpanded(true)
+					return false
 				}
-				return false
 			}
 		}
 		return true
 	})
 
 	if foundNode != nil {
+		if gameNode != nil {
+			gameNode.SetExpanded(true)
+		}
 		gv.Tree.SetCurrentNode(foundNode)
 	}
 }
@@ -359,12 +484,17 @@ func (gv *GameView) GetCurrentSelection() *GameState {
 }
 
 // SetCurrentGame loads a game by ID and stores it as the active game context.
+// The character tree follows the active game's party.
 func (gv *GameView) SetCurrentGame(gameID int64) error {
 	g, err := gv.gameService.GetByID(gameID)
 	if err != nil {
 		return err
 	}
+	changed := gv.currentGame == nil || gv.currentGame.ID != g.ID
 	gv.currentGame = g
+	if changed {
+		gv.app.characterView.RefreshTree()
+	}
 	return nil
 }
 
@@ -2,29 +2,33 @@ package ui
 
 import (
 	"soloterm/domain/game"
+	"soloterm/domain/notes"
 	"soloterm/domain/session"
 )
 
 type GameWithSessions struct {
 	Game     *game.Game
 	Sessions []*session.Session
+	Pages    []*notes.Page
 }
 
 // GameViewHelper coordinates game-related UI operations
 type GameViewHelper struct {
 	gameService    *game.Service
 	sessionService *session.Service
+	notesService   *notes.Service
 }
 
-// Create a new game helper which uses the game and log services provided
-func NewGameViewHelper(gameService *game.Service, sessionService *session.Service) *GameViewHelper {
+// Create a new game helper which uses the game, log and notes services provided
+func NewGameViewHelper(gameService *game.Service, sessionService *session.Service, notesService *notes.Service) *GameViewHelper {
 	return &GameViewHelper{
 		gameService:    gameService,
 		sessionService: sessionService,
+		notesService:   notesService,
 	}
 }
 
-// LoadAllGames loads all the games and the sessions for the game and
+// LoadAllGames loads all the games with their sessions and notes pages and
 // combines them into a structure and returns an array of those structures
 func (gh *GameViewHelper) LoadAllGames() ([]*GameWithSessions, error) {
 	var gamesWithSessions []*GameWithSessions
@@ -40,7 +44,13 @@ func (gh *GameViewHelper) LoadAllGames() ([]*GameWithSessions, error) {
 		if err != nil {
 			return nil, err
 		}
-		gamesWithSessions = append(gamesWithSessions, &GameWithSessions{Game: g, Sessions: sessions})
+
+		// Load the notes pages for the game
+		pages, err := gh.notesService.GetAllForGame(g.ID)
+		if err != nil {
+			return nil, err
+		}
+		gamesWithSessions = append(gamesWithSessions, &GameWithSessions{Game: g, Sessions: sessions, Pages: pages})
 	}
 
 	return gamesWithSessions, nil
@@ -2,7 +2,9 @@ package ui
 
 import (
 	"soloterm/config"
+	"soloterm/database"
 	"soloterm/domain/game"
+	"soloterm/domain/notes"
 	"soloterm/domain/session"
 	"soloterm/domain/tag"
 	testHelper "soloterm/shared/testing"
@@ -20,6 +22,13 @@ import (
 // setupTestApp creates a fully wired App backed by an in-memory database.
 func setupTestApp(t *testing.T) *App {
 	t.Helper()
+	app, _ := setupTestAppWithDB(t)
+	return app
+}
+
+// setupTestAppWithDB is setupTestApp for tests that also need the database
+func setupTestAppWithDB(t *testing.T) (*App, *database.DBStore) {
+	t.Helper()
 
 	db := testHelper.SetupTestDB(t)
 	t.Cleanup(func() { testHelper.TeardownTestDB(t, db) })
@@ -31,7 +40,7 @@ func setupTestApp(t *testing.T) *App {
 	}
 
 	app := NewApp(db, cfg, AppInfo{})
-	return app
+	return app, db
 }
 
 // createGame is a test helper that creates a game, refreshes the tree, and selects it.
@@ -45,10 +54,21 @@ func createGame(t *testing.T, app *App, name string) *game.Game {
 	return g
 }
 
-func addNotesToGame(t *testing.T, app *App, gameID int64, notes string) {
+// addNotesToGame adds a notes page named "Notes" holding content to the game
+func addNotesToGame(t *testing.T, app *App, gameID int64, content string) *notes.Page {
 	t.Helper()
-	err := app.gameView.gameService.SaveNotes(gameID, notes)
-	require.NoError(t, err, "Failed to set notes on game")
+	page, err := app.notesService.Save(&notes.Page{GameID: gameID, Name: notes.DefaultPageName, Content: content})
+	require.NoError(t, err, "Failed to add notes to game")
+	return page
+}
+
+// notesContent returns the saved content of the game's first notes page
+func notesContent(t *testing.T, app *App, gameID int64) string {
+	t.Helper()
+	pages, err := app.notesService.GetAllForGame(gameID)
+	require.NoError(t, err)
+	require.NotEmpty(t, pages, "Expected the game to have a notes page")
+	return pages[0].Content
 }
 
 func createSession(t *testing.T, app *App, gameID int64, name string) *session.Session {
@@ -283,7 +303,7 @@ func TestGameView_SelectGame(t *testing.T) {
 		testHelper.SimulateEnter(app.gameView.Tree, app.Application)
 		assert.Equal(t, session.ID, *app.sessionView.currentSessionID)
 
-		sessionLabel := "New Session (" + session.CreatedAt.Format("2006-01-02") + ")"
+		sessionLabel := "New Session (" + session.PlayedAt.Format("2006-01-02") + ")"
 		assert.Equal(t, sessionLabel, app.gameView.Tree.GetCurrentNode().GetText())
 
 		// Refreshing the view will re-select the session
@@ -293,3 +313,24 @@ func TestGameView_SelectGame(t *testing.T) {
 	})
 
 }
+
+func TestGameView_ReorderSessions(t *testing.T) {
+	app := setupTestApp(t)
+	g := createGame(t, app, "Test Game")
+	s1 := createSession(t, app, g.ID, "Session One")
+	s2 := createSession(t, app, g.ID, "Session Two")
+	app.gameView.Refresh()
+	app.gameView.SelectSession(s2.ID)
+
+	testHelper.SimulateRune(app.gameView.Tree, app.Application, 'u')
+
+	sessions, err := app.sessionView.sessionService.GetAllForGame(g.ID)
+	require.NoError(t, err)
+	require.Len(t, sessions, 2)
+	assert.Equal(t, []int64{s2.ID, s1.ID}, []int64{sessions[0].ID, sessions[1].ID})
+
+	selection := app.gameView.GetCurrentSelection()
+	require.NotNil(t, selection)
+	require.NotNil(t, selection.SessionID)
+	assert.Equal(t, s2.ID, *selection.SessionID, "Expected the moved session to stay selected")
+}
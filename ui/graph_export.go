@@ -0,0 +1,34 @@
+package ui
+
+import "soloterm/domain/graph"
+
+// GraphExport writes a game's tag and codex graph through the file export
+// flow. The file's extension picks the format: .mmd for Mermaid, .md for
+// Mermaid in Markdown and anything else, such as .dot, for Graphviz DOT.
+type GraphExport struct {
+	graph *graph.Graph
+	title string
+	path  string
+}
+
+// NewGraphExport creates an export of the graph, named title where the
+// format allows
+func NewGraphExport(g *graph.Graph, title string) *GraphExport {
+	return &GraphExport{graph: g, title: title}
+}
+
+// ====== FileTarget implementation ======
+
+func (ge *GraphExport) GetFileContent() string {
+	return ge.graph.Render(graph.FormatForPath(ge.path), ge.title)
+}
+
+func (ge *GraphExport) SetFileContent(data string, position ImportPosition) {} // unused; the graph is export only
+
+func (ge *GraphExport) UsePositionField() bool { return false }
+
+func (ge *GraphExport) FileDir() string { return "" } // unused; FileView uses dirs.ExportDir()
+
+func (ge *GraphExport) OnFileDone() {}
+
+func (ge *GraphExport) SetFilePath(path string) { ge.path = path }
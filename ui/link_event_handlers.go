@@ -0,0 +1,89 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"soloterm/domain/link"
+
+	"github.com/rivo/tview"
+)
+
+// currentGameID returns the game of the open session or notes page, or 0
+// when neither is open
+func (a *App) currentGameID() int64 {
+	if p := a.sessionView.currentPage; p != nil {
+		return p.GameID
+	}
+	if s := a.sessionView.currentSession; s != nil {
+		return s.GameID
+	}
+	if g := a.CurrentGame(); g != nil {
+		return g.ID
+	}
+	return 0
+}
+
+// currentDocument returns the open session or notes page as the document
+// links are followed from
+func (a *App) currentDocument() link.Document {
+	sv := a.sessionView
+	if sv.IsNotesMode() {
+		return link.Document{PageID: sv.currentPage.ID}
+	}
+	if sv.currentSessionID != nil {
+		return link.Document{SessionID: *sv.currentSessionID}
+	}
+	return link.Document{}
+}
+
+func (a *App) handleLinkFollow(e *LinkFollowEvent) {
+	a.Autosave()
+
+	dest, err := a.linkService.Resolve(a.currentGameID(), a.currentDocument(), e.Link)
+	if errors.Is(err, link.ErrNotFound) {
+		a.notification.ShowWarning(fmt.Sprintf("There is no session or notes page named %s in this game.", tview.Escape(e.Link.Target)))
+		return
+	}
+	if err != nil {
+		a.notification.ShowError(fmt.Sprintf("Error following the link: %v", err))
+		return
+	}
+
+	a.openAt(dest.SessionID, dest.PageID, dest.Offset, 0)
+	if !dest.HeadingFound {
+		a.notification.ShowWarning(fmt.Sprintf("The heading %s wasn't found, so the link opened at the top.", tview.Escape(e.Link.Heading)))
+	}
+}
+
+func (a *App) handleBacklinksShow(_ *BacklinksShowEvent) {
+	sv := a.sessionView
+	var name string
+	if sv.IsNotesMode() {
+		name = sv.currentPage.Name
+	} else {
+		if sv.currentSession == nil {
+			return
+		}
+		name = sv.currentSession.Name
+	}
+
+	a.Autosave()
+	a.backlinksView.returnFocus = a.GetFocus()
+	a.backlinksView.Load(a.currentGameID(), a.currentDocument(), name)
+	a.pages.ShowPage(BACKLINKS_MODAL_ID)
+	a.SetFocus(a.backlinksView.Table)
+}
+
+func (a *App) handleBacklinksCancel(_ *BacklinksCancelEvent) {
+	a.pages.HidePage(BACKLINKS_MODAL_ID)
+	a.SetFocus(a.backlinksView.returnFocus)
+}
+
+func (a *App) handleBacklinksSelect(e *BacklinksSelectEvent) {
+	a.pages.HidePage(BACKLINKS_MODAL_ID)
+	if e.Backlink.PageID != nil {
+		a.openAt(0, *e.Backlink.PageID, e.Backlink.Offset, len(e.Backlink.Raw))
+		return
+	}
+	a.openAt(*e.Backlink.SessionID, 0, e.Backlink.Offset, len(e.Backlink.Raw))
+}
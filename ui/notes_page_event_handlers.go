@@ -0,0 +1,81 @@
+package ui
+
+import "fmt"
+
+func (a *App) handleNotesPageShowNew(e *NotesPageShowNewEvent) {
+	a.notesPageView.Form.Reset(e.GameID)
+	a.notesPageView.formModal.SetTitle(" New Notes Page ")
+	a.pages.ShowPage(NOTES_PAGE_MODAL_ID)
+	a.SetFocus(a.notesPageView.Form)
+}
+
+func (a *App) handleNotesPageShowEdit(e *NotesPageShowEditEvent) {
+	// Save any typing first so a rename does not overwrite it
+	a.Autosave()
+
+	page, err := a.notesService.GetByID(e.PageID)
+	if err != nil {
+		a.notification.ShowError(fmt.Sprintf("Error loading page: %v", err))
+		return
+	}
+
+	a.notesPageView.Form.PopulateForEdit(page)
+	a.notesPageView.formModal.SetTitle(" Edit Notes Page ")
+	a.pages.ShowPage(NOTES_PAGE_MODAL_ID)
+	a.SetFocus(a.notesPageView.Form)
+}
+
+func (a *App) handleNotesPageSaved(e *NotesPageSavedEvent) {
+	a.notesPageView.Form.ClearFieldErrors()
+	a.pages.HidePage(NOTES_PAGE_MODAL_ID)
+	a.gameView.Refresh()
+	a.HandleEvent(&GameNotesSelectedEvent{
+		BaseEvent: BaseEvent{action: GAME_NOTES_SELECTED},
+		GameID:    e.Page.GameID,
+		PageID:    e.Page.ID,
+	})
+	a.SetFocus(a.sessionView.TextArea)
+	a.notification.ShowSuccess("Page saved successfully")
+}
+
+func (a *App) handleNotesPageCancel(_ *NotesPageCancelledEvent) {
+	a.pages.HidePage(NOTES_PAGE_MODAL_ID)
+	a.SetFocus(a.gameView.Tree)
+}
+
+func (a *App) handleNotesPageDeleteConfirm(e *NotesPageDeleteConfirmEvent) {
+	returnFocus := a.GetFocus()
+
+	a.confirmModal.Configure(
+		"Are you sure you want to delete the page \""+e.Page.Name+"\"?\n\nIt can be restored from the Trash (t).",
+		func() {
+			a.notesPageView.ConfirmDelete(e.Page)
+		},
+		func() {
+			a.pages.HidePage(CONFIRM_MODAL_ID)
+			a.SetFocus(returnFocus)
+		},
+	)
+
+	a.pages.ShowPage(CONFIRM_MODAL_ID)
+}
+
+func (a *App) handleNotesPageDeleted(e *NotesPageDeletedEvent) {
+	a.pages.HidePage(CONFIRM_MODAL_ID)
+	a.pages.HidePage(NOTES_PAGE_MODAL_ID)
+
+	// Clear the editor when it was showing the deleted page
+	if page := a.sessionView.currentPage; page != nil && page.ID == e.Page.ID {
+		a.sessionView.Reset()
+		a.sessionView.Refresh()
+	}
+
+	a.gameView.Refresh()
+	a.SetFocus(a.gameView.Tree)
+	a.notification.ShowSuccess("Page deleted")
+}
+
+func (a *App) handleNotesPageDeleteFailed(e *NotesPageDeleteFailedEvent) {
+	a.pages.HidePage(CONFIRM_MODAL_ID)
+	a.notification.ShowError("Error deleting page: " + e.Error.Error())
+}
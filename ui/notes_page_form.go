@@ -0,0 +1,91 @@
+package ui
+
+import (
+	"soloterm/domain/notes"
+	sharedui "soloterm/shared/ui"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// NotesPageForm represents a form for naming notes pages
+type NotesPageForm struct {
+	*sharedui.DataForm
+	page      *notes.Page // the page being renamed, or nil for a new page
+	gameID    int64
+	nameField *tview.InputField
+}
+
+// NewNotesPageForm creates a new notes page form
+func NewNotesPageForm() *NotesPageForm {
+	f := &NotesPageForm{
+		DataForm: sharedui.NewDataForm(),
+	}
+
+	f.nameField = tview.NewInputField().
+		SetLabel("Name").
+		SetPlaceholder("e.g. NPCs, Locations, Factions or Rumours").
+		SetPlaceholderStyle(tcell.StyleDefault.Foreground(Style.EmptyStateMessageColor)).
+		SetFieldBackgroundColor(tcell.ColorDefault).
+		SetFieldWidth(0)
+
+	f.Clear(true)
+	f.AddFormItem(f.nameField)
+	f.SetBorder(false)
+	f.SetButtonsAlign(tview.AlignCenter)
+	f.SetItemPadding(1)
+
+	return f
+}
+
+// Reset clears the form for a new page in the game
+func (f *NotesPageForm) Reset(gameID int64) {
+	f.page = nil
+	f.gameID = gameID
+	f.nameField.SetText("")
+	f.ClearFieldErrors()
+	f.RemoveDeleteButton()
+	f.SetFocus(0)
+}
+
+// PopulateForEdit fills the form with an existing page's name
+func (f *NotesPageForm) PopulateForEdit(page *notes.Page) {
+	f.page = page
+	f.gameID = page.GameID
+	f.nameField.SetText(page.Name)
+	f.ClearFieldErrors()
+	f.AddDeleteButton()
+	f.SetFocus(0)
+}
+
+// BuildDomain constructs a Page from the form, keeping the content of a page
+// being renamed
+func (f *NotesPageForm) BuildDomain() *notes.Page {
+	if f.page != nil {
+		page := *f.page
+		page.Name = f.nameField.GetText()
+		return &page
+	}
+	page, _ := notes.NewPage(f.gameID, f.nameField.GetText())
+	return page
+}
+
+// SetFieldErrors sets errors and updates field labels
+func (f *NotesPageForm) SetFieldErrors(errors map[string]string) {
+	f.DataForm.SetFieldErrors(errors)
+	f.updateFieldLabels()
+}
+
+// ClearFieldErrors removes all error highlights
+func (f *NotesPageForm) ClearFieldErrors() {
+	f.DataForm.ClearFieldErrors()
+	f.updateFieldLabels()
+}
+
+func (f *NotesPageForm) updateFieldLabels() {
+	if f.HasFieldError("name") {
+		f.nameField.SetLabel("[" + Style.ErrorTextColor + "]Name[" + Style.NormalTextColor + "]")
+	} else {
+		f.nameField.SetLabel("Name")
+	}
+}
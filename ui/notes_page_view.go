@@ -0,0 +1,112 @@
+package ui
+
+import (
+	"fmt"
+	"soloterm/domain/notes"
+	sharedui "soloterm/shared/ui"
+
+	"github.com/rivo/tview"
+)
+
+// NotesPageView provides the form for adding, renaming and deleting the
+// named pages of a game's notes
+type NotesPageView struct {
+	app          *App
+	notesService *notes.Service
+
+	Form      *NotesPageForm
+	Modal     *tview.Flex
+	formModal *sharedui.FormModal
+}
+
+// NewNotesPageView creates a new notes page view
+func NewNotesPageView(app *App, notesService *notes.Service) *NotesPageView {
+	pv := &NotesPageView{
+		app:          app,
+		notesService: notesService,
+	}
+
+	pv.Setup()
+
+	return pv
+}
+
+// Setup initializes all notes page UI components
+func (pv *NotesPageView) Setup() {
+	pv.Form = NewNotesPageForm()
+	pv.Form.SetupHandlers(
+		pv.HandleSave,
+		pv.HandleCancel,
+		pv.HandleDelete,
+	)
+
+	pv.formModal = sharedui.NewFormModal(pv.Form, 7)
+	pv.Modal = pv.formModal.Modal
+
+	pv.Form.SetFocusFunc(func() {
+		pv.app.SetModalHelpMessage(*pv.Form.DataForm)
+		pv.formModal.SetBorderColor(Style.BorderFocusColor)
+	})
+	pv.Form.SetBlurFunc(func() {
+		pv.formModal.SetBorderColor(Style.BorderColor)
+	})
+}
+
+// HandleSave processes notes page save operation
+func (pv *NotesPageView) HandleSave() {
+	page, err := pv.notesService.Save(pv.Form.BuildDomain())
+	if err != nil && !isIndexingError(err) {
+		// Check if it's a validation error
+		if sharedui.HandleValidationError(err, pv.Form) {
+			return
+		}
+
+		// Other errors
+		pv.app.notification.ShowError(fmt.Sprintf("Error saving page: %v", err))
+		return
+	}
+
+	pv.app.HandleEvent(&NotesPageSavedEvent{
+		BaseEvent: BaseEvent{action: NOTES_PAGE_SAVED},
+		Page:      page,
+	})
+
+	// The page was saved but its tags weren't indexed
+	if err != nil {
+		pv.app.showIndexingWarning(err)
+	}
+}
+
+// HandleCancel processes notes page form cancellation
+func (pv *NotesPageView) HandleCancel() {
+	pv.app.HandleEvent(&NotesPageCancelledEvent{
+		BaseEvent: BaseEvent{action: NOTES_PAGE_CANCEL},
+	})
+}
+
+// HandleDelete asks to confirm deleting the page being renamed
+func (pv *NotesPageView) HandleDelete() {
+	if pv.Form.page == nil {
+		return
+	}
+	pv.app.HandleEvent(&NotesPageDeleteConfirmEvent{
+		BaseEvent: BaseEvent{action: NOTES_PAGE_DELETE_CONFIRM},
+		Page:      pv.Form.page,
+	})
+}
+
+// ConfirmDelete executes the actual deletion after user confirmation
+func (pv *NotesPageView) ConfirmDelete(page *notes.Page) {
+	if err := pv.notesService.Delete(page.ID); err != nil {
+		pv.app.HandleEvent(&NotesPageDeleteFailedEvent{
+			BaseEvent: BaseEvent{action: NOTES_PAGE_DELETE_FAILED},
+			Error:     err,
+		})
+		return
+	}
+
+	pv.app.HandleEvent(&NotesPageDeletedEvent{
+		BaseEvent: BaseEvent{action: NOTES_PAGE_DELETED},
+		Page:      page,
+	})
+}
@@ -3,6 +3,7 @@ package ui
 import (
 	"os"
 	"path/filepath"
+	"soloterm/domain/notes"
 	testHelper "soloterm/shared/testing"
 	"testing"
 
@@ -26,8 +27,7 @@ func TestNotes_SelectingLoadsContent(t *testing.T) {
 	app := setupTestApp(t)
 	g := createGame(t, app, "My Campaign")
 
-	err := app.gameView.gameService.SaveNotes(g.ID, "[N:Malichi | Hostile mage]")
-	require.NoError(t, err)
+	addNotesToGame(t, app, g.ID, "[N:Malichi | Hostile mage]")
 
 	app.gameView.Refresh()
 	selectNotes(t, app)
@@ -97,9 +97,7 @@ func TestNotes_AutosavePersistsContent(t *testing.T) {
 
 	app.Autosave()
 
-	saved, err := app.gameView.gameService.GetByID(g.ID)
-	require.NoError(t, err)
-	assert.Equal(t, "[N:Malichi | Hostile mage]", saved.Notes)
+	assert.Equal(t, "[N:Malichi | Hostile mage]", notesContent(t, app, g.ID))
 	assert.False(t, app.sessionView.isDirty, "isDirty must clear after autosave")
 }
 
@@ -144,9 +142,7 @@ func TestNotes_SwitchToSessionAutosavesNotes(t *testing.T) {
 	})
 
 	// Notes were saved.
-	saved, err := app.gameView.gameService.GetByID(g.ID)
-	require.NoError(t, err)
-	assert.Equal(t, "NPC notes here", saved.Notes)
+	assert.Equal(t, "NPC notes here", notesContent(t, app, g.ID))
 
 	// Session is now loaded.
 	assert.False(t, app.sessionView.IsNotesMode())
@@ -274,18 +270,17 @@ func TestNotes_ImportFile(t *testing.T) {
 	assert.False(t, app.isPageVisible(FILE_MODAL_ID), "Expected file modal to close after import")
 	assert.Equal(t, "Imported notes content", app.sessionView.TextArea.GetText())
 
-	saved, err := app.gameView.gameService.GetByID(g.ID)
-	require.NoError(t, err)
-	assert.Equal(t, "Imported notes content", saved.Notes)
+	assert.Equal(t, "Imported notes content", notesContent(t, app, g.ID))
 }
 
-// TestNotes_ExportFile verifies that exporting while in notes mode writes the
-// current notes content to the specified file.
+// TestNotes_ExportFile verifies that exporting while in notes mode writes
+// every notes page of the game to the specified file.
 func TestNotes_ExportFile(t *testing.T) {
 	app := setupTestApp(t)
 	g := createGame(t, app, "My Campaign")
 
-	err := app.gameView.gameService.SaveNotes(g.ID, "Notes to export")
+	addNotesToGame(t, app, g.ID, "Notes to export")
+	_, err := app.notesService.Save(&notes.Page{GameID: g.ID, Name: "People", Content: "[N:Vex]"})
 	require.NoError(t, err)
 
 	app.gameView.Refresh()
@@ -305,5 +300,131 @@ func TestNotes_ExportFile(t *testing.T) {
 
 	data, err := os.ReadFile(exportPath)
 	require.NoError(t, err)
-	assert.Equal(t, "Notes to export", string(data))
+	assert.Equal(t, "# Notes\n\nNotes to export\n\n# People\n\n[N:Vex]\n", string(data))
+}
+
+// TestNotes_EnterOnNotesCreatesFirstPage verifies that opening the notes of a
+// game without pages creates the first page and shows it in the tree.
+func TestNotes_EnterOnNotesCreatesFirstPage(t *testing.T) {
+	app := setupTestApp(t)
+	g := createGame(t, app, "My Campaign")
+
+	app.gameView.Refresh()
+	selectNotes(t, app)
+
+	pages, err := app.notesService.GetAllForGame(g.ID)
+	require.NoError(t, err)
+	require.Len(t, pages, 1)
+	assert.Equal(t, notes.DefaultPageName, pages[0].Name)
+
+	state := app.gameView.GetCurrentSelection()
+	require.NotNil(t, state)
+	require.NotNil(t, state.PageID, "Expected the new page to be selected in the tree")
+	assert.Equal(t, pages[0].ID, *state.PageID)
+}
+
+// TestNotes_PagesAppearUnderNotesNode verifies that each page is a child of
+// the game's Notes node and selecting one opens it.
+func TestNotes_PagesAppearUnderNotesNode(t *testing.T) {
+	app := setupTestApp(t)
+	g := createGame(t, app, "My Campaign")
+	addNotesToGame(t, app, g.ID, "General notes")
+	people, err := app.notesService.Save(&notes.Page{GameID: g.ID, Name: "People", Content: "[N:Vex]"})
+	require.NoError(t, err)
+
+	app.gameView.Refresh()
+	selectNotes(t, app)                                              // opens the first page
+	testHelper.SimulateDownArrow(app.gameView.Tree, app.Application) // Notes page → People
+	testHelper.SimulateEnter(app.gameView.Tree, app.Application)
+
+	require.True(t, app.sessionView.IsNotesMode())
+	assert.Equal(t, people.ID, app.sessionView.currentPage.ID)
+	assert.Equal(t, "[N:Vex]", app.sessionView.TextArea.GetText())
+	assert.Contains(t, app.sessionView.textAreaFrame.GetTitle(), "My Campaign: People")
+}
+
+// TestNotes_AddPageFromTree verifies that p in the game tree opens the page
+// form and saving it adds and opens the new page.
+func TestNotes_AddPageFromTree(t *testing.T) {
+	app := setupTestApp(t)
+	g := createGame(t, app, "My Campaign")
+
+	testHelper.SimulateRune(app.gameView.Tree, app.Application, 'p')
+	require.True(t, app.isPageVisible(NOTES_PAGE_MODAL_ID), "p must open the notes page modal")
+
+	app.notesPageView.Form.nameField.SetText("Rumours")
+	testHelper.SimulateKey(app.notesPageView.Form, app.Application, tcell.KeyCtrlS)
+	assert.False(t, app.isPageVisible(NOTES_PAGE_MODAL_ID))
+
+	pages, err := app.notesService.GetAllForGame(g.ID)
+	require.NoError(t, err)
+	require.Len(t, pages, 1)
+	assert.Equal(t, "Rumours", pages[0].Name)
+
+	require.True(t, app.sessionView.IsNotesMode())
+	assert.Equal(t, pages[0].ID, app.sessionView.currentPage.ID)
+	state := app.gameView.GetCurrentSelection()
+	require.NotNil(t, state.PageID)
+	assert.Equal(t, pages[0].ID, *state.PageID)
+}
+
+// TestNotes_AddPageRequiresName verifies the page form keeps the modal open
+// with a field error when the name is blank.
+func TestNotes_AddPageRequiresName(t *testing.T) {
+	app := setupTestApp(t)
+	createGame(t, app, "My Campaign")
+
+	testHelper.SimulateRune(app.gameView.Tree, app.Application, 'p')
+	testHelper.SimulateKey(app.notesPageView.Form, app.Application, tcell.KeyCtrlS)
+
+	assert.True(t, app.isPageVisible(NOTES_PAGE_MODAL_ID))
+	assert.True(t, app.notesPageView.Form.HasFieldError("name"))
+}
+
+// TestNotes_RenamePageKeepsContent verifies that e on a page renames it
+// without losing unsaved typing.
+func TestNotes_RenamePageKeepsContent(t *testing.T) {
+	app := setupTestApp(t)
+	g := createGame(t, app, "My Campaign")
+	page := addNotesToGame(t, app, g.ID, "Old")
+
+	app.gameView.Refresh()
+	selectNotes(t, app)
+	app.sessionView.TextArea.SetText("Typed before renaming", false)
+
+	testHelper.SimulateRune(app.gameView.Tree, app.Application, 'e')
+	require.True(t, app.isPageVisible(NOTES_PAGE_MODAL_ID))
+	app.notesPageView.Form.nameField.SetText("Locations")
+	testHelper.SimulateKey(app.notesPageView.Form, app.Application, tcell.KeyCtrlS)
+
+	stored, err := app.notesService.GetByID(page.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Locations", stored.Name)
+	assert.Equal(t, "Typed before renaming", stored.Content)
+	assert.Contains(t, app.sessionView.textAreaFrame.GetTitle(), "Locations")
+}
+
+// TestNotes_DeletePage verifies that deleting the open page removes it and
+// clears the editor.
+func TestNotes_DeletePage(t *testing.T) {
+	app := setupTestApp(t)
+	g := createGame(t, app, "My Campaign")
+	page := addNotesToGame(t, app, g.ID, "[N:Vex]")
+
+	app.gameView.Refresh()
+	selectNotes(t, app)
+	testHelper.SimulateRune(app.gameView.Tree, app.Application, 'e')
+	require.True(t, app.isPageVisible(NOTES_PAGE_MODAL_ID))
+
+	app.notesPageView.HandleDelete()
+	require.True(t, app.isPageVisible(CONFIRM_MODAL_ID))
+	app.notesPageView.ConfirmDelete(page)
+
+	assert.False(t, app.isPageVisible(CONFIRM_MODAL_ID))
+	assert.False(t, app.isPageVisible(NOTES_PAGE_MODAL_ID))
+	assert.False(t, app.sessionView.IsNotesMode(), "Expected the deleted page to be closed")
+
+	pages, err := app.notesService.GetAllForGame(g.ID)
+	require.NoError(t, err)
+	assert.Empty(t, pages)
 }
@@ -0,0 +1,12 @@
+package ui
+
+func (a *App) handlePromptSubmit(e *PromptSubmitEvent) {
+	a.pages.HidePage(PROMPT_MODAL_ID)
+	a.SetFocus(a.promptView.returnFocus)
+	a.sessionView.insertExpanded(e.Template, e.Values)
+}
+
+func (a *App) handlePromptCancel(_ *PromptCancelEvent) {
+	a.pages.HidePage(PROMPT_MODAL_ID)
+	a.SetFocus(a.promptView.returnFocus)
+}
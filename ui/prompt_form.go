@@ -0,0 +1,51 @@
+package ui
+
+import (
+	sharedui "soloterm/shared/ui"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// PromptForm asks for the answers to a template's {{prompt:Name}} placeholders
+type PromptForm struct {
+	*sharedui.DataForm
+	names  []string
+	fields []*tview.InputField
+}
+
+// NewPromptForm creates a new prompt form
+func NewPromptForm() *PromptForm {
+	pf := &PromptForm{
+		DataForm: sharedui.NewDataForm(),
+	}
+	pf.SetBorder(false)
+	pf.SetButtonsAlign(tview.AlignCenter)
+	pf.SetItemPadding(1)
+	return pf
+}
+
+// SetPrompts rebuilds the form with an empty field for each prompt name
+func (pf *PromptForm) SetPrompts(names []string) {
+	pf.Clear(false) // clear items, keep buttons
+	pf.names = names
+	pf.fields = make([]*tview.InputField, len(names))
+	for i, name := range names {
+		pf.fields[i] = tview.NewInputField().
+			SetLabel(name).
+			SetFieldBackgroundColor(tcell.ColorDefault).
+			SetFieldWidth(0)
+		pf.AddFormItem(pf.fields[i])
+	}
+	pf.ClearFieldErrors()
+	pf.SetFocus(0)
+}
+
+// Values returns the answers keyed by prompt name
+func (pf *PromptForm) Values() map[string]string {
+	values := make(map[string]string, len(pf.names))
+	for i, name := range pf.names {
+		values[name] = pf.fields[i].GetText()
+	}
+	return values
+}
@@ -0,0 +1,71 @@
+package ui
+
+import (
+	sharedui "soloterm/shared/ui"
+
+	"github.com/rivo/tview"
+)
+
+// PromptView manages the modal that asks for a template's prompt answers
+// before the template is inserted into the session.
+type PromptView struct {
+	app       *App
+	Modal     *tview.Flex
+	Form      *PromptForm
+	formModal *sharedui.FormModal
+
+	template    string
+	returnFocus tview.Primitive
+}
+
+// NewPromptView creates and sets up the prompt modal.
+func NewPromptView(app *App) *PromptView {
+	pv := &PromptView{app: app}
+	pv.setup()
+	return pv
+}
+
+func (pv *PromptView) setup() {
+	pv.Form = NewPromptForm()
+
+	pv.Form.SetupHandlers(
+		func() {
+			pv.app.HandleEvent(&PromptSubmitEvent{
+				BaseEvent: BaseEvent{action: PROMPT_SUBMIT},
+				Template:  pv.template,
+				Values:    pv.Form.Values(),
+			})
+		},
+		func() {
+			pv.app.HandleEvent(&PromptCancelEvent{
+				BaseEvent: BaseEvent{action: PROMPT_CANCEL},
+			})
+		},
+		nil,
+	)
+	pv.Form.GetButton(0).SetLabel("Insert")
+
+	pv.formModal = sharedui.NewFormModal(pv.Form, 7)
+	pv.formModal.SetTitle(" Template ")
+	pv.Modal = pv.formModal.Modal
+
+	pv.Form.SetFocusFunc(func() {
+		pv.formModal.SetBorderColor(Style.BorderFocusColor)
+	})
+
+	pv.Form.SetBlurFunc(func() {
+		pv.formModal.SetBorderColor(Style.BorderColor)
+	})
+}
+
+// Show opens the modal with a field for each of the template's prompts.
+func (pv *PromptView) Show(template string, prompts []string, returnFocus tview.Primitive) {
+	pv.template = template
+	pv.returnFocus = returnFocus
+	pv.Form.SetPrompts(prompts)
+	// Each field takes a row plus padding, around the border, padding and buttons
+	pv.formModal.SetBaseHeight(2*len(prompts) + 5)
+	pv.app.pages.ShowPage(PROMPT_MODAL_ID)
+	pv.app.SetFocus(pv.Form)
+	pv.app.updateFooterHelp(helpBar("Template", []helpEntry{{"Ctrl+S", "Insert"}, {"Esc", "Cancel"}}))
+}
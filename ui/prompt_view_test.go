@@ -0,0 +1,56 @@
+package ui
+
+import (
+	testHelper "soloterm/shared/testing"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSessionView_TemplatePlaceholders(t *testing.T) {
+	app := setupTestApp(t)
+	loadSessionWithContent(t, app, 0, "")
+
+	app.cfg.CoreTags.Action.Template = "{{game}} / {{session}}: {{roll:1d1}} [{{cursor}}]"
+	testHelper.SimulateKey(app.sessionView.TextArea, app.Application, tcell.KeyF2)
+
+	assert.Equal(t, "Test Game / Test Session: 1 []", app.sessionView.TextArea.GetText())
+	_, start, end := app.sessionView.TextArea.GetSelection()
+	assert.Equal(t, 29, start, "Expected the cursor at the {{cursor}} placeholder")
+	assert.Equal(t, start, end)
+}
+
+func TestPromptView_SubmitInsertsAnswers(t *testing.T) {
+	app := setupTestApp(t)
+	loadSessionWithContent(t, app, 0, "")
+
+	app.cfg.CoreTags.Action.Template = "[N:{{prompt:Name}} | {{prompt:Role}}] {{prompt:Name}}"
+	testHelper.SimulateKey(app.sessionView.TextArea, app.Application, tcell.KeyF2)
+	require.True(t, app.isPageVisible(PROMPT_MODAL_ID), "Expected prompt modal to be visible")
+	assert.Empty(t, app.sessionView.TextArea.GetText(), "Nothing should be inserted until the prompts are answered")
+
+	app.promptView.Form.fields[0].SetText("Vance")
+	app.promptView.Form.fields[1].SetText("guard")
+	testHelper.SimulateKey(app.promptView.Form, app.Application, tcell.KeyCtrlS)
+
+	assert.False(t, app.isPageVisible(PROMPT_MODAL_ID), "Expected prompt modal to close")
+	assert.Equal(t, "[N:Vance | guard] Vance", app.sessionView.TextArea.GetText())
+	assert.Equal(t, app.sessionView.TextArea, app.GetFocus())
+}
+
+func TestPromptView_CancelInsertsNothing(t *testing.T) {
+	app := setupTestApp(t)
+	loadSessionWithContent(t, app, 0, "")
+
+	app.cfg.CoreTags.Action.Template = "[N:{{prompt:Name}}]"
+	testHelper.SimulateKey(app.sessionView.TextArea, app.Application, tcell.KeyF2)
+	require.True(t, app.isPageVisible(PROMPT_MODAL_ID), "Expected prompt modal to be visible")
+
+	testHelper.SimulateKey(app.promptView.Form, app.Application, tcell.KeyEscape)
+
+	assert.False(t, app.isPageVisible(PROMPT_MODAL_ID), "Expected prompt modal to close")
+	assert.Empty(t, app.sessionView.TextArea.GetText())
+	assert.Equal(t, app.sessionView.TextArea, app.GetFocus())
+}
@@ -0,0 +1,31 @@
+package ui
+
+func (a *App) handleRecapShow(e *RecapShowEvent) {
+	// Store current focus so we can restore it after closing
+	a.recapView.returnFocus = a.GetFocus()
+	a.recapView.Load(e.GameID)
+	a.pages.ShowPage(RECAP_MODAL_ID)
+	a.SetFocus(a.recapView.TextView)
+}
+
+func (a *App) handleRecapCancel(_ *RecapCancelEvent) {
+	a.pages.HidePage(RECAP_MODAL_ID)
+	a.SetFocus(a.recapView.returnFocus)
+}
+
+func (a *App) handleRecapInsert(e *RecapInsertEvent) {
+	if a.sessionView.currentSession == nil {
+		a.notification.ShowWarning("Open a session to insert the recap into.")
+		return
+	}
+	if e.Recap == "" {
+		a.notification.ShowWarning("There are no session summaries to insert.")
+		return
+	}
+
+	a.pages.HidePage(RECAP_MODAL_ID)
+	a.sessionView.TextArea.Select(0, 0)
+	a.sessionView.InsertAtCursor(e.Recap + "\n")
+	a.SetFocus(a.sessionView.TextArea)
+	a.Autosave()
+}
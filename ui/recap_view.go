@@ -0,0 +1,128 @@
+package ui
+
+import (
+	"fmt"
+	"soloterm/domain/session"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// RecapView shows the campaign recap: every session summary of a game in
+// chronological order. The recap can be exported or inserted into the session.
+type RecapView struct {
+	app            *App
+	sessionService *session.Service
+	Modal          *tview.Flex
+	recapFrame     *tview.Frame
+	TextView       *tview.TextView
+	recap          string
+	returnFocus    tview.Primitive
+}
+
+// NewRecapView creates a new recap view
+func NewRecapView(app *App, sessionService *session.Service) *RecapView {
+	recapView := &RecapView{app: app, sessionService: sessionService}
+	recapView.Setup()
+	return recapView
+}
+
+// Setup initializes all recap UI components
+func (rv *RecapView) Setup() {
+	rv.setupModal()
+	rv.setupKeyBindings()
+}
+
+func (rv *RecapView) setupModal() {
+	rv.TextView = tview.NewTextView().
+		SetDynamicColors(false).
+		SetWordWrap(true).
+		SetScrollable(true)
+
+	rv.recapFrame = tview.NewFrame(rv.TextView).
+		SetBorders(1, 1, 0, 0, 1, 1)
+	rv.recapFrame.SetBorder(true).
+		SetTitleAlign(tview.AlignLeft).
+		SetTitle("[::b] Recap ([" + Style.HelpKeyTextColor + "]Esc[" + Style.NormalTextColor + "] Close) [-::-]")
+
+	rv.Modal = tview.NewFlex().
+		AddItem(nil, 0, 1, false).
+		AddItem(
+			tview.NewFlex().
+				SetDirection(tview.FlexRow).
+				AddItem(nil, 0, 1, false).
+				AddItem(rv.recapFrame, 0, 4, true).
+				AddItem(nil, 0, 1, false),
+			0, 3, true,
+		).
+		AddItem(nil, 0, 1, false)
+
+	rv.TextView.SetFocusFunc(func() {
+		rv.app.updateFooterHelp(helpBar("Recap", []helpEntry{
+			{"↑/↓", "Scroll"},
+			{"i", "Insert at Top of Session"},
+			{"Ctrl+X", "Export"},
+			{"Esc", "Close"},
+		}))
+		rv.recapFrame.SetBorderColor(Style.BorderFocusColor)
+	})
+	rv.TextView.SetBlurFunc(func() {
+		rv.recapFrame.SetBorderColor(Style.BorderColor)
+	})
+}
+
+func (rv *RecapView) setupKeyBindings() {
+	rv.TextView.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Key() {
+		case tcell.KeyEsc:
+			rv.app.HandleEvent(&RecapCancelEvent{
+				BaseEvent: BaseEvent{action: RECAP_CANCEL},
+			})
+			return nil
+		case tcell.KeyCtrlX:
+			if rv.recap == "" {
+				rv.app.notification.ShowWarning("There are no session summaries to export.")
+				return nil
+			}
+			rv.app.fileView.ShowExport(rv, rv.TextView)
+			return nil
+		case tcell.KeyRune:
+			if event.Rune() == 'i' {
+				rv.app.HandleEvent(&RecapInsertEvent{
+					BaseEvent: BaseEvent{action: RECAP_INSERT},
+					Recap:     rv.recap,
+				})
+				return nil
+			}
+		}
+		return event
+	})
+}
+
+// Load compiles the recap for the game
+func (rv *RecapView) Load(gameID int64) {
+	recap, err := rv.sessionService.Recap(gameID)
+	if err != nil {
+		rv.app.notification.ShowError(fmt.Sprintf("Error loading recap: %v", err))
+	}
+	rv.recap = recap
+
+	if recap == "" {
+		rv.TextView.SetText("No session summaries yet. Add a summary to a session by editing it.")
+	} else {
+		rv.TextView.SetText(recap)
+	}
+	rv.TextView.ScrollToBeginning()
+}
+
+// ====== FileTarget implementation ======
+
+func (rv *RecapView) GetFileContent() string { return rv.recap }
+
+func (rv *RecapView) SetFileContent(data string, position ImportPosition) {} // unused; the recap is export only
+
+func (rv *RecapView) UsePositionField() bool { return false }
+
+func (rv *RecapView) FileDir() string { return "" } // unused; FileView uses dirs.ExportDir()
+
+func (rv *RecapView) OnFileDone() {}
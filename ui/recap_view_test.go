@@ -0,0 +1,107 @@
+package ui
+
+import (
+	"soloterm/domain/game"
+	"soloterm/domain/session"
+	testHelper "soloterm/shared/testing"
+	"strings"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// createSummarizedSession is a test helper that saves a session with a summary
+func createSummarizedSession(t *testing.T, app *App, gameID int64, name, summary string) *session.Session {
+	t.Helper()
+	s, err := app.sessionView.sessionService.Save(&session.Session{GameID: gameID, Name: name, Summary: summary})
+	require.NoError(t, err, "Failed to create test session")
+	return s
+}
+
+// openRecap opens the recap modal for the game selected in the tree
+func openRecap(t *testing.T, app *App, g *game.Game) {
+	t.Helper()
+	app.gameView.Refresh()
+	app.gameView.SelectGame(&g.ID)
+	testHelper.SimulateRune(app.gameView.Tree, app.Application, 'r')
+	require.True(t, app.isPageVisible(RECAP_MODAL_ID), "Expected recap modal to be visible")
+}
+
+func TestRecapView_ShowsSummariesInOrder(t *testing.T) {
+	app := setupTestApp(t)
+	g := createGame(t, app, "Test Game")
+	createSummarizedSession(t, app, g.ID, "Session One", "We met Vance.")
+	createSummarizedSession(t, app, g.ID, "Session Two", "")
+	createSummarizedSession(t, app, g.ID, "Session Three", "Vance betrayed us.")
+
+	openRecap(t, app, g)
+
+	text := app.recapView.TextView.GetText(true)
+	assert.Contains(t, text, "# Recap: Test Game")
+	assert.NotContains(t, text, "Session Two", "Sessions without a summary are left out")
+	assert.Less(t, strings.Index(text, "We met Vance."), strings.Index(text, "Vance betrayed us."))
+
+	testHelper.SimulateKey(app.recapView.TextView, app.Application, tcell.KeyEscape)
+	assert.False(t, app.isPageVisible(RECAP_MODAL_ID), "Expected recap modal to close")
+}
+
+func TestRecapView_EmptyState(t *testing.T) {
+	app := setupTestApp(t)
+	g := createGame(t, app, "Test Game")
+	createSession(t, app, g.ID, "Session One")
+
+	openRecap(t, app, g)
+	assert.Contains(t, app.recapView.TextView.GetText(true), "No session summaries yet")
+
+	testHelper.SimulateKey(app.recapView.TextView, app.Application, tcell.KeyCtrlX)
+	assert.False(t, app.isPageVisible(FILE_MODAL_ID), "Nothing to export without summaries")
+}
+
+func TestRecapView_Export(t *testing.T) {
+	app := setupTestApp(t)
+	g := createGame(t, app, "Test Game")
+	createSummarizedSession(t, app, g.ID, "Session One", "We met Vance.")
+
+	openRecap(t, app, g)
+	testHelper.SimulateKey(app.recapView.TextView, app.Application, tcell.KeyCtrlX)
+	assert.True(t, app.isPageVisible(FILE_MODAL_ID), "Expected file modal to be visible")
+	assert.Equal(t, app.recapView.recap, app.fileView.target.GetFileContent())
+}
+
+func TestRecapView_InsertAtTopOfSession(t *testing.T) {
+	app := setupTestApp(t)
+	g := createGame(t, app, "Test Game")
+	createSummarizedSession(t, app, g.ID, "Session One", "We met Vance.")
+	s := createSession(t, app, g.ID, "Session Two")
+	s.Content = "S1 *The keep*"
+	_, err := app.sessionView.sessionService.Save(s)
+	require.NoError(t, err)
+
+	app.HandleEvent(&SessionSelectedEvent{BaseEvent: BaseEvent{action: SESSION_SELECTED}, SessionID: s.ID, GameID: g.ID})
+	openRecap(t, app, g)
+	testHelper.SimulateRune(app.recapView.TextView, app.Application, 'i')
+
+	assert.False(t, app.isPageVisible(RECAP_MODAL_ID), "Expected recap modal to close")
+	assert.Equal(t, app.sessionView.TextArea, app.GetFocus())
+
+	saved, err := app.sessionView.sessionService.GetByID(s.ID)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(saved.Content, "# Recap: Test Game\n"), "Expected the recap at the top, got %q", saved.Content)
+	assert.True(t, strings.HasSuffix(saved.Content, "\nS1 *The keep*"))
+}
+
+func TestSessionView_EditSessionSummary(t *testing.T) {
+	app := setupTestApp(t)
+	g := createGame(t, app, "Test Game")
+	s := createSession(t, app, g.ID, "Session One")
+
+	app.HandleEvent(&SessionShowEditEvent{BaseEvent: BaseEvent{action: SESSION_SHOW_EDIT}, Session: s})
+	app.sessionView.Form.summaryField.SetText("We met Vance.", false)
+	testHelper.SimulateKey(app.sessionView.Form, app.Application, tcell.KeyCtrlS)
+
+	saved, err := app.sessionView.sessionService.GetByID(s.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "We met Vance.", saved.Summary)
+}
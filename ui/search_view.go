@@ -14,8 +14,8 @@ const searchContextLen = 40
 type searchMatch struct {
 	sessionID   int64
 	sessionName string
-	offset      int  // byte offset into content
-	isNotes     bool // true = match is in game notes, not a session
+	offset      int   // byte offset into content
+	pageID      int64 // set when the match is in a notes page, not a session
 }
 
 type SearchView struct {
@@ -221,6 +221,11 @@ func (sv *SearchView) performSearch(key tcell.Key) {
 		sv.searchTextView.SetText("Search error: " + err.Error())
 		return
 	}
+	pages, err := sv.app.notesService.GetAllForGame(g.ID)
+	if err != nil {
+		sv.searchTextView.SetText("Search error: " + err.Error())
+		return
+	}
 
 	sv.matches = nil
 	sv.lastTerm = term
@@ -228,14 +233,14 @@ func (sv *SearchView) performSearch(key tcell.Key) {
 
 	var b strings.Builder
 
-	// Search game notes first
-	if g.Notes != "" {
-		sv.searchContent(&b, g.Notes, "Notes", term, 0, true)
+	// Search the notes pages first
+	for _, p := range pages {
+		sv.searchContent(&b, p.Content, "Notes: "+p.Name, term, 0, p.ID)
 	}
 
 	// Then search sessions
 	for _, s := range sessions {
-		sv.searchContent(&b, s.Content, s.Name, term, s.ID, false)
+		sv.searchContent(&b, s.Content, s.Name, term, s.ID, 0)
 	}
 
 	if len(sv.matches) == 0 {
@@ -255,7 +260,7 @@ func (sv *SearchView) performSearch(key tcell.Key) {
 
 // searchContent scans content for all occurrences of term, appending a match
 // entry and writing a formatted result block to b for each one found.
-func (sv *SearchView) searchContent(b *strings.Builder, content, sessionName, term string, sessionID int64, isNotes bool) {
+func (sv *SearchView) searchContent(b *strings.Builder, content, sessionName, term string, sessionID, pageID int64) {
 	termLower := strings.ToLower(term)
 	contentLower := strings.ToLower(content)
 	searchFrom := 0
@@ -272,7 +277,7 @@ func (sv *SearchView) searchContent(b *strings.Builder, content, sessionName, te
 			sessionID:   sessionID,
 			sessionName: sessionName,
 			offset:      absOffset,
-			isNotes:     isNotes,
+			pageID:      pageID,
 		})
 
 		startCtx := max(0, absOffset-searchContextLen)
@@ -1,6 +1,7 @@
 package ui
 
 import (
+	"soloterm/domain/notes"
 	testHelper "soloterm/shared/testing"
 	"testing"
 
@@ -28,27 +29,49 @@ func openSearchFromNotes(t *testing.T, app *App) {
 func TestSearch_FindsMatchInNotes(t *testing.T) {
 	app := setupTestApp(t)
 	g := createGame(t, app, "Campaign")
-	err := app.gameView.gameService.SaveNotes(g.ID, "Malichi is a hostile mage")
-	require.NoError(t, err)
+	addNotesToGame(t, app, g.ID, "Malichi is a hostile mage")
 
 	openSearchFromNotes(t, app)
 	runSearch(app, "hostile")
 
 	require.Len(t, app.searchView.matches, 1)
-	assert.True(t, app.searchView.matches[0].isNotes)
-	assert.Equal(t, "Notes", app.searchView.matches[0].sessionName)
+	assert.NotZero(t, app.searchView.matches[0].pageID)
+	assert.Equal(t, "Notes: Notes", app.searchView.matches[0].sessionName)
+}
+
+func TestSearch_FindsMatchesInEveryNotesPage(t *testing.T) {
+	app := setupTestApp(t)
+	g := createGame(t, app, "Campaign")
+	addNotesToGame(t, app, g.ID, "The keep is quiet")
+	people, err := app.notesService.Save(&notes.Page{GameID: g.ID, Name: "People", Content: "Vex guards the keep"})
+	require.NoError(t, err)
+
+	openSearchFromNotes(t, app)
+	runSearch(app, "keep")
+
+	require.Len(t, app.searchView.matches, 2)
+	assert.Equal(t, "Notes: People", app.searchView.matches[1].sessionName)
+	assert.Equal(t, people.ID, app.searchView.matches[1].pageID)
+
+	// Selecting the match opens that page
+	app.searchView.currentMatchIdx = 1
+	app.HandleEvent(&SearchSelectResultEvent{
+		BaseEvent: BaseEvent{action: SEARCH_SELECT_RESULT},
+	})
+	require.True(t, app.sessionView.IsNotesMode())
+	assert.Equal(t, people.ID, app.sessionView.currentPage.ID)
+	assert.Equal(t, "Vex guards the keep", app.sessionView.TextArea.GetText())
 }
 
 func TestSearch_FindsMatchesInBothSessionsAndNotes(t *testing.T) {
 	app := setupTestApp(t)
 	g := createGame(t, app, "Campaign")
 
-	err := app.gameView.gameService.SaveNotes(g.ID, "dragon sighted in the north")
-	require.NoError(t, err)
+	addNotesToGame(t, app, g.ID, "dragon sighted in the north")
 
 	s := createSession(t, app, g.ID, "Session One")
 	s.Content = "the dragon attacked the village"
-	_, err = app.sessionView.sessionService.Save(s)
+	_, err := app.sessionView.sessionService.Save(s)
 	require.NoError(t, err)
 
 	openSearchFromNotes(t, app)
@@ -59,7 +82,7 @@ func TestSearch_FindsMatchesInBothSessionsAndNotes(t *testing.T) {
 	sessionMatches := 0
 	notesMatches := 0
 	for _, m := range app.searchView.matches {
-		if m.isNotes {
+		if m.pageID != 0 {
 			notesMatches++
 		} else {
 			sessionMatches++
@@ -72,8 +95,7 @@ func TestSearch_FindsMatchesInBothSessionsAndNotes(t *testing.T) {
 func TestSearch_NoNotesMatchWhenTermAbsent(t *testing.T) {
 	app := setupTestApp(t)
 	g := createGame(t, app, "Campaign")
-	err := app.gameView.gameService.SaveNotes(g.ID, "Malichi is a hostile mage")
-	require.NoError(t, err)
+	addNotesToGame(t, app, g.ID, "Malichi is a hostile mage")
 
 	openSearchFromNotes(t, app)
 	runSearch(app, "dragon")
@@ -84,14 +106,13 @@ func TestSearch_NoNotesMatchWhenTermAbsent(t *testing.T) {
 func TestSearch_SelectNotesResult_LoadsNotesPane(t *testing.T) {
 	app := setupTestApp(t)
 	g := createGame(t, app, "Campaign")
-	err := app.gameView.gameService.SaveNotes(g.ID, "Malichi is a hostile mage")
-	require.NoError(t, err)
+	addNotesToGame(t, app, g.ID, "Malichi is a hostile mage")
 
 	openSearchFromNotes(t, app)
 	runSearch(app, "hostile")
 
 	require.Len(t, app.searchView.matches, 1)
-	require.True(t, app.searchView.matches[0].isNotes)
+	require.NotZero(t, app.searchView.matches[0].pageID)
 
 	app.HandleEvent(&SearchSelectResultEvent{
 		BaseEvent: BaseEvent{action: SEARCH_SELECT_RESULT},
@@ -105,8 +126,7 @@ func TestSearch_SelectNotesResult_LoadsNotesPane(t *testing.T) {
 func TestSearch_SelectNotesResult_SelectsNotesNodeInTree(t *testing.T) {
 	app := setupTestApp(t)
 	g := createGame(t, app, "Campaign")
-	err := app.gameView.gameService.SaveNotes(g.ID, "Malichi is a hostile mage")
-	require.NoError(t, err)
+	page := addNotesToGame(t, app, g.ID, "Malichi is a hostile mage")
 
 	openSearchFromNotes(t, app)
 	runSearch(app, "hostile")
@@ -116,10 +136,12 @@ func TestSearch_SelectNotesResult_SelectsNotesNodeInTree(t *testing.T) {
 		BaseEvent: BaseEvent{action: SEARCH_SELECT_RESULT},
 	})
 
-	// The tree's current node should be the Notes node for this game.
+	// The tree's current node should be the notes page for this game.
 	state := app.gameView.GetCurrentSelection()
 	require.NotNil(t, state)
 	assert.True(t, state.IsNotes)
+	require.NotNil(t, state.PageID)
+	assert.Equal(t, page.ID, *state.PageID)
 	assert.Equal(t, g.ID, *state.GameID)
 }
 
@@ -127,12 +149,11 @@ func TestSearch_NotesOnlyResult_NoSessionsNeeded(t *testing.T) {
 	// Search works and returns notes results even when the game has no sessions.
 	app := setupTestApp(t)
 	g := createGame(t, app, "Campaign")
-	err := app.gameView.gameService.SaveNotes(g.ID, "tower of the archmage")
-	require.NoError(t, err)
+	addNotesToGame(t, app, g.ID, "tower of the archmage")
 
 	openSearchFromNotes(t, app)
 	runSearch(app, "archmage")
 
 	require.Len(t, app.searchView.matches, 1)
-	assert.True(t, app.searchView.matches[0].isNotes)
+	assert.NotZero(t, app.searchView.matches[0].pageID)
 }
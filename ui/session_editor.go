@@ -0,0 +1,151 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// editorCommand returns the user's editor and its arguments from $VISUAL or
+// $EDITOR, falling back to vi (notepad on Windows)
+func editorCommand() []string {
+	for _, env := range []string{"VISUAL", "EDITOR"} {
+		if fields := strings.Fields(os.Getenv(env)); len(fields) > 0 {
+			return fields
+		}
+	}
+	if runtime.GOOS == "windows" {
+		return []string{"notepad"}
+	}
+	return []string{"vi"}
+}
+
+// launchEditor suspends the app and runs the user's editor on path, handing
+// it the terminal until it exits
+func (sv *SessionView) launchEditor(path string) error {
+	command := editorCommand()
+	var err error
+	suspended := sv.app.Suspend(func() {
+		cmd := exec.Command(command[0], append(command[1:], path)...)
+		cmd.Stdin = os.Stdin
+		cmd.Stdout = os.Stdout
+		cmd.Stderr = os.Stderr
+		err = cmd.Run()
+	})
+	if !suspended {
+		return fmt.Errorf("the app could not hand over the terminal")
+	}
+	if err != nil {
+		return fmt.Errorf("%s: %w", command[0], err)
+	}
+	return nil
+}
+
+// OpenInEditor writes the session or notes to a temp file and opens it in
+// the user's editor. When the editor exits the file is read back into the
+// pane and saved. If the saved copy changed while the editor was open, the
+// user is asked before it is replaced.
+func (sv *SessionView) OpenInEditor() {
+	if sv.currentSessionID == nil && !sv.IsNotesMode() {
+		return
+	}
+
+	sv.app.Autosave()
+	original := sv.TextArea.GetText()
+
+	f, err := os.CreateTemp("", "soloterm-*.md")
+	if err != nil {
+		sv.app.notification.ShowError(fmt.Sprintf("Error creating temp file: %v", err))
+		return
+	}
+	path := f.Name()
+	_, err = f.WriteString(original)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(path)
+		sv.app.notification.ShowError(fmt.Sprintf("Error writing temp file: %v", err))
+		return
+	}
+
+	if err := sv.runEditor(path); err != nil {
+		os.Remove(path)
+		sv.app.notification.ShowError(fmt.Sprintf("Error running editor: %v", err))
+		return
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		os.Remove(path)
+		sv.app.notification.ShowError(fmt.Sprintf("Error reading temp file: %v", err))
+		return
+	}
+	edited := string(data)
+
+	if edited == original {
+		os.Remove(path)
+		sv.app.notification.ShowInfo("No changes from the editor")
+		return
+	}
+
+	stored, err := sv.storedContent()
+	if err != nil {
+		os.Remove(path)
+		sv.app.notification.ShowError(fmt.Sprintf("Error checking for changes: %v", err))
+		return
+	}
+	if stored != original {
+		sv.app.HandleEvent(&SessionEditorConflictEvent{
+			BaseEvent: BaseEvent{action: SESSION_EDITOR_CONFLICT},
+			Content:   edited,
+			Path:      path,
+		})
+		return
+	}
+
+	os.Remove(path)
+	sv.ApplyEditorContent(edited)
+}
+
+// ApplyEditorContent replaces the pane's content with the editor's and saves it
+func (sv *SessionView) ApplyEditorContent(content string) {
+	sv.SetFileContent(content, ImportReplace)
+	sv.app.Autosave()
+	sv.app.notification.ShowSuccess("Updated from the editor")
+}
+
+// storedContent returns the saved copy of the session or notes page
+func (sv *SessionView) storedContent() (string, error) {
+	if sv.IsNotesMode() {
+		stored, err := sv.app.notesService.GetByID(sv.currentPage.ID)
+		if err != nil {
+			return "", err
+		}
+		return stored.Content, nil
+	}
+
+	stored, err := sv.sessionService.GetByID(*sv.currentSessionID)
+	if err != nil {
+		return "", err
+	}
+	return stored.Content, nil
+}
+
+// ReloadStored replaces the pane's content with the saved copy, discarding
+// what is in the editor
+func (sv *SessionView) ReloadStored() {
+	stored, err := sv.storedContent()
+	if err != nil {
+		sv.app.notification.ShowError(fmt.Sprintf("Error loading the saved copy: %v", err))
+		return
+	}
+	if sv.IsNotesMode() {
+		sv.currentPage.Content = stored
+	}
+	sv.SetText(stored, false)
+	sv.isDirty = false
+	sv.updateTitle()
+}
@@ -0,0 +1,115 @@
+package ui
+
+import (
+	"os"
+	testHelper "soloterm/shared/testing"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeEditor replaces the external editor with one that writes content to the
+// file, running change first to simulate edits made elsewhere meanwhile.
+// Returns a pointer to the path the editor was given.
+func fakeEditor(t *testing.T, app *App, content string, change func()) *string {
+	t.Helper()
+	var opened string
+	app.sessionView.runEditor = func(path string) error {
+		opened = path
+		if change != nil {
+			change()
+		}
+		return os.WriteFile(path, []byte(content), 0644)
+	}
+	return &opened
+}
+
+func TestEditorCommand(t *testing.T) {
+	t.Setenv("VISUAL", "")
+	t.Setenv("EDITOR", "code --wait")
+	assert.Equal(t, []string{"code", "--wait"}, editorCommand())
+
+	t.Setenv("VISUAL", "hx")
+	assert.Equal(t, []string{"hx"}, editorCommand())
+}
+
+func TestSessionEditor_ReloadsAndSaves(t *testing.T) {
+	app := setupTestApp(t)
+	loadSessionWithContent(t, app, 0, "@ Pick the lock\n")
+	opened := fakeEditor(t, app, "@ Pick the lock\nd: 2d6 -> 9\n", nil)
+
+	testHelper.SimulateKey(app.sessionView.TextArea, app.Application, tcell.KeyF8)
+
+	assert.Equal(t, "@ Pick the lock\nd: 2d6 -> 9\n", app.sessionView.TextArea.GetText())
+	assert.False(t, app.sessionView.isDirty, "Expected the edits to be saved")
+	saved, err := app.sessionView.sessionService.GetByID(*app.sessionView.currentSessionID)
+	require.NoError(t, err)
+	assert.Equal(t, "@ Pick the lock\nd: 2d6 -> 9\n", saved.Content)
+
+	_, err = os.Stat(*opened)
+	assert.True(t, os.IsNotExist(err), "Expected the temp file to be removed")
+}
+
+func TestSessionEditor_Notes(t *testing.T) {
+	app := setupTestApp(t)
+	g := createGame(t, app, "Test Game")
+	addNotesToGame(t, app, g.ID, "Old notes")
+	app.gameView.Refresh()
+	selectNotes(t, app)
+	fakeEditor(t, app, "New notes", nil)
+
+	app.sessionView.OpenInEditor()
+
+	assert.Equal(t, "New notes", notesContent(t, app, g.ID))
+}
+
+func TestSessionEditor_Conflict(t *testing.T) {
+	setup := func(t *testing.T) (*App, *string) {
+		app := setupTestApp(t)
+		loadSessionWithContent(t, app, 0, "original")
+		opened := fakeEditor(t, app, "from the editor", func() {
+			s := app.sessionView.currentSession
+			s.Content = "changed elsewhere"
+			_, err := app.sessionView.sessionService.Save(s)
+			require.NoError(t, err)
+		})
+
+		app.sessionView.OpenInEditor()
+		require.True(t, app.isPageVisible(CONFIRM_MODAL_ID), "Expected to be asked about the conflict")
+		return app, opened
+	}
+
+	t.Run("replace with the edits", func(t *testing.T) {
+		app, opened := setup(t)
+		app.confirmModal.onConfirm()
+
+		saved, err := app.sessionView.sessionService.GetByID(*app.sessionView.currentSessionID)
+		require.NoError(t, err)
+		assert.Equal(t, "from the editor", saved.Content)
+		_, err = os.Stat(*opened)
+		assert.True(t, os.IsNotExist(err), "Expected the temp file to be removed")
+	})
+
+	t.Run("keep the saved copy", func(t *testing.T) {
+		app, opened := setup(t)
+		t.Cleanup(func() { os.Remove(*opened) })
+		app.confirmModal.onCancel()
+
+		assert.Equal(t, "changed elsewhere", app.sessionView.TextArea.GetText())
+		data, err := os.ReadFile(*opened)
+		require.NoError(t, err, "Expected the edits to be kept in the temp file")
+		assert.Equal(t, "from the editor", string(data))
+	})
+}
+
+func TestSessionEditor_NoChanges(t *testing.T) {
+	app := setupTestApp(t)
+	loadSessionWithContent(t, app, 0, "same")
+	fakeEditor(t, app, "same", nil)
+
+	app.sessionView.OpenInEditor()
+	assert.Equal(t, "same", app.sessionView.TextArea.GetText())
+	assert.False(t, app.sessionView.isDirty)
+}
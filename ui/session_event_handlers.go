@@ -2,6 +2,10 @@ package ui
 
 import (
 	"fmt"
+	"os"
+	"soloterm/domain/notes"
+	"soloterm/domain/session"
+	"strings"
 )
 
 func (a *App) handleSessionShowNew(e *SessionShowNewEvent) {
@@ -21,11 +25,26 @@ func (a *App) handleSessionShowNew(e *SessionShowNewEvent) {
 	}
 
 	a.sessionView.Form.Reset(gameID)
+	a.sessionView.Form.SetTemplates(a.sessionTemplates(gameID))
+	a.sessionView.resizeFormModal()
 	a.sessionView.formModal.SetTitle(" New Session — " + gameName + " ")
 	a.pages.ShowPage(SESSION_MODAL_ID)
 	a.SetFocus(a.sessionView.Form)
 }
 
+// sessionTemplates returns the templates offered for a new session in the game:
+// the game's own template first, then those from the config.
+func (a *App) sessionTemplates(gameID int64) []session.Template {
+	var templates []session.Template
+	g, err := a.gameView.gameService.GetByID(gameID)
+	if err != nil {
+		a.notification.ShowError(fmt.Sprintf("Error loading game: %v", err))
+	} else if strings.TrimSpace(g.SessionTemplate) != "" {
+		templates = append(templates, session.Template{Name: "Game template", Content: g.SessionTemplate})
+	}
+	return append(templates, a.cfg.SessionTemplates...)
+}
+
 func (a *App) handleSessionCancelled(e *SessionCancelledEvent) {
 	a.pages.HidePage(SESSION_MODAL_ID)
 	a.SetFocus(a.gameView.Tree)
@@ -37,7 +56,25 @@ func (a *App) handleGameNotesSelected(e *GameNotesSelectedEvent) {
 		a.notification.ShowError(fmt.Sprintf("Error loading notes: %v", err))
 		return
 	}
-	a.sessionView.SelectNotes()
+
+	var page *notes.Page
+	var err error
+	if e.PageID != 0 {
+		page, err = a.notesService.GetByID(e.PageID)
+	} else {
+		// The first page is created on demand, so the tree may need it added
+		page, err = a.notesService.FirstPage(e.GameID)
+		if err == nil {
+			a.gameView.Refresh()
+		}
+	}
+	if err != nil {
+		a.notification.ShowError(fmt.Sprintf("Error loading notes: %v", err))
+		return
+	}
+
+	a.sessionView.SelectNotes(page)
+	a.gameView.SelectPage(page.ID)
 }
 
 func (a *App) handleSessionSelected(e *SessionSelectedEvent) {
@@ -57,6 +94,9 @@ func (a *App) handleSessionSaved(e *SessionSavedEvent) {
 	a.gameView.SelectSession(e.Session.ID)
 	a.SetFocus(a.sessionView.TextArea)
 	a.notification.ShowSuccess("Session saved successfully")
+	if e.Template != "" {
+		a.sessionView.InsertTemplate(e.Template)
+	}
 }
 
 func (a *App) handleSessionShowEdit(e *SessionShowEditEvent) {
@@ -77,6 +117,7 @@ func (a *App) handleSessionShowEdit(e *SessionShowEditEvent) {
 	a.sessionView.currentSessionID = &s.ID
 	a.sessionView.currentSession = s
 	a.sessionView.Form.PopulateForEdit(s)
+	a.sessionView.resizeFormModal()
 	a.sessionView.formModal.SetTitle(" Edit Session ")
 	a.pages.ShowPage(SESSION_MODAL_ID)
 	a.SetFocus(a.sessionView.Form)
@@ -86,7 +127,7 @@ func (a *App) handleSessionDeleteConfirm(e *SessionDeleteConfirmEvent) {
 	returnFocus := a.GetFocus()
 
 	a.confirmModal.Configure(
-		"Are you sure you want to delete this session?",
+		"Are you sure you want to delete this session?\n\nIt can be restored from the Trash (t).",
 		func() {
 			a.sessionView.ConfirmDelete(e.Session.ID)
 		},
@@ -107,7 +148,7 @@ func (a *App) handleSessionDeleted(_ *SessionDeletedEvent) {
 	a.sessionView.Reset()
 	a.sessionView.Refresh()
 	a.SetFocus(a.gameView.Tree)
-	a.notification.ShowSuccess("Session deleted successfully")
+	a.notification.ShowSuccess("Session moved to the Trash")
 }
 
 func (a *App) handleSessionDeleteFailed(e *SessionDeleteFailedEvent) {
@@ -128,3 +169,127 @@ func (a *App) handleSessionShowExport(_ *SessionShowExportEvent) {
 	}
 	a.fileView.ShowExport(a.sessionView, a.sessionView.TextArea)
 }
+
+func (a *App) handleSessionShowMove(e *SessionShowMoveEvent) {
+	s, err := a.sessionView.sessionService.GetByID(e.SessionID)
+	if err != nil {
+		a.notification.ShowError(fmt.Sprintf("Error loading session: %v", err))
+		return
+	}
+
+	a.Autosave()
+	if a.moveView.Show(s.ID, s.Name, s.GameID) {
+		a.pages.ShowPage(SESSION_MOVE_MODAL_ID)
+		a.SetFocus(a.moveView.Form)
+	}
+}
+
+func (a *App) handleSessionMoveCancelled(_ *SessionMoveCancelEvent) {
+	a.pages.HidePage(SESSION_MOVE_MODAL_ID)
+	a.SetFocus(a.gameView.Tree)
+}
+
+func (a *App) handleSessionMoved(e *SessionMovedEvent) {
+	a.pages.HidePage(SESSION_MOVE_MODAL_ID)
+	a.showReshapedSession(e.Session)
+	a.SetFocus(a.gameView.Tree)
+	a.notification.ShowSuccess("Session moved to " + e.Session.GameName)
+}
+
+func (a *App) handleSessionSplitConfirm(e *SessionSplitConfirmEvent) {
+	returnFocus := a.GetFocus()
+
+	a.confirmModal.Configure(
+		"Split this session at the cursor? Everything after the cursor will move to a new session.",
+		func() {
+			a.sessionView.ConfirmSplit(e.SessionID, e.Offset)
+		},
+		func() {
+			a.pages.HidePage(CONFIRM_MODAL_ID)
+			a.SetFocus(returnFocus)
+		},
+		"Split",
+	)
+
+	a.pages.ShowPage(CONFIRM_MODAL_ID)
+}
+
+func (a *App) handleSessionSplit(e *SessionSplitEvent) {
+	a.pages.HidePage(CONFIRM_MODAL_ID)
+	a.showReshapedSession(e.Second)
+	a.SetFocus(a.sessionView.TextArea)
+	a.notification.ShowSuccess("Session split into " + e.First.Name + " and " + e.Second.Name)
+}
+
+func (a *App) handleSessionMergeConfirm(e *SessionMergeConfirmEvent) {
+	a.Autosave()
+	returnFocus := a.GetFocus()
+
+	a.confirmModal.Configure(
+		"Merge the next session into this one? The next session will be removed.",
+		func() {
+			a.sessionView.ConfirmMerge(e.SessionID)
+		},
+		func() {
+			a.pages.HidePage(CONFIRM_MODAL_ID)
+			a.SetFocus(returnFocus)
+		},
+		"Merge",
+	)
+
+	a.pages.ShowPage(CONFIRM_MODAL_ID)
+}
+
+func (a *App) handleSessionMerged(e *SessionMergedEvent) {
+	a.pages.HidePage(CONFIRM_MODAL_ID)
+	a.showReshapedSession(e.Session)
+	a.SetFocus(a.gameView.Tree)
+	a.notification.ShowSuccess("Sessions merged successfully")
+}
+
+func (a *App) handleSessionReorder(e *SessionReorderEvent) {
+	id, err := a.sessionView.sessionService.Reorder(e.SessionID, e.Direction)
+	if err != nil {
+		a.notification.ShowError("Failed to reorder session: " + err.Error())
+		return
+	}
+	if id == 0 {
+		return // boundary, nothing changed
+	}
+	a.gameView.Refresh()
+	a.gameView.SelectSession(id)
+}
+
+// showReshapedSession reloads the tree and editor after a session was moved,
+// split or merged, and opens the session.
+func (a *App) showReshapedSession(s *session.Session) {
+	a.sessionView.Reset()
+	a.HandleEvent(&SessionSelectedEvent{
+		BaseEvent: BaseEvent{action: SESSION_SELECTED},
+		SessionID: s.ID,
+		GameID:    s.GameID,
+	})
+	a.gameView.Refresh()
+	a.gameView.SelectSession(s.ID)
+}
+
+func (a *App) handleSessionEditorConflict(e *SessionEditorConflictEvent) {
+	a.confirmModal.Configure(
+		"This changed somewhere else while it was open in your editor.\n\nReplace it with your edits?",
+		func() {
+			os.Remove(e.Path)
+			a.pages.HidePage(CONFIRM_MODAL_ID)
+			a.SetFocus(a.sessionView.TextArea)
+			a.sessionView.ApplyEditorContent(e.Content)
+		},
+		func() {
+			a.pages.HidePage(CONFIRM_MODAL_ID)
+			a.SetFocus(a.sessionView.TextArea)
+			a.sessionView.ReloadStored()
+			a.notification.ShowWarning("Kept the saved copy. Your edits are in " + e.Path)
+		},
+		"Replace",
+	)
+
+	a.pages.ShowPage(CONFIRM_MODAL_ID)
+}
@@ -2,7 +2,10 @@ package ui
 
 import (
 	"soloterm/domain/session"
+	"soloterm/shared/text"
 	sharedui "soloterm/shared/ui"
+	"strings"
+	"time"
 
 	"github.com/gdamore/tcell/v2"
 	"github.com/rivo/tview"
@@ -11,11 +14,15 @@ import (
 // SesisonForm represents a form for creating/editing sessions
 type SessionForm struct {
 	*sharedui.DataForm
-	sessionID    *int64
-	gameID       *int64
-	content      string
-	nameField    *tview.InputField
-	errorMessage *tview.TextView
+	sessionID     *int64
+	gameID        *int64
+	content       string
+	nameField     *tview.InputField
+	playedAtField *tview.InputField
+	summaryField  *tview.TextArea
+	templateField *tview.DropDown
+	templates     []session.Template
+	errorMessage  *tview.TextView
 }
 
 // NewSessionForm creates a new session form
@@ -34,17 +41,39 @@ func NewSessionForm() *SessionForm {
 		SetFieldBackgroundColor(tcell.ColorDefault).
 		SetFieldWidth(0) // 0 means full width
 
+	// Play date field
+	sf.playedAtField = tview.NewInputField().
+		SetLabel("Played On").
+		SetPlaceholder(text.DateFormat).
+		SetFieldBackgroundColor(tcell.ColorDefault).
+		SetFieldWidth(len(text.DateFormat) + 1)
+
+	// Summary field
+	sf.summaryField = tview.NewTextArea().
+		SetLabel("Summary").
+		SetPlaceholder("Optional, shown in the campaign recap").
+		SetMaxLength(session.MaxSummaryLength).
+		SetSize(4, 0)
+
+	// Template field, only shown for new sessions
+	sf.templateField = tview.NewDropDown().
+		SetLabel("Template").
+		SetFieldBackgroundColor(tcell.ColorDefault)
+
 	sf.setupForm()
 	return sf
 }
 
 // Fill the fields with the data from the session passed in
-func (sf *SessionForm) PopulateForEdit(session *session.Session) {
-	sf.gameID = &session.GameID
-	sf.sessionID = &session.ID
-	sf.content = session.Content
+func (sf *SessionForm) PopulateForEdit(s *session.Session) {
+	sf.gameID = &s.GameID
+	sf.sessionID = &s.ID
+	sf.content = s.Content
 
-	sf.nameField.SetText(session.Name)
+	sf.nameField.SetText(s.Name)
+	sf.playedAtField.SetText(s.PlayedAt.Format(text.DateFormat))
+	sf.summaryField.SetText(s.Summary, false)
+	sf.SetTemplates(nil)
 
 	sf.AddDeleteButton()
 
@@ -55,6 +84,8 @@ func (sf *SessionForm) setupForm() {
 	sf.Clear(true)
 
 	sf.AddFormItem(sf.nameField)
+	sf.AddFormItem(sf.playedAtField)
+	sf.AddFormItem(sf.summaryField)
 
 	// Buttons will be set up when handlers are attached
 	sf.SetBorder(false)
@@ -71,11 +102,44 @@ func (sf *SessionForm) Reset(gameID int64) {
 	sf.sessionID = nil
 	sf.content = ""
 	sf.nameField.SetText("")
+	sf.playedAtField.SetText(session.Today().Format(text.DateFormat))
+	sf.summaryField.SetText("", false)
 	sf.ClearFieldErrors()
 	sf.RemoveDeleteButton()
 	sf.SetFocus(0)
 }
 
+// SetTemplates sets the templates offered for the new session. The template
+// field is hidden when there are none.
+func (sf *SessionForm) SetTemplates(templates []session.Template) {
+	sf.templates = templates
+	if idx := sf.GetFormItemIndex("Template"); idx >= 0 {
+		sf.RemoveFormItem(idx)
+	}
+	if len(templates) == 0 {
+		return
+	}
+
+	options := []string{"None"}
+	for _, t := range templates {
+		options = append(options, t.Name)
+	}
+	sf.templateField.SetOptions(options, nil).SetCurrentOption(0)
+	sf.AddFormItem(sf.templateField)
+}
+
+// SelectedTemplate returns the content of the chosen template, or "" when none was chosen
+func (sf *SessionForm) SelectedTemplate() string {
+	if sf.GetFormItemIndex("Template") < 0 {
+		return ""
+	}
+	idx, _ := sf.templateField.GetCurrentOption()
+	if idx <= 0 || idx > len(sf.templates) {
+		return ""
+	}
+	return sf.templates[idx-1].Content
+}
+
 // SetFieldErrors sets multiple field errors at once and updates labels
 func (sf *SessionForm) SetFieldErrors(errors map[string]string) {
 	sf.DataForm.SetFieldErrors(errors)
@@ -92,6 +156,20 @@ func (sf *SessionForm) updateFieldLabels() {
 		sf.nameField.SetLabel("Name")
 	}
 
+	// Update play date field label
+	if sf.HasFieldError("played_at") {
+		sf.playedAtField.SetLabel("[" + Style.ErrorTextColor + "]Played On[" + Style.NormalTextColor + "]")
+	} else {
+		sf.playedAtField.SetLabel("Played On")
+	}
+
+	// Update summary field label
+	if sf.HasFieldError("summary") {
+		sf.summaryField.SetLabel("[" + Style.ErrorTextColor + "]Summary[" + Style.NormalTextColor + "]")
+	} else {
+		sf.summaryField.SetLabel("Summary")
+	}
+
 }
 
 // ClearFieldErrors removes all error highlights
@@ -100,11 +178,23 @@ func (sf *SessionForm) ClearFieldErrors() {
 	sf.updateFieldLabels()
 }
 
+// PlayedAt parses the play date field. Returns false when it isn't a valid date.
+func (sf *SessionForm) PlayedAt() (time.Time, bool) {
+	playedAt, err := time.Parse(text.DateFormat, strings.TrimSpace(sf.playedAtField.GetText()))
+	return playedAt, err == nil
+}
+
 // BuildDomain constructs a Session entity from the form data
 func (sf *SessionForm) BuildDomain() *session.Session {
 	s := &session.Session{
 		Name:    sf.nameField.GetText(),
 		Content: sf.content,
+		Summary: sf.summaryField.GetText(),
+	}
+
+	// Left zero when it doesn't parse, which fails validation
+	if playedAt, ok := sf.PlayedAt(); ok {
+		s.PlayedAt = playedAt
 	}
 
 	if sf.sessionID != nil {
@@ -0,0 +1,177 @@
+package ui
+
+import (
+	"regexp"
+	"slices"
+	"soloterm/domain/tag"
+	"strings"
+
+	"github.com/rivo/tview"
+)
+
+// tagPrefixRegex matches an unfinished tag identifier at the end of the text, e.g. "[N:Va"
+var tagPrefixRegex = regexp.MustCompile(`\[([^\[\]|\n]*:[^\[\]|\n]*)$`)
+
+// hintKind identifies what the session hint panel is currently suggesting
+type hintKind int
+
+const (
+	hintNone hintKind = iota
+	hintTag
+	hintTable
+)
+
+// currentTagPrefix returns the tag identifier being typed after an unclosed "[",
+// and whether one is active. An identifier needs a ":" to be considered a tag.
+func currentTagPrefix(text string) (prefix string, active bool) {
+	m := tagPrefixRegex.FindStringSubmatch(text)
+	if m == nil {
+		return "", false
+	}
+	return m[1], true
+}
+
+// currentLine returns the text between the start of the line and the cursor
+func (sv *SessionView) currentLine() (line string, cursor int) {
+	text := sv.TextArea.GetText()
+	_, cursor, _ = sv.TextArea.GetSelection()
+	before := text[:cursor]
+	return before[strings.LastIndexByte(before, '\n')+1:], cursor
+}
+
+// updateHints refreshes the hint panel for the tag or @table reference being
+// typed at the cursor. Hides the panel when neither is being typed.
+func (sv *SessionView) updateHints() {
+	if sv.previewing || (sv.currentSessionID == nil && !sv.IsNotesMode()) {
+		sv.hideHints()
+		return
+	}
+
+	line, _ := sv.currentLine()
+	var hints []string
+	if prefix, active := currentTagPrefix(line); active {
+		if sv.hintKind != hintTag {
+			sv.tagCandidates = sv.loadTagCandidates()
+		}
+		sv.hintKind = hintTag
+		for _, t := range sv.matchingTags(prefix) {
+			hints = append(hints, t.Template)
+		}
+		sv.hintView.SetTitle(" Tags ([" + Style.HelpKeyTextColor + "]Tab[" + Style.NormalTextColor + "] Select) ")
+	} else if prefix, active := currentOraclePrefix(line); active {
+		sv.hintKind = hintTable
+		hints = sv.oracleService.GetTableHints(prefix)
+		sv.hintView.SetTitle(" Tables ([" + Style.HelpKeyTextColor + "]Tab[" + Style.NormalTextColor + "] Select) ")
+	} else {
+		sv.hideHints()
+		return
+	}
+
+	if len(hints) == 0 {
+		sv.hintView.SetText("[" + Style.ErrorTextColor + "]no matches[" + Style.NormalTextColor + "]")
+		sv.editorContent.ResizeItem(sv.hintView, 35, 0)
+		return
+	}
+
+	const maxDisplay = 20
+	truncated := len(hints) > maxDisplay
+	if truncated {
+		hints = hints[:maxDisplay]
+	}
+
+	var b strings.Builder
+	b.WriteString("[::b]" + tview.Escape(hints[0]) + "[::-]") // first entry bold — Tab selects it
+	for _, h := range hints[1:] {
+		b.WriteString("\n")
+		b.WriteString(tview.Escape(h))
+	}
+	if truncated {
+		b.WriteString("\n[" + Style.HelpKeyTextColor + "]+more[" + Style.NormalTextColor + "]")
+	}
+
+	sv.hintView.SetText(b.String())
+	sv.editorContent.ResizeItem(sv.hintView, 35, 0)
+}
+
+// hideHints collapses the hint panel
+func (sv *SessionView) hideHints() {
+	sv.hintKind = hintNone
+	sv.tagCandidates = nil
+	sv.hintView.Clear()
+	sv.editorContent.ResizeItem(sv.hintView, 0, 0)
+}
+
+// acceptFirstHint completes the reference at the cursor with the first hint.
+// Tags are completed with their latest full text, tables with "@name ".
+// Returns true if a completion was applied.
+func (sv *SessionView) acceptFirstHint() bool {
+	line, cursor := sv.currentLine()
+	switch sv.hintKind {
+	case hintTag:
+		prefix, active := currentTagPrefix(line)
+		if !active {
+			return false
+		}
+		matches := sv.matchingTags(prefix)
+		if len(matches) == 0 {
+			return false
+		}
+		start := cursor - len(prefix) - 1 // include the opening "["
+		sv.TextArea.Replace(start, cursor, matches[0].Template)
+		return true
+	case hintTable:
+		prefix, active := currentOraclePrefix(line)
+		if !active {
+			return false
+		}
+		hints := sv.oracleService.GetTableHints(prefix)
+		if len(hints) == 0 {
+			return false
+		}
+		start := cursor - len(prefix) - 1 // include the "@"
+		sv.TextArea.Replace(start, cursor, "@"+hints[0]+" ")
+		return true
+	}
+	return false
+}
+
+// loadTagCandidates returns the active and notes tags of the current game,
+// with active tags taking precedence when both use an identifier.
+func (sv *SessionView) loadTagCandidates() []tag.TagType {
+	var gameID int64
+	if sv.currentSession != nil {
+		gameID = sv.currentSession.GameID
+	} else if g := sv.app.CurrentGame(); g != nil {
+		gameID = g.ID
+	}
+	result, err := sv.tagService.LoadTagsForGame(gameID, nil, sv.app.cfg.TagExcludeWords)
+	if err != nil {
+		return nil
+	}
+
+	seen := make(map[string]bool)
+	var candidates []tag.TagType
+	for _, t := range slices.Concat(result.Active, result.Notes) {
+		if !seen[t.Label] {
+			seen[t.Label] = true
+			candidates = append(candidates, t)
+		}
+	}
+	return candidates
+}
+
+// matchingTags returns the candidates whose identifier starts with prefix,
+// ignoring case and spaces (so "n: va" matches "N:Vance")
+func (sv *SessionView) matchingTags(prefix string) []tag.TagType {
+	normalize := func(s string) string {
+		return strings.ToLower(strings.ReplaceAll(s, " ", ""))
+	}
+	prefix = normalize(prefix)
+	var matches []tag.TagType
+	for _, t := range sv.tagCandidates {
+		if strings.HasPrefix(normalize(t.Label), prefix) {
+			matches = append(matches, t)
+		}
+	}
+	return matches
+}
@@ -0,0 +1,122 @@
+package ui
+
+import (
+	"soloterm/domain/session"
+	testHelper "soloterm/shared/testing"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// loadSessionWithContent is a test helper that creates a session with content
+// in the game, or in a new game when gameID is 0, and loads the session into
+// the editor.
+func loadSessionWithContent(t *testing.T, app *App, gameID int64, content string) *session.Session {
+	t.Helper()
+	if gameID == 0 {
+		gameID = createGame(t, app, "Test Game").ID
+	}
+	s := createSession(t, app, gameID, "Test Session")
+	s.Content = content
+	_, err := app.sessionView.sessionService.Save(s)
+	require.NoError(t, err)
+	app.gameView.Refresh()
+	app.sessionView.SelectSession(s.ID)
+	app.SetFocus(app.sessionView.TextArea)
+	return s
+}
+
+func TestCurrentTagPrefix(t *testing.T) {
+	tests := []struct {
+		text       string
+		wantPrefix string
+		wantActive bool
+	}{
+		{"Met [N:Va", "N:Va", true},
+		{"Met [N:", "N:", true},
+		{"Met [N:Vex] and", "", false},
+		{"Met [N:Vex | wary", "", false},
+		{"Rolled [4 5", "", false},
+		{"plain text", "", false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.text, func(t *testing.T) {
+			prefix, active := currentTagPrefix(tc.text)
+			assert.Equal(t, tc.wantActive, active)
+			assert.Equal(t, tc.wantPrefix, prefix)
+		})
+	}
+}
+
+func TestSessionHints_TagCompletion(t *testing.T) {
+	app := setupTestApp(t)
+	loadSessionWithContent(t, app, 0, "[N:Vance | wary]\n[N:Vance | ally]\n[L:Docks]\n")
+	sv := app.sessionView
+
+	sv.TextArea.SetText(sv.TextArea.GetText()+"Spoke to [n:va", true)
+	assert.Equal(t, hintTag, sv.hintKind, "Expected tag hints to be active")
+	assert.Contains(t, sv.hintView.GetText(true), "[N:Vance | ally]")
+	assert.NotContains(t, sv.hintView.GetText(true), "Docks")
+
+	testHelper.SimulateTab(app.Application)
+
+	assert.Equal(t, "[N:Vance | wary]\n[N:Vance | ally]\n[L:Docks]\nSpoke to [N:Vance | ally]", sv.TextArea.GetText())
+	assert.Equal(t, hintNone, sv.hintKind, "Expected hints to close after completion")
+	assert.Equal(t, sv.TextArea, app.GetFocus(), "Tab should not move focus when completing")
+}
+
+func TestSessionHints_TableCompletion(t *testing.T) {
+	app := setupTestApp(t)
+	createOracle(t, app, "Monsters", "encounters", "Goblin")
+	loadSessionWithContent(t, app, 0, "")
+	sv := app.sessionView
+
+	sv.TextArea.SetText("tbl: @enc", true)
+	assert.Equal(t, hintTable, sv.hintKind, "Expected table hints to be active")
+
+	testHelper.SimulateTab(app.Application)
+	assert.Equal(t, "tbl: @Monsters/encounters ", sv.TextArea.GetText())
+}
+
+func TestSessionHints_TabMovesFocusWithoutHints(t *testing.T) {
+	app := setupTestApp(t)
+	loadSessionWithContent(t, app, 0, "No tags here")
+
+	testHelper.SimulateTab(app.Application)
+	assert.Equal(t, app.characterView.CharTree, app.GetFocus())
+}
+
+func TestSessionHints_FollowTheCursorAfterOpenAt(t *testing.T) {
+	app := setupTestApp(t)
+	content := strings.Repeat("filler\n", 40) + "Spoke to [N:Va"
+	loadSessionWithContent(t, app, 0, content)
+	sv := app.sessionView
+
+	app.openAt(*sv.currentSessionID, 0, 0, 0)
+	// As openAt's queued update does once the editor has drawn
+	sv.selectAtTop(len(content), 0)
+	assert.Equal(t, hintTag, sv.hintKind, "Expected hints to open at the opened tag")
+	assert.False(t, sv.cursorToTop, "Expected only the opening move to scroll")
+
+	sv.TextArea.Select(0, 0)
+	assert.Equal(t, hintNone, sv.hintKind, "Expected hints to keep following the cursor")
+}
+
+func TestSessionHints_FollowTheCursor(t *testing.T) {
+	app := setupTestApp(t)
+	loadSessionWithContent(t, app, 0, "[N:Vance | wary]\n")
+	sv := app.sessionView
+
+	sv.TextArea.SetText(sv.TextArea.GetText()+"Spoke to [N:Va", true)
+	require.Equal(t, hintTag, sv.hintKind)
+
+	sv.TextArea.Select(0, 0)
+	assert.Equal(t, hintNone, sv.hintKind, "Expected hints to close when the cursor leaves the tag")
+
+	end := len(sv.TextArea.GetText())
+	sv.TextArea.Select(end, end)
+	assert.Equal(t, hintTag, sv.hintKind, "Expected hints to open when the cursor returns to the tag")
+}
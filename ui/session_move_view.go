@@ -0,0 +1,115 @@
+package ui
+
+import (
+	"fmt"
+	"soloterm/domain/game"
+	sharedui "soloterm/shared/ui"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// SessionMoveView manages the modal for moving a session to another game
+type SessionMoveView struct {
+	app       *App
+	Modal     *tview.Flex
+	Form      *sharedui.DataForm
+	formModal *sharedui.FormModal
+	gameField *tview.DropDown
+
+	sessionID int64
+	games     []*game.Game
+}
+
+// NewSessionMoveView creates and sets up the move modal
+func NewSessionMoveView(app *App) *SessionMoveView {
+	mv := &SessionMoveView{app: app}
+	mv.setup()
+	return mv
+}
+
+func (mv *SessionMoveView) setup() {
+	mv.gameField = tview.NewDropDown().
+		SetLabel("Move to").
+		SetFieldBackgroundColor(tcell.ColorDefault)
+
+	mv.Form = sharedui.NewDataForm()
+	mv.Form.AddFormItem(mv.gameField)
+	mv.Form.SetBorder(false)
+	mv.Form.SetButtonsAlign(tview.AlignCenter)
+	mv.Form.SetItemPadding(1)
+	mv.Form.SetupHandlers(
+		mv.HandleSave,
+		func() {
+			mv.app.HandleEvent(&SessionMoveCancelEvent{
+				BaseEvent: BaseEvent{action: SESSION_MOVE_CANCEL},
+			})
+		},
+		nil,
+	)
+	mv.Form.GetButton(0).SetLabel("Move")
+
+	mv.formModal = sharedui.NewFormModal(mv.Form, 7)
+	mv.Modal = mv.formModal.Modal
+
+	mv.Form.SetFocusFunc(func() {
+		mv.app.updateFooterHelp(helpBar("Move Session", []helpEntry{{"Ctrl+S", "Move"}, {"Esc", "Cancel"}}))
+		mv.formModal.SetBorderColor(Style.BorderFocusColor)
+	})
+	mv.Form.SetBlurFunc(func() {
+		mv.formModal.SetBorderColor(Style.BorderColor)
+	})
+}
+
+// Show opens the modal for the session, offering every game except its own.
+// Returns false when there is no other game to move to.
+func (mv *SessionMoveView) Show(sessionID int64, sessionName string, gameID int64) bool {
+	games, err := mv.app.gameView.gameService.GetAll()
+	if err != nil {
+		mv.app.notification.ShowError(fmt.Sprintf("Error loading games: %v", err))
+		return false
+	}
+
+	mv.sessionID = sessionID
+	mv.games = nil
+	var options []string
+	for _, g := range games {
+		if g.ID != gameID {
+			mv.games = append(mv.games, g)
+			options = append(options, g.Name)
+		}
+	}
+	if len(options) == 0 {
+		mv.app.notification.ShowWarning("Add another game to move this session to.")
+		return false
+	}
+
+	mv.gameField.SetOptions(options, nil).SetCurrentOption(0)
+	mv.formModal.SetTitle(" Move " + sessionName + " ")
+	mv.Form.ClearFieldErrors()
+	mv.Form.SetFocus(0)
+	return true
+}
+
+// HandleSave moves the session to the chosen game
+func (mv *SessionMoveView) HandleSave() {
+	idx, _ := mv.gameField.GetCurrentOption()
+	if idx < 0 || idx >= len(mv.games) {
+		mv.Form.SetFieldErrors(map[string]string{"game": "choose a game"})
+		return
+	}
+
+	moved, err := mv.app.sessionView.sessionService.Move(mv.sessionID, mv.games[idx].ID)
+	if err != nil && !isIndexingError(err) {
+		mv.app.notification.ShowError(fmt.Sprintf("Error moving session: %v", err))
+		return
+	}
+
+	mv.app.HandleEvent(&SessionMovedEvent{
+		BaseEvent: BaseEvent{action: SESSION_MOVED},
+		Session:   moved,
+	})
+	if err != nil {
+		mv.app.showIndexingWarning(err)
+	}
+}
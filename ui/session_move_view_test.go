@@ -0,0 +1,90 @@
+package ui
+
+import (
+	testHelper "soloterm/shared/testing"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSessionMoveView_MoveToAnotherGame(t *testing.T) {
+	app := setupTestApp(t)
+	g1 := createGame(t, app, "Game One")
+	g2 := createGame(t, app, "Game Two")
+	s := createSession(t, app, g1.ID, "Session One")
+	app.gameView.Refresh()
+	app.gameView.SelectSession(s.ID)
+
+	testHelper.SimulateRune(app.gameView.Tree, app.Application, 'm')
+	require.True(t, app.isPageVisible(SESSION_MOVE_MODAL_ID), "Expected move modal to be visible")
+	assert.Equal(t, 1, app.moveView.gameField.GetOptionCount(), "The session's own game is not offered")
+
+	testHelper.SimulateKey(app.moveView.Form, app.Application, tcell.KeyCtrlS)
+	assert.False(t, app.isPageVisible(SESSION_MOVE_MODAL_ID), "Expected move modal to close")
+
+	moved, err := app.sessionView.sessionService.GetByID(s.ID)
+	require.NoError(t, err)
+	assert.Equal(t, g2.ID, moved.GameID)
+	require.NotNil(t, app.sessionView.currentSessionID)
+	assert.Equal(t, s.ID, *app.sessionView.currentSessionID)
+}
+
+func TestSessionMoveView_NeedsAnotherGame(t *testing.T) {
+	app := setupTestApp(t)
+	g := createGame(t, app, "Game One")
+	s := createSession(t, app, g.ID, "Session One")
+	app.gameView.Refresh()
+	app.gameView.SelectSession(s.ID)
+
+	testHelper.SimulateRune(app.gameView.Tree, app.Application, 'm')
+	assert.False(t, app.isPageVisible(SESSION_MOVE_MODAL_ID), "Nothing to move to with a single game")
+}
+
+func TestSessionView_SplitAtCursor(t *testing.T) {
+	app := setupTestApp(t)
+	loadSessionWithContent(t, app, 0, "")
+	id := *app.sessionView.currentSessionID
+	app.sessionView.TextArea.SetText("S1 *The keep*\n\nS2 *The road*", false)
+	app.sessionView.TextArea.Select(15, 15)
+
+	testHelper.SimulateKey(app.sessionView.TextArea, app.Application, tcell.KeyCtrlBackslash)
+	require.True(t, app.isPageVisible(CONFIRM_MODAL_ID), "Expected confirmation modal to be visible")
+	app.sessionView.ConfirmSplit(id, 15)
+	assert.False(t, app.isPageVisible(CONFIRM_MODAL_ID), "Expected confirmation modal to be hidden")
+
+	first, err := app.sessionView.sessionService.GetByID(id)
+	require.NoError(t, err)
+	assert.Equal(t, "S1 *The keep*", first.Content)
+
+	require.NotNil(t, app.sessionView.currentSessionID)
+	second, err := app.sessionView.sessionService.GetByID(*app.sessionView.currentSessionID)
+	require.NoError(t, err)
+	assert.Equal(t, "Test Session (2)", second.Name)
+	assert.Equal(t, "S2 *The road*", second.Content)
+	assert.Equal(t, "S2 *The road*", app.sessionView.TextArea.GetText())
+}
+
+func TestGameView_MergeNextSession(t *testing.T) {
+	app := setupTestApp(t)
+	g := createGame(t, app, "Test Game")
+	s1 := createSession(t, app, g.ID, "Session One")
+	s2 := createSession(t, app, g.ID, "Session Two")
+	s2.Content = "S2 *The road*"
+	_, err := app.sessionView.sessionService.Save(s2)
+	require.NoError(t, err)
+	app.gameView.Refresh()
+	app.gameView.SelectSession(s1.ID)
+
+	testHelper.SimulateRune(app.gameView.Tree, app.Application, 'j')
+	require.True(t, app.isPageVisible(CONFIRM_MODAL_ID), "Expected confirmation modal to be visible")
+	app.sessionView.ConfirmMerge(s1.ID)
+	assert.False(t, app.isPageVisible(CONFIRM_MODAL_ID), "Expected confirmation modal to be hidden")
+
+	sessions, err := app.sessionView.sessionService.GetAllForGame(g.ID)
+	require.NoError(t, err)
+	require.Len(t, sessions, 1)
+	assert.Equal(t, s1.ID, sessions[0].ID)
+	assert.Equal(t, "S2 *The road*", app.sessionView.TextArea.GetText())
+}
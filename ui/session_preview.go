@@ -0,0 +1,200 @@
+package ui
+
+import (
+	"soloterm/domain/lonelog"
+	"soloterm/shared/markdown"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// setupPreview configures the read-only view that shows the session with its
+// Lonelog notation highlighted, or rendered as Markdown. It sits beside the
+// editor with no width until it is toggled on, when the editor gives up its
+// width instead.
+func (sv *SessionView) setupPreview() {
+	sv.Preview = tview.NewTextView().
+		SetDynamicColors(true).
+		SetWrap(true).
+		SetWordWrap(true)
+	sv.editorContent.AddItem(sv.Preview, 0, 0, false)
+
+	sv.Preview.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Key() {
+		case tcell.KeyF7, tcell.KeyEsc:
+			sv.TogglePreview()
+			return nil
+		case tcell.KeyF12:
+			sv.ShowHelpModal()
+			return nil
+		case tcell.KeyRune:
+			if event.Rune() == 'm' {
+				sv.toggleMarkdown()
+				return nil
+			}
+		}
+		return event
+	})
+
+	sv.Preview.SetFocusFunc(func() {
+		sv.updatePreviewHelp()
+		sv.textAreaFrame.SetBorderColor(Style.BorderFocusColor)
+	})
+	sv.Preview.SetBlurFunc(func() {
+		sv.textAreaFrame.SetBorderColor(Style.BorderColor)
+	})
+}
+
+// IsPreviewing reports whether the pane is showing the preview, highlighted or
+// rendered as Markdown, instead of the editor
+func (sv *SessionView) IsPreviewing() bool {
+	return sv.previewing
+}
+
+// TogglePreview switches between the editor and the preview, in whichever of
+// the highlighted and Markdown modes was last shown
+func (sv *SessionView) TogglePreview() {
+	if sv.previewing {
+		sv.closePreview()
+		return
+	}
+
+	if sv.currentSessionID == nil && !sv.IsNotesMode() {
+		return
+	}
+
+	sv.app.Autosave()
+	sv.hideHints()
+	sv.previewing = true
+	sv.renderPreview()
+	sv.Preview.ScrollToBeginning()
+	sv.editorContent.ResizeItem(sv.TextArea, 0, 0).ResizeItem(sv.Preview, 0, 1)
+	sv.updateTitle()
+	sv.app.SetFocus(sv.Preview)
+}
+
+// closePreview puts the editor back in the pane. Focus follows if the
+// preview had it.
+func (sv *SessionView) closePreview() {
+	if !sv.previewing {
+		return
+	}
+
+	sv.previewing = false
+	sv.editorContent.ResizeItem(sv.Preview, 0, 0).ResizeItem(sv.TextArea, 0, 1)
+	sv.updateTitle()
+	if sv.app.GetFocus() == sv.Preview {
+		sv.app.SetFocus(sv.TextArea)
+	}
+}
+
+// toggleMarkdown switches the preview between Lonelog highlighting and
+// rendered Markdown. The choice is kept for the next preview.
+func (sv *SessionView) toggleMarkdown() {
+	sv.previewMarkdown = !sv.previewMarkdown
+	sv.renderPreview()
+	sv.updatePreviewHelp()
+	sv.updateTitle()
+}
+
+func (sv *SessionView) updatePreviewHelp() {
+	other := "Markdown"
+	if sv.previewMarkdown {
+		other = "Lonelog"
+	}
+	sv.app.updateFooterHelp(helpBar("Preview", []helpEntry{
+		{"PgUp/PgDn/↑/↓", "Scroll"},
+		{"F12", "Help"},
+		{"m", other},
+		{"F7/Esc", "Edit"},
+	}))
+}
+
+// renderPreview redraws the preview from the editor's text
+func (sv *SessionView) renderPreview() {
+	if !sv.previewing {
+		return
+	}
+	if sv.previewMarkdown {
+		sv.Preview.SetText(markdown.Render(sv.TextArea.GetText(), markdownTheme()))
+		return
+	}
+	sv.Preview.SetText(highlightLonelog(sv.TextArea.GetText()))
+}
+
+// markdownTheme returns the colours for rendering Markdown from Style
+func markdownTheme() markdown.Theme {
+	return markdown.Theme{
+		Heading: Style.MarkdownHeadingColor,
+		Quote:   Style.MarkdownQuoteColor,
+		Code:    Style.MarkdownCodeColor,
+		Link:    Style.MarkdownLinkColor,
+		Rule:    Style.MarkdownRuleColor,
+	}
+}
+
+// highlightLonelog returns content with its Lonelog notation wrapped in
+// tview color tags. Lines are coloured by their marker, inline "->" results
+// take the consequence colour, and tags are coloured wherever they appear.
+func highlightLonelog(content string) string {
+	colors := []string{
+		"",
+		Style.LonelogActionColor,
+		Style.LonelogOracleColor,
+		Style.LonelogDiceColor,
+		Style.LonelogConsequenceColor,
+		Style.LonelogSceneColor,
+		Style.LonelogTagColor,
+	}
+	colorIndex := func(kind lonelog.Kind) uint8 {
+		switch kind {
+		case lonelog.KindAction:
+			return 1
+		case lonelog.KindOracle:
+			return 2
+		case lonelog.KindDice, lonelog.KindTable:
+			return 3
+		case lonelog.KindConsequence:
+			return 4
+		case lonelog.KindScene:
+			return 5
+		case lonelog.KindTag:
+			return 6
+		}
+		return 0
+	}
+
+	// Colour every byte. Tags follow their line in the node list, so they
+	// paint over the line's colour.
+	painted := make([]uint8, len(content))
+	fill := func(start, end int, color uint8) {
+		for i := start; i < end; i++ {
+			painted[i] = color
+		}
+	}
+	for _, n := range lonelog.Parse(content).Nodes {
+		fill(n.Offset, n.End, colorIndex(n.Kind))
+		if n.Result != "" {
+			if idx := strings.Index(n.Raw[len(n.Marker):], "->"); idx != -1 {
+				fill(n.Offset+len(n.Marker)+idx, n.End, colorIndex(lonelog.KindConsequence))
+			}
+		}
+	}
+
+	var b strings.Builder
+	for start := 0; start < len(content); {
+		end := start + 1
+		for end < len(content) && painted[end] == painted[start] {
+			end++
+		}
+		text := tview.Escape(content[start:end])
+		if color := colors[painted[start]]; color != "" {
+			b.WriteString("[" + color + "]" + text + "[-:-:-]")
+		} else {
+			b.WriteString(text)
+		}
+		start = end
+	}
+	return b.String()
+}
@@ -0,0 +1,104 @@
+package ui
+
+import (
+	testHelper "soloterm/shared/testing"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHighlightLonelog(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected string
+	}{
+		{"plain text", "Just prose", "Just prose"},
+		{"action", "@ Pick the lock", "[yellow]@ Pick the lock[-:-:-]"},
+		{"oracle with result", "? Is anyone home -> No", "[aqua]? Is anyone home [-:-:-][fuchsia]-> No[-:-:-]"},
+		{"dice", "d: 2d6 -> 9", "[lime]d: 2d6 [-:-:-][fuchsia]-> 9[-:-:-]"},
+		{"consequence", "=> The gate opens", "[fuchsia]=> The gate opens[-:-:-]"},
+		{"scene", "S1 *The Gate*", "[green::b]S1 *The Gate*[-:-:-]"},
+		{"tag in prose", "Met [N:Vance | wary] here", "Met [orange][N:Vance | wary][-:-:-] here"},
+		{"tag in an action", "@ Follow [N:Vance]", "[yellow]@ Follow [-:-:-][orange][N:Vance[][-:-:-]"},
+		{"dice breakdown is not a tag", "Rolled [3 4]", "Rolled [3 4[]"},
+		{
+			"lines are coloured separately",
+			"@ Knock\n  ? Answer\nprose",
+			"[yellow]@ Knock[-:-:-]\n  [aqua]? Answer[-:-:-]\nprose",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, highlightLonelog(tc.content))
+		})
+	}
+}
+
+func TestSessionPreview_Toggle(t *testing.T) {
+	app := setupTestApp(t)
+	loadSessionWithContent(t, app, 0, "@ Pick the lock\n")
+
+	testHelper.SimulateKey(app.sessionView.TextArea, app.Application, tcell.KeyF7)
+	assert.True(t, app.sessionView.IsPreviewing())
+	assert.Equal(t, app.sessionView.Preview, app.GetFocus(), "Expected the preview to have focus")
+	assert.Equal(t, "[yellow]@ Pick the lock[-:-:-]\n", app.sessionView.Preview.GetText(false))
+
+	// Returning to the pane while previewing stays in the preview
+	app.SetFocus(app.gameView.Tree)
+	app.SetFocus(app.sessionView.TextArea)
+	assert.Equal(t, app.sessionView.Preview, app.GetFocus())
+
+	testHelper.SimulateKey(app.sessionView.Preview, app.Application, tcell.KeyEsc)
+	assert.False(t, app.sessionView.IsPreviewing())
+	assert.Equal(t, app.sessionView.TextArea, app.GetFocus(), "Expected the editor to have focus")
+}
+
+func TestSessionPreview_NeedsSession(t *testing.T) {
+	app := setupTestApp(t)
+	app.SetFocus(app.sessionView.TextArea)
+
+	testHelper.SimulateKey(app.sessionView.TextArea, app.Application, tcell.KeyF7)
+	assert.False(t, app.sessionView.IsPreviewing())
+}
+
+func TestSessionPreview_ClosesWhenSessionDeleted(t *testing.T) {
+	app := setupTestApp(t)
+	loadSessionWithContent(t, app, 0, "=> Done\n")
+	app.sessionView.TogglePreview()
+	assert.True(t, app.sessionView.IsPreviewing())
+
+	app.sessionView.Reset()
+	assert.False(t, app.sessionView.IsPreviewing())
+}
+
+func TestSessionPreview_Markdown(t *testing.T) {
+	app := setupTestApp(t)
+	loadSessionWithContent(t, app, 0, "## The Keep\n- **Vance** waits")
+	app.sessionView.TogglePreview()
+
+	testHelper.SimulateRune(app.sessionView.Preview, app.Application, 'm')
+	assert.Equal(t, "[yellow::b]The Keep[-::-]\n  • [-::b]Vance[-::-] waits", app.sessionView.Preview.GetText(false))
+
+	// The choice is remembered for the next preview
+	app.sessionView.TogglePreview()
+	app.sessionView.TogglePreview()
+	assert.Contains(t, app.sessionView.Preview.GetText(false), "[yellow::b]The Keep")
+
+	testHelper.SimulateRune(app.sessionView.Preview, app.Application, 'm')
+	assert.Equal(t, "## The Keep\n- **Vance** waits", app.sessionView.Preview.GetText(false))
+}
+
+func TestSessionPreview_Notes(t *testing.T) {
+	app := setupTestApp(t)
+	g := createGame(t, app, "Test Game")
+	addNotesToGame(t, app, g.ID, "> Remember the keep")
+	app.gameView.Refresh()
+	selectNotes(t, app)
+
+	app.sessionView.TogglePreview()
+	testHelper.SimulateRune(app.sessionView.Preview, app.Application, 'm')
+	assert.Equal(t, "[grey::-]│ [-::-][grey::i]Remember the keep[-::-]", app.sessionView.Preview.GetText(false))
+}
@@ -2,7 +2,14 @@ package ui
 
 import (
 	"fmt"
+	"soloterm/config"
+	"soloterm/domain/link"
+	"soloterm/domain/lonelog"
+	"soloterm/domain/notes"
+	"soloterm/domain/oracle"
 	"soloterm/domain/session"
+	"soloterm/domain/tag"
+	"soloterm/shared/text"
 	sharedui "soloterm/shared/ui"
 	"strings"
 	"time"
@@ -13,25 +20,38 @@ import (
 
 // SessionView provides session-specific UI operations
 type SessionView struct {
-	TextArea      *tview.TextArea
-	textAreaFrame *tview.Frame
-	Form          *SessionForm
-	Modal         *tview.Flex
-	formModal     *sharedui.FormModal
-	app           *App
+	TextArea         *tview.TextArea
+	textAreaFrame    *tview.Frame
+	editorContent    *tview.Flex     // TextArea plus the hint panel
+	hintView         *tview.TextView // tag and table suggestions, hidden until needed
+	Preview          *tview.TextView // read-only view of the session, highlighted or in Markdown
+	previewing       bool
+	previewMarkdown  bool                    // render the preview as Markdown rather than Lonelog
+	runEditor        func(path string) error // opens path in the external editor
+	hintKind         hintKind
+	tagCandidates    []tag.TagType // loaded when tag hints open, cleared when they close
+	keyBindings      []boundKey    // user-defined keys from the config
+	Form             *SessionForm
+	Modal            *tview.Flex
+	formModal        *sharedui.FormModal
+	app              *App
 	sessionService   *session.Service
+	oracleService    *oracle.Service // tables for @table hints and template rolls
+	tagService       *tag.Service    // tags for tag hints and templates
 	currentSessionID *int64
 	currentSession   *session.Session
-	isNotes          bool
+	currentPage      *notes.Page // the notes page shown instead of a session
 	isLoading        bool
 	isDirty          bool
+	keepCursorInView bool // scroll to the cursor the next time it moves
+	cursorToTop      bool // scroll the cursor's row to the top the next time it moves
 	autosaveTicker   *time.Ticker
 	autosaveStop     chan struct{}
 }
 
-// IsNotesMode reports whether the pane is displaying game notes rather than a session.
+// IsNotesMode reports whether the pane is displaying a notes page rather than a session.
 func (sv *SessionView) IsNotesMode() bool {
-	return sv.isNotes
+	return sv.currentPage != nil
 }
 
 const (
@@ -39,10 +59,12 @@ const (
 )
 
 // NewSessionView creates a new session view helper
-func NewSessionView(app *App, service *session.Service) *SessionView {
+func NewSessionView(app *App, service *session.Service, oracleService *oracle.Service, tagService *tag.Service) *SessionView {
 	sessionView := &SessionView{
 		app:            app,
 		sessionService: service,
+		oracleService:  oracleService,
+		tagService:     tagService,
 		isDirty:        false,
 	}
 
@@ -51,9 +73,18 @@ func NewSessionView(app *App, service *session.Service) *SessionView {
 	return sessionView
 }
 
+// boundKey is a configured key binding with its parsed key
+type boundKey struct {
+	key     config.Key
+	binding config.KeyBinding
+}
+
 // Setup initializes all session UI components
 func (sv *SessionView) Setup() {
+	sv.runEditor = sv.launchEditor
+	sv.loadKeyBindings()
 	sv.setupTextArea()
+	sv.setupPreview()
 	sv.setupModal()
 	sv.setupKeyBindings()
 	sv.setupFocusHandlers()
@@ -73,14 +104,53 @@ func (sv *SessionView) setupTextArea() {
 	sv.TextArea.SetDisabled(true)
 	sv.TextArea.SetChangedFunc(func() {
 		if sv.isLoading {
+			sv.hideHints()
 			return
 		}
 		sv.isDirty = true
+		sv.renderPreview()
 		sv.updateTitle()
 		sv.startAutosave()
+		sv.updateHints()
+	})
+	sv.TextArea.SetMovedFunc(func() {
+		// When inserting a template pushed the cursor below the viewable
+		// area, scroll up just enough so the cursor remains visible.
+		if sv.keepCursorInView {
+			sv.keepCursorInView = false
+			row, _, _, _ := sv.TextArea.GetCursor()
+			offsetRow, _ := sv.TextArea.GetOffset()
+			_, _, _, height := sv.TextArea.GetInnerRect()
+			if row >= offsetRow+height {
+				sv.TextArea.SetOffset(row-height+1, 0)
+			}
+		}
+		// Select calls this after updating the cursor, so GetCursor is
+		// reliable here when opening a search match or link
+		if sv.cursorToTop {
+			sv.cursorToTop = false
+			row, _, _, _ := sv.TextArea.GetCursor()
+			sv.TextArea.SetOffset(row, 0)
+		}
+		// Moving the cursor into or out of a tag or @table reference
+		// opens or closes its hints
+		if !sv.isLoading {
+			sv.updateHints()
+		}
 	})
 
-	sv.textAreaFrame = tview.NewFrame(sv.TextArea).
+	sv.hintView = tview.NewTextView().
+		SetDynamicColors(true).
+		SetWrap(false)
+	sv.hintView.SetBorder(true).
+		SetTitleAlign(tview.AlignLeft)
+
+	// Horizontal split: editor + hint panel (hidden until a tag or @table is typed)
+	sv.editorContent = tview.NewFlex().
+		AddItem(sv.TextArea, 0, 1, true).
+		AddItem(sv.hintView, 0, 0, false)
+
+	sv.textAreaFrame = tview.NewFrame(sv.editorContent).
 		SetBorders(1, 1, 0, 0, 1, 1)
 	sv.textAreaFrame.SetTitle(DEFAULT_SECTION_TITLE).
 		SetTitleAlign(tview.AlignLeft).
@@ -99,7 +169,7 @@ func (sv *SessionView) setupModal() {
 		sv.HandleDelete,
 	)
 
-	sv.formModal = sharedui.NewFormModal(sv.Form, 7)
+	sv.formModal = sharedui.NewFormModal(sv.Form, 14)
 	sv.Modal = sv.formModal.Modal
 
 	sv.Form.SetFocusFunc(func() {
@@ -115,23 +185,28 @@ func (sv *SessionView) setupModal() {
 // setupKeyBindings configures keyboard shortcuts for the session tree
 func (sv *SessionView) setupKeyBindings() {
 	sv.TextArea.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		// User-defined keys take precedence over the built-in ones
+		if sv.handleKeyBinding(event) {
+			return nil
+		}
+
 		switch event.Key() {
 		case tcell.KeyF12:
 			sv.ShowHelpModal()
 			return nil
 		case tcell.KeyF2:
 			if sv.currentSessionID != nil {
-				sv.InsertAtCursor(sv.app.cfg.CoreTags.Action.Template)
+				sv.InsertTemplate(sv.app.cfg.CoreTags.Action.Template)
 			}
 			return nil
 		case tcell.KeyF3:
 			if sv.currentSessionID != nil {
-				sv.InsertAtCursor(sv.app.cfg.CoreTags.Oracle.Template)
+				sv.InsertTemplate(sv.app.cfg.CoreTags.Oracle.Template)
 			}
 			return nil
 		case tcell.KeyF4:
 			if sv.currentSessionID != nil {
-				sv.InsertAtCursor(sv.app.cfg.CoreTags.Dice.Template)
+				sv.InsertTemplate(sv.app.cfg.CoreTags.Dice.Template)
 			}
 			return nil
 		case tcell.KeyF5:
@@ -141,6 +216,57 @@ func (sv *SessionView) setupKeyBindings() {
 					BaseEvent: BaseEvent{action: SEARCH_SHOW},
 				})
 			}
+		case tcell.KeyF6:
+			if sv.currentSessionID != nil {
+				sv.app.Autosave()
+				sv.app.HandleEvent(&ClockShowEvent{
+					BaseEvent: BaseEvent{action: CLOCK_SHOW},
+				})
+			}
+			return nil
+		case tcell.KeyF7:
+			sv.TogglePreview()
+			return nil
+		case tcell.KeyF8:
+			sv.OpenInEditor()
+			return nil
+		case tcell.KeyF9:
+			if sv.currentSessionID != nil || sv.IsNotesMode() {
+				_, offset, _ := sv.TextArea.GetSelection()
+				l := link.At(sv.TextArea.GetText(), offset)
+				if l == nil {
+					sv.app.notification.ShowWarning("Place the cursor on a [[link[]] to follow it.")
+					return nil
+				}
+				sv.app.HandleEvent(&LinkFollowEvent{
+					BaseEvent: BaseEvent{action: LINK_FOLLOW},
+					Link:      l,
+				})
+			}
+			return nil
+		case tcell.KeyCtrlN:
+			if sv.currentSessionID != nil || sv.IsNotesMode() {
+				sv.app.HandleEvent(&BacklinksShowEvent{
+					BaseEvent: BaseEvent{action: BACKLINKS_SHOW},
+				})
+			}
+			return nil
+		case tcell.KeyCtrlRightSq:
+			if sv.currentSessionID != nil || sv.IsNotesMode() {
+				_, offset, _ := sv.TextArea.GetSelection()
+				if node := lonelog.TagAt(sv.TextArea.GetText(), offset); node != nil {
+					sv.app.HandleEvent(&CodexTagFollowEvent{
+						BaseEvent: BaseEvent{action: CODEX_TAG_FOLLOW},
+						Tag:       node.Tag,
+					})
+				} else {
+					sv.app.HandleEvent(&CodexShowEvent{
+						BaseEvent: BaseEvent{action: CODEX_SHOW},
+						GameID:    sv.app.currentGameID(),
+					})
+				}
+			}
+			return nil
 		case tcell.KeyCtrlT:
 			if sv.currentSessionID != nil || sv.IsNotesMode() {
 				sv.app.Autosave()
@@ -159,15 +285,74 @@ func (sv *SessionView) setupKeyBindings() {
 				BaseEvent: BaseEvent{action: SESSION_SHOW_EXPORT},
 			})
 			return nil
+		case tcell.KeyCtrlBackslash:
+			if sv.currentSessionID != nil {
+				sv.app.Autosave()
+				_, offset, _ := sv.TextArea.GetSelection()
+				sv.app.HandleEvent(&SessionSplitConfirmEvent{
+					BaseEvent: BaseEvent{action: SESSION_SPLIT_CONFIRM},
+					SessionID: *sv.currentSessionID,
+					Offset:    offset,
+				})
+			}
+			return nil
 		}
 
 		return event
 	})
 }
 
+// loadKeyBindings parses the key bindings from the config. Bindings are
+// validated when the config loads, so any that fail to parse are skipped.
+func (sv *SessionView) loadKeyBindings() {
+	sv.keyBindings = nil
+	for _, b := range sv.app.cfg.KeyBindings {
+		key, err := config.ParseKey(b.Key)
+		if err != nil {
+			continue
+		}
+		sv.keyBindings = append(sv.keyBindings, boundKey{key: key, binding: b})
+	}
+}
+
+// handleKeyBinding runs the user-defined binding for event, if there is one.
+// Returns true when the event was handled.
+func (sv *SessionView) handleKeyBinding(event *tcell.EventKey) bool {
+	for _, bk := range sv.keyBindings {
+		if !bk.key.Matches(event) {
+			continue
+		}
+		if sv.currentSessionID == nil && !sv.IsNotesMode() {
+			return true
+		}
+
+		b := bk.binding
+		switch {
+		case b.Template != "":
+			sv.InsertTemplate(b.Template)
+		case b.SnippetID != 0:
+			s, err := sv.app.snippetView.snippetService.GetByID(b.SnippetID)
+			if err != nil {
+				sv.app.notification.ShowError(fmt.Sprintf("Snippet %d not found for %s", b.SnippetID, b.Key))
+				return true
+			}
+			sv.InsertAtCursor(sv.app.diceView.RollText(s.Content))
+		default:
+			sv.InsertAtCursor(sv.app.diceView.RollText(b.Roll))
+		}
+		return true
+	}
+	return false
+}
+
 // setupFocusHandlers configures focus event handlers
 func (sv *SessionView) setupFocusHandlers() {
 	sv.TextArea.SetFocusFunc(func() {
+		// The editor has no width while previewing, so focus the preview instead
+		if sv.previewing {
+			sv.app.SetFocus(sv.Preview)
+			return
+		}
 		if sv.currentSessionID != nil {
 			sv.app.updateFooterHelp(helpBar("Session", []helpEntry{
 				{"PgUp/PgDn/↑/↓", "Scroll"},
@@ -177,6 +362,13 @@ func (sv *SessionView) setupFocusHandlers() {
 				{"F3", "Oracle"},
 				{"F4", "Dice"},
 				{"F5", "Search"},
+				{"F6", "Clocks"},
+				{"F7", "Preview"},
+				{"F8", "Editor"},
+				{"F9", "Follow Link"},
+				{"Ctrl+N", "Backlinks"},
+				{"Ctrl+]", "Codex"},
+				{"Ctrl+\\", "Split"},
 			}))
 		} else if sv.IsNotesMode() {
 			sv.app.updateFooterHelp(helpBar("Notes", []helpEntry{
@@ -184,6 +376,11 @@ func (sv *SessionView) setupFocusHandlers() {
 				{"F12", "Help"},
 				{"Ctrl+T", "Tag"},
 				{"F5", "Search"},
+				{"F7", "Preview"},
+				{"F8", "Editor"},
+				{"F9", "Follow Link"},
+				{"Ctrl+N", "Backlinks"},
+				{"Ctrl+]", "Codex"},
 			}))
 		} else {
 			sv.app.updateFooterHelp(helpBar("Session", []helpEntry{
@@ -202,16 +399,18 @@ func (sv *SessionView) setupFocusHandlers() {
 func (sv *SessionView) Reset() {
 	sv.currentSessionID = nil
 	sv.currentSession = nil
-	sv.isNotes = false
+	sv.currentPage = nil
 	sv.isLoading = false
 	sv.isDirty = false
 	sv.stopAutosave()
+	sv.closePreview()
 }
 
 func (sv *SessionView) SetText(text string, cursorAtEnd bool) {
 	sv.isLoading = true
 	sv.TextArea.SetText(text, cursorAtEnd)
 	sv.isLoading = false
+	sv.renderPreview()
 }
 
 // Refresh reloads the session tree from the database and restores selection
@@ -219,12 +418,8 @@ func (sv *SessionView) Refresh() {
 	sv.app.Autosave()
 
 	if sv.IsNotesMode() {
-		g := sv.app.CurrentGame()
-		if g == nil {
-			return
-		}
-		if g.Notes != sv.TextArea.GetText() {
-			sv.SetText(g.Notes, false)
+		if sv.currentPage.Content != sv.TextArea.GetText() {
+			sv.SetText(sv.currentPage.Content, false)
 		}
 		sv.updateTitle()
 		sv.TextArea.SetDisabled(false)
@@ -232,6 +427,7 @@ func (sv *SessionView) Refresh() {
 	}
 
 	if sv.currentSessionID == nil {
+		sv.closePreview()
 		sv.textAreaFrame.SetTitle(DEFAULT_SECTION_TITLE)
 		sv.SetText("", true)
 		sv.currentSession = nil
@@ -254,12 +450,29 @@ func (sv *SessionView) Refresh() {
 	sv.TextArea.SetDisabled(false)
 }
 
+// resizeFormModal fits the form modal to the form's fields, which change
+// depending on whether session templates are offered
+func (sv *SessionView) resizeFormModal() {
+	// Each field takes its height plus padding, around the border, padding and buttons
+	height := 5
+	for i := range sv.Form.GetFormItemCount() {
+		height += sv.Form.GetFormItem(i).GetFieldHeight() + 1
+	}
+	sv.formModal.SetBaseHeight(height)
+}
+
 // HandleSave processes session save operation
 func (sv *SessionView) HandleSave() {
+	if _, ok := sv.Form.PlayedAt(); !ok {
+		sv.Form.SetFieldErrors(map[string]string{"played_at": "must be a date like " + text.DateFormat})
+		return
+	}
+
 	session := sv.Form.BuildDomain()
+	template := sv.Form.SelectedTemplate()
 
 	session, err := sv.sessionService.Save(session)
-	if err != nil {
+	if err != nil && !isIndexingError(err) {
 		// Check if it's a validation error
 		if sharedui.HandleValidationError(err, sv.Form) {
 			return
@@ -275,8 +488,14 @@ func (sv *SessionView) HandleSave() {
 	sv.app.HandleEvent(&SessionSavedEvent{
 		BaseEvent: BaseEvent{action: SESSION_SAVED},
 		Session:   *session,
+		Template:  template,
 	})
 
+	// The session was saved but its tags weren't indexed
+	if err != nil {
+		sv.app.showIndexingWarning(err)
+	}
+
 }
 
 // HandleCancel processes session form cancellation
@@ -327,6 +546,52 @@ func (sv *SessionView) ConfirmDelete(sessionID int64) {
 	})
 }
 
+// ConfirmSplit splits the session at the offset, naming the new second half
+// after the original
+func (sv *SessionView) ConfirmSplit(sessionID int64, offset int) {
+	s, err := sv.sessionService.GetByID(sessionID)
+	if err != nil {
+		sv.app.notification.ShowError(fmt.Sprintf("Error loading session: %v", err))
+		return
+	}
+
+	first, second, err := sv.sessionService.Split(sessionID, offset, s.Name+" (2)")
+	if err != nil && !isIndexingError(err) {
+		sv.app.pages.HidePage(CONFIRM_MODAL_ID)
+		sv.app.SetFocus(sv.TextArea)
+		sv.app.notification.ShowError(fmt.Sprintf("Error splitting session: %v", err))
+		return
+	}
+
+	sv.app.HandleEvent(&SessionSplitEvent{
+		BaseEvent: BaseEvent{action: SESSION_SPLIT},
+		First:     first,
+		Second:    second,
+	})
+	if err != nil {
+		sv.app.showIndexingWarning(err)
+	}
+}
+
+// ConfirmMerge merges the following session into this one
+func (sv *SessionView) ConfirmMerge(sessionID int64) {
+	merged, err := sv.sessionService.Merge(sessionID)
+	if err != nil && !isIndexingError(err) {
+		sv.app.pages.HidePage(CONFIRM_MODAL_ID)
+		sv.app.SetFocus(sv.app.gameView.Tree)
+		sv.app.notification.ShowError(fmt.Sprintf("Error merging sessions: %v", err))
+		return
+	}
+
+	sv.app.HandleEvent(&SessionMergedEvent{
+		BaseEvent: BaseEvent{action: SESSION_MERGED},
+		Session:   merged,
+	})
+	if err != nil {
+		sv.app.showIndexingWarning(err)
+	}
+}
+
 // ShowNewModal displays the session form modal for creating a new session
 func (sv *SessionView) ShowNewModal() {
 	sv.app.HandleEvent(&SessionShowNewEvent{
@@ -359,25 +624,44 @@ func (sv *SessionView) buildHelpText() string {
 	if isNotes {
 		b.WriteString("[green]Notes[white]\n\n")
 		b.WriteString("Notes are where you can track things for the entire game. For example, key NPCs, locations, or other details that cross multiple sessions. Notes are also searchable and tags added here will appear in the list of available tags.\n\n")
+		b.WriteString("Notes can be split into named pages, such as NPCs, Locations or Rumours. In the game view, press p on a game to add a page, and e on a page to rename or delete it. Export writes every page into one file.\n\n")
 	} else {
 		b.WriteString("[green]Session Management[white]\n\n")
 		b.WriteString("Select the session in the game view to edit the name or delete the session.\n\n")
 	}
 
 	b.WriteString("[yellow]Note:[white] Do not paste large amounts of text into the session log or notes. It is slow. Instead, use Import.\n\n")
-	b.WriteString("[yellow]Ctrl+N[white]: Add a new session.\n")
 	b.WriteString("[yellow]Ctrl-O[white]: Open a text file to import.\n")
 	b.WriteString("[yellow]Ctrl-X[white]: Export to a text file.\n")
 	b.WriteString("[yellow]F5[white]: Search the notes and sessions.\n")
+	b.WriteString("[yellow]F7[white]: Toggle a read-only preview with the Lonelog notation highlighted. Press m in the preview to switch between Lonelog and Markdown, and F7 or Esc to return to editing.\n")
+	b.WriteString("[yellow]F8[white]: Open in your own editor ($VISUAL or $EDITOR). The changes are loaded and saved when the editor exits.\n")
+	b.WriteString("[yellow]F9[white]: Follow the [[link[]] under the cursor. Link to a session with [[Session name[]], to a heading or scene with [[Session name#Heading[]], to a notes page with [[Page name[]], and to the notes with [[Notes[]] or [[Notes#Heading[]].\n")
+	b.WriteString("[yellow]Ctrl+N[white]: List the sessions that link here.\n")
+	b.WriteString("[yellow]Ctrl+][white]: Open the codex entry for the [N:NPC[], [L:Location[], [F:Faction[] or [I:Item[] tag under the cursor, or add it to the codex when it isn't there yet. Away from a tag, open the codex.\n")
+	if !isNotes {
+		b.WriteString("[yellow]Ctrl+\\[white]: Split the session at the cursor. Everything after the cursor moves to a new session.\n")
+	}
 
 	if !isNotes {
 		b.WriteString("\n[green][:::https://zeruhur.itch.io/lonelog]Lonelog[:::-] https://zeruhur.itch.io/lonelog\n\n")
 		b.WriteString("[yellow]F2[white]: Insert the Character Action template.\n")
 		b.WriteString("[yellow]F3[white]: Insert the Oracle template.\n")
 		b.WriteString("[yellow]F4[white]: Insert the Dice template.\n")
+		b.WriteString("[yellow]F6[white]: Show open clocks and tracks to increment or decrement.\n")
 	}
 
 	b.WriteString("[yellow]Ctrl+T[white]: Select a template (NPC, Event, Location, etc.) to insert.\n")
+	b.WriteString("Templates can use placeholders like {{cursor}}, {{date}}, {{session}}, {{game}}, {{roll:2d6}}, {{@table}} and {{prompt:Name}}. Prompts open a form to fill in before inserting.\n")
+	b.WriteString("[yellow]Tab[white]: While typing a tag identifier (e.g. [N:Va) or a table reference (e.g. @names), complete it with the first suggestion. Tags are completed with their latest data.\n")
+
+	if len(sv.keyBindings) > 0 {
+		b.WriteString("\n[green]Custom Keys[white]\n\n")
+		for _, bk := range sv.keyBindings {
+			b.WriteString("[yellow]" + tview.Escape(bk.binding.Key) + "[white]: " + tview.Escape(bk.binding.Description()) + "\n")
+		}
+		b.WriteString("\nCustom keys are defined in the [yellow]key_bindings[white] section of the config file.\n")
+	}
 
 	b.WriteString(`
 [green]Navigation
@@ -439,13 +723,20 @@ func (sv *SessionView) updateTitle() {
 	if sv.isDirty {
 		prefix = "[" + Style.ErrorTextColor + "]●[-] "
 	}
+	if sv.previewing {
+		mode := "Lonelog"
+		if sv.previewMarkdown {
+			mode = "Markdown"
+		}
+		keyHelp = " " + mode + " Preview ([" + Style.HelpKeyTextColor + "]F7[" + Style.NormalTextColor + "] Edit) "
+	}
 
 	if sv.IsNotesMode() {
 		g := sv.app.CurrentGame()
 		if g == nil {
 			return
 		}
-		body = tview.Escape(g.Name) + ": Notes"
+		body = tview.Escape(g.Name) + ": " + tview.Escape(sv.currentPage.Name)
 	} else {
 		if sv.currentSession == nil {
 			return
@@ -498,41 +789,123 @@ func (sv *SessionView) stopAutosave() {
 
 // SelectSession switches to session mode and loads the given session into the editor.
 func (sv *SessionView) SelectSession(sessionID int64) {
-	sv.isNotes = false
+	sv.currentPage = nil
 	sv.currentSession = nil
 	sv.currentSessionID = &sessionID
 	sv.Refresh()
 }
 
-// SelectNotes switches to notes mode and loads the active game's notes into the editor.
-func (sv *SessionView) SelectNotes() {
-	sv.isNotes = true
+// SelectNotes switches to notes mode and loads the notes page into the editor.
+func (sv *SessionView) SelectNotes(page *notes.Page) {
+	sv.currentPage = page
 	sv.currentSession = nil
 	sv.currentSessionID = nil
 	sv.Refresh()
 }
 
+// selectAtTop selects length bytes starting at offset, scrolling the cursor's
+// row to the top of the editor
+func (sv *SessionView) selectAtTop(offset, length int) {
+	sv.cursorToTop = true
+	sv.TextArea.Select(offset, offset+length)
+	// Select doesn't report a cursor that didn't move
+	sv.cursorToTop = false
+}
+
 func (sv *SessionView) InsertAtCursor(template string) {
 	_, start, _ := sv.TextArea.GetSelection()
-	// When inserting the string would push the cursor below the
-	// viewable area, scroll up just enough so the cursor remains
-	// visible after inserting the content.
-	sv.TextArea.SetMovedFunc(func() {
-		sv.TextArea.SetMovedFunc(nil)
-		row, _, _, _ := sv.TextArea.GetCursor()
-		offsetRow, _ := sv.TextArea.GetOffset()
-		_, _, _, height := sv.TextArea.GetInnerRect()
-		if row >= offsetRow+height {
-			sv.TextArea.SetOffset(row-height+1, 0)
-		}
-	})
+	sv.keepCursorInView = true
 	sv.TextArea.Replace(start, start, template)
 }
 
+// InsertTemplate expands the placeholders in template and inserts the result
+// at the cursor. Templates with {{prompt:Name}} placeholders ask for the
+// answers first.
+func (sv *SessionView) InsertTemplate(template string) {
+	if prompts := tag.TemplatePrompts(template); len(prompts) > 0 {
+		sv.app.promptView.Show(template, prompts, sv.TextArea)
+		return
+	}
+	sv.insertExpanded(template, nil)
+}
+
+// insertExpanded expands template with the given prompt answers, inserts it
+// at the cursor and moves the cursor to its {{cursor}} placeholder.
+func (sv *SessionView) insertExpanded(template string, prompts map[string]string) {
+	ctx := tag.TemplateContext{
+		Now:     time.Now(),
+		Oracles: sv.oracleService,
+		Prompts: prompts,
+	}
+	var gameID int64
+	if sv.currentSession != nil {
+		gameID = sv.currentSession.GameID
+		ctx.Session = sv.currentSession.Name
+		ctx.Game = sv.currentSession.GameName
+		sessionID := sv.currentSession.ID
+		ctx.Previously = func() string {
+			recap, err := sv.sessionService.Previously(sessionID)
+			if err != nil {
+				sv.app.notification.ShowError(fmt.Sprintf("Error loading previous session: %v", err))
+			}
+			return recap
+		}
+	} else if g := sv.app.CurrentGame(); g != nil {
+		gameID = g.ID
+		ctx.Game = g.Name
+	}
+	ctx.Recap = func() string {
+		recap, err := sv.sessionService.Recap(gameID)
+		if err != nil {
+			sv.app.notification.ShowError(fmt.Sprintf("Error loading recap: %v", err))
+		}
+		return recap
+	}
+	ctx.ActiveTags = func() string {
+		result, err := sv.tagService.LoadTagsForGame(gameID, nil, sv.app.cfg.TagExcludeWords)
+		if err != nil {
+			sv.app.notification.ShowError(fmt.Sprintf("Error loading tags: %v", err))
+			return ""
+		}
+		lines := make([]string, len(result.Active))
+		for i, t := range result.Active {
+			lines[i] = t.Template
+		}
+		return strings.Join(lines, "\n")
+	}
+
+	expansion := tag.ExpandTemplate(template, ctx)
+	_, start, _ := sv.TextArea.GetSelection()
+	sv.InsertAtCursor(expansion.Text)
+	sv.TextArea.Select(start+expansion.Cursor, start+expansion.Cursor)
+}
+
+// InsertLineAtCursor inserts text on a line of its own at the cursor, breaking
+// the current line first when the cursor is not at its start.
+func (sv *SessionView) InsertLineAtCursor(text string) {
+	_, start, _ := sv.TextArea.GetSelection()
+	if start > 0 && sv.TextArea.GetText()[start-1] != '\n' {
+		text = "\n" + text
+	}
+	sv.InsertAtCursor(text + "\n")
+}
+
 // ====== FileTarget implementation ======
 
+// GetFileContent returns the session, or every notes page of the game so
+// an export in notes mode spans them all
 func (sv *SessionView) GetFileContent() string {
-	return sv.TextArea.GetText()
+	if !sv.IsNotesMode() {
+		return sv.TextArea.GetText()
+	}
+
+	sv.app.Autosave()
+	content, err := sv.app.notesService.Export(sv.currentPage.GameID)
+	if err != nil {
+		sv.app.notification.ShowError(fmt.Sprintf("Error exporting notes: %v", err))
+		return sv.TextArea.GetText()
+	}
+	return content
 }
 
 func (sv *SessionView) SetFileContent(data string, position ImportPosition) {
@@ -1,7 +1,10 @@
 package ui
 
 import (
+	"soloterm/config"
+	"soloterm/domain/session"
 	testHelper "soloterm/shared/testing"
+	"soloterm/shared/text"
 	"testing"
 
 	"github.com/gdamore/tcell/v2"
@@ -123,9 +126,7 @@ func TestSessionView_DeleteSession(t *testing.T) {
 	assert.Empty(t, sessions)
 
 	// Verify the game still has notes
-	g, err = app.gameView.gameService.GetByID(g.ID)
-	assert.Nil(t, err, "Expected err to be nil")
-	assert.NotEqual(t, "", g.Notes, "Expected notes to not be empty")
+	assert.NotEqual(t, "", notesContent(t, app, g.ID), "Expected notes to not be empty")
 
 	// Verify the current session is cleared
 	assert.Nil(t, app.sessionView.currentSessionID, "Expected current session to be nil after deletion")
@@ -226,7 +227,7 @@ func TestSessionView_SessionAppearsInGameTree(t *testing.T) {
 
 	require.Len(t, children, 2)
 	assert.Equal(t, "Notes", children[0].GetText())
-	expectedLabel := "New Session (" + s.CreatedAt.Format("2006-01-02") + ")"
+	expectedLabel := "New Session (" + s.PlayedAt.Format(text.DateFormat) + ")"
 	assert.Equal(t, expectedLabel, children[1].GetText())
 }
 
@@ -336,3 +337,114 @@ func TestSessionView_EditAndDeleteSessionFromGameTree(t *testing.T) {
 	require.Len(t, remaining, 1, "Expected only Session A to remain")
 	assert.Equal(t, "Session A", remaining[0].Name)
 }
+
+func TestSessionView_CustomKeyBindings(t *testing.T) {
+	app := setupTestApp(t)
+	g := createGame(t, app, "Test Game")
+	createSession(t, app, g.ID, "New Session")
+	sn := createSnippet(t, app, "Damage", "Damage: 1d1", nil)
+
+	app.cfg.KeyBindings = []config.KeyBinding{
+		{Key: "Alt+1", Template: "[N:Name|]"},
+		{Key: "Ctrl+Alt+S", SnippetID: sn.ID},
+		{Key: "F9", Roll: "1d1"},
+	}
+	app.sessionView.loadKeyBindings()
+	app.gameView.Refresh()
+
+	// Select the session
+	testHelper.SimulateDownArrow(app.gameView.Tree, app.Application)
+	testHelper.SimulateDownArrow(app.gameView.Tree, app.Application)
+	testHelper.SimulateEnter(app.gameView.Tree, app.Application)
+
+	testHelper.SimulateRune(app.sessionView.TextArea, app.Application, '1', tcell.ModAlt)
+	assert.Equal(t, "[N:Name|]", app.sessionView.TextArea.GetText())
+
+	app.sessionView.SetText("", true)
+	testHelper.SimulateKey(app.sessionView.TextArea, app.Application, tcell.KeyCtrlS, tcell.ModCtrl|tcell.ModAlt)
+	assert.Equal(t, "Damage: 1d1 -> 1", app.sessionView.TextArea.GetText())
+
+	app.sessionView.SetText("", true)
+	testHelper.SimulateKey(app.sessionView.TextArea, app.Application, tcell.KeyF9)
+	assert.Equal(t, "1d1 -> 1", app.sessionView.TextArea.GetText())
+}
+
+func TestSessionView_AddSessionFromTemplate(t *testing.T) {
+	app := setupTestApp(t)
+	g := createGame(t, app, "Test Game")
+	g.SessionTemplate = "S1 *{{cursor}}*\n{{previously}}\n{{active_tags}}"
+	_, err := app.gameView.gameService.Save(g)
+	require.NoError(t, err)
+	app.cfg.SessionTemplates = []session.Template{{Name: "Short", Content: "Chaos: 5"}}
+
+	prev := createSession(t, app, g.ID, "Session One")
+	prev.Content = "=> We reach the keep\n[N:Vance | wary]"
+	_, err = app.sessionView.sessionService.Save(prev)
+	require.NoError(t, err)
+	app.gameView.Refresh()
+
+	// Open the new session modal; the game template is offered before the config ones
+	testHelper.SimulateDownArrow(app.gameView.Tree, app.Application)
+	testHelper.SimulateRune(app.gameView.Tree, app.Application, 'n')
+	require.True(t, app.isPageVisible(SESSION_MODAL_ID), "Expected session modal to be visible")
+	require.GreaterOrEqual(t, app.sessionView.Form.GetFormItemIndex("Template"), 0, "Expected the template field")
+	assert.Equal(t, 3, app.sessionView.Form.templateField.GetOptionCount())
+
+	app.sessionView.Form.nameField.SetText("Session Two")
+	app.sessionView.Form.templateField.SetCurrentOption(1)
+	testHelper.SimulateKey(app.sessionView.Form, app.Application, tcell.KeyCtrlS)
+
+	assert.Equal(t, "S1 **\nPreviously, in Session One:\n- We reach the keep\n[N:Vance | wary]", app.sessionView.TextArea.GetText())
+	_, start, _ := app.sessionView.TextArea.GetSelection()
+	assert.Equal(t, 4, start, "Expected the cursor inside the scene title")
+}
+
+func TestSessionView_EditSessionHidesTemplates(t *testing.T) {
+	app := setupTestApp(t)
+	g := createGame(t, app, "Test Game")
+	app.cfg.SessionTemplates = []session.Template{{Name: "Short", Content: "Chaos: 5"}}
+	s := createSession(t, app, g.ID, "Session One")
+
+	app.HandleEvent(&SessionShowEditEvent{BaseEvent: BaseEvent{action: SESSION_SHOW_EDIT}, Session: s})
+	assert.Equal(t, -1, app.sessionView.Form.GetFormItemIndex("Template"))
+	assert.Empty(t, app.sessionView.Form.SelectedTemplate())
+}
+
+func TestSessionView_EditPlayDate(t *testing.T) {
+	app := setupTestApp(t)
+	g := createGame(t, app, "Test Game")
+	s := createSession(t, app, g.ID, "Session One")
+
+	app.HandleEvent(&SessionShowEditEvent{BaseEvent: BaseEvent{action: SESSION_SHOW_EDIT}, Session: s})
+	app.sessionView.Form.playedAtField.SetText("last week")
+	testHelper.SimulateKey(app.sessionView.Form, app.Application, tcell.KeyCtrlS)
+	assert.True(t, app.isPageVisible(SESSION_MODAL_ID), "Expected the form to stay open for an invalid date")
+	assert.True(t, app.sessionView.Form.HasFieldError("played_at"))
+
+	app.sessionView.Form.playedAtField.SetText("2023-05-14")
+	testHelper.SimulateKey(app.sessionView.Form, app.Application, tcell.KeyCtrlS)
+	assert.False(t, app.isPageVisible(SESSION_MODAL_ID), "Expected session modal to close")
+
+	saved, err := app.sessionView.sessionService.GetByID(s.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "2023-05-14", saved.PlayedAt.Format(text.DateFormat))
+}
+
+func TestSessionView_AutosaveWarnsWhenIndexingFails(t *testing.T) {
+	app, db := setupTestAppWithDB(t)
+	loadSessionWithContent(t, app, 0, "")
+	sv := app.sessionView
+
+	// Break the tag index so indexing fails after the session is saved
+	_, err := db.Connection.Exec("ALTER TABLE tag_index RENAME TO tag_index_gone")
+	require.NoError(t, err)
+
+	sv.TextArea.SetText("Met [N:Vex]", true)
+	app.Autosave()
+
+	assert.False(t, sv.isDirty, "Expected the session to count as saved")
+	assert.Contains(t, app.notification.GetText(true), "Saved, but the tag index wasn't updated")
+	stored, err := sv.sessionService.GetByID(*sv.currentSessionID)
+	require.NoError(t, err)
+	assert.Equal(t, "Met [N:Vex]", stored.Content)
+}
@@ -10,19 +10,32 @@ import (
 // without needing Style.Theme.BorderColor.
 type AppStyle struct {
 	tview.Theme
-	BorderFocusColor       tcell.Color
-	ErrorMessageColor      tcell.Color
-	EmptyStateMessageColor tcell.Color
-	TopTreeNodeColor       tcell.Color
-	ParentTreeNodeColor    tcell.Color
-	ChildTreeNodeColor     tcell.Color
-	ContextLabelTextColor  string
-	HelpKeyTextColor       string
-	NormalTextColor        string
-	HelpSectionColor       string
-	ErrorTextColor         string
-	SuccessTextColor       string
-	NotificationErrorColor string
+	BorderFocusColor        tcell.Color
+	ErrorMessageColor       tcell.Color
+	EmptyStateMessageColor  tcell.Color
+	TopTreeNodeColor        tcell.Color
+	ParentTreeNodeColor     tcell.Color
+	ChildTreeNodeColor      tcell.Color
+	ContextLabelTextColor   string
+	HelpKeyTextColor        string
+	NormalTextColor         string
+	HelpSectionColor        string
+	ErrorTextColor          string
+	SuccessTextColor        string
+	NotificationErrorColor  string
+	LonelogActionColor      string
+	LonelogOracleColor      string
+	LonelogDiceColor        string
+	LonelogConsequenceColor string
+	LonelogSceneColor       string
+	LonelogTagColor         string
+	MarkdownHeadingColor    string
+	MarkdownQuoteColor      string
+	MarkdownCodeColor       string
+	MarkdownLinkColor       string
+	MarkdownRuleColor       string
+	DiffAppColor            string
+	DiffFileColor           string
 }
 
 // Style is the global style for the application.
@@ -41,19 +54,32 @@ var Style = AppStyle{
 		InverseTextColor:            tcell.ColorBlue,
 		ContrastSecondaryTextColor:  tcell.ColorNavy,
 	},
-	BorderFocusColor:       tcell.ColorWhite,
-	ErrorMessageColor:      tcell.ColorRed,
-	EmptyStateMessageColor: tcell.ColorGrey,
-	TopTreeNodeColor:       tcell.ColorYellow,
-	ParentTreeNodeColor:    tcell.ColorLime,
-	ChildTreeNodeColor:     tcell.ColorAqua,
-	ContextLabelTextColor:  "aqua",   // context label in the help section
-	HelpKeyTextColor:       "yellow", // key combinations in the help section
-	NormalTextColor:        "-",      // reverts to PrimaryTextColor after a colored segment
-	HelpSectionColor:       "green",  // section headers in help text
-	ErrorTextColor:         "pink",   // error labels and dirty indicators
-	SuccessTextColor:       "lime",   // positive result indicators (dice, etc.)
-	NotificationErrorColor: "pink",   // error notification banners
+	BorderFocusColor:        tcell.ColorWhite,
+	ErrorMessageColor:       tcell.ColorRed,
+	EmptyStateMessageColor:  tcell.ColorGrey,
+	TopTreeNodeColor:        tcell.ColorYellow,
+	ParentTreeNodeColor:     tcell.ColorLime,
+	ChildTreeNodeColor:      tcell.ColorAqua,
+	ContextLabelTextColor:   "aqua",         // context label in the help section
+	HelpKeyTextColor:        "yellow",       // key combinations in the help section
+	NormalTextColor:         "-",            // reverts to PrimaryTextColor after a colored segment
+	HelpSectionColor:        "green",        // section headers in help text
+	ErrorTextColor:          "pink",         // error labels and dirty indicators
+	SuccessTextColor:        "lime",         // positive result indicators (dice, etc.)
+	NotificationErrorColor:  "pink",         // error notification banners
+	LonelogActionColor:      "yellow",       // @ actions in the session preview
+	LonelogOracleColor:      "aqua",         // ? oracle questions
+	LonelogDiceColor:        "lime",         // d: dice and tbl: table rolls
+	LonelogConsequenceColor: "fuchsia",      // -> and => outcomes
+	LonelogSceneColor:       "green::b",     // scene headers
+	LonelogTagColor:         "orange",       // [Type:Name | data] tags
+	MarkdownHeadingColor:    "yellow",       // headings in the Markdown preview
+	MarkdownQuoteColor:      "grey",         // block quotes
+	MarkdownCodeColor:       "aqua",         // inline code and code blocks
+	MarkdownLinkColor:       "lightskyblue", // link text
+	MarkdownRuleColor:       "grey",         // rules and table borders
+	DiffAppColor:            "red",          // lines only in the app's copy of a sync conflict
+	DiffFileColor:           "green",        // lines only in the file's copy
 }
 
 // Apply syncs Style.Theme to tview.Styles so all primitives pick up the defaults.
@@ -0,0 +1,177 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"soloterm/domain/filesync"
+	"sync"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// focusScreen passes every event through to tview, calling onFocus when the
+// terminal window regains focus. tview ignores focus events itself.
+type focusScreen struct {
+	tcell.Screen
+	onFocus func()
+}
+
+// Init does nothing, as Run initializes the screen before handing it to tview
+func (s *focusScreen) Init() error { return nil }
+
+func (s *focusScreen) PollEvent() tcell.Event {
+	ev := s.Screen.PollEvent()
+	if focus, ok := ev.(*tcell.EventFocus); ok && focus.Focused {
+		s.onFocus()
+	}
+	return ev
+}
+
+// syncWatch syncs in the background once a game has a sync folder
+type syncWatch struct {
+	screen  *focusScreen
+	ticker  *time.Ticker
+	syncing sync.Mutex // held while files are synced, so syncs don't overlap
+}
+
+// Run starts the application. Games with a sync folder are synced at startup,
+// then in the background whenever the terminal regains focus and every sync
+// interval.
+func (a *App) Run() error {
+	screen, err := tcell.NewScreen()
+	if err != nil {
+		return err
+	}
+	if err := screen.Init(); err != nil {
+		return err
+	}
+	a.syncWatch.screen = &focusScreen{
+		Screen: screen,
+		// PollEvent runs on tview's event goroutine, so sync elsewhere
+		// rather than wait for it
+		onFocus: func() { go a.syncInBackground() },
+	}
+	a.SetScreen(a.syncWatch.screen)
+
+	hasSyncDirs, err := a.syncService.HasSyncDirs()
+	if err != nil {
+		a.notification.ShowError(fmt.Sprintf("Error loading games: %v", err))
+	}
+	if hasSyncDirs {
+		a.SyncFiles()
+		a.startSyncWatch()
+	}
+
+	err = a.Application.Run()
+	if a.syncWatch.ticker != nil {
+		a.syncWatch.ticker.Stop()
+	}
+	return err
+}
+
+// startSyncWatch starts syncing in the background, every sync interval and
+// whenever the terminal regains focus. It does nothing once started, or
+// before Run has set up the screen.
+func (a *App) startSyncWatch() {
+	if a.syncWatch.screen == nil || a.syncWatch.ticker != nil {
+		return
+	}
+	a.syncWatch.screen.EnableFocus()
+	a.syncWatch.ticker = time.NewTicker(a.cfg.SyncInterval())
+	ticks := a.syncWatch.ticker.C
+	go func() {
+		for range ticks {
+			a.syncInBackground()
+		}
+	}()
+}
+
+// SyncFiles syncs every game with a sync folder, refreshing the views when
+// anything was imported and showing any conflicts
+func (a *App) SyncFiles() {
+	a.syncFiles()
+}
+
+func (a *App) syncFiles() (*filesync.Result, error) {
+	// Leave conflicts being resolved alone until the user is done
+	if a.isPageVisible(SYNC_CONFLICT_MODAL_ID) {
+		return nil, nil
+	}
+
+	// Write pending edits first so they're compared rather than overwritten
+	a.Autosave()
+
+	a.syncWatch.syncing.Lock()
+	result, err := a.syncService.SyncAll()
+	a.syncWatch.syncing.Unlock()
+
+	a.showSyncResult(result, err)
+	return result, err
+}
+
+// syncInBackground syncs like SyncFiles, but reads and writes the files on
+// the calling goroutine. Only saving pending edits, importing files changed
+// outside the app and showing the result are queued on the UI goroutine. A
+// sync already under way is left to finish.
+func (a *App) syncInBackground() {
+	busy := false
+	a.QueueUpdate(func() {
+		busy = a.isPageVisible(SYNC_CONFLICT_MODAL_ID)
+		if !busy {
+			a.Autosave()
+		}
+	})
+	if busy || !a.syncWatch.syncing.TryLock() {
+		return
+	}
+	result, err := a.syncService.SyncAll()
+	a.syncWatch.syncing.Unlock()
+
+	a.QueueUpdateDraw(func() {
+		a.showSyncResult(result, err)
+	})
+}
+
+// showSyncResult imports the files changed outside the app, refreshes the
+// views when anything was imported and shows the result of a sync, along with
+// any conflicts
+func (a *App) showSyncResult(result *filesync.Result, err error) {
+	if result != nil {
+		err = errors.Join(err, a.importSyncedFiles(result))
+	}
+	if err != nil {
+		a.notification.ShowError(fmt.Sprintf("Sync failed: %v", err))
+	}
+	if result == nil {
+		return
+	}
+
+	if result.Changed() {
+		a.gameView.Refresh()
+		a.sessionView.Refresh()
+	}
+
+	if len(result.Conflicts) > 0 {
+		a.showSyncConflicts(result.Conflicts)
+		return
+	}
+
+	if err == nil && result.String() != "" {
+		a.notification.ShowInfo("Synced: " + result.String())
+	}
+}
+
+// importSyncedFiles brings the files a sync found changed outside the app into
+// their sessions. The open session's edits would be autosaved over its file,
+// so while it has any its file is compared as a conflict instead.
+func (a *App) importSyncedFiles(result *filesync.Result) error {
+	sv := a.sessionView
+	if sv.isDirty && sv.currentSessionID != nil {
+		result.Hold(*sv.currentSessionID, sv.TextArea.GetText())
+	}
+
+	a.syncWatch.syncing.Lock()
+	defer a.syncWatch.syncing.Unlock()
+	return a.syncService.Import(result)
+}
@@ -0,0 +1,205 @@
+package ui
+
+import (
+	"fmt"
+	"soloterm/domain/filesync"
+	"soloterm/shared/text"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// diffContext is the number of unchanged lines shown around each change
+const diffContext = 3
+
+// SyncConflictView shows sessions changed both in the app and in their file
+// since the last sync, one at a time, with a diff of the two copies. The user
+// keeps one copy or leaves the conflict for a later sync.
+type SyncConflictView struct {
+	app         *App
+	Modal       *tview.Flex
+	diffFrame   *tview.Frame
+	TextView    *tview.TextView
+	conflicts   []*filesync.Conflict
+	returnFocus tview.Primitive
+}
+
+// NewSyncConflictView creates a new sync conflict view
+func NewSyncConflictView(app *App) *SyncConflictView {
+	conflictView := &SyncConflictView{app: app}
+	conflictView.Setup()
+	return conflictView
+}
+
+// Setup initializes all sync conflict UI components
+func (cv *SyncConflictView) Setup() {
+	cv.setupModal()
+	cv.setupKeyBindings()
+}
+
+func (cv *SyncConflictView) setupModal() {
+	cv.TextView = tview.NewTextView().
+		SetDynamicColors(true).
+		SetWordWrap(true).
+		SetScrollable(true)
+
+	cv.diffFrame = tview.NewFrame(cv.TextView).
+		SetBorders(1, 1, 0, 0, 1, 1)
+	cv.diffFrame.SetBorder(true).
+		SetTitleAlign(tview.AlignLeft)
+
+	cv.Modal = tview.NewFlex().
+		AddItem(nil, 0, 1, false).
+		AddItem(
+			tview.NewFlex().
+				SetDirection(tview.FlexRow).
+				AddItem(nil, 0, 1, false).
+				AddItem(cv.diffFrame, 0, 4, true).
+				AddItem(nil, 0, 1, false),
+			0, 3, true,
+		).
+		AddItem(nil, 0, 1, false)
+
+	cv.TextView.SetFocusFunc(func() {
+		cv.app.updateFooterHelp(helpBar("Sync Conflict", []helpEntry{
+			{"↑/↓", "Scroll"},
+			{"a", "Keep App"},
+			{"f", "Keep File"},
+			{"Esc", "Decide Later"},
+		}))
+		cv.diffFrame.SetBorderColor(Style.BorderFocusColor)
+	})
+	cv.TextView.SetBlurFunc(func() {
+		cv.diffFrame.SetBorderColor(Style.BorderColor)
+	})
+}
+
+func (cv *SyncConflictView) setupKeyBindings() {
+	cv.TextView.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Key() {
+		case tcell.KeyEsc:
+			cv.app.HandleEvent(&SyncConflictCancelEvent{
+				BaseEvent: BaseEvent{action: SYNC_CONFLICT_CANCEL},
+			})
+			return nil
+		case tcell.KeyRune:
+			switch event.Rune() {
+			case 'a', 'f':
+				if c := cv.Current(); c != nil {
+					cv.app.HandleEvent(&SyncConflictResolveEvent{
+						BaseEvent: BaseEvent{action: SYNC_CONFLICT_RESOLVE},
+						Conflict:  c,
+						KeepFile:  event.Rune() == 'f',
+					})
+				}
+				return nil
+			}
+		}
+		return event
+	})
+}
+
+// Queue adds conflicts to those waiting to be resolved, replacing any already
+// queued for the same session
+func (cv *SyncConflictView) Queue(conflicts []*filesync.Conflict) {
+	for _, c := range conflicts {
+		replaced := false
+		for i, queued := range cv.conflicts {
+			if queued.SessionID == c.SessionID {
+				cv.conflicts[i] = c
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			cv.conflicts = append(cv.conflicts, c)
+		}
+	}
+}
+
+// Current returns the conflict being shown, or nil when none are queued
+func (cv *SyncConflictView) Current() *filesync.Conflict {
+	if len(cv.conflicts) == 0 {
+		return nil
+	}
+	return cv.conflicts[0]
+}
+
+// Next drops the current conflict and shows the next one. Returns false when
+// none are left.
+func (cv *SyncConflictView) Next() bool {
+	if len(cv.conflicts) > 0 {
+		cv.conflicts = cv.conflicts[1:]
+	}
+	cv.Load()
+	return len(cv.conflicts) > 0
+}
+
+// Clear forgets the queued conflicts. The next sync finds them again.
+func (cv *SyncConflictView) Clear() {
+	cv.conflicts = nil
+}
+
+// Load shows the diff of the current conflict
+func (cv *SyncConflictView) Load() {
+	c := cv.Current()
+	if c == nil {
+		cv.TextView.Clear()
+		return
+	}
+
+	title := fmt.Sprintf("[::b] Sync Conflict: %s", tview.Escape(c.SessionName))
+	if len(cv.conflicts) > 1 {
+		title += fmt.Sprintf(" (1 of %d)", len(cv.conflicts))
+	}
+	cv.diffFrame.SetTitle(title + " [-::-]")
+
+	var sb strings.Builder
+	sb.WriteString("Changed in the app and in [" + Style.HelpKeyTextColor + "]" + tview.Escape(c.Path) + "[-] since the last sync.\n")
+	sb.WriteString("[" + Style.DiffAppColor + "]- only in the app[-]   [" + Style.DiffFileColor + "]+ only in the file[-]\n\n")
+	sb.WriteString(renderDiff(text.DiffLines(c.AppContent, c.FileContent)))
+	cv.TextView.SetText(sb.String())
+	cv.TextView.ScrollToBeginning()
+}
+
+// renderDiff renders diff lines as tagged text, showing the unchanged lines
+// near each change and collapsing the rest
+func renderDiff(diff []text.DiffLine) string {
+	// Mark the unchanged lines close enough to a change to show
+	show := make([]bool, len(diff))
+	for i, line := range diff {
+		if line.Op == text.DiffEqual {
+			continue
+		}
+		for j := max(0, i-diffContext); j <= min(len(diff)-1, i+diffContext); j++ {
+			show[j] = true
+		}
+	}
+
+	var lines []string
+	skipped := 0
+	flush := func() {
+		if skipped > 0 {
+			lines = append(lines, fmt.Sprintf("[%s]… %d unchanged lines …[-]", Style.EmptyStateMessageColor.String(), skipped))
+			skipped = 0
+		}
+	}
+	for i, line := range diff {
+		if !show[i] {
+			skipped++
+			continue
+		}
+		flush()
+		switch line.Op {
+		case text.DiffDelete:
+			lines = append(lines, "["+Style.DiffAppColor+"]- "+tview.Escape(line.Text)+"[-]")
+		case text.DiffInsert:
+			lines = append(lines, "["+Style.DiffFileColor+"]+ "+tview.Escape(line.Text)+"[-]")
+		default:
+			lines = append(lines, "  "+tview.Escape(line.Text))
+		}
+	}
+	flush()
+	return strings.Join(lines, "\n")
+}
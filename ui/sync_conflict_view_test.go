@@ -0,0 +1,189 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	testHelper "soloterm/shared/testing"
+	"soloterm/shared/text"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// linkSyncDir gives the open session's game a temporary sync folder and syncs
+// it, returning the folder
+func linkSyncDir(t *testing.T, app *App) string {
+	t.Helper()
+	g, err := app.gameView.gameService.GetByID(app.sessionView.currentSession.GameID)
+	require.NoError(t, err)
+	g.SyncDir = t.TempDir()
+	_, err = app.gameView.gameService.Save(g)
+	require.NoError(t, err)
+	app.SyncFiles()
+	return g.SyncDir
+}
+
+func TestGameView_AddGameWithSyncDir(t *testing.T) {
+	app := setupTestApp(t)
+	dir := t.TempDir()
+
+	testHelper.SimulateRune(app.gameView.Tree, app.Application, 'n')
+	app.gameView.Form.nameField.SetText("Synced Game")
+	app.gameView.Form.syncDirField.SetText(" " + dir + " ")
+	testHelper.SimulateKey(app.gameView.Form, app.Application, tcell.KeyCtrlS)
+
+	games, err := app.gameView.gameService.GetAll()
+	require.NoError(t, err)
+	require.Len(t, games, 1)
+	assert.Equal(t, dir, games[0].SyncDir)
+
+	// Editing the game shows the folder again
+	app.gameView.ShowEditModal(games[0])
+	assert.Equal(t, dir, app.gameView.Form.syncDirField.GetText())
+}
+
+func TestSync_ImportsIntoOpenSession(t *testing.T) {
+	app := setupTestApp(t)
+	loadSessionWithContent(t, app, 0, "@ Open the gate")
+	dir := linkSyncDir(t, app)
+
+	path := filepath.Join(dir, "Test Session.md")
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "@ Open the gate", string(data))
+
+	require.NoError(t, os.WriteFile(path, []byte("@ Open the gate\n-> It creaks open"), 0644))
+	app.SyncFiles()
+	assert.Equal(t, "@ Open the gate\n-> It creaks open", app.sessionView.TextArea.GetText())
+}
+
+func TestSync_OpenSessionEditedDuringSyncIsAConflict(t *testing.T) {
+	setup := func(t *testing.T) (*App, string) {
+		app := setupTestApp(t)
+		loadSessionWithContent(t, app, 0, "base")
+		dir := linkSyncDir(t, app)
+		path := filepath.Join(dir, "Test Session.md")
+		require.NoError(t, os.WriteFile(path, []byte("file version"), 0644))
+
+		// Sync as in the background, typing while the files are read
+		result, err := app.syncService.SyncAll()
+		require.NoError(t, err)
+		app.sessionView.SetText("base and typing", false)
+		app.sessionView.isDirty = true
+		app.showSyncResult(result, err)
+
+		require.True(t, app.isPageVisible(SYNC_CONFLICT_MODAL_ID), "Expected the import to be shown as a conflict")
+		assert.Contains(t, app.syncConflictView.TextView.GetText(true), "- base and typing")
+		assert.Contains(t, app.syncConflictView.TextView.GetText(true), "+ file version")
+		return app, path
+	}
+
+	t.Run("decide later keeps both copies", func(t *testing.T) {
+		app, path := setup(t)
+		testHelper.SimulateKey(app.syncConflictView.TextView, app.Application, tcell.KeyEsc)
+		app.Autosave()
+		assert.Equal(t, "base and typing", app.sessionView.TextArea.GetText())
+
+		app.SyncFiles()
+		assert.True(t, app.isPageVisible(SYNC_CONFLICT_MODAL_ID), "Expected the next sync to find the conflict again")
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, "file version", string(data), "Expected the file not to be overwritten")
+	})
+
+	t.Run("keep the file's copy", func(t *testing.T) {
+		app, _ := setup(t)
+		testHelper.SimulateRune(app.syncConflictView.TextView, app.Application, 'f')
+
+		assert.Equal(t, "file version", app.sessionView.TextArea.GetText())
+		assert.False(t, app.sessionView.isDirty)
+		sess, err := app.sessionView.sessionService.GetByID(*app.sessionView.currentSessionID)
+		require.NoError(t, err)
+		assert.Equal(t, "file version", sess.Content)
+	})
+
+	t.Run("keep the app's copy", func(t *testing.T) {
+		app, path := setup(t)
+		testHelper.SimulateRune(app.syncConflictView.TextView, app.Application, 'a')
+
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, "base and typing", string(data))
+		assert.Equal(t, "base and typing", app.sessionView.TextArea.GetText())
+	})
+}
+
+func TestSyncConflictView_Resolve(t *testing.T) {
+	setup := func(t *testing.T) (*App, string) {
+		app := setupTestApp(t)
+		loadSessionWithContent(t, app, 0, "base")
+		dir := linkSyncDir(t, app)
+
+		app.sessionView.SetText("app version", false)
+		app.sessionView.isDirty = true
+		path := filepath.Join(dir, "Test Session.md")
+		require.NoError(t, os.WriteFile(path, []byte("file version"), 0644))
+
+		app.SyncFiles()
+		require.True(t, app.isPageVisible(SYNC_CONFLICT_MODAL_ID), "Expected the conflict to be shown")
+		assert.Equal(t, app.syncConflictView.TextView, app.GetFocus())
+		assert.Contains(t, app.syncConflictView.TextView.GetText(true), "- app version")
+		assert.Contains(t, app.syncConflictView.TextView.GetText(true), "+ file version")
+		return app, path
+	}
+
+	t.Run("keep the app's copy", func(t *testing.T) {
+		app, path := setup(t)
+		testHelper.SimulateRune(app.syncConflictView.TextView, app.Application, 'a')
+
+		assert.False(t, app.isPageVisible(SYNC_CONFLICT_MODAL_ID))
+		assert.Equal(t, app.sessionView.TextArea, app.GetFocus())
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, "app version", string(data))
+	})
+
+	t.Run("keep the file's copy", func(t *testing.T) {
+		app, _ := setup(t)
+		testHelper.SimulateRune(app.syncConflictView.TextView, app.Application, 'f')
+
+		assert.False(t, app.isPageVisible(SYNC_CONFLICT_MODAL_ID))
+		assert.Equal(t, "file version", app.sessionView.TextArea.GetText())
+	})
+
+	t.Run("decide later", func(t *testing.T) {
+		app, path := setup(t)
+		testHelper.SimulateKey(app.syncConflictView.TextView, app.Application, tcell.KeyEsc)
+
+		assert.False(t, app.isPageVisible(SYNC_CONFLICT_MODAL_ID))
+		assert.Nil(t, app.syncConflictView.Current())
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, "file version", string(data))
+		assert.Equal(t, "app version", app.sessionView.TextArea.GetText())
+	})
+}
+
+func TestRenderDiff_CollapsesUnchangedLines(t *testing.T) {
+	old := "1\n2\n3\n4\n5\n6\n7\n8\n9\n10"
+	new := "1\n2\n3\n4\n5\n6\n7\n8\n9\nten"
+
+	rendered := renderDiff(text.DiffLines(old, new))
+	assert.Contains(t, rendered, "… 6 unchanged lines …")
+	assert.Contains(t, rendered, "- 10")
+	assert.Contains(t, rendered, "+ ten")
+	assert.NotContains(t, rendered, "  6\n")
+}
+
+func TestSyncNow_WarnsWithoutSyncFolders(t *testing.T) {
+	app := setupTestApp(t)
+	createGame(t, app, "Unsynced")
+	app.gameView.Refresh()
+
+	app.SetFocus(app.gameView.Tree)
+	testHelper.SimulateRune(app.gameView.Tree, app.Application, 'y')
+
+	assert.Contains(t, app.notification.GetText(true), "No game has a sync folder")
+}
@@ -0,0 +1,70 @@
+package ui
+
+import (
+	"fmt"
+	"soloterm/domain/filesync"
+)
+
+func (a *App) handleSyncNow(_ *SyncNowEvent) {
+	hasSyncDirs, err := a.syncService.HasSyncDirs()
+	if err != nil {
+		a.notification.ShowError(fmt.Sprintf("Error loading games: %v", err))
+		return
+	}
+	if !hasSyncDirs {
+		a.notification.ShowWarning("No game has a sync folder. Set one by editing the game.")
+		return
+	}
+
+	result, err := a.syncFiles()
+	if err == nil && result != nil && result.String() == "" {
+		a.notification.ShowSuccess("Sync folders are up to date")
+	}
+	a.startSyncWatch()
+}
+
+// showSyncConflicts queues the conflicts from a sync and shows the first
+func (a *App) showSyncConflicts(conflicts []*filesync.Conflict) {
+	a.syncConflictView.Queue(conflicts)
+	a.syncConflictView.Load()
+	a.syncConflictView.returnFocus = a.GetFocus()
+	a.pages.ShowPage(SYNC_CONFLICT_MODAL_ID)
+	a.SetFocus(a.syncConflictView.TextView)
+}
+
+func (a *App) handleSyncConflictResolve(e *SyncConflictResolveEvent) {
+	var err error
+	if e.KeepFile {
+		err = a.syncService.KeepFile(e.Conflict)
+	} else {
+		err = a.syncService.KeepApp(e.Conflict)
+	}
+	if err != nil {
+		a.notification.ShowError(fmt.Sprintf("Error resolving the conflict: %v", err))
+		return
+	}
+
+	if e.KeepFile {
+		// The file's copy replaces any edits to the open session not yet saved
+		if id := a.sessionView.currentSessionID; id != nil && *id == e.Conflict.SessionID {
+			a.sessionView.isDirty = false
+		}
+		a.sessionView.Refresh()
+		a.notification.ShowSuccess(fmt.Sprintf("Kept the file's copy of %s", e.Conflict.SessionName))
+	} else {
+		a.notification.ShowSuccess(fmt.Sprintf("Kept the app's copy of %s", e.Conflict.SessionName))
+	}
+
+	if a.syncConflictView.Next() {
+		return
+	}
+	a.pages.HidePage(SYNC_CONFLICT_MODAL_ID)
+	a.SetFocus(a.syncConflictView.returnFocus)
+}
+
+func (a *App) handleSyncConflictCancel(_ *SyncConflictCancelEvent) {
+	a.syncConflictView.Clear()
+	a.pages.HidePage(SYNC_CONFLICT_MODAL_ID)
+	a.SetFocus(a.syncConflictView.returnFocus)
+	a.notification.ShowWarning("Conflicting sessions were left as they are until the next sync")
+}
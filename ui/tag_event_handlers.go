@@ -2,8 +2,14 @@ package ui
 
 func (a *App) handleTagSelected(e *TagSelectedEvent) {
 	a.pages.HidePage(TAG_MODAL_ID)
-	a.sessionView.InsertAtCursor(e.TagType.Template)
 	a.SetFocus(a.sessionView.TextArea)
+	// Tags used in the game are inserted as they were written, so text that
+	// looks like a placeholder isn't expanded
+	if !e.Configured {
+		a.sessionView.InsertAtCursor(e.TagType.Template)
+		return
+	}
+	a.sessionView.InsertTemplate(e.TagType.Template)
 }
 
 func (a *App) handleTagCancelled(e *TagCancelledEvent) {
@@ -18,3 +24,23 @@ func (a *App) handleTagShow(e *TagShowEvent) {
 	a.SetFocus(a.tagView.TagTable)
 }
 
+func (a *App) handleTagTimelineShow(e *TagTimelineShowEvent) {
+	var gameID int64
+	if g := a.CurrentGame(); g != nil {
+		gameID = g.ID
+	}
+	a.timelineView.Load(gameID, e.Identifier)
+	a.pages.ShowPage(TAG_TIMELINE_MODAL_ID)
+	a.SetFocus(a.timelineView.Table)
+}
+
+func (a *App) handleTagTimelineCancel(e *TagTimelineCancelEvent) {
+	a.pages.HidePage(TAG_TIMELINE_MODAL_ID)
+	a.SetFocus(a.tagView.TagTable)
+}
+
+func (a *App) handleTagTimelineSelect(e *TagTimelineSelectEvent) {
+	a.pages.HidePage(TAG_TIMELINE_MODAL_ID)
+	a.pages.HidePage(TAG_MODAL_ID)
+	a.openAt(e.Occurrence.SessionID, 0, e.Occurrence.Offset, len(e.Occurrence.Raw))
+}
@@ -0,0 +1,147 @@
+package ui
+
+import (
+	"fmt"
+	"soloterm/domain/tag"
+	"soloterm/shared/text"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// TagTimelineView shows every occurrence of a single tag across the game's sessions
+type TagTimelineView struct {
+	app           *App
+	tagService    *tag.Service
+	Modal         *tview.Flex
+	timelineFrame *tview.Frame
+	Table         *tview.Table
+	identifier    string
+	occurrences   []tag.Occurrence
+}
+
+// NewTagTimelineView creates a new tag timeline view
+func NewTagTimelineView(app *App, tagService *tag.Service) *TagTimelineView {
+	timelineView := &TagTimelineView{app: app, tagService: tagService}
+	timelineView.Setup()
+	return timelineView
+}
+
+// Setup initializes all timeline UI components
+func (tl *TagTimelineView) Setup() {
+	tl.setupModal()
+	tl.setupKeyBindings()
+}
+
+func (tl *TagTimelineView) setupModal() {
+	tl.Table = tview.NewTable().
+		SetBorders(false).
+		SetSelectable(true, false).
+		SetFixed(1, 0)
+	tl.Table.SetSelectedStyle(tcell.Style{}.Background(tcell.ColorAqua).Foreground(tcell.ColorBlack))
+
+	tl.timelineFrame = tview.NewFrame(tl.Table).
+		SetBorders(1, 1, 0, 0, 1, 1)
+	tl.timelineFrame.SetBorder(true).
+		SetTitleAlign(tview.AlignLeft)
+
+	tl.Modal = tview.NewFlex().
+		AddItem(nil, 0, 1, false).
+		AddItem(
+			tview.NewFlex().
+				SetDirection(tview.FlexRow).
+				AddItem(nil, 0, 1, false).
+				AddItem(tl.timelineFrame, 0, 4, true).
+				AddItem(nil, 0, 1, false),
+			0, 4, true,
+		).
+		AddItem(nil, 0, 1, false)
+
+	tl.Table.SetFocusFunc(func() {
+		tl.app.updateFooterHelp(helpBar("Timeline", []helpEntry{
+			{"↑/↓", "Navigate"},
+			{"Enter", "Go To"},
+			{"Esc", "Back"},
+		}))
+		tl.timelineFrame.SetBorderColor(Style.BorderFocusColor)
+	})
+	tl.Table.SetBlurFunc(func() {
+		tl.timelineFrame.SetBorderColor(Style.BorderColor)
+	})
+}
+
+func (tl *TagTimelineView) setupKeyBindings() {
+	tl.Modal.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEsc {
+			tl.app.HandleEvent(&TagTimelineCancelEvent{
+				BaseEvent: BaseEvent{action: TAG_TIMELINE_CANCEL},
+			})
+			return nil
+		}
+		return event
+	})
+
+	tl.Table.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEnter {
+			if occurrence := tl.Selected(); occurrence != nil {
+				tl.app.HandleEvent(&TagTimelineSelectEvent{
+					BaseEvent:  BaseEvent{action: TAG_TIMELINE_SELECT},
+					Occurrence: occurrence,
+				})
+			}
+			return nil
+		}
+		return event
+	})
+}
+
+// Load fetches the occurrences of identifier in the given game and renders them
+func (tl *TagTimelineView) Load(gameID int64, identifier string) {
+	tl.identifier = identifier
+	tl.timelineFrame.SetTitle("[::b] " + tview.Escape(identifier) + " Timeline ([" + Style.HelpKeyTextColor + "]Esc[" + Style.NormalTextColor + "] Back) [-::-]")
+
+	occurrences, err := tl.tagService.Timeline(gameID, identifier)
+	if err != nil {
+		tl.app.notification.ShowError(fmt.Sprintf("Error loading timeline: %v", err))
+	}
+	tl.occurrences = occurrences
+
+	tl.Table.Clear()
+	for col, label := range []string{"Session", "Date", "Data"} {
+		tl.Table.SetCell(0, col, tview.NewTableCell(label).
+			SetTextColor(tcell.ColorYellow).
+			SetAlign(tview.AlignLeft).
+			SetSelectable(false))
+	}
+
+	if len(tl.occurrences) == 0 {
+		tl.Table.SetCell(1, 0, tview.NewTableCell("(No occurrences in sessions)").
+			SetTextColor(Style.EmptyStateMessageColor).
+			SetSelectable(false))
+		return
+	}
+
+	for i, o := range tl.occurrences {
+		row := i + 1
+		tl.Table.SetCell(row, 0, tview.NewTableCell(tview.Escape(o.SessionName)).
+			SetTextColor(tcell.ColorWhite).
+			SetMaxWidth(25))
+		tl.Table.SetCell(row, 1, tview.NewTableCell(o.PlayedAt.Format(text.DateFormat)).
+			SetTextColor(tcell.ColorWhite))
+		tl.Table.SetCell(row, 2, tview.NewTableCell(tview.Escape(strings.TrimSpace(o.Data))).
+			SetTextColor(tcell.ColorWhite).
+			SetExpansion(1))
+	}
+	// Start on the newest occurrence
+	tl.Table.Select(len(tl.occurrences), 0)
+}
+
+// Selected returns the occurrence on the selected row, or nil if there is none
+func (tl *TagTimelineView) Selected() *tag.Occurrence {
+	row, _ := tl.Table.GetSelection()
+	if row < 1 || row > len(tl.occurrences) {
+		return nil
+	}
+	return &tl.occurrences[row-1]
+}
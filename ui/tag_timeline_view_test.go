@@ -0,0 +1,84 @@
+package ui
+
+import (
+	testHelper "soloterm/shared/testing"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// openTagTimeline is a test helper that creates two sessions mentioning an NPC,
+// opens the tag modal, selects the NPC row and presses 't'.
+func openTagTimeline(t *testing.T, app *App) {
+	t.Helper()
+	g := createGame(t, app, "Test Game")
+	s1 := createSession(t, app, g.ID, "Session One")
+	s1.Content = "Met [N:Vex | wary]"
+	_, err := app.sessionView.sessionService.Save(s1)
+	require.NoError(t, err)
+	s2 := createSession(t, app, g.ID, "Session Two")
+	s2.Content = "Intro\n[N:Vex | ally]"
+	_, err = app.sessionView.sessionService.Save(s2)
+	require.NoError(t, err)
+
+	app.gameView.Refresh()
+	require.NoError(t, app.gameView.SetCurrentGame(g.ID))
+	app.sessionView.SelectSession(s1.ID)
+	app.SetFocus(app.sessionView.TextArea)
+	testHelper.SimulateKey(app.sessionView.TextArea, app.Application, tcell.KeyCtrlT)
+
+	for row := 1; row < app.tagView.TagTable.GetRowCount(); row++ {
+		if app.tagView.TagTable.GetCell(row, 0).Text == "N:Vex" {
+			app.tagView.TagTable.Select(row, 0)
+			break
+		}
+	}
+	testHelper.SimulateRune(app.tagView.TagTable, app.Application, 't')
+}
+
+func TestTagTimelineView_ListsOccurrences(t *testing.T) {
+	app := setupTestApp(t)
+	openTagTimeline(t, app)
+
+	assert.True(t, app.isPageVisible(TAG_TIMELINE_MODAL_ID), "Expected timeline modal to be visible")
+	require.Len(t, app.timelineView.occurrences, 2)
+	assert.Equal(t, "Session One", app.timelineView.Table.GetCell(1, 0).Text)
+	assert.Equal(t, "wary", app.timelineView.Table.GetCell(1, 2).Text)
+	assert.Equal(t, "ally", app.timelineView.Table.GetCell(2, 2).Text)
+
+	row, _ := app.timelineView.Table.GetSelection()
+	assert.Equal(t, 2, row, "Expected the newest occurrence to be selected")
+}
+
+func TestTagTimelineView_IgnoresConfiguredTags(t *testing.T) {
+	app := setupTestApp(t)
+	openTagModal(t, app)
+
+	app.tagView.TagTable.Select(1, 0)
+	testHelper.SimulateRune(app.tagView.TagTable, app.Application, 't')
+	assert.False(t, app.isPageVisible(TAG_TIMELINE_MODAL_ID), "Configured tag types have no timeline")
+}
+
+func TestTagTimelineView_EscapeReturnsToTags(t *testing.T) {
+	app := setupTestApp(t)
+	openTagTimeline(t, app)
+
+	testHelper.SimulateKey(app.timelineView.Modal, app.Application, tcell.KeyEsc)
+	assert.False(t, app.isPageVisible(TAG_TIMELINE_MODAL_ID))
+	assert.True(t, app.isPageVisible(TAG_MODAL_ID))
+	assert.Equal(t, app.tagView.TagTable, app.GetFocus())
+}
+
+func TestTagTimelineView_SelectOpensSession(t *testing.T) {
+	app := setupTestApp(t)
+	openTagTimeline(t, app)
+
+	testHelper.SimulateKey(app.timelineView.Table, app.Application, tcell.KeyEnter)
+
+	assert.False(t, app.isPageVisible(TAG_TIMELINE_MODAL_ID))
+	assert.False(t, app.isPageVisible(TAG_MODAL_ID))
+	assert.Equal(t, "Intro\n[N:Vex | ally]", app.sessionView.TextArea.GetText(), "Expected the second session to be loaded")
+	assert.Equal(t, app.sessionView.TextArea, app.GetFocus())
+}
@@ -2,6 +2,7 @@ package ui
 
 import (
 	"fmt"
+	"slices"
 	"soloterm/config"
 	"soloterm/domain/tag"
 	"soloterm/shared/text"
@@ -116,6 +117,8 @@ func (tv *TagView) setupModal() {
 			{"↑/↓/←/→", "Scroll"},
 			{"F12", "Help"},
 			{"Enter", "Select"},
+			{"t", "Timeline"},
+			{"r", "Rebuild"},
 			{"Esc", "Close"},
 		}))
 		tv.tagFrame.SetBorderColor(Style.BorderFocusColor)
@@ -133,14 +136,12 @@ func (tv *TagView) Refresh() {
 
 	// Get the currently active game
 	var gameID int64
-	var notesContent string
 	if g := tv.app.CurrentGame(); g != nil {
 		gameID = g.ID
-		notesContent = g.Notes
 	}
 
 	// Load tags: configured, active (from sessions), and notes
-	result, err := tv.tagService.LoadTagsForGame(gameID, notesContent, tv.cfg.TagTypes, tv.cfg.TagExcludeWords)
+	result, err := tv.tagService.LoadTagsForGame(gameID, tv.cfg.TagTypes, tv.cfg.TagExcludeWords)
 	if err != nil {
 		result = &tag.TagsForGame{Config: tv.cfg.TagTypes}
 	}
@@ -242,12 +243,62 @@ func (tv *TagView) selectTag() {
 
 	// Fire the event for the selected tag
 	tv.app.HandleEvent(&TagSelectedEvent{
-		BaseEvent: BaseEvent{action: TAG_SELECTED},
-		TagType:   &tagType,
+		BaseEvent:  BaseEvent{action: TAG_SELECTED},
+		TagType:    &tagType,
+		Configured: tv.isConfiguredTag(tagType),
 	})
 
 }
 
+// showTimeline opens the timeline for the selected tag when it was used in the game
+func (tv *TagView) showTimeline() {
+	row, _ := tv.TagTable.GetSelection()
+	if row < 1 || row >= tv.TagTable.GetRowCount() || tv.tagsResult == nil {
+		return
+	}
+	label := tv.TagTable.GetCell(row, 0).Text
+	if !tv.isUsedTag(label) {
+		return
+	}
+
+	tv.app.HandleEvent(&TagTimelineShowEvent{
+		BaseEvent:  BaseEvent{action: TAG_TIMELINE_SHOW},
+		Identifier: label,
+	})
+}
+
+// rebuildIndex re-reads every session and the notes of the current game into the tag index
+func (tv *TagView) rebuildIndex() {
+	g := tv.app.CurrentGame()
+	if g == nil {
+		return
+	}
+	if err := tv.tagService.RebuildIndex(g.ID); err != nil {
+		tv.app.notification.ShowError(fmt.Sprintf("Error rebuilding tag index: %v", err))
+		return
+	}
+	tv.Refresh()
+	tv.app.notification.ShowSuccess("Tag index rebuilt")
+}
+
+// isUsedTag reports whether label is an identifier found in the sessions or notes
+func (tv *TagView) isUsedTag(label string) bool {
+	for _, t := range slices.Concat(tv.tagsResult.Active, tv.tagsResult.Notes) {
+		if t.Label == label {
+			return true
+		}
+	}
+	return false
+}
+
+// isConfiguredTag reports whether tagType is one of the tag templates in the config
+func (tv *TagView) isConfiguredTag(tagType tag.TagType) bool {
+	if tv.tagsResult == nil {
+		return false
+	}
+	return slices.Contains(tv.tagsResult.Config, tagType)
+}
+
 func (tv *TagView) setupKeyBindings() {
 	tv.Modal.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
 		switch event.Key() {
@@ -273,6 +324,15 @@ func (tv *TagView) setupKeyBindings() {
 		case tcell.KeyEnter:
 			tv.selectTag()
 			return nil
+		case tcell.KeyRune:
+			switch event.Rune() {
+			case 't':
+				tv.showTimeline()
+				return nil
+			case 'r':
+				tv.rebuildIndex()
+				return nil
+			}
 		case tcell.KeyF12:
 			tv.app.HandleEvent(&ShowHelpEvent{
 				BaseEvent:   BaseEvent{action: SHOW_HELP},
@@ -307,6 +367,14 @@ Press [yellow]Ctrl+T[white] to open the tag list, then use [yellow]↑/↓[white
 
 Tags used in your sessions appear under "Active Tags" in the tag list for quick reuse.
 
+[green]Tag Timeline[white]
+
+Press [yellow]t[white] on an active or notes tag to see every session that mentioned it, oldest first, with the data section at each point. Press [yellow]Enter[white] on an entry to open that session at the tag.
+
+[green]Tag Index[white]
+
+Tags are indexed as sessions and notes are saved. If the list ever looks out of date, press [yellow]r[white] to rebuild the index for the current game.
+
 [green]Closing a Tag[white]
 
 To close a tag so it no longer appears in the active list, add %s to its data section.
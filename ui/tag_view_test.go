@@ -2,6 +2,7 @@ package ui
 
 import (
 	"soloterm/domain/game"
+	"soloterm/domain/notes"
 	"soloterm/domain/tag"
 	testHelper "soloterm/shared/testing"
 	"testing"
@@ -193,8 +194,7 @@ func TestTagView_ShowHelpModal(t *testing.T) {
 func openTagModalFromNotes(t *testing.T, app *App, notesContent string) *game.Game {
 	t.Helper()
 	g := createGame(t, app, "Test Game")
-	err := app.gameView.gameService.SaveNotes(g.ID, notesContent)
-	require.NoError(t, err)
+	addNotesToGame(t, app, g.ID, notesContent)
 	app.gameView.Refresh()
 	selectNotes(t, app)
 	testHelper.SimulateKey(app.sessionView.TextArea, app.Application, tcell.KeyCtrlT)
@@ -229,6 +229,21 @@ func TestTagView_ShowsNotesTags(t *testing.T) {
 	assert.True(t, found, "Expected Notes Tags section header")
 }
 
+func TestTagView_ShowsTagsFromEveryNotesPage(t *testing.T) {
+	app := setupTestApp(t)
+	g := createGame(t, app, "Test Game")
+	addNotesToGame(t, app, g.ID, "[N:Malichi | Hostile mage]")
+	_, err := app.notesService.Save(&notes.Page{GameID: g.ID, Name: "Places", Content: "[L:Sunken Tower | Flooded]"})
+	require.NoError(t, err)
+
+	app.gameView.Refresh()
+	selectNotes(t, app)
+	testHelper.SimulateKey(app.sessionView.TextArea, app.Application, tcell.KeyCtrlT)
+
+	assert.NotEqual(t, -1, findTagInTable(app.tagView.TagTable, "N:Malichi"), "Expected the first page's tag")
+	assert.NotEqual(t, -1, findTagInTable(app.tagView.TagTable, "L:Sunken Tower"), "Expected the second page's tag")
+}
+
 func TestTagView_HidesNotesSectionWhenEmpty(t *testing.T) {
 	app := setupTestApp(t)
 	openTagModalFromNotes(t, app, "") // empty notes
@@ -246,13 +261,12 @@ func TestTagView_NoteTagsIndependentOfSessionClose(t *testing.T) {
 	g := createGame(t, app, "Test Game")
 
 	// Notes contain Malichi as an open tag.
-	err := app.gameView.gameService.SaveNotes(g.ID, "[N:Malichi | Hostile mage]")
-	require.NoError(t, err)
+	addNotesToGame(t, app, g.ID, "[N:Malichi | Hostile mage]")
 
 	// A session closes Malichi.
 	s := createSession(t, app, g.ID, "Session One")
 	s.Content = "[N:Malichi | Hostile mage; Closed]"
-	_, err = app.sessionView.sessionService.Save(s)
+	_, err := app.sessionView.sessionService.Save(s)
 	require.NoError(t, err)
 
 	app.gameView.Refresh()
@@ -297,12 +311,11 @@ func TestTagView_ShowsBothActiveAndNotesTags(t *testing.T) {
 	app := setupTestApp(t)
 	g := createGame(t, app, "Test Game")
 
-	err := app.gameView.gameService.SaveNotes(g.ID, "[N:Malichi | Hostile mage]")
-	require.NoError(t, err)
+	addNotesToGame(t, app, g.ID, "[N:Malichi | Hostile mage]")
 
 	s := createSession(t, app, g.ID, "Session One")
 	s.Content = "[L:Tavern | Cozy]"
-	_, err = app.sessionView.sessionService.Save(s)
+	_, err := app.sessionView.sessionService.Save(s)
 	require.NoError(t, err)
 
 	app.gameView.Refresh()
@@ -448,3 +461,31 @@ func TestTagView_NavigateWithArrowKeys(t *testing.T) {
 	newRow, _ = app.tagView.TagTable.GetSelection()
 	assert.Equal(t, initialRow, newRow, "Expected selection to move back up")
 }
+
+func TestTagView_UsedTagIsInsertedAsWritten(t *testing.T) {
+	app := setupTestApp(t)
+	g := createGame(t, app, "Test Game")
+	s := createSession(t, app, g.ID, "Test Session")
+	s.Content = "[N:Vex | knows {{game}}]\n"
+	_, err := app.sessionView.sessionService.Save(s)
+	require.NoError(t, err)
+
+	app.gameView.Refresh()
+	require.NoError(t, app.gameView.SetCurrentGame(g.ID))
+	app.sessionView.SelectSession(s.ID)
+	app.sessionView.TextArea.Select(len(s.Content), len(s.Content))
+	app.SetFocus(app.sessionView.TextArea)
+	testHelper.SimulateKey(app.sessionView.TextArea, app.Application, tcell.KeyCtrlT)
+
+	row := -1
+	for r := 1; r < app.tagView.TagTable.GetRowCount(); r++ {
+		if app.tagView.TagTable.GetCell(r, 0).Text == "N:Vex" {
+			row = r
+		}
+	}
+	require.NotEqual(t, -1, row, "Expected the used tag in the table")
+	app.tagView.TagTable.Select(row, 0)
+	testHelper.SimulateKey(app.tagView.TagTable, app.Application, tcell.KeyEnter)
+
+	assert.Equal(t, "[N:Vex | knows {{game}}]\n[N:Vex | knows {{game}}]", app.sessionView.TextArea.GetText())
+}
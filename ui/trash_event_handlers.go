@@ -0,0 +1,75 @@
+package ui
+
+import (
+	"fmt"
+	"soloterm/domain/trash"
+)
+
+func (a *App) handleTrashShow(_ *TrashShowEvent) {
+	// Store current focus so we can restore it after closing
+	a.trashView.returnFocus = a.GetFocus()
+	a.trashView.Refresh()
+	a.pages.ShowPage(TRASH_MODAL_ID)
+	a.SetFocus(a.trashView.Table)
+}
+
+func (a *App) handleTrashCancel(_ *TrashCancelEvent) {
+	a.pages.HidePage(TRASH_MODAL_ID)
+	a.SetFocus(a.trashView.returnFocus)
+}
+
+func (a *App) handleTrashRestore(e *TrashRestoreEvent) {
+	err := a.trashView.trashService.Restore(e.Item)
+	if err != nil && !isIndexingError(err) {
+		a.notification.ShowError(fmt.Sprintf("Error restoring %s: %v", e.Item.Name, err))
+		return
+	}
+
+	a.refreshAfterTrash(e.Item)
+	if err != nil {
+		a.showIndexingWarning(err)
+		return
+	}
+	a.notification.ShowSuccess(string(e.Item.Kind) + " restored: " + e.Item.Name)
+}
+
+func (a *App) handleTrashPurgeConfirm(e *TrashPurgeConfirmEvent) {
+	message := "Permanently delete " + e.Item.Name + "?\n\nThis action cannot be undone."
+	switch e.Item.Kind {
+	case trash.KindGame:
+		message = "Permanently delete " + e.Item.Name + " and all of its sessions?\n\nThis action cannot be undone."
+	case trash.KindCharacter:
+		message = "Permanently delete " + e.Item.Name + " and their sheet?\n\nThis action cannot be undone."
+	}
+
+	a.confirmModal.Configure(
+		message,
+		func() {
+			a.pages.HidePage(CONFIRM_MODAL_ID)
+			a.SetFocus(a.trashView.Table)
+			if err := a.trashView.trashService.Purge(e.Item); err != nil {
+				a.notification.ShowError(fmt.Sprintf("Error purging %s: %v", e.Item.Name, err))
+				return
+			}
+			a.refreshAfterTrash(e.Item)
+			a.notification.ShowSuccess(string(e.Item.Kind) + " permanently deleted: " + e.Item.Name)
+		},
+		func() {
+			a.pages.HidePage(CONFIRM_MODAL_ID)
+			a.SetFocus(a.trashView.Table)
+		},
+		"Purge",
+	)
+
+	a.pages.ShowPage(CONFIRM_MODAL_ID)
+}
+
+// refreshAfterTrash reloads the trash and the tree the item belongs in
+func (a *App) refreshAfterTrash(item *trash.Item) {
+	a.trashView.Refresh()
+	if item.Kind == trash.KindCharacter {
+		a.characterView.RefreshTree()
+	} else {
+		a.gameView.Refresh()
+	}
+}
@@ -0,0 +1,153 @@
+package ui
+
+import (
+	"fmt"
+	"soloterm/domain/trash"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// TrashView lists deleted games, sessions and characters so they can be
+// restored or purged for good
+type TrashView struct {
+	app          *App
+	trashService *trash.Service
+	Modal        *tview.Flex
+	frame        *tview.Frame
+	Table        *tview.Table
+	items        []*trash.Item
+	returnFocus  tview.Primitive
+}
+
+// NewTrashView creates a new trash view
+func NewTrashView(app *App, trashService *trash.Service) *TrashView {
+	tv := &TrashView{app: app, trashService: trashService}
+	tv.Setup()
+	return tv
+}
+
+// Setup initializes all trash UI components
+func (tv *TrashView) Setup() {
+	tv.setupModal()
+	tv.setupKeyBindings()
+}
+
+func (tv *TrashView) setupModal() {
+	tv.Table = tview.NewTable().
+		SetSelectable(true, false).
+		SetFixed(1, 0).
+		SetSelectedStyle(tcell.Style{}.Background(tcell.ColorAqua).Foreground(tcell.ColorBlack))
+
+	tv.frame = tview.NewFrame(tv.Table).
+		SetBorders(1, 0, 0, 0, 1, 1)
+	tv.frame.SetBorder(true).
+		SetTitleAlign(tview.AlignLeft).
+		SetTitle("[::b] Trash ([" + Style.HelpKeyTextColor + "]Esc[" + Style.NormalTextColor + "] Close) [-::-]")
+
+	tv.Modal = tview.NewFlex().
+		AddItem(nil, 0, 1, false).
+		AddItem(
+			tview.NewFlex().
+				SetDirection(tview.FlexRow).
+				AddItem(nil, 0, 1, false).
+				AddItem(tv.frame, 0, 2, true).
+				AddItem(nil, 0, 1, false),
+			80, 1, true,
+		).
+		AddItem(nil, 0, 1, false)
+
+	tv.Table.SetFocusFunc(func() {
+		tv.app.updateFooterHelp(helpBar("Trash", []helpEntry{
+			{"↑/↓", "Navigate"},
+			{"r", "Restore"},
+			{"p", "Purge"},
+			{"Esc", "Close"},
+		}))
+		tv.frame.SetBorderColor(Style.BorderFocusColor)
+	})
+	tv.Table.SetBlurFunc(func() {
+		tv.frame.SetBorderColor(Style.BorderColor)
+	})
+}
+
+func (tv *TrashView) setupKeyBindings() {
+	tv.Table.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Key() {
+		case tcell.KeyEsc:
+			tv.app.HandleEvent(&TrashCancelEvent{
+				BaseEvent: BaseEvent{action: TRASH_CANCEL},
+			})
+			return nil
+		case tcell.KeyRune:
+			switch event.Rune() {
+			case 'r':
+				if item := tv.selectedItem(); item != nil {
+					tv.app.HandleEvent(&TrashRestoreEvent{
+						BaseEvent: BaseEvent{action: TRASH_RESTORE},
+						Item:      item,
+					})
+				}
+				return nil
+			case 'p':
+				if item := tv.selectedItem(); item != nil {
+					tv.app.HandleEvent(&TrashPurgeConfirmEvent{
+						BaseEvent: BaseEvent{action: TRASH_PURGE_CONFIRM},
+						Item:      item,
+					})
+				}
+				return nil
+			}
+		}
+		return event
+	})
+}
+
+// Refresh reloads the deleted items
+func (tv *TrashView) Refresh() {
+	items, err := tv.trashService.GetAll()
+	if err != nil {
+		tv.app.notification.ShowError(fmt.Sprintf("Error loading trash: %v", err))
+	}
+	tv.items = items
+
+	row, _ := tv.Table.GetSelection()
+	tv.Table.Clear()
+	for col, header := range []string{"Type", "Name", "From", "Deleted"} {
+		tv.Table.SetCell(0, col, tview.NewTableCell(header).
+			SetTextColor(tcell.ColorYellow).
+			SetAlign(tview.AlignLeft).
+			SetSelectable(false))
+	}
+
+	if len(items) == 0 {
+		tv.Table.SetCell(1, 0, tview.NewTableCell("(The trash is empty)").
+			SetTextColor(Style.EmptyStateMessageColor).
+			SetSelectable(false))
+		return
+	}
+
+	for i, item := range items {
+		tv.Table.SetCell(i+1, 0, tview.NewTableCell(string(item.Kind)).
+			SetReference(item))
+		tv.Table.SetCell(i+1, 1, tview.NewTableCell(tview.Escape(item.Name)).
+			SetMaxWidth(30).
+			SetExpansion(1))
+		tv.Table.SetCell(i+1, 2, tview.NewTableCell(tview.Escape(item.Detail)).
+			SetMaxWidth(20))
+		tv.Table.SetCell(i+1, 3, tview.NewTableCell(item.DeletedAt.Local().Format("2006-01-02 15:04")))
+	}
+
+	// Keep the selection in place as rows are removed
+	tv.Table.Select(max(1, min(row, len(items))), 0)
+}
+
+// selectedItem returns the item in the selected row, or nil
+func (tv *TrashView) selectedItem() *trash.Item {
+	row, _ := tv.Table.GetSelection()
+	ref := tv.Table.GetCell(row, 0).GetReference()
+	if ref == nil {
+		return nil
+	}
+	return ref.(*trash.Item)
+}
@@ -0,0 +1,66 @@
+package ui
+
+import (
+	"soloterm/domain/trash"
+	testHelper "soloterm/shared/testing"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// openTrash opens the trash from the game tree
+func openTrash(t *testing.T, app *App) {
+	t.Helper()
+	testHelper.SimulateRune(app.gameView.Tree, app.Application, 't')
+	require.True(t, app.isPageVisible(TRASH_MODAL_ID), "Expected trash modal to be visible")
+}
+
+func TestTrashView_RestoreSession(t *testing.T) {
+	app := setupTestApp(t)
+	g := createGame(t, app, "Test Game")
+	s := createSession(t, app, g.ID, "Session One")
+
+	app.sessionView.ConfirmDelete(s.ID)
+	sessions, err := app.sessionView.sessionService.GetAllForGame(g.ID)
+	require.NoError(t, err)
+	assert.Empty(t, sessions, "Expected the session to be hidden once deleted")
+
+	openTrash(t, app)
+	item := app.trashView.selectedItem()
+	require.NotNil(t, item)
+	assert.Equal(t, trash.KindSession, item.Kind)
+	assert.Equal(t, "Session One", item.Name)
+
+	testHelper.SimulateRune(app.trashView.Table, app.Application, 'r')
+	restored, err := app.sessionView.sessionService.GetByID(s.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Session One", restored.Name)
+	assert.Nil(t, app.trashView.selectedItem(), "Expected the trash to be empty")
+
+	testHelper.SimulateKey(app.trashView.Table, app.Application, tcell.KeyEscape)
+	assert.False(t, app.isPageVisible(TRASH_MODAL_ID), "Expected trash modal to close")
+}
+
+func TestTrashView_PurgeGame(t *testing.T) {
+	app := setupTestApp(t)
+	g := createGame(t, app, "Test Game")
+	createSession(t, app, g.ID, "Session One")
+
+	app.gameView.ConfirmDelete(g.ID)
+	openTrash(t, app)
+	item := app.trashView.selectedItem()
+	require.NotNil(t, item)
+	assert.Equal(t, trash.KindGame, item.Kind)
+
+	testHelper.SimulateRune(app.trashView.Table, app.Application, 'p')
+	require.True(t, app.isPageVisible(CONFIRM_MODAL_ID), "Expected confirmation modal to be visible")
+	app.confirmModal.onConfirm()
+	assert.False(t, app.isPageVisible(CONFIRM_MODAL_ID), "Expected confirmation modal to be hidden")
+
+	items, err := app.trashView.trashService.GetAll()
+	require.NoError(t, err)
+	assert.Empty(t, items)
+	assert.Error(t, app.trashView.trashService.Restore(item), "Expected nothing left to restore")
+}